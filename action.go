@@ -0,0 +1,190 @@
+package chartype
+
+import (
+	"sort"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+const (
+	// AdjustSplits specifies that only splits are adjusted for.
+	AdjustSplits AdjustMode = iota + 1
+
+	// AdjustDividends specifies that only dividends are adjusted for.
+	AdjustDividends
+
+	// AdjustAll specifies that both splits and dividends are adjusted
+	// for.
+	AdjustAll
+)
+
+var (
+	// ErrInvalidAdjustMode is returned when adjust mode with invalid
+	// value is being used.
+	ErrInvalidAdjustMode = newError(CodeInvalidArgument, "invalid adjust mode")
+
+	// ErrInvalidCorporateAction is returned when corporate action with
+	// missing date, negative values or a dividend not smaller than
+	// the previous close is being used.
+	ErrInvalidCorporateAction = newError(CodeInvalidArgument, "invalid corporate action")
+)
+
+// AdjustMode specifies which corporate actions are adjusted for.
+// Can be included in configuration structures.
+type AdjustMode int
+
+// Validate checks whether the adjust mode is one of supported mode
+// types or not.
+func (am AdjustMode) Validate() error {
+	switch am {
+	case AdjustSplits, AdjustDividends, AdjustAll:
+		return nil
+	default:
+		return ErrInvalidAdjustMode
+	}
+}
+
+// MarshalText turns adjust mode to appropriate string
+// representation.
+func (am AdjustMode) MarshalText() ([]byte, error) {
+	var v string
+
+	switch am {
+	case AdjustSplits:
+		v = "splits"
+	case AdjustDividends:
+		v = "dividends"
+	case AdjustAll:
+		v = "all"
+	default:
+		return nil, ErrInvalidAdjustMode
+	}
+
+	return []byte(v), nil
+}
+
+// UnmarshalText turns string to appropriate adjust mode value.
+func (am *AdjustMode) UnmarshalText(d []byte) error {
+	switch string(d) {
+	case "splits":
+		*am = AdjustSplits
+	case "dividends":
+		*am = AdjustDividends
+	case "all":
+		*am = AdjustAll
+	default:
+		return ErrInvalidAdjustMode
+	}
+
+	return nil
+}
+
+// CorporateAction holds a stock split and/or a cash dividend taking
+// effect on the provided (ex-)date.
+type CorporateAction struct {
+	// Date specifies the time from which the action is in effect.
+	Date time.Time `json:"date" yaml:"date"`
+
+	// SplitRatio specifies the number of new shares per old share,
+	// e.g. 4 for a 4-for-1 split. Zero means no split.
+	SplitRatio decimal.Decimal `json:"split_ratio" yaml:"split_ratio"`
+
+	// Dividend specifies the cash dividend per share. Zero means no
+	// dividend.
+	Dividend decimal.Decimal `json:"dividend" yaml:"dividend"`
+}
+
+// Validate checks whether corporate action's date is set and whether
+// its values are not negative.
+func (ca CorporateAction) Validate() error {
+	if ca.Date.IsZero() || ca.SplitRatio.IsNegative() || ca.Dividend.IsNegative() {
+		return ErrInvalidCorporateAction
+	}
+
+	return nil
+}
+
+// Adjust returns a new series with candles before each corporate
+// action adjusted for it: prices, including adjusted closes, are
+// divided and volumes, including volume deltas, multiplied by split
+// ratios, prices are multiplied by 1 - dividend / close of the last
+// candle before the action. Candles must be sorted by timestamp in
+// ascending order.
+func Adjust(cc []Candle, actions []CorporateAction, am AdjustMode) ([]Candle, error) {
+	if err := am.Validate(); err != nil {
+		return nil, err
+	}
+
+	aa := make([]CorporateAction, len(actions))
+	copy(aa, actions)
+
+	sort.Slice(aa, func(i, j int) bool {
+		return aa[i].Date.Before(aa[j].Date)
+	})
+
+	one := decimal.NewFromInt(1)
+	df := make([]decimal.Decimal, len(aa))
+	sf := make([]decimal.Decimal, len(aa))
+
+	for i, a := range aa {
+		if err := a.Validate(); err != nil {
+			return nil, err
+		}
+
+		df[i], sf[i] = one, one
+
+		if am != AdjustDividends && !a.SplitRatio.IsZero() {
+			sf[i] = a.SplitRatio
+		}
+
+		if am == AdjustSplits || a.Dividend.IsZero() {
+			continue
+		}
+
+		j := sort.Search(len(cc), func(j int) bool {
+			return !cc[j].Timestamp.Before(a.Date)
+		})
+
+		if j == 0 {
+			continue
+		}
+
+		cl := cc[j-1].Close
+		if !a.Dividend.LessThan(cl) {
+			return nil, ErrInvalidCorporateAction
+		}
+
+		df[i] = one.Sub(a.Dividend.Div(cl))
+	}
+
+	res := make([]Candle, len(cc))
+	d, sr := one, one
+	j := len(aa) - 1
+
+	for i := len(cc) - 1; i >= 0; i-- {
+		c := cc[i]
+
+		for ; j >= 0 && c.Timestamp.Before(aa[j].Date); j-- {
+			d = d.Mul(df[j])
+			sr = sr.Mul(sf[j])
+		}
+
+		// prices are divided by the cumulative split ratio instead
+		// of being multiplied by its rounded inverse
+		c = adjustPrices(c, func(v decimal.Decimal) decimal.Decimal {
+			return v.Mul(d).Div(sr)
+		})
+
+		c.Volume = c.Volume.Mul(sr)
+
+		if c.Delta != nil {
+			dv := c.Delta.Mul(sr)
+			c.Delta = &dv
+		}
+
+		res[i] = c
+	}
+
+	return res, nil
+}
@@ -0,0 +1,288 @@
+package chartype
+
+import (
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_AdjustMode_Validate(t *testing.T) {
+	cc := map[string]struct {
+		Mode AdjustMode
+		Err  error
+	}{
+		"Invalid AdjustMode": {
+			Mode: 70,
+			Err:  ErrInvalidAdjustMode,
+		},
+		"Successful AdjustSplits validation": {
+			Mode: AdjustSplits,
+		},
+		"Successful AdjustDividends validation": {
+			Mode: AdjustDividends,
+		},
+		"Successful AdjustAll validation": {
+			Mode: AdjustAll,
+		},
+	}
+
+	for cn, c := range cc {
+		c := c
+
+		t.Run(cn, func(t *testing.T) {
+			t.Parallel()
+
+			equalError(t, c.Err, c.Mode.Validate())
+		})
+	}
+}
+
+func Test_AdjustMode_MarshalText(t *testing.T) {
+	cc := map[string]struct {
+		Mode AdjustMode
+		Text string
+		Err  error
+	}{
+		"Invalid AdjustMode": {
+			Mode: 70,
+			Err:  ErrInvalidAdjustMode,
+		},
+		"Successful AdjustSplits marshal": {
+			Mode: AdjustSplits,
+			Text: "splits",
+		},
+		"Successful AdjustDividends marshal": {
+			Mode: AdjustDividends,
+			Text: "dividends",
+		},
+		"Successful AdjustAll marshal": {
+			Mode: AdjustAll,
+			Text: "all",
+		},
+	}
+
+	for cn, c := range cc {
+		c := c
+
+		t.Run(cn, func(t *testing.T) {
+			t.Parallel()
+
+			d, err := c.Mode.MarshalText()
+			equalError(t, c.Err, err)
+			if err != nil {
+				return
+			}
+
+			assert.Equal(t, c.Text, string(d))
+		})
+	}
+}
+
+func Test_AdjustMode_UnmarshalText(t *testing.T) {
+	cc := map[string]struct {
+		Text   string
+		Result AdjustMode
+		Err    error
+	}{
+		"Invalid AdjustMode": {
+			Text: "x",
+			Err:  ErrInvalidAdjustMode,
+		},
+		"Successful AdjustSplits unmarshal": {
+			Text:   "splits",
+			Result: AdjustSplits,
+		},
+		"Successful AdjustDividends unmarshal": {
+			Text:   "dividends",
+			Result: AdjustDividends,
+		},
+		"Successful AdjustAll unmarshal": {
+			Text:   "all",
+			Result: AdjustAll,
+		},
+	}
+
+	for cn, c := range cc {
+		c := c
+
+		t.Run(cn, func(t *testing.T) {
+			t.Parallel()
+
+			var am AdjustMode
+
+			err := am.UnmarshalText([]byte(c.Text))
+			equalError(t, c.Err, err)
+			if err != nil {
+				return
+			}
+
+			assert.Equal(t, c.Result, am)
+		})
+	}
+}
+
+func Test_CorporateAction_Validate(t *testing.T) {
+	tm := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	cc := map[string]struct {
+		Action CorporateAction
+		Err    error
+	}{
+		"Missing date": {
+			Action: CorporateAction{SplitRatio: decimal.NewFromInt(2)},
+			Err:    ErrInvalidCorporateAction,
+		},
+		"Negative split ratio": {
+			Action: CorporateAction{Date: tm, SplitRatio: decimal.NewFromInt(-2)},
+			Err:    ErrInvalidCorporateAction,
+		},
+		"Negative dividend": {
+			Action: CorporateAction{Date: tm, Dividend: decimal.NewFromInt(-2)},
+			Err:    ErrInvalidCorporateAction,
+		},
+		"Successful validation": {
+			Action: CorporateAction{Date: tm, SplitRatio: decimal.NewFromInt(2)},
+		},
+	}
+
+	for cn, c := range cc {
+		c := c
+
+		t.Run(cn, func(t *testing.T) {
+			t.Parallel()
+
+			equalError(t, c.Err, c.Action.Validate())
+		})
+	}
+}
+
+func Test_Adjust(t *testing.T) {
+	tm := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	at := func(d int) time.Time {
+		return tm.Add(time.Duration(d) * 24 * time.Hour)
+	}
+
+	series := []Candle{
+		testCandle(at(0), 80, 80, 80, 80, 10),
+		testCandle(at(1), 100, 100, 100, 100, 10),
+		testCandle(at(2), 50, 50, 50, 50, 20),
+		testCandle(at(3), 40, 40, 40, 40, 20),
+	}
+
+	actions := []CorporateAction{
+		{Date: at(3), Dividend: decimal.NewFromInt(10)},
+		{Date: at(2), SplitRatio: decimal.NewFromInt(2)},
+		{Date: at(0), Dividend: decimal.NewFromInt(1)},
+	}
+
+	split := testCandle(at(0), 300, 300, 300, 300, 10)
+	split.AdjClose = decimalPtr(300)
+	split.Delta = decimalPtr(-4)
+
+	type result struct {
+		Close    string
+		AdjClose string
+		Volume   string
+		Delta    string
+	}
+
+	optional := func(d *decimal.Decimal) string {
+		if d == nil {
+			return ""
+		}
+
+		return d.String()
+	}
+
+	cc := map[string]struct {
+		Series  []Candle
+		Actions []CorporateAction
+		Mode    AdjustMode
+		Result  []result
+		Err     error
+	}{
+		"Invalid adjust mode": {
+			Err: ErrInvalidAdjustMode,
+		},
+		"Invalid corporate action": {
+			Actions: []CorporateAction{{}},
+			Mode:    AdjustAll,
+			Err:     ErrInvalidCorporateAction,
+		},
+		"Dividend not smaller than close": {
+			Actions: []CorporateAction{{Date: at(1), Dividend: decimal.NewFromInt(80)}},
+			Mode:    AdjustAll,
+			Err:     ErrInvalidCorporateAction,
+		},
+		"Successful splits adjustment": {
+			Actions: actions,
+			Mode:    AdjustSplits,
+			Result: []result{
+				{Close: "40", Volume: "20"},
+				{Close: "50", Volume: "20"},
+				{Close: "50", Volume: "20"},
+				{Close: "40", Volume: "20"},
+			},
+		},
+		"Successful 3-for-1 split adjustment": {
+			Series:  []Candle{split, testCandle(at(1), 100, 100, 100, 100, 30)},
+			Actions: []CorporateAction{{Date: at(1), SplitRatio: decimal.NewFromInt(3)}},
+			Mode:    AdjustAll,
+			Result: []result{
+				{Close: "100", AdjClose: "100", Volume: "30", Delta: "-12"},
+				{Close: "100", Volume: "30"},
+			},
+		},
+		"Successful dividends adjustment": {
+			Actions: actions,
+			Mode:    AdjustDividends,
+			Result: []result{
+				{Close: "64", Volume: "10"},
+				{Close: "80", Volume: "10"},
+				{Close: "40", Volume: "20"},
+				{Close: "40", Volume: "20"},
+			},
+		},
+		"Successful full adjustment": {
+			Actions: actions,
+			Mode:    AdjustAll,
+			Result: []result{
+				{Close: "32", Volume: "20"},
+				{Close: "40", Volume: "20"},
+				{Close: "40", Volume: "20"},
+				{Close: "40", Volume: "20"},
+			},
+		},
+	}
+
+	for cn, c := range cc {
+		c := c
+
+		t.Run(cn, func(t *testing.T) {
+			t.Parallel()
+
+			ss := c.Series
+			if ss == nil {
+				ss = series
+			}
+
+			res, err := Adjust(ss, c.Actions, c.Mode)
+			equalError(t, c.Err, err)
+			if err != nil {
+				return
+			}
+
+			assert.Len(t, res, len(c.Result))
+
+			for i, r := range c.Result {
+				assert.Equal(t, ss[i].Timestamp, res[i].Timestamp)
+				assert.Equal(t, r.Close, res[i].Close.String(), "candle %d", i)
+				assert.Equal(t, r.AdjClose, optional(res[i].AdjClose), "candle %d", i)
+				assert.Equal(t, r.Volume, res[i].Volume.String(), "candle %d", i)
+				assert.Equal(t, r.Delta, optional(res[i].Delta), "candle %d", i)
+			}
+		})
+	}
+}
@@ -0,0 +1,101 @@
+package chartype
+
+import (
+	"github.com/shopspring/decimal"
+)
+
+// Aggregator combines values of a single candle field when candles are
+// resampled into longer interval candles.
+type Aggregator interface {
+	// Aggregate returns the field's value after candle c is merged
+	// into the aggregated candle agg. Agg holds the state before
+	// the merge.
+	Aggregate(agg, c Candle) decimal.Decimal
+}
+
+// AggregatorFunc is an adapter that allows ordinary functions to be
+// used as aggregators.
+type AggregatorFunc func(agg, c Candle) decimal.Decimal
+
+// Aggregate calls the underlying function.
+func (af AggregatorFunc) Aggregate(agg, c Candle) decimal.Decimal {
+	return af(agg, c)
+}
+
+// AggregateFirst returns an aggregator that keeps the first value of
+// the candle field.
+func AggregateFirst(cf CandleField) Aggregator {
+	return AggregatorFunc(func(agg, _ Candle) decimal.Decimal {
+		return cf.Extract(agg)
+	})
+}
+
+// AggregateLast returns an aggregator that uses the last value of the
+// candle field.
+func AggregateLast(cf CandleField) Aggregator {
+	return AggregatorFunc(func(_, c Candle) decimal.Decimal {
+		return cf.Extract(c)
+	})
+}
+
+// AggregateMax returns an aggregator that uses the highest value of
+// the candle field.
+func AggregateMax(cf CandleField) Aggregator {
+	return AggregatorFunc(func(agg, c Candle) decimal.Decimal {
+		return decimal.Max(cf.Extract(agg), cf.Extract(c))
+	})
+}
+
+// AggregateMin returns an aggregator that uses the lowest value of
+// the candle field.
+func AggregateMin(cf CandleField) Aggregator {
+	return AggregatorFunc(func(agg, c Candle) decimal.Decimal {
+		return decimal.Min(cf.Extract(agg), cf.Extract(c))
+	})
+}
+
+// AggregateSum returns an aggregator that sums values of the candle
+// field.
+func AggregateSum(cf CandleField) Aggregator {
+	return AggregatorFunc(func(agg, c Candle) decimal.Decimal {
+		return cf.Extract(agg).Add(cf.Extract(c))
+	})
+}
+
+// defaultAggregator returns the aggregator used for the candle field
+// when no custom one is provided.
+func defaultAggregator(cf CandleField) Aggregator {
+	switch cf {
+	case CandleOpen:
+		return AggregateFirst(cf)
+	case CandleHigh:
+		return AggregateMax(cf)
+	case CandleLow:
+		return AggregateMin(cf)
+	case CandleVolume, CandleDelta:
+		return AggregateSum(cf)
+	default:
+		return AggregateLast(cf)
+	}
+}
+
+// setField sets candle's value specified in the candle field type.
+// Candle field must be valid.
+func setField(c *Candle, cf CandleField, v decimal.Decimal) {
+	switch cf {
+	case CandleOpen:
+		c.Open = v
+	case CandleHigh:
+		c.High = v
+	case CandleLow:
+		c.Low = v
+	case CandleClose:
+		c.Close = v
+	case CandleVolume:
+		c.Volume = v
+	case CandleAdjClose:
+		c.AdjClose = &v
+	default:
+		c.Delta = &v
+	}
+}
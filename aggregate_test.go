@@ -0,0 +1,59 @@
+package chartype
+
+import (
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Aggregators(t *testing.T) {
+	agg := testCandle(time.Time{}, 1, 6, 2, 4, 10)
+	c := testCandle(time.Time{}, 5, 8, 3, 7, 30)
+
+	vwap := AggregatorFunc(func(agg, c Candle) decimal.Decimal {
+		v := agg.Close.Mul(agg.Volume).Add(c.Close.Mul(c.Volume))
+		return v.Div(agg.Volume.Add(c.Volume))
+	})
+
+	cc := map[string]struct {
+		Aggregator Aggregator
+		Result     string
+	}{
+		"Successful first aggregation": {
+			Aggregator: AggregateFirst(CandleOpen),
+			Result:     "1",
+		},
+		"Successful last aggregation": {
+			Aggregator: AggregateLast(CandleOpen),
+			Result:     "5",
+		},
+		"Successful max aggregation": {
+			Aggregator: AggregateMax(CandleLow),
+			Result:     "3",
+		},
+		"Successful min aggregation": {
+			Aggregator: AggregateMin(CandleHigh),
+			Result:     "6",
+		},
+		"Successful sum aggregation": {
+			Aggregator: AggregateSum(CandleVolume),
+			Result:     "40",
+		},
+		"Successful custom aggregation": {
+			Aggregator: vwap,
+			Result:     "6.25",
+		},
+	}
+
+	for cn, c1 := range cc {
+		c1 := c1
+
+		t.Run(cn, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, c1.Result, c1.Aggregator.Aggregate(agg, c).String())
+		})
+	}
+}
@@ -0,0 +1,120 @@
+package chartype
+
+import "github.com/shopspring/decimal"
+
+// Aggregates incrementally maintains running statistics of a candle
+// series: cumulative volume, volume weighted average price and the
+// highest high and lowest low of the last n candles. Each append
+// takes amortized constant time.
+//
+// Aggregates is not safe for concurrent use.
+type Aggregates struct {
+	n        int
+	count    int
+	volume   decimal.Decimal
+	notional decimal.Decimal
+	highs    []windowValue
+	lows     []windowValue
+}
+
+// windowValue holds a value together with the position of the candle
+// it belongs to.
+type windowValue struct {
+	pos   int
+	value decimal.Decimal
+}
+
+// NewAggregates creates new empty aggregates with a rolling window of
+// n candles.
+func NewAggregates(n int) (*Aggregates, error) {
+	if n <= 0 {
+		return nil, ErrInvalidLength
+	}
+
+	return &Aggregates{n: n}, nil
+}
+
+// Aggregates creates new aggregates with a rolling window of n
+// candles and appends all series' candles to them.
+func (cc Candles) Aggregates(n int) (*Aggregates, error) {
+	a, err := NewAggregates(n)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, c := range cc {
+		a.Append(c)
+	}
+
+	return a, nil
+}
+
+// Append updates the aggregates with the candle.
+func (a *Aggregates) Append(c Candle) {
+	a.volume = a.volume.Add(c.Volume)
+	a.notional = a.notional.Add(c.Notional())
+
+	expired := a.count - a.n
+
+	a.highs = pushWindow(a.highs, windowValue{pos: a.count, value: c.High}, expired, decimal.Decimal.LessThanOrEqual)
+	a.lows = pushWindow(a.lows, windowValue{pos: a.count, value: c.Low}, expired, decimal.Decimal.GreaterThanOrEqual)
+
+	a.count++
+}
+
+// pushWindow appends the value to the monotonic window after removing
+// values dominated by it and values at or before the expired position.
+func pushWindow(ww []windowValue, w windowValue, expired int, dominated func(v, nv decimal.Decimal) bool) []windowValue {
+	for len(ww) > 0 && dominated(ww[len(ww)-1].value, w.value) {
+		ww = ww[:len(ww)-1]
+	}
+
+	ww = append(ww, w)
+
+	for ww[0].pos <= expired {
+		ww = ww[1:]
+	}
+
+	return ww
+}
+
+// Count returns the number of appended candles.
+func (a *Aggregates) Count() int {
+	return a.count
+}
+
+// Volume returns the cumulative volume of all appended candles.
+func (a *Aggregates) Volume() decimal.Decimal {
+	return a.volume
+}
+
+// VWAP returns the volume weighted average typical price of all
+// appended candles. Zero is returned if the cumulative volume is
+// zero.
+func (a *Aggregates) VWAP() decimal.Decimal {
+	if a.volume.IsZero() {
+		return decimal.Zero
+	}
+
+	return a.notional.Div(a.volume)
+}
+
+// High returns the highest high of the last n candles and whether
+// any candle was appended.
+func (a *Aggregates) High() (decimal.Decimal, bool) {
+	if len(a.highs) == 0 {
+		return decimal.Zero, false
+	}
+
+	return a.highs[0].value, true
+}
+
+// Low returns the lowest low of the last n candles and whether any
+// candle was appended.
+func (a *Aggregates) Low() (decimal.Decimal, bool) {
+	if len(a.lows) == 0 {
+		return decimal.Zero, false
+	}
+
+	return a.lows[0].value, true
+}
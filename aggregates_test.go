@@ -0,0 +1,70 @@
+package chartype
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_NewAggregates(t *testing.T) {
+	_, err := NewAggregates(0)
+	assert.Equal(t, ErrInvalidLength, err)
+
+	a, err := NewAggregates(2)
+	assert.NoError(t, err)
+	assert.NotNil(t, a)
+}
+
+func Test_Candles_Aggregates(t *testing.T) {
+	tm := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	_, err := Candles{}.Aggregates(0)
+	assert.Equal(t, ErrInvalidLength, err)
+
+	a, err := Candles{}.Aggregates(2)
+	assert.NoError(t, err)
+	assert.Equal(t, 0, a.Count())
+	assert.True(t, a.Volume().IsZero())
+	assert.True(t, a.VWAP().IsZero())
+
+	_, ok := a.High()
+	assert.False(t, ok)
+
+	_, ok = a.Low()
+	assert.False(t, ok)
+
+	a, err = Candles{
+		testCandle(tm, 5, 9, 3, 6, 1),
+		testCandle(tm.Add(time.Minute), 6, 7, 4, 7, 3),
+	}.Aggregates(2)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, a.Count())
+	assert.Equal(t, "4", a.Volume().String())
+	assert.Equal(t, "6", a.VWAP().String())
+
+	h, ok := a.High()
+	assert.True(t, ok)
+	assert.Equal(t, "9", h.String())
+
+	l, ok := a.Low()
+	assert.True(t, ok)
+	assert.Equal(t, "3", l.String())
+
+	a.Append(testCandle(tm.Add(2*time.Minute), 7, 8, 5, 8, 0))
+
+	h, _ = a.High()
+	assert.Equal(t, "8", h.String())
+
+	l, _ = a.Low()
+	assert.Equal(t, "4", l.String())
+
+	a.Append(testCandle(tm.Add(3*time.Minute), 8, 8, 6, 7, 0))
+
+	h, _ = a.High()
+	assert.Equal(t, "8", h.String())
+
+	l, _ = a.Low()
+	assert.Equal(t, "5", l.String())
+	assert.Equal(t, "6", a.VWAP().String())
+}
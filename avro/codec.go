@@ -0,0 +1,255 @@
+package avro
+
+import (
+	"encoding/binary"
+	"time"
+
+	"github.com/jellydator/chartype"
+	"github.com/shopspring/decimal"
+)
+
+// magicByte is the first byte of every message in the schema
+// registry wire format.
+const magicByte byte = 0
+
+var (
+	// ErrInvalidData is returned when provided data cannot be decoded
+	// as the expected Avro record.
+	ErrInvalidData = &chartype.Error{
+		Code:    chartype.CodeParseFailure,
+		Message: "invalid avro data",
+	}
+)
+
+// EncodeCandle appends Avro binary encoding of the candle
+// to dst and returns the extended slice.
+func EncodeCandle(dst []byte, c chartype.Candle) []byte {
+	dst = appendLong(dst, c.Timestamp.UnixNano())
+	dst = appendDecimal(dst, c.Open)
+	dst = appendDecimal(dst, c.High)
+	dst = appendDecimal(dst, c.Low)
+	dst = appendDecimal(dst, c.Close)
+	dst = appendDecimal(dst, c.Volume)
+
+	dst = appendOptionalDecimal(dst, c.AdjClose)
+
+	return appendOptionalDecimal(dst, c.Delta)
+}
+
+// DecodeCandle decodes Avro binary encoded candle.
+func DecodeCandle(d []byte) (chartype.Candle, error) {
+	r := reader{data: d}
+	ts := r.long()
+
+	c, err := chartype.ParseCandle(time.Unix(0, ts).UTC(), r.string(), r.string(),
+		r.string(), r.string(), r.string())
+	if err == nil {
+		c.AdjClose, err = r.optionalDecimal()
+	}
+
+	if err == nil {
+		c.Delta, err = r.optionalDecimal()
+	}
+
+	if err = r.finish(err); err != nil {
+		return chartype.Candle{}, err
+	}
+
+	return c, nil
+}
+
+// EncodeTicker appends Avro binary encoding of the ticker
+// to dst and returns the extended slice.
+func EncodeTicker(dst []byte, t chartype.Ticker) []byte {
+	dst = appendDecimal(dst, t.Last)
+	dst = appendDecimal(dst, t.Ask)
+	dst = appendDecimal(dst, t.Bid)
+	dst = appendDecimal(dst, t.Change)
+	dst = appendDecimal(dst, t.PercentChange.Points())
+
+	return appendDecimal(dst, t.Volume)
+}
+
+// DecodeTicker decodes Avro binary encoded ticker.
+func DecodeTicker(d []byte) (chartype.Ticker, error) {
+	r := reader{data: d}
+
+	t, err := chartype.ParseTicker(r.string(), r.string(), r.string(),
+		r.string(), r.string(), r.string())
+	if err = r.finish(err); err != nil {
+		return chartype.Ticker{}, err
+	}
+
+	return t, nil
+}
+
+// EncodeTrade appends Avro binary encoding of the trade
+// to dst and returns the extended slice. Trade's side must be valid.
+func EncodeTrade(dst []byte, t chartype.Trade) ([]byte, error) {
+	if err := t.Side.Validate(); err != nil {
+		return nil, err
+	}
+
+	dst = appendString(dst, t.ID)
+	dst = appendLong(dst, t.Timestamp.UnixNano())
+	dst = appendDecimal(dst, t.Price)
+	dst = appendDecimal(dst, t.Amount)
+
+	// enum symbols are ordered the same way as side constants
+	return appendLong(dst, int64(t.Side-chartype.SideBuy)), nil
+}
+
+// DecodeTrade decodes Avro binary encoded trade.
+func DecodeTrade(d []byte) (chartype.Trade, error) {
+	r := reader{data: d}
+	id := r.string()
+	ts := r.long()
+	ps, as := r.string(), r.string()
+	s := chartype.Side(r.long()) + chartype.SideBuy
+
+	t, err := chartype.ParseTrade(time.Unix(0, ts).UTC(), ps, as, s)
+	if err = r.finish(err); err != nil {
+		return chartype.Trade{}, err
+	}
+
+	t.ID = id
+
+	return t, nil
+}
+
+// Frame prefixes Avro encoded data with the schema registry wire
+// format header: a zero magic byte and a big-endian schema ID.
+func Frame(schemaID uint32, d []byte) []byte {
+	res := make([]byte, 5, 5+len(d))
+	res[0] = magicByte
+	binary.BigEndian.PutUint32(res[1:], schemaID)
+
+	return append(res, d...)
+}
+
+// Unframe splits schema registry wire format message into schema ID
+// and Avro encoded data.
+func Unframe(d []byte) (uint32, []byte, error) {
+	if len(d) < 5 || d[0] != magicByte {
+		return 0, nil, ErrInvalidData
+	}
+
+	return binary.BigEndian.Uint32(d[1:5]), d[5:], nil
+}
+
+// appendLong appends zig-zag variable-length encoded integer.
+func appendLong(dst []byte, v int64) []byte {
+	var b [binary.MaxVarintLen64]byte
+
+	n := binary.PutVarint(b[:], v)
+
+	return append(dst, b[:n]...)
+}
+
+// appendString appends length-prefixed string.
+func appendString(dst []byte, s string) []byte {
+	dst = appendLong(dst, int64(len(s)))
+	return append(dst, s...)
+}
+
+// appendDecimal appends decimal as a length-prefixed string.
+func appendDecimal(dst []byte, d decimal.Decimal) []byte {
+	return appendString(dst, d.String())
+}
+
+// appendOptionalDecimal appends decimal as a union of null and
+// length-prefixed string.
+func appendOptionalDecimal(dst []byte, d *decimal.Decimal) []byte {
+	if d == nil {
+		return appendLong(dst, 0)
+	}
+
+	return appendDecimal(appendLong(dst, 1), *d)
+}
+
+// reader decodes Avro primitive values sequentially. The first
+// decoding error is remembered and all subsequent reads return zero
+// values.
+type reader struct {
+	data []byte
+	pos  int
+	err  error
+}
+
+// long reads zig-zag variable-length encoded integer.
+func (r *reader) long() int64 {
+	if r.err != nil {
+		return 0
+	}
+
+	v, n := binary.Varint(r.data[r.pos:])
+	if n <= 0 {
+		r.err = ErrInvalidData
+		return 0
+	}
+
+	r.pos += n
+
+	return v
+}
+
+// string reads length-prefixed string.
+func (r *reader) string() string {
+	l := r.long()
+	if r.err != nil {
+		return ""
+	}
+
+	if l < 0 || int64(len(r.data)-r.pos) < l {
+		r.err = ErrInvalidData
+		return ""
+	}
+
+	s := string(r.data[r.pos : r.pos+int(l)])
+	r.pos += int(l)
+
+	return s
+}
+
+// optionalDecimal reads a union of null and length-prefixed string
+// and parses the string as a decimal. Decoding errors are remembered,
+// parsing errors are returned.
+func (r *reader) optionalDecimal() (*decimal.Decimal, error) {
+	switch r.long() {
+	case 0:
+		return nil, nil
+	case 1:
+		s := r.string()
+		if r.err != nil {
+			return nil, nil
+		}
+
+		d, err := decimal.NewFromString(s)
+		if err != nil {
+			return nil, err
+		}
+
+		return &d, nil
+	default:
+		if r.err == nil {
+			r.err = ErrInvalidData
+		}
+
+		return nil, nil
+	}
+}
+
+// finish returns the first decoding error, the provided parsing
+// error or an error if unread data remains.
+func (r *reader) finish(err error) error {
+	switch {
+	case r.err != nil:
+		return r.err
+	case err != nil:
+		return err
+	case r.pos != len(r.data):
+		return ErrInvalidData
+	default:
+		return nil
+	}
+}
@@ -0,0 +1,287 @@
+package avro
+
+import (
+	"testing"
+	"time"
+
+	"github.com/jellydator/chartype"
+	"github.com/jellydator/chartype/chartypetest"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_EncodeCandle(t *testing.T) {
+	c := chartype.Candle{
+		Timestamp: time.Unix(0, 1).UTC(),
+		Open:      decimal.NewFromInt(1),
+		High:      decimal.NewFromInt(-1),
+		Low:       decimal.NewFromInt(3),
+		Close:     decimal.NewFromInt(4),
+		Volume:    decimal.NewFromInt(5),
+	}
+
+	d := EncodeCandle([]byte{9}, c)
+	assert.Equal(t, []byte{9, 2, 2, '1', 4, '-', '1', 2, '3', 2, '4', 2, '5', 0, 0}, d)
+
+	res, err := DecodeCandle(d[1:])
+	assert.NoError(t, err)
+	assert.Equal(t, c, res)
+
+	adj := decimal.RequireFromString("3.5")
+	c.AdjClose = &adj
+
+	d = EncodeCandle(nil, c)
+	assert.Equal(t, []byte{2, 2, '1', 4, '-', '1', 2, '3', 2, '4', 2, '5', 2, 6, '3', '.', '5', 0}, d)
+
+	res, err = DecodeCandle(d)
+	assert.NoError(t, err)
+	assert.Equal(t, c, res)
+
+	delta := decimal.RequireFromString("-2")
+	c.Delta = &delta
+
+	d = EncodeCandle(nil, c)
+	assert.Equal(t, []byte{2, 2, '1', 4, '-', '1', 2, '3', 2, '4', 2, '5', 2, 6, '3', '.', '5', 2, 4, '-', '2'}, d)
+
+	res, err = DecodeCandle(d)
+	assert.NoError(t, err)
+	assert.Equal(t, c, res)
+}
+
+func Test_DecodeCandle(t *testing.T) {
+	cc := map[string]struct {
+		Data []byte
+		Err  error
+	}{
+		"Invalid timestamp": {
+			Data: []byte{0xff},
+			Err:  ErrInvalidData,
+		},
+		"Missing string": {
+			Data: []byte{2},
+			Err:  ErrInvalidData,
+		},
+		"Too long string": {
+			Data: []byte{2, 4, '1'},
+			Err:  ErrInvalidData,
+		},
+		"Negative string length": {
+			Data: []byte{2, 1},
+			Err:  ErrInvalidData,
+		},
+		"Invalid decimal": {
+			Data: []byte{2, 2, '-', 2, '2', 2, '3', 2, '4', 2, '5'},
+			Err:  assert.AnError,
+		},
+		"Missing adjusted close": {
+			Data: []byte{2, 2, '1', 2, '2', 2, '3', 2, '4', 2, '5'},
+			Err:  ErrInvalidData,
+		},
+		"Invalid adjusted close union index": {
+			Data: []byte{2, 2, '1', 2, '2', 2, '3', 2, '4', 2, '5', 4},
+			Err:  ErrInvalidData,
+		},
+		"Missing adjusted close string": {
+			Data: []byte{2, 2, '1', 2, '2', 2, '3', 2, '4', 2, '5', 2},
+			Err:  ErrInvalidData,
+		},
+		"Invalid adjusted close": {
+			Data: []byte{2, 2, '1', 2, '2', 2, '3', 2, '4', 2, '5', 2, 2, '-'},
+			Err:  assert.AnError,
+		},
+		"Missing volume delta": {
+			Data: []byte{2, 2, '1', 2, '2', 2, '3', 2, '4', 2, '5', 0},
+			Err:  ErrInvalidData,
+		},
+		"Invalid volume delta": {
+			Data: []byte{2, 2, '1', 2, '2', 2, '3', 2, '4', 2, '5', 0, 2, 2, '-'},
+			Err:  assert.AnError,
+		},
+		"Trailing data": {
+			Data: []byte{2, 2, '1', 2, '2', 2, '3', 2, '4', 2, '5', 0, 0, 0},
+			Err:  ErrInvalidData,
+		},
+		"Successful decode": {
+			Data: []byte{2, 2, '1', 2, '2', 2, '3', 2, '4', 2, '5', 0, 0},
+		},
+		"Successful decode with adjusted close": {
+			Data: []byte{2, 2, '1', 2, '2', 2, '3', 2, '4', 2, '5', 2, 2, '4', 0},
+		},
+		"Successful decode with volume delta": {
+			Data: []byte{2, 2, '1', 2, '2', 2, '3', 2, '4', 2, '5', 0, 2, 2, '4'},
+		},
+	}
+
+	for cn, c := range cc {
+		c := c
+
+		t.Run(cn, func(t *testing.T) {
+			t.Parallel()
+
+			_, err := DecodeCandle(c.Data)
+			chartypetest.AssertErrorEqual(t, c.Err, err)
+		})
+	}
+}
+
+func Test_EncodeTicker(t *testing.T) {
+	tk := chartype.Ticker{
+		Last:          decimal.NewFromInt(1),
+		Ask:           decimal.NewFromInt(2),
+		Bid:           decimal.NewFromInt(3),
+		Change:        decimal.NewFromInt(4),
+		PercentChange: chartype.NewPercent(decimal.RequireFromString("0.5")),
+		Volume:        decimal.NewFromInt(6),
+	}
+
+	res, err := DecodeTicker(EncodeTicker(nil, tk))
+	assert.NoError(t, err)
+	assert.Equal(t, tk, res)
+}
+
+func Test_DecodeTicker(t *testing.T) {
+	cc := map[string]struct {
+		Data []byte
+		Err  error
+	}{
+		"Missing string": {
+			Data: []byte{},
+			Err:  ErrInvalidData,
+		},
+		"Invalid decimal": {
+			Data: []byte{2, '-', 2, '2', 2, '3', 2, '4', 2, '5', 2, '6'},
+			Err:  assert.AnError,
+		},
+		"Successful decode": {
+			Data: []byte{2, '1', 2, '2', 2, '3', 2, '4', 2, '5', 2, '6'},
+		},
+	}
+
+	for cn, c := range cc {
+		c := c
+
+		t.Run(cn, func(t *testing.T) {
+			t.Parallel()
+
+			_, err := DecodeTicker(c.Data)
+			chartypetest.AssertErrorEqual(t, c.Err, err)
+		})
+	}
+}
+
+func Test_EncodeTrade(t *testing.T) {
+	cc := map[string]struct {
+		Trade chartype.Trade
+		Err   error
+	}{
+		"Invalid Side": {
+			Trade: chartype.Trade{},
+			Err:   chartype.ErrInvalidSide,
+		},
+		"Successful buy encode": {
+			Trade: chartype.Trade{
+				ID:        "abc",
+				Timestamp: time.Unix(5, 0).UTC(),
+				Price:     decimal.NewFromInt(1),
+				Amount:    decimal.RequireFromString("0.25"),
+				Side:      chartype.SideBuy,
+			},
+		},
+		"Successful sell encode": {
+			Trade: chartype.Trade{
+				Timestamp: time.Unix(5, 0).UTC(),
+				Price:     decimal.NewFromInt(1),
+				Amount:    decimal.NewFromInt(2),
+				Side:      chartype.SideSell,
+			},
+		},
+	}
+
+	for cn, c := range cc {
+		c := c
+
+		t.Run(cn, func(t *testing.T) {
+			t.Parallel()
+
+			d, err := EncodeTrade(nil, c.Trade)
+			chartypetest.AssertErrorEqual(t, c.Err, err)
+			if err != nil {
+				return
+			}
+
+			res, err := DecodeTrade(d)
+			assert.NoError(t, err)
+			assert.Equal(t, c.Trade, res)
+		})
+	}
+}
+
+func Test_DecodeTrade(t *testing.T) {
+	cc := map[string]struct {
+		Data []byte
+		Err  error
+	}{
+		"Missing ID": {
+			Data: []byte{},
+			Err:  ErrInvalidData,
+		},
+		"Invalid Side": {
+			Data: []byte{0, 2, 2, '1', 2, '2', 4},
+			Err:  chartype.ErrInvalidSide,
+		},
+		"Successful decode": {
+			Data: []byte{0, 2, 2, '1', 2, '2', 2},
+		},
+	}
+
+	for cn, c := range cc {
+		c := c
+
+		t.Run(cn, func(t *testing.T) {
+			t.Parallel()
+
+			_, err := DecodeTrade(c.Data)
+			chartypetest.AssertErrorEqual(t, c.Err, err)
+		})
+	}
+}
+
+func Test_Frame(t *testing.T) {
+	d := Frame(258, []byte{7})
+	assert.Equal(t, []byte{0, 0, 0, 1, 2, 7}, d)
+
+	id, res, err := Unframe(d)
+	assert.NoError(t, err)
+	assert.Equal(t, uint32(258), id)
+	assert.Equal(t, []byte{7}, res)
+}
+
+func Test_Unframe(t *testing.T) {
+	cc := map[string]struct {
+		Data []byte
+		Err  error
+	}{
+		"Too short": {
+			Data: []byte{0, 0, 0, 1},
+			Err:  ErrInvalidData,
+		},
+		"Invalid magic byte": {
+			Data: []byte{1, 0, 0, 0, 1},
+			Err:  ErrInvalidData,
+		},
+		"Successful unframe": {
+			Data: []byte{0, 0, 0, 0, 1},
+		},
+	}
+
+	for cn, c := range cc {
+		c := c
+
+		t.Run(cn, func(t *testing.T) {
+			t.Parallel()
+
+			_, _, err := Unframe(c.Data)
+			chartypetest.AssertErrorEqual(t, c.Err, err)
+		})
+	}
+}
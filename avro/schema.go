@@ -0,0 +1,57 @@
+// Package avro provides Avro schema definitions and binary encoding
+// and decoding of chartype's structures, compatible with schema
+// registry based pipelines.
+//
+// Decimal values are encoded as strings to preserve their exact
+// representation. Optional decimal values are encoded as unions of
+// null and string. Timestamps are encoded as Unix time in
+// nanoseconds.
+package avro
+
+const (
+	// CandleSchema is the Avro schema of chartype.Candle.
+	CandleSchema = `{
+  "type": "record",
+  "name": "Candle",
+  "namespace": "com.jellydator.chartype",
+  "fields": [
+    {"name": "timestamp", "type": {"type": "long", "logicalType": "timestamp-nanos"}},
+    {"name": "open", "type": "string"},
+    {"name": "high", "type": "string"},
+    {"name": "low", "type": "string"},
+    {"name": "close", "type": "string"},
+    {"name": "volume", "type": "string"},
+    {"name": "adj_close", "type": ["null", "string"], "default": null},
+    {"name": "delta", "type": ["null", "string"], "default": null}
+  ]
+}`
+
+	// TickerSchema is the Avro schema of chartype.Ticker.
+	TickerSchema = `{
+  "type": "record",
+  "name": "Ticker",
+  "namespace": "com.jellydator.chartype",
+  "fields": [
+    {"name": "last", "type": "string"},
+    {"name": "ask", "type": "string"},
+    {"name": "bid", "type": "string"},
+    {"name": "change", "type": "string"},
+    {"name": "percent_change", "type": "string"},
+    {"name": "volume", "type": "string"}
+  ]
+}`
+
+	// TradeSchema is the Avro schema of chartype.Trade.
+	TradeSchema = `{
+  "type": "record",
+  "name": "Trade",
+  "namespace": "com.jellydator.chartype",
+  "fields": [
+    {"name": "id", "type": "string"},
+    {"name": "timestamp", "type": {"type": "long", "logicalType": "timestamp-nanos"}},
+    {"name": "price", "type": "string"},
+    {"name": "amount", "type": "string"},
+    {"name": "side", "type": {"type": "enum", "name": "Side", "symbols": ["BUY", "SELL"]}}
+  ]
+}`
+)
@@ -0,0 +1,14 @@
+package avro
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Schemas(t *testing.T) {
+	for _, s := range []string{CandleSchema, TickerSchema, TradeSchema} {
+		assert.True(t, json.Valid([]byte(s)))
+	}
+}
@@ -0,0 +1,183 @@
+package chartype
+
+import (
+	"context"
+	"time"
+)
+
+var (
+	// ErrInvalidChunkSize is returned when negative chunk size is
+	// being used.
+	ErrInvalidChunkSize = newError(CodeInvalidArgument, "invalid chunk size")
+
+	// ErrCorruptCandles is returned when the source returns candles
+	// with anomalies, such as invalid price ranges or misaligned
+	// timestamps.
+	ErrCorruptCandles = newError(CodeDataLoss, "corrupt candles")
+
+	// ErrChecksumMismatch is returned when candles read back from the
+	// store differ from the written ones.
+	ErrChecksumMismatch = newError(CodeDataLoss, "checksum mismatch")
+)
+
+// BackfillProgress describes the state of a backfill job after a
+// chunk is written.
+type BackfillProgress struct {
+	// Chunk specifies the time range of the written chunk.
+	Chunk TimeRange `json:"chunk" yaml:"chunk"`
+
+	// Done specifies the number of written chunks.
+	Done int `json:"done" yaml:"done"`
+
+	// Total specifies the number of planned chunks.
+	Total int `json:"total" yaml:"total"`
+
+	// Candles specifies the number of written candles.
+	Candles int `json:"candles" yaml:"candles"`
+}
+
+// Backfill fills gaps of candle series in a store with candles
+// retrieved from a source. Every chunk is verified before and after
+// it is written, so corrupt data is never silently persisted.
+//
+// Jobs are planned from the store's contents, so a job that is
+// interrupted, e.g. by a canceled context or a failed request,
+// resumes from the first missing chunk when it is run again.
+type Backfill struct {
+	// Store specifies the store whose gaps are filled.
+	Store CandleStore
+
+	// Source specifies the source of missing candles.
+	Source CandleSource
+
+	// ChunkSize specifies the maximum number of candles retrieved by
+	// a single request. Zero means that each gap is retrieved by a
+	// single request.
+	ChunkSize int
+
+	// OnProgress is an optional function called after each chunk is
+	// written.
+	OnProgress func(BackfillProgress)
+}
+
+// Plan returns time ranges, at most chunk size candles long, that
+// contain no candles of the pair's interval series in the store
+// within the provided time range.
+func (b Backfill) Plan(p Pair, i Interval, tr TimeRange) ([]TimeRange, error) {
+	if b.ChunkSize < 0 {
+		return nil, ErrInvalidChunkSize
+	}
+
+	if _, err := newSeriesKey(p, i); err != nil {
+		return nil, err
+	}
+
+	if err := tr.Validate(); err != nil {
+		return nil, err
+	}
+
+	cc, err := b.Store.Range(p, i, tr.From, tr.To)
+	if err != nil {
+		return nil, err
+	}
+
+	gg := Candles(cc).gaps(i, tr)
+
+	if b.ChunkSize == 0 {
+		return gg, nil
+	}
+
+	step := i.Duration() * time.Duration(b.ChunkSize)
+
+	var res []TimeRange
+
+	for _, g := range gg {
+		for from := g.From; from.Before(g.To); from = from.Add(step) {
+			to := from.Add(step)
+			if to.After(g.To) {
+				to = g.To
+			}
+
+			res = append(res, TimeRange{From: from, To: to})
+		}
+	}
+
+	return res, nil
+}
+
+// Run plans the pair's interval series gaps within the provided time
+// range and fills them chunk by chunk in chronological order.
+// Candles of each chunk are checked for anomalies before they are
+// written and compared with the stored ones by their hashes after
+// they are written. The first error stops the job; chunks written
+// before it are kept.
+func (b Backfill) Run(ctx context.Context, p Pair, i Interval, tr TimeRange) error {
+	plan, err := b.Plan(p, i, tr)
+	if err != nil {
+		return err
+	}
+
+	prog := BackfillProgress{Total: len(plan)}
+
+	for _, chunk := range plan {
+		if err = ctx.Err(); err != nil {
+			return err
+		}
+
+		var n int
+
+		if n, err = b.fill(ctx, p, i, chunk); err != nil {
+			return err
+		}
+
+		prog.Chunk = chunk
+		prog.Done++
+		prog.Candles += n
+
+		if b.OnProgress != nil {
+			b.OnProgress(prog)
+		}
+	}
+
+	return nil
+}
+
+// fill retrieves, verifies and writes the candles of a single chunk
+// and returns their number.
+func (b Backfill) fill(ctx context.Context, p Pair, i Interval, chunk TimeRange) (int, error) {
+	cc, err := b.Source.Candles(ctx, CandleRequest{Pair: p, Interval: i, Range: chunk})
+	if err != nil {
+		return 0, err
+	}
+
+	res := make([]Candle, 0, len(cc))
+
+	for _, c := range cc {
+		if chunk.Contains(c.Timestamp) {
+			res = append(res, c)
+		}
+	}
+
+	if len(res) == 0 {
+		return 0, nil
+	}
+
+	if len(Candles(res).anomalies(i)) > 0 {
+		return 0, ErrCorruptCandles
+	}
+
+	if err = b.Store.Put(p, i, res...); err != nil {
+		return 0, err
+	}
+
+	stored, err := b.Store.Range(p, i, chunk.From, chunk.To)
+	if err != nil {
+		return 0, err
+	}
+
+	if Hash(stored) != Hash(res) {
+		return 0, ErrChecksumMismatch
+	}
+
+	return len(res), nil
+}
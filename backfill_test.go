@@ -0,0 +1,198 @@
+package chartype
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// faultyStore is a memory store whose operations can be made to fail
+// or to return altered candles.
+type faultyStore struct {
+	*MemoryStore
+	putErr   error
+	rangeErr error
+	alter    bool
+}
+
+func (fs *faultyStore) Put(p Pair, i Interval, cc ...Candle) error {
+	if fs.putErr != nil {
+		return fs.putErr
+	}
+
+	return fs.MemoryStore.Put(p, i, cc...)
+}
+
+func (fs *faultyStore) Range(p Pair, i Interval, from, to time.Time) ([]Candle, error) {
+	if fs.rangeErr != nil {
+		return nil, fs.rangeErr
+	}
+
+	cc, err := fs.MemoryStore.Range(p, i, from, to)
+	if fs.alter && len(cc) > 0 {
+		cc[0].Volume = cc[0].Volume.Add(cc[0].Volume)
+	}
+
+	return cc, err
+}
+
+func Test_Backfill_Plan(t *testing.T) {
+	tm := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	p := Pair{Base: "BTC", Quote: "USD"}
+	tr := TimeRange{From: tm, To: tm.Add(10 * time.Minute)}
+
+	ms := NewMemoryStore()
+	require.NoError(t, ms.Put(p, IntervalMinute,
+		testCandle(tm.Add(2*time.Minute), 1, 1, 1, 1, 1),
+		testCandle(tm.Add(3*time.Minute), 1, 1, 1, 1, 1),
+	))
+
+	_, err := Backfill{Store: ms, ChunkSize: -1}.Plan(p, IntervalMinute, tr)
+	assert.Equal(t, ErrInvalidChunkSize, err)
+
+	_, err = Backfill{Store: ms}.Plan(p, IntervalMinute, TimeRange{})
+	assert.Equal(t, ErrInvalidTimeRange, err)
+
+	_, err = Backfill{Store: ms}.Plan(Pair{}, IntervalMinute, tr)
+	assert.Equal(t, ErrInvalidPair, err)
+
+	_, err = Backfill{Store: &faultyStore{MemoryStore: ms, rangeErr: assert.AnError}}.Plan(p, IntervalMinute, tr)
+	assert.Equal(t, assert.AnError, err)
+
+	_, err = Backfill{Store: ms}.Plan(p, 70, tr)
+	assert.Equal(t, ErrInvalidInterval, err)
+
+	res, err := Backfill{Store: ms}.Plan(p, IntervalMinute, tr)
+	assert.NoError(t, err)
+	assert.Equal(t, []TimeRange{
+		{From: tm, To: tm.Add(2 * time.Minute)},
+		{From: tm.Add(4 * time.Minute), To: tm.Add(10 * time.Minute)},
+	}, res)
+
+	res, err = Backfill{Store: ms, ChunkSize: 4}.Plan(p, IntervalMinute, tr)
+	assert.NoError(t, err)
+	assert.Equal(t, []TimeRange{
+		{From: tm, To: tm.Add(2 * time.Minute)},
+		{From: tm.Add(4 * time.Minute), To: tm.Add(8 * time.Minute)},
+		{From: tm.Add(8 * time.Minute), To: tm.Add(10 * time.Minute)},
+	}, res)
+}
+
+func Test_Backfill_Run(t *testing.T) {
+	tm := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	p := Pair{Base: "BTC", Quote: "USD"}
+	tr := TimeRange{From: tm, To: tm.Add(6 * time.Minute)}
+
+	var series []Candle
+	for i := 0; i < 6; i++ {
+		series = append(series, testCandle(tm.Add(time.Duration(i)*time.Minute), 1, 2, 1, 2, 3))
+	}
+
+	// the last minute was not traded
+	src := &testSource{candles: series[:5]}
+	ms := NewMemoryStore()
+	require.NoError(t, ms.Put(p, IntervalMinute, series[2]))
+
+	assert.Equal(t, ErrInvalidChunkSize, Backfill{Store: ms, ChunkSize: -1}.Run(context.Background(), p, IntervalMinute, tr))
+
+	var prog []BackfillProgress
+
+	b := Backfill{
+		Store:      ms,
+		Source:     src,
+		ChunkSize:  2,
+		OnProgress: func(bp BackfillProgress) { prog = append(prog, bp) },
+	}
+
+	// interrupted job
+	src.setErr(assert.AnError)
+	assert.Equal(t, assert.AnError, b.Run(context.Background(), p, IntervalMinute, tr))
+	assert.Empty(t, prog)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	assert.Equal(t, context.Canceled, b.Run(ctx, p, IntervalMinute, tr))
+
+	src.setErr(nil)
+	assert.NoError(t, b.Run(context.Background(), p, IntervalMinute, tr))
+
+	res, err := ms.Range(p, IntervalMinute, tr.From, tr.To)
+	require.NoError(t, err)
+	assert.Equal(t, series[:5], res)
+	assert.Equal(t, []BackfillProgress{
+		{Chunk: TimeRange{From: tm, To: tm.Add(2 * time.Minute)}, Done: 1, Total: 3, Candles: 2},
+		{Chunk: TimeRange{From: tm.Add(3 * time.Minute), To: tm.Add(5 * time.Minute)}, Done: 2, Total: 3, Candles: 4},
+		{Chunk: TimeRange{From: tm.Add(5 * time.Minute), To: tm.Add(6 * time.Minute)}, Done: 3, Total: 3, Candles: 4},
+	}, prog)
+
+	// resumed job retrieves only the remaining gap
+	calls := src.Calls()
+	prog = nil
+
+	assert.NoError(t, b.Run(context.Background(), p, IntervalMinute, tr))
+	assert.Equal(t, calls+1, src.Calls())
+	assert.Len(t, prog, 1)
+}
+
+func Test_Backfill_fill(t *testing.T) {
+	tm := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	p := Pair{Base: "BTC", Quote: "USD"}
+	chunk := TimeRange{From: tm, To: tm.Add(2 * time.Minute)}
+
+	valid := &testSource{candles: []Candle{
+		testCandle(tm.Add(-time.Minute), 1, 1, 1, 1, 1),
+		testCandle(tm, 1, 1, 1, 1, 1),
+		testCandle(tm.Add(2*time.Minute), 1, 1, 1, 1, 1),
+	}}
+
+	cc := map[string]struct {
+		Source CandleSource
+		Store  *faultyStore
+		Count  int
+		Err    error
+	}{
+		"Candles with anomalies": {
+			Source: &testSource{candles: []Candle{testCandle(tm, 1, 1, 2, 1, 1)}},
+			Store:  &faultyStore{},
+			Err:    ErrCorruptCandles,
+		},
+		"Put error": {
+			Source: valid,
+			Store:  &faultyStore{putErr: assert.AnError},
+			Err:    assert.AnError,
+		},
+		"Range error": {
+			Source: valid,
+			Store:  &faultyStore{rangeErr: assert.AnError},
+			Err:    assert.AnError,
+		},
+		"Checksum mismatch": {
+			Source: valid,
+			Store:  &faultyStore{alter: true},
+			Err:    ErrChecksumMismatch,
+		},
+		"Candles outside of the chunk are skipped": {
+			Source: valid,
+			Store:  &faultyStore{},
+			Count:  1,
+		},
+	}
+
+	for cn, c := range cc {
+		c := c
+
+		t.Run(cn, func(t *testing.T) {
+			t.Parallel()
+
+			c.Store.MemoryStore = NewMemoryStore()
+
+			n, err := Backfill{Store: c.Store, Source: c.Source}.fill(context.Background(), p, IntervalMinute, chunk)
+			equalError(t, c.Err, err)
+			assert.Equal(t, c.Count, n)
+		})
+	}
+}
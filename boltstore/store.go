@@ -0,0 +1,332 @@
+// Package boltstore provides a bbolt-backed implementation of
+// chartype's candle store.
+package boltstore
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/json"
+	"time"
+
+	"github.com/jellydator/chartype"
+	bolt "go.etcd.io/bbolt"
+)
+
+// rootBucket is the name of the bucket that holds all candle series.
+const rootBucket = "candles"
+
+// Store is a candle store that keeps candles in a bbolt database.
+// Each series is stored in a separate nested bucket, named after
+// its pair and interval, with big-endian timestamp keys, so range
+// scans are ordered by pair, interval and timestamp.
+// It is safe for concurrent use.
+type Store struct {
+	db *bolt.DB
+}
+
+var (
+	_ chartype.CandleStore     = (*Store)(nil)
+	_ chartype.ResampledRanger = (*Store)(nil)
+	_ chartype.SeriesLister    = (*Store)(nil)
+)
+
+// New creates a new candle store on top of the database. The root
+// bucket is created if it does not exist.
+func New(db *bolt.DB) (*Store, error) {
+	err := db.Update(func(tx *bolt.Tx) error {
+		_, err := tx.CreateBucketIfNotExists([]byte(rootBucket))
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &Store{db: db}, nil
+}
+
+// Put inserts the candles into the pair's interval series.
+// Candles with timestamps that already exist replace the stored ones.
+func (s *Store) Put(p chartype.Pair, i chartype.Interval, cc ...chartype.Candle) error {
+	name, err := seriesName(p, i)
+	if err != nil {
+		return err
+	}
+
+	return s.db.Update(func(tx *bolt.Tx) error {
+		b, err := tx.Bucket([]byte(rootBucket)).CreateBucketIfNotExists(name)
+		if err != nil {
+			return err
+		}
+
+		for _, c := range cc {
+			v, err := json.Marshal(c)
+			if err != nil {
+				return err
+			}
+
+			if err = b.Put(timeKey(c.Timestamp), v); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+}
+
+// Range returns the pair's interval series candles within the
+// [from, to) period, sorted by timestamp in ascending order.
+func (s *Store) Range(p chartype.Pair, i chartype.Interval, from, to time.Time) ([]chartype.Candle, error) {
+	name, err := seriesRangeName(p, i, from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	var res []chartype.Candle
+
+	err = s.db.View(func(tx *bolt.Tx) error {
+		b := tx.Bucket([]byte(rootBucket)).Bucket(name)
+		if b == nil {
+			return nil
+		}
+
+		end := timeKey(to)
+		cur := b.Cursor()
+
+		for k, v := cur.Seek(timeKey(from)); k != nil && bytes.Compare(k, end) < 0; k, v = cur.Next() {
+			c, err := decodeCandle(k, v)
+			if err != nil {
+				return err
+			}
+
+			res = append(res, c)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+// RangeResampled returns the pair's src interval series candles
+// within the [from, to) period resampled into dst interval candles,
+// sorted by timestamp in ascending order. Candles are aggregated
+// while being scanned, so the raw series is never loaded into memory.
+func (s *Store) RangeResampled(p chartype.Pair, src, dst chartype.Interval, from, to time.Time) ([]chartype.Candle, error) {
+	sr, err := chartype.NewStreamResampler(chartype.Resampler{Interval: dst})
+	if err != nil {
+		return nil, err
+	}
+
+	if err = src.ValidateResample(dst); err != nil {
+		return nil, err
+	}
+
+	name, err := seriesRangeName(p, src, from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	var res []chartype.Candle
+
+	err = s.db.View(func(tx *bolt.Tx) error {
+		b := tx.Bucket([]byte(rootBucket)).Bucket(name)
+		if b == nil {
+			return nil
+		}
+
+		end := timeKey(to)
+		cur := b.Cursor()
+
+		for k, v := cur.Seek(timeKey(from)); k != nil && bytes.Compare(k, end) < 0; k, v = cur.Next() {
+			c, err := decodeCandle(k, v)
+			if err != nil {
+				return err
+			}
+
+			if rc, ok := sr.Add(c); ok {
+				res = append(res, rc)
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if rc, ok := sr.Flush(); ok {
+		res = append(res, rc)
+	}
+
+	return res, nil
+}
+
+// Latest returns the newest candle of the pair's interval series.
+// chartype.ErrCandleNotFound is returned if the series is empty.
+func (s *Store) Latest(p chartype.Pair, i chartype.Interval) (chartype.Candle, error) {
+	name, err := seriesName(p, i)
+	if err != nil {
+		return chartype.Candle{}, err
+	}
+
+	var res chartype.Candle
+
+	err = s.db.View(func(tx *bolt.Tx) error {
+		b := tx.Bucket([]byte(rootBucket)).Bucket(name)
+		if b == nil {
+			return chartype.ErrCandleNotFound
+		}
+
+		k, v := b.Cursor().Last()
+		if k == nil {
+			return chartype.ErrCandleNotFound
+		}
+
+		res, err = decodeCandle(k, v)
+
+		return err
+	})
+	if err != nil {
+		return chartype.Candle{}, err
+	}
+
+	return res, nil
+}
+
+// Delete removes the pair's interval series candles within the
+// [from, to) period.
+func (s *Store) Delete(p chartype.Pair, i chartype.Interval, from, to time.Time) error {
+	name, err := seriesRangeName(p, i, from, to)
+	if err != nil {
+		return err
+	}
+
+	return s.db.Update(func(tx *bolt.Tx) error {
+		b := tx.Bucket([]byte(rootBucket)).Bucket(name)
+		if b == nil {
+			return nil
+		}
+
+		end := timeKey(to)
+		cur := b.Cursor()
+
+		var kk [][]byte
+
+		for k, _ := cur.Seek(timeKey(from)); k != nil && bytes.Compare(k, end) < 0; k, _ = cur.Next() {
+			kk = append(kk, k)
+		}
+
+		for _, k := range kk {
+			if err := b.Delete(k); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+}
+
+// Series returns all non-empty series of the store, sorted by their
+// bucket names.
+func (s *Store) Series() ([]chartype.Series, error) {
+	var res []chartype.Series
+
+	err := s.db.View(func(tx *bolt.Tx) error {
+		root := tx.Bucket([]byte(rootBucket))
+
+		return root.ForEach(func(name, _ []byte) error {
+			if k, _ := root.Bucket(name).Cursor().First(); k == nil {
+				return nil
+			}
+
+			sr, err := parseSeriesName(name)
+			if err != nil {
+				return err
+			}
+
+			res = append(res, sr)
+
+			return nil
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+// seriesName validates the pair and the interval and returns the name
+// of their series bucket, e.g. "BTC_USDT/1h".
+func seriesName(p chartype.Pair, i chartype.Interval) ([]byte, error) {
+	if err := p.Validate(); err != nil {
+		return nil, err
+	}
+
+	if err := i.Validate(); err != nil {
+		return nil, err
+	}
+
+	return []byte(p.String() + "/" + i.String()), nil
+}
+
+// parseSeriesName parses the name of a series bucket into its pair
+// and interval.
+func parseSeriesName(name []byte) (chartype.Series, error) {
+	var sr chartype.Series
+
+	j := bytes.LastIndexByte(name, '/')
+	if j < 0 {
+		return chartype.Series{}, chartype.ErrInvalidPair
+	}
+
+	if err := sr.Pair.UnmarshalText(name[:j]); err != nil {
+		return chartype.Series{}, err
+	}
+
+	if err := sr.Interval.UnmarshalText(name[j+1:]); err != nil {
+		return chartype.Series{}, err
+	}
+
+	return sr, nil
+}
+
+// seriesRangeName validates the pair, the interval and the period of
+// time and returns the name of their series bucket.
+func seriesRangeName(p chartype.Pair, i chartype.Interval, from, to time.Time) ([]byte, error) {
+	name, err := seriesName(p, i)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = (chartype.TimeRange{From: from, To: to}).Validate(); err != nil {
+		return nil, err
+	}
+
+	return name, nil
+}
+
+// timeKey returns the key of the timestamp. The sign bit is flipped,
+// so byte order of keys matches chronological order.
+func timeKey(t time.Time) []byte {
+	k := make([]byte, 8)
+	binary.BigEndian.PutUint64(k, uint64(t.UnixNano())^(1<<63))
+
+	return k
+}
+
+// decodeCandle decodes the stored candle. Its timestamp is restored
+// from the key in UTC.
+func decodeCandle(k, v []byte) (chartype.Candle, error) {
+	var c chartype.Candle
+	if err := json.Unmarshal(v, &c); err != nil {
+		return chartype.Candle{}, err
+	}
+
+	c.Timestamp = time.Unix(0, int64(binary.BigEndian.Uint64(k)^(1<<63))).UTC()
+
+	return c, nil
+}
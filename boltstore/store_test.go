@@ -0,0 +1,275 @@
+package boltstore
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/jellydator/chartype"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	bolt "go.etcd.io/bbolt"
+)
+
+func testCandle(tm time.Time, v int64) chartype.Candle {
+	return chartype.Candle{
+		Timestamp: tm,
+		Open:      decimal.NewFromInt(v),
+		High:      decimal.NewFromInt(v),
+		Low:       decimal.NewFromInt(v),
+		Close:     decimal.NewFromInt(v),
+		Volume:    decimal.NewFromInt(v),
+	}
+}
+
+func testDB(t *testing.T) *bolt.DB {
+	t.Helper()
+
+	dir, err := ioutil.TempDir("", "boltstore")
+	require.NoError(t, err)
+
+	t.Cleanup(func() { os.RemoveAll(dir) })
+
+	db, err := bolt.Open(filepath.Join(dir, "candles.db"), 0600, nil)
+	require.NoError(t, err)
+
+	t.Cleanup(func() { db.Close() })
+
+	return db
+}
+
+func Test_New(t *testing.T) {
+	db := testDB(t)
+	require.NoError(t, db.Close())
+
+	_, err := New(db)
+	assert.Equal(t, bolt.ErrDatabaseNotOpen, err)
+
+	s, err := New(testDB(t))
+	assert.NoError(t, err)
+	assert.NotNil(t, s)
+}
+
+func Test_Store(t *testing.T) {
+	tm := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	p := chartype.Pair{Base: "BTC", Quote: "USDT"}
+	at := func(m int) time.Time {
+		return tm.Add(time.Duration(m) * time.Minute)
+	}
+
+	s, err := New(testDB(t))
+	require.NoError(t, err)
+
+	assert.Equal(t, chartype.ErrInvalidPair, s.Put(chartype.Pair{}, chartype.IntervalMinute))
+	assert.Equal(t, chartype.ErrInvalidInterval, s.Put(p, 0))
+	assert.Error(t, s.Put(p, chartype.IntervalMinute, testCandle(time.Date(10000, 1, 1, 0, 0, 0, 0, time.UTC), 1)))
+
+	_, err = s.Latest(p, chartype.IntervalMinute)
+	assert.Equal(t, chartype.ErrCandleNotFound, err)
+
+	assert.NoError(t, s.Put(p, chartype.IntervalMinute,
+		testCandle(at(2), 3),
+		testCandle(at(0), 1),
+		testCandle(at(1), 2),
+		testCandle(at(-1), 9),
+	))
+	assert.NoError(t, s.Put(p, chartype.IntervalMinute,
+		testCandle(at(1), 5),
+		testCandle(at(3), 4),
+	))
+
+	_, err = s.Range(chartype.Pair{}, chartype.IntervalMinute, at(0), at(3))
+	assert.Equal(t, chartype.ErrInvalidPair, err)
+
+	_, err = s.Range(p, chartype.IntervalMinute, at(3), at(0))
+	assert.Equal(t, chartype.ErrInvalidTimeRange, err)
+
+	res, err := s.Range(p, chartype.IntervalMinute, at(1), at(3))
+	assert.NoError(t, err)
+	assert.Equal(t, []chartype.Candle{testCandle(at(1), 5), testCandle(at(2), 3)}, res)
+
+	res, err = s.Range(p, chartype.IntervalHour, at(0), at(3))
+	assert.NoError(t, err)
+	assert.Empty(t, res)
+
+	_, err = s.Latest(chartype.Pair{}, chartype.IntervalMinute)
+	assert.Equal(t, chartype.ErrInvalidPair, err)
+
+	_, err = s.Latest(p, chartype.IntervalHour)
+	assert.Equal(t, chartype.ErrCandleNotFound, err)
+
+	c, err := s.Latest(p, chartype.IntervalMinute)
+	assert.NoError(t, err)
+	assert.Equal(t, testCandle(at(3), 4), c)
+
+	assert.Equal(t, chartype.ErrInvalidPair, s.Delete(chartype.Pair{}, chartype.IntervalMinute, at(0), at(1)))
+	assert.Equal(t, chartype.ErrInvalidTimeRange, s.Delete(p, chartype.IntervalMinute, at(1), at(0)))
+	assert.NoError(t, s.Delete(p, chartype.IntervalHour, at(0), at(1)))
+	assert.NoError(t, s.Delete(p, chartype.IntervalMinute, at(1), at(3)))
+
+	res, err = s.Range(p, chartype.IntervalMinute, at(-1), at(4))
+	assert.NoError(t, err)
+	assert.Equal(t, []chartype.Candle{testCandle(at(-1), 9), testCandle(at(0), 1), testCandle(at(3), 4)}, res)
+
+	assert.NoError(t, s.Delete(p, chartype.IntervalMinute, at(-1), at(4)))
+
+	_, err = s.Latest(p, chartype.IntervalMinute)
+	assert.Equal(t, chartype.ErrCandleNotFound, err)
+}
+
+func Test_Store_Errors(t *testing.T) {
+	tm := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	p := chartype.Pair{Base: "BTC", Quote: "USDT"}
+
+	db := testDB(t)
+
+	s, err := New(db)
+	require.NoError(t, err)
+
+	require.NoError(t, db.Update(func(tx *bolt.Tx) error {
+		b, err := tx.Bucket([]byte(rootBucket)).CreateBucket([]byte("BTC_USDT/1m"))
+		if err != nil {
+			return err
+		}
+
+		return b.Put(timeKey(tm), []byte("{"))
+	}))
+
+	_, err = s.Range(p, chartype.IntervalMinute, tm, tm.Add(time.Hour))
+	assert.Error(t, err)
+
+	_, err = s.Latest(p, chartype.IntervalMinute)
+	assert.Error(t, err)
+
+	// series bucket name is taken by a value
+	require.NoError(t, db.Update(func(tx *bolt.Tx) error {
+		return tx.Bucket([]byte(rootBucket)).Put([]byte("BTC_USDT/1h"), []byte("{"))
+	}))
+
+	assert.Equal(t, bolt.ErrIncompatibleValue, s.Put(p, chartype.IntervalHour, testCandle(tm, 1)))
+
+	// candle key is taken by a nested bucket
+	require.NoError(t, db.Update(func(tx *bolt.Tx) error {
+		b, err := tx.Bucket([]byte(rootBucket)).CreateBucket([]byte("BTC_USDT/1d"))
+		if err != nil {
+			return err
+		}
+
+		_, err = b.CreateBucket(timeKey(tm))
+
+		return err
+	}))
+
+	assert.Equal(t, bolt.ErrIncompatibleValue, s.Put(p, chartype.IntervalDay, testCandle(tm, 1)))
+	assert.Equal(t, bolt.ErrIncompatibleValue, s.Delete(p, chartype.IntervalDay, tm, tm.Add(time.Hour)))
+
+	require.NoError(t, db.Close())
+
+	assert.Equal(t, bolt.ErrDatabaseNotOpen, s.Put(p, chartype.IntervalMinute))
+	assert.Equal(t, bolt.ErrDatabaseNotOpen, s.Delete(p, chartype.IntervalMinute, tm, tm.Add(time.Hour)))
+}
+
+func Test_Store_RangeResampled(t *testing.T) {
+	tm := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	p := chartype.Pair{Base: "BTC", Quote: "USDT"}
+
+	db := testDB(t)
+
+	s, err := New(db)
+	require.NoError(t, err)
+
+	require.NoError(t, s.Put(p, chartype.IntervalHour,
+		testCandle(tm.Add(-time.Hour), 9),
+		testCandle(tm, 1),
+		testCandle(tm.Add(12*time.Hour), 3),
+		testCandle(tm.Add(24*time.Hour), 4),
+		testCandle(tm.Add(48*time.Hour), 8),
+	))
+
+	_, err = s.RangeResampled(p, chartype.IntervalHour, 0, tm, tm.Add(time.Hour))
+	assert.Equal(t, chartype.ErrInvalidInterval, err)
+
+	_, err = s.RangeResampled(p, chartype.IntervalDay, chartype.IntervalHour, tm, tm.Add(time.Hour))
+	assert.Equal(t, chartype.ErrIncompatibleIntervals, err)
+
+	_, err = s.RangeResampled(chartype.Pair{}, chartype.IntervalHour, chartype.IntervalDay, tm, tm.Add(time.Hour))
+	assert.Equal(t, chartype.ErrInvalidPair, err)
+
+	res, err := s.RangeResampled(p, chartype.IntervalMinute, chartype.IntervalDay, tm, tm.Add(48*time.Hour))
+	assert.NoError(t, err)
+	assert.Empty(t, res)
+
+	res, err = s.RangeResampled(p, chartype.IntervalHour, chartype.IntervalDay, tm, tm.Add(48*time.Hour))
+	assert.NoError(t, err)
+	assert.Equal(t, []chartype.Candle{
+		{
+			Timestamp: tm,
+			Open:      decimal.NewFromInt(1),
+			High:      decimal.NewFromInt(3),
+			Low:       decimal.NewFromInt(1),
+			Close:     decimal.NewFromInt(3),
+			Volume:    decimal.NewFromInt(4),
+		},
+		testCandle(tm.Add(24*time.Hour), 4),
+	}, res)
+
+	require.NoError(t, db.Update(func(tx *bolt.Tx) error {
+		return tx.Bucket([]byte(rootBucket)).Bucket([]byte("BTC_USDT/1h")).Put(timeKey(tm), []byte("{"))
+	}))
+
+	_, err = s.RangeResampled(p, chartype.IntervalHour, chartype.IntervalDay, tm, tm.Add(48*time.Hour))
+	assert.Error(t, err)
+}
+
+func Test_Store_Series(t *testing.T) {
+	tm := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	btc, eth := chartype.Pair{Base: "BTC", Quote: "USDT"}, chartype.Pair{Base: "ETH", Quote: "USDT"}
+
+	db := testDB(t)
+
+	s, err := New(db)
+	require.NoError(t, err)
+
+	require.NoError(t, s.Put(btc, chartype.IntervalMinute, testCandle(tm, 1)))
+	require.NoError(t, s.Put(btc, chartype.IntervalHour, testCandle(tm, 1)))
+	require.NoError(t, s.Put(eth, chartype.IntervalMinute, testCandle(tm, 1)))
+	require.NoError(t, s.Put(eth, chartype.IntervalHour))
+
+	res, err := s.Series()
+	assert.NoError(t, err)
+	assert.Equal(t, []chartype.Series{
+		{Pair: btc, Interval: chartype.IntervalHour},
+		{Pair: btc, Interval: chartype.IntervalMinute},
+		{Pair: eth, Interval: chartype.IntervalMinute},
+	}, res)
+
+	for name, exp := range map[string]error{
+		"x":          chartype.ErrInvalidPair,
+		"BTC/1m":     chartype.ErrInvalidPair,
+		"BTC_USDT/x": chartype.ErrInvalidInterval,
+	} {
+		require.NoError(t, db.Update(func(tx *bolt.Tx) error {
+			b, err := tx.Bucket([]byte(rootBucket)).CreateBucket([]byte(name))
+			if err != nil {
+				return err
+			}
+
+			return b.Put(timeKey(tm), []byte("{}"))
+		}))
+
+		_, err = s.Series()
+		assert.Equal(t, exp, err)
+
+		require.NoError(t, db.Update(func(tx *bolt.Tx) error {
+			return tx.Bucket([]byte(rootBucket)).DeleteBucket([]byte(name))
+		}))
+	}
+
+	require.NoError(t, db.Close())
+
+	_, err = s.Series()
+	assert.Equal(t, bolt.ErrDatabaseNotOpen, err)
+}
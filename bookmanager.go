@@ -0,0 +1,197 @@
+package chartype
+
+import (
+	"sort"
+	"time"
+)
+
+var (
+	// ErrBufferFull is returned when a delta cannot be buffered
+	// because the buffer is full.
+	ErrBufferFull = newError(CodeBufferFull, "buffer full")
+)
+
+// BookDelta holds changes of order book's price levels. Levels with
+// zero amount are removed from the book, other levels replace the
+// ones with equal prices or are inserted.
+type BookDelta struct {
+	Sequence  uint64       `json:"sequence" yaml:"sequence"`
+	Timestamp time.Time    `json:"timestamp" yaml:"timestamp"`
+	Bids      []PriceLevel `json:"bids" yaml:"bids"`
+	Asks      []PriceLevel `json:"asks" yaml:"asks"`
+}
+
+// BookManager maintains a local order book from a snapshot and a
+// stream of sequenced deltas.
+//
+// Deltas received before the snapshot are buffered, up to the buffer
+// size, and those newer than the snapshot are applied to it once it
+// arrives. When a gap in
+// sequence numbers is detected, the book is discarded, the resync
+// function is called, ErrSequenceGap is returned and deltas, starting
+// with the one that revealed the gap, are buffered again until a new
+// snapshot is provided. Stale deltas are ignored.
+//
+// BookManager is not safe for concurrent use.
+type BookManager struct {
+	onResync func()
+	size     int
+	tracker  *SequenceTracker
+	book     OrderBook
+	synced   bool
+	buffer   []BookDelta
+}
+
+// NewBookManager creates a new book manager that waits for a
+// snapshot and buffers up to size deltas until it arrives. The resync
+// function is called synchronously whenever a new snapshot is needed.
+func NewBookManager(size int, onResync func()) (*BookManager, error) {
+	if size <= 0 {
+		return nil, ErrInvalidBufferSize
+	}
+
+	return &BookManager{
+		onResync: onResync,
+		size:     size,
+		tracker:  NewSequenceTracker(),
+	}, nil
+}
+
+// Snapshot replaces the book with the snapshot whose state includes
+// all deltas up to the provided sequence number and applies buffered
+// deltas newer than it. ErrCrossedBook is returned and the snapshot
+// is ignored if its best bid is not lower than its best ask.
+// ErrSequenceGap is returned if a gap was detected among the buffered
+// deltas and a resync was requested.
+func (bm *BookManager) Snapshot(ob OrderBook, seq uint64) error {
+	if err := ob.Validate(); err != nil {
+		return err
+	}
+
+	bm.book = OrderBook{
+		Timestamp: ob.Timestamp,
+		Bids:      append([]PriceLevel(nil), ob.Bids...),
+		Asks:      append([]PriceLevel(nil), ob.Asks...),
+	}
+	bm.synced = true
+	bm.tracker.Reset(seq + 1)
+
+	buffer := bm.buffer
+	bm.buffer = nil
+
+	sort.SliceStable(buffer, func(i, j int) bool {
+		return buffer[i].Sequence < buffer[j].Sequence
+	})
+
+	for i, d := range buffer {
+		if err := bm.Apply(d); err != nil {
+			bm.buffer = append(bm.buffer, buffer[i+1:]...)
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Apply applies the delta to the book or buffers it if there is no
+// snapshot yet. ErrBufferFull is returned and the delta is dropped if
+// the buffer is full; unless a snapshot includes it, the gap it
+// leaves is detected after the snapshot arrives. ErrSequenceGap is returned if a
+// gap was detected and a resync was requested.
+func (bm *BookManager) Apply(d BookDelta) error {
+	if !bm.synced {
+		if len(bm.buffer) >= bm.size {
+			return ErrBufferFull
+		}
+
+		bm.buffer = append(bm.buffer, d)
+
+		return nil
+	}
+
+	switch bm.tracker.Track(d.Sequence) {
+	case SequenceStale:
+		return nil
+	case SequenceGap:
+		bm.book = OrderBook{}
+		bm.synced = false
+		bm.buffer = append(bm.buffer, d)
+
+		if bm.onResync != nil {
+			bm.onResync()
+		}
+
+		return ErrSequenceGap
+	}
+
+	if !d.Timestamp.IsZero() {
+		bm.book.Timestamp = d.Timestamp
+	}
+
+	for _, l := range d.Bids {
+		bm.book.Bids = updateLevel(bm.book.Bids, l, true)
+	}
+
+	for _, l := range d.Asks {
+		bm.book.Asks = updateLevel(bm.book.Asks, l, false)
+	}
+
+	return nil
+}
+
+// Book returns a copy of the current order book and whether it is in
+// sync with the feed.
+func (bm *BookManager) Book() (OrderBook, bool) {
+	if !bm.synced {
+		return OrderBook{}, false
+	}
+
+	return OrderBook{
+		Timestamp: bm.book.Timestamp,
+		Bids:      append([]PriceLevel(nil), bm.book.Bids...),
+		Asks:      append([]PriceLevel(nil), bm.book.Asks...),
+	}, true
+}
+
+// Sequence returns the sequence number of the last applied delta or
+// snapshot and whether the book is in sync with the feed.
+func (bm *BookManager) Sequence() (uint64, bool) {
+	if !bm.synced {
+		return 0, false
+	}
+
+	next, _ := bm.tracker.Next()
+
+	return next - 1, true
+}
+
+// updateLevel replaces, inserts or, if its amount is zero, removes
+// the price level in the side ordered from the highest price if desc
+// is true or from the lowest one otherwise.
+func updateLevel(ll []PriceLevel, l PriceLevel, desc bool) []PriceLevel {
+	i := sort.Search(len(ll), func(i int) bool {
+		if desc {
+			return ll[i].Price.LessThanOrEqual(l.Price)
+		}
+
+		return ll[i].Price.GreaterThanOrEqual(l.Price)
+	})
+
+	found := i < len(ll) && ll[i].Price.Equal(l.Price)
+
+	switch {
+	case l.Amount.IsZero() && found:
+		return append(ll[:i], ll[i+1:]...)
+	case l.Amount.IsZero():
+		return ll
+	case found:
+		ll[i] = l
+		return ll
+	}
+
+	ll = append(ll, PriceLevel{})
+	copy(ll[i+1:], ll[i:])
+	ll[i] = l
+
+	return ll
+}
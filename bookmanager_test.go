@@ -0,0 +1,244 @@
+package chartype
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_NewBookManager(t *testing.T) {
+	cc := map[string]struct {
+		Size int
+		Err  error
+	}{
+		"Invalid size": {
+			Err: ErrInvalidBufferSize,
+		},
+		"Successful creation": {
+			Size: 1,
+		},
+	}
+
+	for cn, c := range cc {
+		c := c
+
+		t.Run(cn, func(t *testing.T) {
+			t.Parallel()
+
+			bm, err := NewBookManager(c.Size, nil)
+			assert.Equal(t, c.Err, err)
+			if err != nil {
+				return
+			}
+
+			assert.NotNil(t, bm)
+		})
+	}
+}
+
+// bookStep is a single book manager operation: a snapshot when Book is
+// set, a delta otherwise.
+type bookStep struct {
+	Book     *OrderBook
+	Sequence uint64
+	Delta    BookDelta
+	Err      error
+}
+
+func Test_BookManager(t *testing.T) {
+	tm := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	snap := OrderBook{
+		Timestamp: tm,
+		Bids:      []PriceLevel{testLevel("9", "1"), testLevel("7", "1")},
+		Asks:      []PriceLevel{testLevel("11", "1"), testLevel("12", "1")},
+	}
+
+	crossed := OrderBook{
+		Bids: []PriceLevel{testLevel("11", "1")},
+		Asks: []PriceLevel{testLevel("11", "1")},
+	}
+
+	empty := OrderBook{Timestamp: tm}
+
+	cc := map[string]struct {
+		Size     int
+		NoResync bool
+		Steps    []bookStep
+		Book     *OrderBook
+		Sequence uint64
+		Resyncs  int
+	}{
+		"Not synced": {},
+		"Full buffer": {
+			Size: 1,
+			Steps: []bookStep{
+				{Delta: BookDelta{Sequence: 11}},
+				{Delta: BookDelta{Sequence: 12}, Err: ErrBufferFull},
+				{Book: &empty, Sequence: 10},
+				{Delta: BookDelta{Sequence: 13}, Err: ErrSequenceGap},
+			},
+			Resyncs: 1,
+		},
+		"Successful snapshot after a full buffer": {
+			Size: 1,
+			Steps: []bookStep{
+				{Delta: BookDelta{Sequence: 11}},
+				{Delta: BookDelta{Sequence: 12}, Err: ErrBufferFull},
+				{Book: &empty, Sequence: 12},
+			},
+			Book:     &empty,
+			Sequence: 12,
+		},
+		"Crossed snapshot": {
+			Steps: []bookStep{
+				{Book: &crossed, Sequence: 10, Err: ErrCrossedBook},
+			},
+		},
+		"Successful snapshot with buffered deltas": {
+			Steps: []bookStep{
+				{Delta: BookDelta{Sequence: 12, Bids: []PriceLevel{testLevel("9", "0")}}},
+				{Delta: BookDelta{Sequence: 10, Bids: []PriceLevel{testLevel("8", "1")}}},
+				{Delta: BookDelta{Sequence: 11, Timestamp: tm.Add(time.Second), Asks: []PriceLevel{testLevel("11", "3")}}},
+				{Book: &snap, Sequence: 10},
+			},
+			Book: &OrderBook{
+				Timestamp: tm.Add(time.Second),
+				Bids:      []PriceLevel{testLevel("7", "1")},
+				Asks:      []PriceLevel{testLevel("11", "3"), testLevel("12", "1")},
+			},
+			Sequence: 12,
+		},
+		"Successful delta application": {
+			Steps: []bookStep{
+				{Book: &snap, Sequence: 12},
+				{Delta: BookDelta{
+					Sequence: 13,
+					Bids:     []PriceLevel{testLevel("10", "2"), testLevel("6", "1"), testLevel("8", "4"), testLevel("5", "0")},
+					Asks:     []PriceLevel{testLevel("13", "1"), testLevel("10", "1")},
+				}},
+				// stale
+				{Delta: BookDelta{Sequence: 13, Bids: []PriceLevel{testLevel("10", "0")}}},
+			},
+			Book: &OrderBook{
+				Timestamp: tm,
+				Bids:      []PriceLevel{testLevel("10", "2"), testLevel("9", "1"), testLevel("8", "4"), testLevel("7", "1"), testLevel("6", "1")},
+				Asks:      []PriceLevel{testLevel("10", "1"), testLevel("11", "1"), testLevel("12", "1"), testLevel("13", "1")},
+			},
+			Sequence: 13,
+		},
+		"Gap in deltas": {
+			Steps: []bookStep{
+				{Book: &snap, Sequence: 13},
+				{Delta: BookDelta{Sequence: 15}, Err: ErrSequenceGap},
+			},
+			Resyncs: 1,
+		},
+		"Gap in deltas without resync function": {
+			NoResync: true,
+			Steps: []bookStep{
+				{Book: &snap, Sequence: 1},
+				{Delta: BookDelta{Sequence: 3}, Err: ErrSequenceGap},
+			},
+		},
+		"Gap in buffered deltas": {
+			Steps: []bookStep{
+				{Delta: BookDelta{Sequence: 17}},
+				{Book: &snap, Sequence: 15, Err: ErrSequenceGap},
+			},
+			Resyncs: 1,
+		},
+		"Successful resync with buffered deltas": {
+			Steps: []bookStep{
+				{Delta: BookDelta{Sequence: 17}},
+				{Book: &snap, Sequence: 15, Err: ErrSequenceGap},
+				{Book: &snap, Sequence: 16},
+			},
+			Book:     &snap,
+			Sequence: 17,
+			Resyncs:  1,
+		},
+		"Successful resync with the delta that revealed a gap": {
+			Steps: []bookStep{
+				{Book: &empty, Sequence: 10},
+				{Delta: BookDelta{Sequence: 12, Bids: []PriceLevel{testLevel("9", "1")}}, Err: ErrSequenceGap},
+				{Delta: BookDelta{Sequence: 14, Asks: []PriceLevel{testLevel("11", "1")}}},
+				{Delta: BookDelta{Sequence: 13, Bids: []PriceLevel{testLevel("8", "1")}}},
+				{Book: &empty, Sequence: 11},
+			},
+			Book: &OrderBook{
+				Timestamp: tm,
+				Bids:      []PriceLevel{testLevel("9", "1"), testLevel("8", "1")},
+				Asks:      []PriceLevel{testLevel("11", "1")},
+			},
+			Sequence: 14,
+			Resyncs:  1,
+		},
+		"Successful resync with deltas following a replayed gap": {
+			Steps: []bookStep{
+				{Book: &empty, Sequence: 14},
+				{Delta: BookDelta{Sequence: 16}, Err: ErrSequenceGap},
+				{Delta: BookDelta{Sequence: 18}},
+				{Book: &empty, Sequence: 14, Err: ErrSequenceGap},
+				{Book: &empty, Sequence: 17},
+			},
+			Book:     &empty,
+			Sequence: 18,
+			Resyncs:  2,
+		},
+	}
+
+	for cn, c := range cc {
+		c := c
+
+		t.Run(cn, func(t *testing.T) {
+			t.Parallel()
+
+			var resyncs int
+
+			onResync := func() {
+				resyncs++
+			}
+
+			if c.NoResync {
+				onResync = nil
+			}
+
+			size := c.Size
+			if size == 0 {
+				size = 10
+			}
+
+			bm, err := NewBookManager(size, onResync)
+			require.NoError(t, err)
+
+			for _, s := range c.Steps {
+				if s.Book != nil {
+					assert.Equal(t, s.Err, bm.Snapshot(*s.Book, s.Sequence))
+					continue
+				}
+
+				assert.Equal(t, s.Err, bm.Apply(s.Delta))
+			}
+
+			assert.Equal(t, c.Resyncs, resyncs)
+
+			ob, ok := bm.Book()
+			assert.Equal(t, c.Book != nil, ok)
+
+			seq, sok := bm.Sequence()
+			assert.Equal(t, ok, sok)
+
+			if c.Book != nil {
+				assert.Equal(t, *c.Book, ob)
+				assert.Equal(t, c.Sequence, seq)
+			}
+
+			// snapshots are not modified by the applied deltas
+			assert.Len(t, snap.Bids, 2)
+			assert.Len(t, snap.Asks, 2)
+		})
+	}
+}
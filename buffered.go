@@ -0,0 +1,259 @@
+package chartype
+
+import (
+	"sort"
+	"sync"
+	"time"
+)
+
+var (
+	// ErrInvalidBufferSize is returned when zero or negative buffer
+	// size is being used.
+	ErrInvalidBufferSize = newError(CodeInvalidArgument, "invalid buffer size")
+
+	// ErrStoreClosed is returned when a closed store is being used.
+	ErrStoreClosed = newError(CodeClosed, "store closed")
+)
+
+// BufferedStore is a candle store decorator that buffers written
+// candles and writes them to the underlying store in batches. Writes
+// of candles with equal timestamps are deduplicated, so only the
+// latest state of an in-progress candle is written.
+//
+// Buffered candles are written when their number reaches the buffer
+// size, periodically, before reads and deletes, and on close. Errors
+// of periodic writes are returned by the next Put, Flush or Close
+// call; candles that failed to be written are kept in the buffer.
+//
+// BufferedStore is safe for concurrent use.
+type BufferedStore struct {
+	store CandleStore
+	size  int
+
+	flushMu sync.Mutex
+
+	mu      sync.Mutex
+	pending map[seriesKey]map[int64]Candle
+	count   int
+	err     error
+	closed  bool
+
+	stop chan struct{}
+	done chan struct{}
+}
+
+// NewBufferedStore creates a new buffered store on top of the candle
+// store. Buffered candles are written when their number reaches the
+// size and every period; zero period disables periodic writes.
+func NewBufferedStore(cs CandleStore, size int, period time.Duration) (*BufferedStore, error) {
+	if size <= 0 {
+		return nil, ErrInvalidBufferSize
+	}
+
+	if period < 0 {
+		return nil, ErrInvalidDuration
+	}
+
+	bs := &BufferedStore{
+		store:   cs,
+		size:    size,
+		pending: make(map[seriesKey]map[int64]Candle),
+		stop:    make(chan struct{}),
+		done:    make(chan struct{}),
+	}
+
+	if period == 0 {
+		close(bs.done)
+		return bs, nil
+	}
+
+	go bs.run(period)
+
+	return bs, nil
+}
+
+// run periodically writes buffered candles until the store is closed.
+func (bs *BufferedStore) run(period time.Duration) {
+	defer close(bs.done)
+
+	t := time.NewTicker(period)
+	defer t.Stop()
+
+	for {
+		select {
+		case <-t.C:
+			if err := bs.flush(); err != nil {
+				bs.mu.Lock()
+				bs.err = err
+				bs.mu.Unlock()
+			}
+		case <-bs.stop:
+			return
+		}
+	}
+}
+
+// Put buffers the candles of the pair's interval series. Buffered
+// candles are written if their number reaches the buffer size.
+func (bs *BufferedStore) Put(p Pair, i Interval, cc ...Candle) error {
+	k, err := newSeriesKey(p, i)
+	if err != nil {
+		return err
+	}
+
+	bs.mu.Lock()
+
+	if bs.closed {
+		bs.mu.Unlock()
+		return ErrStoreClosed
+	}
+
+	err, bs.err = bs.err, nil
+
+	for _, c := range cc {
+		bs.add(k, c)
+	}
+
+	full := bs.count >= bs.size
+	bs.mu.Unlock()
+
+	if err != nil {
+		return err
+	}
+
+	if full {
+		return bs.flush()
+	}
+
+	return nil
+}
+
+// add buffers the candle, replacing the buffered one with the same
+// timestamp. Mutex must be held.
+func (bs *BufferedStore) add(k seriesKey, c Candle) {
+	s, ok := bs.pending[k]
+	if !ok {
+		s = make(map[int64]Candle)
+		bs.pending[k] = s
+	}
+
+	ts := c.Timestamp.UnixNano()
+	if _, ok := s[ts]; !ok {
+		bs.count++
+	}
+
+	s[ts] = c
+}
+
+// Range writes buffered candles and returns the pair's interval
+// series candles within the [from, to) period.
+func (bs *BufferedStore) Range(p Pair, i Interval, from, to time.Time) ([]Candle, error) {
+	if err := bs.flush(); err != nil {
+		return nil, err
+	}
+
+	return bs.store.Range(p, i, from, to)
+}
+
+// Latest writes buffered candles and returns the newest candle of the
+// pair's interval series.
+func (bs *BufferedStore) Latest(p Pair, i Interval) (Candle, error) {
+	if err := bs.flush(); err != nil {
+		return Candle{}, err
+	}
+
+	return bs.store.Latest(p, i)
+}
+
+// Delete writes buffered candles and removes the pair's interval
+// series candles within the [from, to) period.
+func (bs *BufferedStore) Delete(p Pair, i Interval, from, to time.Time) error {
+	if err := bs.flush(); err != nil {
+		return err
+	}
+
+	return bs.store.Delete(p, i, from, to)
+}
+
+// Flush writes all buffered candles to the underlying store.
+func (bs *BufferedStore) Flush() error {
+	bs.mu.Lock()
+	err := bs.err
+	bs.err = nil
+	bs.mu.Unlock()
+
+	if err != nil {
+		return err
+	}
+
+	return bs.flush()
+}
+
+// Close stops periodic writes and writes all buffered candles to the
+// underlying store. The store cannot be written to after it is
+// closed.
+func (bs *BufferedStore) Close() error {
+	bs.mu.Lock()
+
+	if bs.closed {
+		bs.mu.Unlock()
+		return ErrStoreClosed
+	}
+
+	bs.closed = true
+	bs.mu.Unlock()
+
+	close(bs.stop)
+	<-bs.done
+
+	return bs.Flush()
+}
+
+// flush writes all buffered candles to the underlying store, series
+// by series. Series that failed to be written are returned to the
+// buffer unless newer candles were buffered in the meantime.
+func (bs *BufferedStore) flush() error {
+	bs.flushMu.Lock()
+	defer bs.flushMu.Unlock()
+
+	bs.mu.Lock()
+	pending := bs.pending
+	bs.pending = make(map[seriesKey]map[int64]Candle)
+	bs.count = 0
+	bs.mu.Unlock()
+
+	for k, s := range pending {
+		cc := make([]Candle, 0, len(s))
+		for _, c := range s {
+			cc = append(cc, c)
+		}
+
+		sort.Slice(cc, func(i, j int) bool {
+			return cc[i].Timestamp.Before(cc[j].Timestamp)
+		})
+
+		if err := bs.store.Put(k.pair, k.interval, cc...); err != nil {
+			bs.restore(pending)
+			return err
+		}
+
+		delete(pending, k)
+	}
+
+	return nil
+}
+
+// restore returns the candles to the buffer unless newer candles with
+// the same timestamps were buffered.
+func (bs *BufferedStore) restore(pending map[seriesKey]map[int64]Candle) {
+	bs.mu.Lock()
+	defer bs.mu.Unlock()
+
+	for k, s := range pending {
+		for ts, c := range s {
+			if _, ok := bs.pending[k][ts]; !ok {
+				bs.add(k, c)
+			}
+		}
+	}
+}
@@ -0,0 +1,174 @@
+package chartype
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// failingStore is a candle store whose writes fail while it is
+// set to fail.
+type failingStore struct {
+	*MemoryStore
+
+	mu   sync.Mutex
+	fail bool
+}
+
+func (fs *failingStore) setFail(fail bool) {
+	fs.mu.Lock()
+	fs.fail = fail
+	fs.mu.Unlock()
+}
+
+func (fs *failingStore) Put(p Pair, i Interval, cc ...Candle) error {
+	fs.mu.Lock()
+	defer fs.mu.Unlock()
+
+	if fs.fail {
+		return assert.AnError
+	}
+
+	return fs.MemoryStore.Put(p, i, cc...)
+}
+
+func Test_NewBufferedStore(t *testing.T) {
+	_, err := NewBufferedStore(NewMemoryStore(), 0, 0)
+	assert.Equal(t, ErrInvalidBufferSize, err)
+
+	_, err = NewBufferedStore(NewMemoryStore(), 1, -time.Second)
+	assert.Equal(t, ErrInvalidDuration, err)
+
+	bs, err := NewBufferedStore(NewMemoryStore(), 1, 0)
+	assert.NoError(t, err)
+
+	var _ CandleStore = bs
+}
+
+func Test_BufferedStore(t *testing.T) {
+	tm := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	p := Pair{Base: "BTC", Quote: "USDT"}
+	at := func(m int) time.Time {
+		return tm.Add(time.Duration(m) * time.Minute)
+	}
+
+	fs := &failingStore{MemoryStore: NewMemoryStore()}
+
+	bs, err := NewBufferedStore(fs, 3, 0)
+	require.NoError(t, err)
+
+	assert.Equal(t, ErrInvalidPair, bs.Put(Pair{}, IntervalMinute))
+
+	assert.NoError(t, bs.Put(p, IntervalMinute, testCandle(at(0), 1, 1, 1, 1, 1)))
+	assert.NoError(t, bs.Put(p, IntervalMinute, testCandle(at(0), 1, 2, 1, 2, 2)))
+	assert.NoError(t, bs.Put(p, IntervalMinute, testCandle(at(1), 2, 2, 2, 2, 2)))
+
+	_, err = fs.Latest(p, IntervalMinute)
+	assert.Equal(t, ErrCandleNotFound, err)
+
+	assert.NoError(t, bs.Put(p, IntervalHour, testCandle(tm, 1, 2, 1, 2, 3)))
+
+	res, err := fs.Range(p, IntervalMinute, at(0), at(2))
+	assert.NoError(t, err)
+	assert.Equal(t, []Candle{
+		testCandle(at(0), 1, 2, 1, 2, 2),
+		testCandle(at(1), 2, 2, 2, 2, 2),
+	}, res)
+
+	assert.NoError(t, bs.Put(p, IntervalMinute, testCandle(at(2), 3, 3, 3, 3, 3)))
+
+	c, err := bs.Latest(p, IntervalMinute)
+	assert.NoError(t, err)
+	assert.Equal(t, testCandle(at(2), 3, 3, 3, 3, 3), c)
+
+	fs.setFail(true)
+
+	assert.NoError(t, bs.Put(p, IntervalMinute, testCandle(at(3), 4, 4, 4, 4, 4)))
+
+	_, err = bs.Range(p, IntervalMinute, at(0), at(4))
+	assert.Equal(t, assert.AnError, err)
+
+	_, err = bs.Latest(p, IntervalMinute)
+	assert.Equal(t, assert.AnError, err)
+
+	assert.Equal(t, assert.AnError, bs.Delete(p, IntervalMinute, at(0), at(1)))
+
+	assert.NoError(t, bs.Put(p, IntervalMinute, testCandle(at(4), 5, 5, 5, 5, 5)))
+	assert.Equal(t, assert.AnError, bs.Put(p, IntervalMinute, testCandle(at(5), 6, 6, 6, 6, 6)))
+	assert.Equal(t, assert.AnError, bs.Flush())
+
+	fs.setFail(false)
+
+	assert.NoError(t, bs.Delete(p, IntervalMinute, at(0), at(1)))
+
+	res, err = bs.Range(p, IntervalMinute, at(0), at(6))
+	assert.NoError(t, err)
+	assert.Len(t, res, 5)
+
+	// error of a periodic write
+	bs.err = assert.AnError
+	assert.Equal(t, assert.AnError, bs.Put(p, IntervalMinute, testCandle(at(6), 7, 7, 7, 7, 7)))
+	assert.NoError(t, bs.Close())
+	assert.Equal(t, ErrStoreClosed, bs.Close())
+	assert.Equal(t, ErrStoreClosed, bs.Put(p, IntervalMinute))
+
+	c, err = fs.Latest(p, IntervalMinute)
+	assert.NoError(t, err)
+	assert.Equal(t, testCandle(at(6), 7, 7, 7, 7, 7), c)
+}
+
+func Test_BufferedStore_Periodic(t *testing.T) {
+	tm := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	p := Pair{Base: "BTC", Quote: "USDT"}
+
+	fs := &failingStore{MemoryStore: NewMemoryStore(), fail: true}
+
+	bs, err := NewBufferedStore(fs, 10, time.Millisecond)
+	require.NoError(t, err)
+
+	assert.NoError(t, bs.Put(p, IntervalMinute, testCandle(tm, 1, 1, 1, 1, 1)))
+
+	assert.Eventually(t, func() bool {
+		bs.mu.Lock()
+		defer bs.mu.Unlock()
+
+		return bs.err != nil
+	}, time.Second, time.Millisecond)
+
+	fs.setFail(false)
+
+	assert.Eventually(t, func() bool {
+		_, err := fs.Latest(p, IntervalMinute)
+		return err == nil
+	}, time.Second, time.Millisecond)
+
+	assert.Equal(t, assert.AnError, bs.Flush())
+	assert.NoError(t, bs.Put(p, IntervalMinute, testCandle(tm.Add(time.Minute), 2, 2, 2, 2, 2)))
+	assert.NoError(t, bs.Close())
+
+	res, err := fs.Range(p, IntervalMinute, tm, tm.Add(time.Hour))
+	assert.NoError(t, err)
+	assert.Len(t, res, 2)
+}
+
+func Test_BufferedStore_restore(t *testing.T) {
+	tm := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	k := seriesKey{pair: Pair{Base: "BTC", Quote: "USDT"}, interval: IntervalMinute}
+
+	bs, err := NewBufferedStore(NewMemoryStore(), 10, 0)
+	require.NoError(t, err)
+
+	bs.add(k, testCandle(tm, 2, 2, 2, 2, 2))
+	bs.restore(map[seriesKey]map[int64]Candle{
+		k: {
+			tm.UnixNano():                  testCandle(tm, 1, 1, 1, 1, 1),
+			tm.Add(time.Minute).UnixNano(): testCandle(tm.Add(time.Minute), 1, 1, 1, 1, 1),
+		},
+	})
+
+	assert.Equal(t, 2, bs.count)
+	assert.Equal(t, testCandle(tm, 2, 2, 2, 2, 2), bs.pending[k][tm.UnixNano()])
+}
@@ -0,0 +1,242 @@
+package chartype
+
+import (
+	"sort"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+var (
+	// ErrInvalidGracePeriod is returned when negative grace period
+	// is being used.
+	ErrInvalidGracePeriod = newError(CodeInvalidArgument, "invalid grace period")
+)
+
+// BuildResult holds candles produced by a single trade addition.
+type BuildResult struct {
+	// Closed holds candles that were completed because a trade
+	// from a later interval arrived. They are ordered by timestamp.
+	Closed []Candle
+
+	// Corrections holds corrections of already closed candles
+	// caused by late trades arriving within the grace period.
+	Corrections []CandleCorrection
+}
+
+// CandleBuilder aggregates trades into interval-long candles.
+//
+// Trades may arrive out of order. A trade that belongs to an already
+// closed candle is applied to it if the trade's timestamp is not
+// older than the newest seen trade's timestamp minus the grace period
+// (the watermark); a correction of the candle is then reported.
+// Trades older than the watermark are counted and dropped.
+//
+// Produced candles have their volume delta set to the volume of buy
+// trades minus the volume of sell trades. Trades without a valid side
+// count towards the volume only.
+//
+// CandleBuilder is not safe for concurrent use.
+type CandleBuilder struct {
+	interval Interval
+	grace    time.Duration
+
+	newest  time.Time
+	latest  time.Time
+	head    *tradeBucket
+	closed  []*tradeBucket
+	dropped int
+}
+
+// tradeBucket holds a candle being built together with the timestamps
+// of its first and last trades.
+type tradeBucket struct {
+	candle Candle
+	first  time.Time
+	last   time.Time
+}
+
+// NewCandleBuilder creates a new candle builder producing
+// interval-long candles and accepting late trades within the grace
+// period.
+func NewCandleBuilder(i Interval, grace time.Duration) (*CandleBuilder, error) {
+	if err := i.Validate(); err != nil {
+		return nil, err
+	}
+
+	if grace < 0 {
+		return nil, ErrInvalidGracePeriod
+	}
+
+	return &CandleBuilder{interval: i, grace: grace}, nil
+}
+
+// Add applies the trade to the candle of the interval it belongs to.
+func (cb *CandleBuilder) Add(t Trade) BuildResult {
+	var res BuildResult
+
+	if t.Timestamp.After(cb.newest) {
+		cb.newest = t.Timestamp
+	}
+
+	ts := cb.interval.Truncate(t.Timestamp)
+
+	switch {
+	case cb.head != nil && ts.Equal(cb.latest):
+		cb.head.add(t)
+	case cb.latest.IsZero() || ts.After(cb.latest):
+		if cb.head != nil {
+			res.Closed = append(res.Closed, cb.head.candle)
+			cb.closed = append(cb.closed, cb.head)
+		}
+
+		cb.head = newTradeBucket(ts, t)
+		cb.latest = ts
+	case t.Timestamp.Before(cb.watermark()):
+		cb.dropped++
+	default:
+		res.Corrections = append(res.Corrections, cb.amend(ts, t))
+	}
+
+	cb.prune()
+
+	return res
+}
+
+// Head returns the candle that is currently being built and whether
+// there is one.
+func (cb *CandleBuilder) Head() (Candle, bool) {
+	if cb.head == nil {
+		return Candle{}, false
+	}
+
+	return cb.head.candle, true
+}
+
+// Flush closes and returns the candle that is currently being built
+// and whether there was one. It can still be amended by late trades
+// within the grace period.
+func (cb *CandleBuilder) Flush() (Candle, bool) {
+	if cb.head == nil {
+		return Candle{}, false
+	}
+
+	c := cb.head.candle
+	cb.closed = append(cb.closed, cb.head)
+	cb.head = nil
+
+	return c, true
+}
+
+// Dropped returns the number of trades dropped because they arrived
+// after the grace period.
+func (cb *CandleBuilder) Dropped() int {
+	return cb.dropped
+}
+
+// watermark returns the time before which trades are dropped.
+func (cb *CandleBuilder) watermark() time.Time {
+	return cb.newest.Add(-cb.grace)
+}
+
+// amend applies the late trade to the closed candle with the
+// provided timestamp, creating the candle if no trades were
+// previously seen in its interval, and returns its correction.
+func (cb *CandleBuilder) amend(ts time.Time, t Trade) CandleCorrection {
+	// closed candles are sorted, i is the last one not after ts
+	i := sort.Search(len(cb.closed), func(j int) bool {
+		return cb.closed[j].candle.Timestamp.After(ts)
+	}) - 1
+
+	if i >= 0 && cb.closed[i].candle.Timestamp.Equal(ts) {
+		cb.closed[i].add(t)
+
+		return CandleCorrection{
+			Timestamp: ts,
+			Candle:    cb.closed[i].candle,
+			Reason:    CorrectionLateTrade,
+		}
+	}
+
+	nb := newTradeBucket(ts, t)
+	cb.closed = append(cb.closed, nil)
+	copy(cb.closed[i+2:], cb.closed[i+1:])
+	cb.closed[i+1] = nb
+
+	return CandleCorrection{
+		Timestamp: ts,
+		Candle:    nb.candle,
+		Reason:    CorrectionMissingCandle,
+	}
+}
+
+// prune removes closed candles that can no longer be amended.
+func (cb *CandleBuilder) prune() {
+	wm := cb.watermark()
+
+	var n int
+
+	for n < len(cb.closed) && !cb.closed[n].candle.Timestamp.Add(cb.interval.Duration()).After(wm) {
+		n++
+	}
+
+	cb.closed = cb.closed[n:]
+}
+
+// newTradeBucket creates a new trade bucket with the provided
+// timestamp and its first trade.
+func newTradeBucket(ts time.Time, t Trade) *tradeBucket {
+	return &tradeBucket{
+		candle: Candle{
+			Timestamp: ts,
+			Open:      t.Price,
+			High:      t.Price,
+			Low:       t.Price,
+			Close:     t.Price,
+			Volume:    t.Amount,
+			Delta:     tradeDelta(t),
+		},
+		first: t.Timestamp,
+		last:  t.Timestamp,
+	}
+}
+
+// add applies the trade to bucket's candle.
+func (b *tradeBucket) add(t Trade) {
+	if t.Timestamp.Before(b.first) {
+		b.first = t.Timestamp
+		b.candle.Open = t.Price
+	}
+
+	if !t.Timestamp.Before(b.last) {
+		b.last = t.Timestamp
+		b.candle.Close = t.Price
+	}
+
+	if t.Price.GreaterThan(b.candle.High) {
+		b.candle.High = t.Price
+	}
+
+	if t.Price.LessThan(b.candle.Low) {
+		b.candle.Low = t.Price
+	}
+
+	b.candle.Volume = b.candle.Volume.Add(t.Amount)
+
+	d := b.candle.Delta.Add(*tradeDelta(t))
+	b.candle.Delta = &d
+}
+
+// tradeDelta returns the trade's contribution to the volume delta.
+func tradeDelta(t Trade) *decimal.Decimal {
+	var d decimal.Decimal
+
+	switch t.Side {
+	case SideBuy:
+		d = t.Amount
+	case SideSell:
+		d = t.Amount.Neg()
+	}
+
+	return &d
+}
@@ -0,0 +1,240 @@
+package chartype
+
+import (
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_NewCandleBuilder(t *testing.T) {
+	cc := map[string]struct {
+		Interval Interval
+		Grace    time.Duration
+		Err      error
+	}{
+		"Invalid interval": {
+			Err: ErrInvalidInterval,
+		},
+		"Invalid grace period": {
+			Interval: IntervalMinute,
+			Grace:    -1,
+			Err:      ErrInvalidGracePeriod,
+		},
+		"Successful creation": {
+			Interval: IntervalMinute,
+			Grace:    time.Second,
+		},
+	}
+
+	for cn, c := range cc {
+		c := c
+
+		t.Run(cn, func(t *testing.T) {
+			t.Parallel()
+
+			cb, err := NewCandleBuilder(c.Interval, c.Grace)
+			equalError(t, c.Err, err)
+			if err != nil {
+				return
+			}
+
+			assert.NotNil(t, cb)
+		})
+	}
+}
+
+func testTrade(tm time.Time, p, a int64) Trade {
+	return Trade{
+		Timestamp: tm,
+		Price:     decimal.NewFromInt(p),
+		Amount:    decimal.NewFromInt(a),
+		Side:      SideBuy,
+	}
+}
+
+func testCandle(tm time.Time, o, h, l, c, v int64) Candle {
+	return Candle{
+		Timestamp: tm,
+		Open:      decimal.NewFromInt(o),
+		High:      decimal.NewFromInt(h),
+		Low:       decimal.NewFromInt(l),
+		Close:     decimal.NewFromInt(c),
+		Volume:    decimal.NewFromInt(v),
+	}
+}
+
+func testBuiltCandle(tm time.Time, o, h, l, c, v, d int64) Candle {
+	res := testCandle(tm, o, h, l, c, v)
+	res.Delta = decimalPtr(d)
+
+	return res
+}
+
+func testCorrection(c Candle, r CorrectionReason) CandleCorrection {
+	return CandleCorrection{Timestamp: c.Timestamp, Candle: c, Reason: r}
+}
+
+func Test_CandleBuilder_Add(t *testing.T) {
+	tm := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	cb, err := NewCandleBuilder(IntervalMinute, 30*time.Second)
+	assert.NoError(t, err)
+
+	_, ok := cb.Head()
+	assert.False(t, ok)
+
+	_, ok = cb.Flush()
+	assert.False(t, ok)
+
+	steps := []struct {
+		Trade  Trade
+		Result BuildResult
+		Head   Candle
+	}{
+		{
+			Trade: testTrade(tm.Add(10*time.Second), 10, 1),
+			Head:  testBuiltCandle(tm, 10, 10, 10, 10, 1, 1),
+		},
+		{
+			// out of order, but within the same interval
+			Trade: testTrade(tm.Add(5*time.Second), 9, 1),
+			Head:  testBuiltCandle(tm, 9, 10, 9, 10, 2, 2),
+		},
+		{
+			Trade: testTrade(tm.Add(50*time.Second), 12, 1),
+			Head:  testBuiltCandle(tm, 9, 12, 9, 12, 3, 3),
+		},
+		{
+			Trade: testTrade(tm.Add(70*time.Second), 11, 1),
+			Result: BuildResult{
+				Closed: []Candle{testBuiltCandle(tm, 9, 12, 9, 12, 3, 3)},
+			},
+			Head: testBuiltCandle(tm.Add(time.Minute), 11, 11, 11, 11, 1, 1),
+		},
+		{
+			// late, but within the grace period
+			Trade: testTrade(tm.Add(45*time.Second), 8, 1),
+			Result: BuildResult{
+				Corrections: []CandleCorrection{
+					testCorrection(testBuiltCandle(tm, 9, 12, 8, 12, 4, 4), CorrectionLateTrade),
+				},
+			},
+			Head: testBuiltCandle(tm.Add(time.Minute), 11, 11, 11, 11, 1, 1),
+		},
+		{
+			// late, after the grace period
+			Trade: testTrade(tm.Add(30*time.Second), 7, 1),
+			Head:  testBuiltCandle(tm.Add(time.Minute), 11, 11, 11, 11, 1, 1),
+		},
+		{
+			Trade: testTrade(tm.Add(140*time.Second), 13, 2),
+			Result: BuildResult{
+				Closed: []Candle{testBuiltCandle(tm.Add(time.Minute), 11, 11, 11, 11, 1, 1)},
+			},
+			Head: testBuiltCandle(tm.Add(2*time.Minute), 13, 13, 13, 13, 2, 2),
+		},
+		{
+			// first interval's candle is no longer kept
+			Trade: testTrade(tm.Add(59*time.Second), 7, 1),
+			Head:  testBuiltCandle(tm.Add(2*time.Minute), 13, 13, 13, 13, 2, 2),
+		},
+		{
+			Trade: testTrade(tm.Add(115*time.Second), 14, 1),
+			Result: BuildResult{
+				Corrections: []CandleCorrection{
+					testCorrection(testBuiltCandle(tm.Add(time.Minute), 11, 14, 11, 14, 2, 2), CorrectionLateTrade),
+				},
+			},
+			Head: testBuiltCandle(tm.Add(2*time.Minute), 13, 13, 13, 13, 2, 2),
+		},
+	}
+
+	for i, s := range steps {
+		res := cb.Add(s.Trade)
+		assert.Equal(t, s.Result, res, "step %d", i)
+
+		h, ok := cb.Head()
+		assert.True(t, ok)
+		assert.Equal(t, s.Head, h, "step %d", i)
+	}
+
+	assert.Equal(t, 2, cb.Dropped())
+
+	c, ok := cb.Flush()
+	assert.True(t, ok)
+	assert.Equal(t, testBuiltCandle(tm.Add(2*time.Minute), 13, 13, 13, 13, 2, 2), c)
+
+	_, ok = cb.Head()
+	assert.False(t, ok)
+
+	// flushed candle can still be amended
+	res := cb.Add(testTrade(tm.Add(141*time.Second), 15, 1))
+	assert.Equal(t, BuildResult{
+		Corrections: []CandleCorrection{
+			testCorrection(testBuiltCandle(tm.Add(2*time.Minute), 13, 15, 13, 15, 3, 3), CorrectionLateTrade),
+		},
+	}, res)
+}
+
+func Test_CandleBuilder_Add_MissingInterval(t *testing.T) {
+	tm := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	cb, err := NewCandleBuilder(IntervalMinute, 5*time.Minute)
+	assert.NoError(t, err)
+
+	cb.Add(testTrade(tm.Add(time.Minute), 1, 1))
+	cb.Add(testTrade(tm.Add(3*time.Minute), 3, 1))
+	cb.Add(testTrade(tm.Add(5*time.Minute), 5, 1))
+
+	// between closed candles
+	res := cb.Add(testTrade(tm.Add(2*time.Minute), 2, 1))
+	assert.Equal(t, BuildResult{
+		Corrections: []CandleCorrection{
+			testCorrection(testBuiltCandle(tm.Add(2*time.Minute), 2, 2, 2, 2, 1, 1), CorrectionMissingCandle),
+		},
+	}, res)
+
+	// before all closed candles
+	res = cb.Add(testTrade(tm, 4, 1))
+	assert.Equal(t, BuildResult{
+		Corrections: []CandleCorrection{
+			testCorrection(testBuiltCandle(tm, 4, 4, 4, 4, 1, 1), CorrectionMissingCandle),
+		},
+	}, res)
+
+	// between the last closed candle and the head
+	res = cb.Add(testTrade(tm.Add(4*time.Minute), 6, 1))
+	assert.Equal(t, BuildResult{
+		Corrections: []CandleCorrection{
+			testCorrection(testBuiltCandle(tm.Add(4*time.Minute), 6, 6, 6, 6, 1, 1), CorrectionMissingCandle),
+		},
+	}, res)
+
+	assert.Zero(t, cb.Dropped())
+}
+
+func Test_CandleBuilder_Add_Delta(t *testing.T) {
+	tm := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	cb, err := NewCandleBuilder(IntervalMinute, 0)
+	assert.NoError(t, err)
+
+	cb.Add(testSideTrade(tm, 10, 3, SideBuy))
+	cb.Add(testSideTrade(tm.Add(time.Second), 11, 5, SideSell))
+	cb.Add(testSideTrade(tm.Add(2*time.Second), 12, 4, 0))
+
+	h, ok := cb.Head()
+	assert.True(t, ok)
+	assert.Equal(t, testBuiltCandle(tm, 10, 12, 10, 12, 12, -2), h)
+
+	cb, err = NewCandleBuilder(IntervalMinute, 0)
+	assert.NoError(t, err)
+
+	cb.Add(testSideTrade(tm, 10, 3, SideSell))
+
+	h, ok = cb.Head()
+	assert.True(t, ok)
+	assert.Equal(t, "-3", h.Delta.String())
+}
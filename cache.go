@@ -0,0 +1,372 @@
+package chartype
+
+import (
+	"container/list"
+	"context"
+	"sync"
+	"time"
+)
+
+var (
+	// ErrInvalidCacheSize is returned when zero or negative cache
+	// size is being used.
+	ErrInvalidCacheSize = newError(CodeInvalidArgument, "invalid cache size")
+)
+
+// CacheHooks holds optional functions called on cache events, e.g.
+// to collect hit and miss metrics.
+type CacheHooks struct {
+	// OnHit is called when a range is served from the cache.
+	OnHit func(p Pair, i Interval)
+
+	// OnMiss is called when a range is fetched from the underlying
+	// store or source.
+	OnMiss func(p Pair, i Interval)
+
+	// OnEvict is called when a series is evicted from the cache
+	// because the cache is full.
+	OnEvict func(p Pair, i Interval)
+}
+
+// CachedStore is a candle store decorator that caches the last
+// fetched range of each pair's interval series. Cached series are
+// evicted when the number of series exceeds the cache size (least
+// recently used first) and expire after the time to live. Writes and
+// deletes are passed to the underlying store and invalidate the
+// series.
+//
+// CachedStore is safe for concurrent use.
+type CachedStore struct {
+	store CandleStore
+	cache *seriesCache
+}
+
+// NewCachedStore creates a new cached store on top of the candle
+// store, caching up to size series for the time to live. Zero time
+// to live means that cached series do not expire.
+func NewCachedStore(cs CandleStore, size int, ttl time.Duration, h CacheHooks) (*CachedStore, error) {
+	sc, err := newSeriesCache(size, ttl, h)
+	if err != nil {
+		return nil, err
+	}
+
+	return &CachedStore{store: cs, cache: sc}, nil
+}
+
+// Put writes the candles to the underlying store and invalidates the
+// pair's interval series.
+func (cs *CachedStore) Put(p Pair, i Interval, cc ...Candle) error {
+	defer cs.cache.invalidate(seriesKey{pair: p, interval: i})
+
+	return cs.store.Put(p, i, cc...)
+}
+
+// Range returns the pair's interval series candles within the
+// [from, to) period. They are served from the cache if the cached
+// range of the series covers the period; otherwise they are fetched
+// from the underlying store and cached, unless the series is
+// invalidated while they are being fetched.
+func (cs *CachedStore) Range(p Pair, i Interval, from, to time.Time) ([]Candle, error) {
+	k := seriesKey{pair: p, interval: i}
+
+	cc, gen, ok := cs.cache.get(k, from, to)
+	if ok {
+		return cc, nil
+	}
+
+	cc, err := cs.store.Range(p, i, from, to)
+	if err != nil {
+		cs.cache.cancel(k)
+		return nil, err
+	}
+
+	cs.cache.set(k, gen, TimeRange{From: from, To: to}, cc)
+
+	res := make([]Candle, len(cc))
+	copy(res, cc)
+
+	return res, nil
+}
+
+// Latest returns the newest candle of the pair's interval series from
+// the underlying store.
+func (cs *CachedStore) Latest(p Pair, i Interval) (Candle, error) {
+	return cs.store.Latest(p, i)
+}
+
+// Delete removes the pair's interval series candles within the
+// [from, to) period from the underlying store and invalidates the
+// series.
+func (cs *CachedStore) Delete(p Pair, i Interval, from, to time.Time) error {
+	defer cs.cache.invalidate(seriesKey{pair: p, interval: i})
+
+	return cs.store.Delete(p, i, from, to)
+}
+
+// CachedSource is a candle source decorator that caches the last
+// retrieved range of each pair's interval series, e.g. to avoid
+// requesting hot ranges from an exchange API repeatedly. Cached
+// series are evicted and expire the same way as by CachedStore.
+// Candles retrieved by requests with a limit are cached only if the
+// limit was not reached, i.e. when they cover the whole range. With
+// zero time to live, ranges that include the candle that is still
+// being built are not cached, as it would never be refreshed.
+//
+// CachedSource is safe for concurrent use.
+type CachedSource struct {
+	source CandleSource
+	cache  *seriesCache
+}
+
+// NewCachedSource creates a new cached source on top of the candle
+// source, caching up to size series for the time to live. Zero time
+// to live means that cached series do not expire.
+func NewCachedSource(src CandleSource, size int, ttl time.Duration, h CacheHooks) (*CachedSource, error) {
+	sc, err := newSeriesCache(size, ttl, h)
+	if err != nil {
+		return nil, err
+	}
+
+	return &CachedSource{source: src, cache: sc}, nil
+}
+
+// Candles returns candles matching the request. They are served from
+// the cache if the cached range of the series covers the request's
+// range; otherwise they are retrieved from the underlying source.
+func (cs *CachedSource) Candles(ctx context.Context, cr CandleRequest) ([]Candle, error) {
+	if err := cr.Validate(); err != nil {
+		return nil, err
+	}
+
+	k := seriesKey{pair: cr.Pair, interval: cr.Interval}
+
+	cc, gen, ok := cs.cache.get(k, cr.Range.From, cr.Range.To)
+	if ok {
+		return limitCandles(cc, cr.Limit), nil
+	}
+
+	cc, err := cs.source.Candles(ctx, cr)
+	if err != nil {
+		cs.cache.cancel(k)
+		return nil, err
+	}
+
+	live := cs.cache.ttl == 0 && cr.Range.To.After(cr.Interval.Truncate(cs.cache.now()))
+
+	if !live && (cr.Limit == 0 || len(cc) < cr.Limit) {
+		cs.cache.set(k, gen, cr.Range, cc)
+	} else {
+		cs.cache.cancel(k)
+	}
+
+	res := make([]Candle, len(cc))
+	copy(res, cc)
+
+	return res, nil
+}
+
+// Stream retrieves candles matching the request and replays them.
+func (cs *CachedSource) Stream(ctx context.Context, cr CandleRequest) (*Subscription, error) {
+	return streamCandles(ctx, cs, cr)
+}
+
+// seriesCache is an LRU cache of the last fetched range of each
+// series. Series that are being fetched have a generation that is
+// incremented when they are invalidated, so that candles fetched
+// before an invalidation are not cached after it.
+type seriesCache struct {
+	size  int
+	ttl   time.Duration
+	hooks CacheHooks
+	now   func() time.Time
+
+	mu      sync.Mutex
+	lru     *list.List
+	entries map[seriesKey]*list.Element
+	fetches map[seriesKey]*seriesFetch
+}
+
+// seriesFetch tracks fetches of a single series that are in progress.
+// It is removed once all of them are finished, so that only series
+// that are being fetched are tracked.
+type seriesFetch struct {
+	count      int
+	generation uint64
+}
+
+// cacheEntry holds cached candles of a single series.
+type cacheEntry struct {
+	key     seriesKey
+	rng     TimeRange
+	candles []Candle
+	expires time.Time
+}
+
+// newSeriesCache creates a new series cache holding up to size series
+// for the time to live.
+func newSeriesCache(size int, ttl time.Duration, h CacheHooks) (*seriesCache, error) {
+	if size <= 0 {
+		return nil, ErrInvalidCacheSize
+	}
+
+	if ttl < 0 {
+		return nil, ErrInvalidDuration
+	}
+
+	return &seriesCache{
+		size:    size,
+		ttl:     ttl,
+		hooks:   h,
+		now:     time.Now,
+		lru:     list.New(),
+		entries: make(map[seriesKey]*list.Element),
+		fetches: make(map[seriesKey]*seriesFetch),
+	}, nil
+}
+
+// get returns a copy of the cached series candles within the period
+// and whether the cached range covers the period. The hit or the miss
+// hook is called accordingly. On a miss, a fetch of the series is
+// started and its generation is returned; the fetch must be finished
+// with set or cancel.
+func (sc *seriesCache) get(k seriesKey, from, to time.Time) ([]Candle, uint64, bool) {
+	cc, gen, ok := sc.lookup(k, from, to)
+
+	switch {
+	case ok && sc.hooks.OnHit != nil:
+		sc.hooks.OnHit(k.pair, k.interval)
+	case !ok && sc.hooks.OnMiss != nil:
+		sc.hooks.OnMiss(k.pair, k.interval)
+	}
+
+	return cc, gen, ok
+}
+
+// lookup returns a copy of the cached series candles within the
+// period and whether the cached range covers the period. On a miss,
+// a fetch of the series is started and its generation is returned.
+func (sc *seriesCache) lookup(k seriesKey, from, to time.Time) ([]Candle, uint64, bool) {
+	sc.mu.Lock()
+	defer sc.mu.Unlock()
+
+	el, ok := sc.entries[k]
+	if !ok {
+		return nil, sc.fetch(k), false
+	}
+
+	e := el.Value.(*cacheEntry)
+
+	if !e.expires.IsZero() && !sc.now().Before(e.expires) {
+		sc.remove(el)
+		return nil, sc.fetch(k), false
+	}
+
+	if from.Before(e.rng.From) || to.After(e.rng.To) {
+		return nil, sc.fetch(k), false
+	}
+
+	sc.lru.MoveToFront(el)
+
+	cc := e.candles[searchCandles(e.candles, from):searchCandles(e.candles, to)]
+
+	res := make([]Candle, len(cc))
+	copy(res, cc)
+
+	return res, 0, true
+}
+
+// fetch starts a fetch of the series and returns its current
+// generation. Mutex must be held.
+func (sc *seriesCache) fetch(k seriesKey) uint64 {
+	f, ok := sc.fetches[k]
+	if !ok {
+		f = &seriesFetch{}
+		sc.fetches[k] = f
+	}
+
+	f.count++
+
+	return f.generation
+}
+
+// finish finishes a fetch of the series and reports whether the
+// series was not invalidated since the generation was retrieved.
+// Mutex must be held.
+func (sc *seriesCache) finish(k seriesKey, gen uint64) bool {
+	f := sc.fetches[k]
+
+	f.count--
+	if f.count == 0 {
+		delete(sc.fetches, k)
+	}
+
+	return f.generation == gen
+}
+
+// set finishes a fetch of the series and caches its candles of the
+// range, replacing the previously cached ones, and evicts the least
+// recently used series if the cache is full. Candles are not cached
+// if the series was invalidated since the fetch was started.
+func (sc *seriesCache) set(k seriesKey, gen uint64, tr TimeRange, cc []Candle) {
+	e := &cacheEntry{key: k, rng: tr, candles: cc}
+	if sc.ttl > 0 {
+		e.expires = sc.now().Add(sc.ttl)
+	}
+
+	sc.mu.Lock()
+
+	if !sc.finish(k, gen) {
+		sc.mu.Unlock()
+		return
+	}
+
+	if el, ok := sc.entries[k]; ok {
+		sc.remove(el)
+	}
+
+	sc.entries[k] = sc.lru.PushFront(e)
+
+	if sc.lru.Len() <= sc.size {
+		sc.mu.Unlock()
+		return
+	}
+
+	el := sc.lru.Back()
+	sc.remove(el)
+	sc.mu.Unlock()
+
+	if sc.hooks.OnEvict != nil {
+		ek := el.Value.(*cacheEntry).key
+		sc.hooks.OnEvict(ek.pair, ek.interval)
+	}
+}
+
+// cancel finishes a fetch of the series without caching its candles.
+func (sc *seriesCache) cancel(k seriesKey) {
+	sc.mu.Lock()
+	defer sc.mu.Unlock()
+
+	sc.finish(k, 0)
+}
+
+// invalidate removes the series from the cache and increments the
+// generation of its fetches that are in progress.
+func (sc *seriesCache) invalidate(k seriesKey) {
+	sc.mu.Lock()
+	defer sc.mu.Unlock()
+
+	if f, ok := sc.fetches[k]; ok {
+		f.generation++
+	}
+
+	if el, ok := sc.entries[k]; ok {
+		sc.remove(el)
+	}
+}
+
+// remove removes the cache list element and its entry. Mutex must be
+// held.
+func (sc *seriesCache) remove(el *list.Element) {
+	sc.lru.Remove(el)
+	delete(sc.entries, el.Value.(*cacheEntry).key)
+}
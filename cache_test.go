@@ -0,0 +1,484 @@
+package chartype
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_NewCachedStore(t *testing.T) {
+	cc := map[string]struct {
+		Size int
+		TTL  time.Duration
+		Err  error
+	}{
+		"Invalid size": {
+			Err: ErrInvalidCacheSize,
+		},
+		"Invalid TTL": {
+			Size: 1,
+			TTL:  -time.Second,
+			Err:  ErrInvalidDuration,
+		},
+		"Successful creation without TTL": {
+			Size: 1,
+		},
+		"Successful creation with TTL": {
+			Size: 1,
+			TTL:  time.Second,
+		},
+	}
+
+	for cn, c := range cc {
+		c := c
+
+		t.Run(cn, func(t *testing.T) {
+			t.Parallel()
+
+			cs, err := NewCachedStore(NewMemoryStore(), c.Size, c.TTL, CacheHooks{})
+			assert.Equal(t, c.Err, err)
+
+			if err == nil {
+				var _ CandleStore = cs
+			}
+		})
+	}
+}
+
+func Test_CachedStore_Range(t *testing.T) {
+	tm := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	p := Pair{Base: "BTC", Quote: "USDT"}
+	at := func(m int) time.Time {
+		return tm.Add(time.Duration(m) * time.Minute)
+	}
+
+	series := []Candle{
+		testCandle(at(0), 1, 1, 1, 1, 1),
+		testCandle(at(1), 2, 2, 2, 2, 2),
+		testCandle(at(2), 3, 3, 3, 3, 3),
+	}
+
+	cc := map[string]struct {
+		Pair    Pair
+		Ranges  []TimeRange
+		Elapsed time.Duration
+		Faulty  bool
+		Result  []Candle
+		Err     error
+		Hits    int
+		Misses  int
+	}{
+		"Invalid pair": {
+			Ranges: []TimeRange{{From: at(0), To: at(3)}},
+			Err:    ErrInvalidPair,
+			Misses: 1,
+		},
+		"Store error": {
+			Pair:   p,
+			Ranges: []TimeRange{{From: at(0), To: at(3)}},
+			Faulty: true,
+			Err:    assert.AnError,
+			Misses: 1,
+		},
+		"Successful retrieval of a cached range": {
+			Pair:   p,
+			Ranges: []TimeRange{{From: at(0), To: at(3)}, {From: at(0), To: at(3)}},
+			Result: series,
+			Hits:   1,
+			Misses: 1,
+		},
+		"Successful retrieval of a cached sub-range": {
+			Pair:   p,
+			Ranges: []TimeRange{{From: at(0), To: at(3)}, {From: at(1), To: at(2)}},
+			Result: series[1:2],
+			Hits:   1,
+			Misses: 1,
+		},
+		"Successful retrieval of an uncovered range": {
+			Pair:   p,
+			Ranges: []TimeRange{{From: at(0), To: at(1)}, {From: at(0), To: at(3)}},
+			Result: series,
+			Misses: 2,
+		},
+		"Successful retrieval of an expired range": {
+			Pair:    p,
+			Ranges:  []TimeRange{{From: at(0), To: at(3)}, {From: at(0), To: at(3)}},
+			Elapsed: time.Minute,
+			Result:  series,
+			Misses:  2,
+		},
+	}
+
+	for cn, c := range cc {
+		c := c
+
+		t.Run(cn, func(t *testing.T) {
+			t.Parallel()
+
+			var hits, misses int
+
+			ms := NewMemoryStore()
+			require.NoError(t, ms.Put(p, IntervalMinute, series...))
+
+			var st CandleStore = ms
+			if c.Faulty {
+				st = &faultyStore{MemoryStore: ms, rangeErr: assert.AnError}
+			}
+
+			cs, err := NewCachedStore(st, 1, time.Minute, CacheHooks{
+				OnHit:  func(Pair, Interval) { hits++ },
+				OnMiss: func(Pair, Interval) { misses++ },
+			})
+			require.NoError(t, err)
+
+			now := tm
+			cs.cache.now = func() time.Time { return now }
+
+			var res []Candle
+
+			for _, tr := range c.Ranges {
+				res, err = cs.Range(c.Pair, IntervalMinute, tr.From, tr.To)
+				now = now.Add(c.Elapsed)
+			}
+
+			equalError(t, c.Err, err)
+			assert.Equal(t, c.Result, res)
+			assert.Equal(t, c.Hits, hits)
+			assert.Equal(t, c.Misses, misses)
+
+			// finished fetches are not tracked
+			assert.Empty(t, cs.cache.fetches)
+		})
+	}
+}
+
+func Test_CachedStore(t *testing.T) {
+	tm := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	p1 := Pair{Base: "BTC", Quote: "USDT"}
+	p2 := Pair{Base: "ETH", Quote: "USDT"}
+	at := func(m int) time.Time {
+		return tm.Add(time.Duration(m) * time.Minute)
+	}
+
+	var (
+		mu                  sync.Mutex
+		hits, misses, evict []Pair
+	)
+
+	record := func(pp *[]Pair) func(Pair, Interval) {
+		return func(p Pair, _ Interval) {
+			mu.Lock()
+			*pp = append(*pp, p)
+			mu.Unlock()
+		}
+	}
+
+	ms := NewMemoryStore()
+
+	cs, err := NewCachedStore(ms, 1, time.Minute, CacheHooks{
+		OnHit:   record(&hits),
+		OnMiss:  record(&misses),
+		OnEvict: record(&evict),
+	})
+	require.NoError(t, err)
+
+	now := tm
+	cs.cache.now = func() time.Time { return now }
+
+	assert.Equal(t, ErrInvalidPair, cs.Put(Pair{}, IntervalMinute))
+	assert.NoError(t, cs.Put(p1, IntervalMinute,
+		testCandle(at(0), 1, 1, 1, 1, 1),
+		testCandle(at(1), 2, 2, 2, 2, 2),
+		testCandle(at(2), 3, 3, 3, 3, 3),
+	))
+	assert.NoError(t, cs.Put(p2, IntervalMinute, testCandle(at(0), 4, 4, 4, 4, 4)))
+
+	_, err = cs.Range(Pair{}, IntervalMinute, at(0), at(3))
+	assert.Equal(t, ErrInvalidPair, err)
+
+	res, err := cs.Range(p1, IntervalMinute, at(0), at(3))
+	assert.NoError(t, err)
+	assert.Len(t, res, 3)
+
+	// modifications of results do not affect the cache
+	res[0] = Candle{}
+
+	res, err = cs.Range(p1, IntervalMinute, at(1), at(3))
+	assert.NoError(t, err)
+	assert.Equal(t, []Candle{testCandle(at(1), 2, 2, 2, 2, 2), testCandle(at(2), 3, 3, 3, 3, 3)}, res)
+
+	res, err = cs.Range(p1, IntervalMinute, at(0), at(1))
+	assert.NoError(t, err)
+	assert.Equal(t, []Candle{testCandle(at(0), 1, 1, 1, 1, 1)}, res)
+
+	// not covered by the cached range
+	_, err = cs.Range(p1, IntervalMinute, at(0), at(4))
+	assert.NoError(t, err)
+
+	// evicts p1
+	_, err = cs.Range(p2, IntervalMinute, at(0), at(1))
+	assert.NoError(t, err)
+
+	_, err = cs.Range(p2, IntervalMinute, at(0), at(1))
+	assert.NoError(t, err)
+
+	now = now.Add(time.Minute)
+
+	// expired
+	_, err = cs.Range(p2, IntervalMinute, at(0), at(1))
+	assert.NoError(t, err)
+
+	// invalidated
+	assert.NoError(t, cs.Delete(p2, IntervalMinute, at(0), at(1)))
+
+	res, err = cs.Range(p2, IntervalMinute, at(0), at(1))
+	assert.NoError(t, err)
+	assert.Empty(t, res)
+
+	c, err := cs.Latest(p1, IntervalMinute)
+	assert.NoError(t, err)
+	assert.Equal(t, testCandle(at(2), 3, 3, 3, 3, 3), c)
+
+	assert.Equal(t, []Pair{p1, p1, p2}, hits)
+	assert.Equal(t, []Pair{{}, p1, p1, p2, p2, p2}, misses)
+	assert.Equal(t, []Pair{p1}, evict)
+
+	// invalidations of series that are not being fetched are not
+	// tracked
+	assert.Empty(t, cs.cache.fetches)
+}
+
+func Test_CachedStore_NoHooks(t *testing.T) {
+	tm := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	cs, err := NewCachedStore(NewMemoryStore(), 1, 0, CacheHooks{})
+	require.NoError(t, err)
+
+	for _, p := range []Pair{{Base: "BTC", Quote: "USDT"}, {Base: "ETH", Quote: "USDT"}} {
+		for j := 0; j < 2; j++ {
+			_, err = cs.Range(p, IntervalMinute, tm, tm.Add(time.Hour))
+			assert.NoError(t, err)
+		}
+	}
+
+	assert.Equal(t, 1, cs.cache.lru.Len())
+}
+
+// blockingStore is a memory store whose range reads wait for a
+// release after reading the candles.
+type blockingStore struct {
+	*MemoryStore
+	started chan struct{}
+	release chan struct{}
+}
+
+func (bs *blockingStore) Range(p Pair, i Interval, from, to time.Time) ([]Candle, error) {
+	cc, err := bs.MemoryStore.Range(p, i, from, to)
+
+	bs.started <- struct{}{}
+	<-bs.release
+
+	return cc, err
+}
+
+func Test_CachedStore_PutDuringRange(t *testing.T) {
+	tm := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	p := Pair{Base: "BTC", Quote: "USDT"}
+
+	bs := &blockingStore{
+		MemoryStore: NewMemoryStore(),
+		started:     make(chan struct{}),
+		release:     make(chan struct{}),
+	}
+
+	cs, err := NewCachedStore(bs, 1, 0, CacheHooks{})
+	require.NoError(t, err)
+
+	stale := make(chan []Candle)
+
+	go func() {
+		cc, _ := cs.Range(p, IntervalMinute, tm, tm.Add(time.Hour)) //nolint:errcheck // checked below
+		stale <- cc
+	}()
+
+	<-bs.started
+
+	// written after the range was read, but before it was cached
+	require.NoError(t, cs.Put(p, IntervalMinute, testCandle(tm, 1, 1, 1, 1, 1)))
+
+	close(bs.release)
+	assert.Empty(t, <-stale)
+
+	go func() {
+		<-bs.started
+	}()
+
+	res, err := cs.Range(p, IntervalMinute, tm, tm.Add(time.Hour))
+	require.NoError(t, err)
+	assert.Equal(t, []Candle{testCandle(tm, 1, 1, 1, 1, 1)}, res)
+	assert.Empty(t, cs.cache.fetches)
+}
+
+func Test_NewCachedSource(t *testing.T) {
+	cc := map[string]struct {
+		Size int
+		Err  error
+	}{
+		"Invalid size": {
+			Err: ErrInvalidCacheSize,
+		},
+		"Successful creation": {
+			Size: 1,
+		},
+	}
+
+	for cn, c := range cc {
+		c := c
+
+		t.Run(cn, func(t *testing.T) {
+			t.Parallel()
+
+			cs, err := NewCachedSource(&testSource{}, c.Size, 0, CacheHooks{})
+			assert.Equal(t, c.Err, err)
+
+			if err == nil {
+				var _ Source = cs
+			}
+		})
+	}
+}
+
+func Test_CachedSource_Candles(t *testing.T) {
+	tm := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	at := func(m int) time.Time {
+		return tm.Add(time.Duration(m) * time.Minute)
+	}
+
+	series := []Candle{
+		testCandle(at(0), 1, 1, 1, 1, 1),
+		testCandle(at(1), 2, 2, 2, 2, 2),
+		testCandle(at(2), 3, 3, 3, 3, 3),
+	}
+
+	req := func(from, to, limit int) CandleRequest {
+		return CandleRequest{
+			Pair:     Pair{Base: "BTC", Quote: "USDT"},
+			Interval: IntervalMinute,
+			Range:    TimeRange{From: at(from), To: at(to)},
+			Limit:    limit,
+		}
+	}
+
+	building := at(2).Add(30 * time.Second)
+	closed := at(3)
+
+	cc := map[string]struct {
+		Now      *time.Time
+		Requests []CandleRequest
+		Err      error
+		Result   []Candle
+		Calls    int
+	}{
+		"Invalid request": {
+			Requests: []CandleRequest{{}},
+			Err:      ErrInvalidPair,
+		},
+		"Source error": {
+			Requests: []CandleRequest{req(0, 3, 0)},
+			Err:      assert.AnError,
+			Calls:    1,
+		},
+		"Successful retrieval of a cached range": {
+			Requests: []CandleRequest{req(0, 3, 0), req(0, 3, 0)},
+			Result:   series,
+			Calls:    1,
+		},
+		"Successful retrieval of a limited cached sub-range": {
+			Requests: []CandleRequest{req(0, 3, 0), req(1, 3, 1)},
+			Result:   series[1:2],
+			Calls:    1,
+		},
+		"Successful retrieval of an uncovered range": {
+			Requests: []CandleRequest{req(0, 2, 0), req(0, 3, 0)},
+			Result:   series,
+			Calls:    2,
+		},
+		"Successful retrieval of a range that reached the limit": {
+			Requests: []CandleRequest{req(0, 3, 2), req(0, 3, 2)},
+			Result:   series[:2],
+			Calls:    2,
+		},
+		"Successful retrieval of a range with a candle being built": {
+			Now:      &building,
+			Requests: []CandleRequest{req(0, 3, 0), req(0, 3, 0)},
+			Result:   series,
+			Calls:    2,
+		},
+		"Successful retrieval of a range with just closed candles": {
+			Now:      &closed,
+			Requests: []CandleRequest{req(0, 3, 0), req(0, 3, 0)},
+			Result:   series,
+			Calls:    1,
+		},
+		"Successful retrieval of a range below the limit": {
+			Requests: []CandleRequest{req(0, 3, 5), req(0, 3, 5)},
+			Result:   series,
+			Calls:    1,
+		},
+	}
+
+	for cn, c := range cc {
+		c := c
+
+		t.Run(cn, func(t *testing.T) {
+			t.Parallel()
+
+			src := &testSource{candles: series}
+			if c.Err == assert.AnError { //nolint:goerr113 // direct check is needed
+				src.err = c.Err
+			}
+
+			cs, err := NewCachedSource(src, 1, 0, CacheHooks{})
+			require.NoError(t, err)
+
+			now := at(10)
+			if c.Now != nil {
+				now = *c.Now
+			}
+
+			cs.cache.now = func() time.Time { return now }
+
+			var res []Candle
+
+			for _, cr := range c.Requests {
+				res, err = cs.Candles(context.Background(), cr)
+			}
+
+			equalError(t, c.Err, err)
+			assert.Equal(t, c.Result, res)
+			assert.Equal(t, c.Calls, src.Calls())
+			assert.Empty(t, cs.cache.fetches)
+		})
+	}
+}
+
+func Test_CachedSource_Stream(t *testing.T) {
+	tm := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	src := &testSource{candles: []Candle{testCandle(tm, 1, 1, 1, 1, 1)}}
+
+	cs, err := NewCachedSource(src, 1, 0, CacheHooks{})
+	require.NoError(t, err)
+
+	sub, err := cs.Stream(context.Background(), CandleRequest{
+		Pair:     Pair{Base: "BTC", Quote: "USDT"},
+		Interval: IntervalMinute,
+		Range:    TimeRange{From: tm, To: tm.Add(time.Hour)},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, src.candles, drainCandles(t, sub))
+}
@@ -0,0 +1,52 @@
+package chartype
+
+import "time"
+
+// dateLayout is the layout of calendar dates.
+const dateLayout = "2006-01-02"
+
+// HolidayCalendar reports days on which a market is closed.
+type HolidayCalendar interface {
+	// IsHoliday checks whether the market is closed on the day the
+	// provided time belongs to.
+	IsHoliday(t time.Time) bool
+}
+
+// StaticCalendar is a holiday calendar with a fixed set of dates.
+type StaticCalendar struct {
+	location *time.Location
+	dates    map[string]struct{}
+}
+
+// NewStaticCalendar creates a new static calendar with the provided
+// dates in "2006-01-02" format. Days are determined in the provided
+// location, or UTC if it is nil. Invalid dates are reported with
+// CodeInvalidArgument.
+func NewStaticCalendar(loc *time.Location, dates ...string) (*StaticCalendar, error) {
+	if loc == nil {
+		loc = time.UTC
+	}
+
+	sc := &StaticCalendar{
+		location: loc,
+		dates:    make(map[string]struct{}, len(dates)),
+	}
+
+	for _, d := range dates {
+		t, err := time.Parse(dateLayout, d)
+		if err != nil {
+			return nil, invalidArgument(err)
+		}
+
+		sc.dates[t.Format(dateLayout)] = struct{}{}
+	}
+
+	return sc, nil
+}
+
+// IsHoliday checks whether the day the provided time belongs to is
+// one of calendar's dates.
+func (sc *StaticCalendar) IsHoliday(t time.Time) bool {
+	_, ok := sc.dates[t.In(sc.location).Format(dateLayout)]
+	return ok
+}
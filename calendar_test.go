@@ -0,0 +1,25 @@
+package chartype
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_NewStaticCalendar(t *testing.T) {
+	_, err := NewStaticCalendar(nil, "2020-13-01")
+	assert.Equal(t, CodeInvalidArgument, Code(err))
+
+	sc, err := NewStaticCalendar(nil, "2020-12-25")
+	assert.NoError(t, err)
+	assert.True(t, sc.IsHoliday(time.Date(2020, 12, 25, 23, 0, 0, 0, time.UTC)))
+	assert.False(t, sc.IsHoliday(time.Date(2020, 12, 26, 0, 0, 0, 0, time.UTC)))
+
+	ny := time.FixedZone("NY", -5*3600)
+
+	sc, err = NewStaticCalendar(ny, "2020-12-25")
+	assert.NoError(t, err)
+	assert.True(t, sc.IsHoliday(time.Date(2020, 12, 26, 2, 0, 0, 0, time.UTC)))
+	assert.False(t, sc.IsHoliday(time.Date(2020, 12, 25, 2, 0, 0, 0, time.UTC)))
+}
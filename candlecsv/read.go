@@ -0,0 +1,501 @@
+// Package candlecsv provides high-throughput reading of chartype's
+// candles from CSV data.
+package candlecsv
+
+import (
+	"bufio"
+	"bytes"
+	"io"
+	"math"
+	"strconv"
+	"time"
+
+	"github.com/jellydator/chartype"
+)
+
+const (
+	// defaultChunkSize is the default size of chunks read at once.
+	defaultChunkSize = 1 << 20
+
+	// minFieldCount is the number of required fields in a single
+	// record.
+	minFieldCount = 6
+
+	// maxFieldCount is the number of required and optional fields in
+	// a single record.
+	maxFieldCount = 8
+
+	// maxTimestampDigits is the maximum number of digits an integer
+	// timestamp may have.
+	maxTimestampDigits = 18
+)
+
+var (
+	// ErrInvalidRecord is returned when a record with invalid number
+	// of fields or invalid timestamp is being read.
+	ErrInvalidRecord = &chartype.Error{
+		Code:    chartype.CodeInvalidField,
+		Message: "invalid record",
+	}
+
+	// ErrInvalidOptions is returned when options with negative values
+	// are being used.
+	ErrInvalidOptions = &chartype.Error{
+		Code:    chartype.CodeInvalidArgument,
+		Message: "invalid options",
+	}
+)
+
+// ParseError describes an error that occurred while reading a
+// specific line.
+type ParseError struct {
+	Line int
+	Err  error
+}
+
+// Error returns the error message prefixed with the line number.
+func (pe *ParseError) Error() string {
+	return "line " + strconv.Itoa(pe.Line) + ": " + pe.Err.Error()
+}
+
+// Unwrap returns the underlying error.
+func (pe *ParseError) Unwrap() error {
+	return pe.Err
+}
+
+// Options specifies the format of CSV data and how it is read.
+type Options struct {
+	// Comma specifies the field delimiter. Zero means ','.
+	Comma byte
+
+	// Header specifies whether the first line is a header that
+	// should be skipped.
+	Header bool
+
+	// TimeUnit specifies the unit of integer timestamps, e.g.
+	// time.Millisecond. Zero means RFC 3339 timestamps.
+	TimeUnit time.Duration
+
+	// ChunkSize specifies the approximate number of bytes read and
+	// parsed at once. Zero means 1 MiB.
+	ChunkSize int
+
+	// Workers specifies the number of goroutines parsing chunks in
+	// parallel. Zero or one means that chunks are parsed
+	// sequentially, reusing a single buffer.
+	Workers int
+
+	// ParseOptions specifies how candle's numbers are parsed, e.g.
+	// chartype.WithDecimalComma for European exports that usually
+	// also use ';' as the field delimiter, or chartype.WithInterning
+	// to share decimals of values that repeat across candles.
+	ParseOptions []chartype.ParseOption
+}
+
+// LoadOption configures how memory is allocated for the candles
+// being read.
+type LoadOption func(*loadConfig)
+
+// loadConfig holds load options' settings.
+type loadConfig struct {
+	sizeHint int
+}
+
+// WithSizeHint specifies the expected number of candles. Candles are
+// then stored in a single block allocated up front instead of being
+// copied into larger blocks as they are read, which reduces garbage
+// when loading large archives. Without it, blocks are sized by the
+// number of lines in each chunk.
+func WithSizeHint(n int) LoadOption {
+	return func(lc *loadConfig) {
+		lc.sizeHint = n
+	}
+}
+
+// Read reads all candles from CSV data. Each line must contain
+// timestamp, open, high, low, close and volume fields, in this order,
+// without quotes, optionally followed by adjusted close and volume
+// delta fields, which are left unset when they are empty. Empty lines
+// are skipped.
+// Candles are returned in the order of lines. Load options adjust
+// how memory is allocated for them.
+func Read(r io.Reader, o Options, opts ...LoadOption) ([]chartype.Candle, error) {
+	var lc loadConfig
+
+	for _, opt := range opts {
+		opt(&lc)
+	}
+
+	if o.TimeUnit < 0 || o.ChunkSize < 0 || o.Workers < 0 || lc.sizeHint < 0 {
+		return nil, ErrInvalidOptions
+	}
+
+	if o.Comma == 0 {
+		o.Comma = ','
+	}
+
+	if o.ChunkSize == 0 {
+		o.ChunkSize = defaultChunkSize
+	}
+
+	br := bufio.NewReaderSize(r, o.ChunkSize)
+
+	line := 1
+
+	if o.Header {
+		if _, err := readLine(br); err != nil {
+			return nil, err
+		}
+
+		line++
+	}
+
+	if o.Workers <= 1 {
+		return readSequential(br, o, lc, line)
+	}
+
+	return readParallel(br, o, lc, line)
+}
+
+// readSequential reads and parses chunks one by one reusing a single
+// buffer.
+func readSequential(br *bufio.Reader, o Options, lc loadConfig, line int) ([]chartype.Candle, error) {
+	var buf []byte
+
+	res := make([]chartype.Candle, 0, lc.sizeHint)
+	p := chartype.NewParser(o.ParseOptions...)
+
+	for {
+		var err error
+
+		buf, err = readChunk(br, buf[:0], o.ChunkSize)
+		if err != nil {
+			return nil, err
+		}
+
+		if len(buf) == 0 {
+			return res, nil
+		}
+
+		if res, err = parseChunk(res, buf, o, p, line); err != nil {
+			return nil, err
+		}
+
+		line += bytes.Count(buf, []byte{'\n'})
+	}
+}
+
+// chunk holds a part of CSV data together with its position.
+type chunk struct {
+	index int
+	line  int
+	data  []byte
+}
+
+// chunkResult holds candles parsed from a chunk.
+type chunkResult struct {
+	index   int
+	candles []chartype.Candle
+	err     error
+}
+
+// readParallel reads chunks and parses them in parallel.
+func readParallel(br *bufio.Reader, o Options, lc loadConfig, line int) ([]chartype.Candle, error) {
+	chunks := make(chan chunk)
+	results := make(chan chunkResult)
+	free := make(chan []byte, o.Workers*2)
+	stop := make(chan struct{})
+
+	for i := 0; i < o.Workers; i++ {
+		go func() {
+			p := chartype.NewParser(o.ParseOptions...)
+
+			for c := range chunks {
+				cc, err := parseChunk(nil, c.data, o, p, c.line)
+
+				select {
+				case free <- c.data[:0]:
+				default:
+				}
+
+				results <- chunkResult{index: c.index, candles: cc, err: err}
+			}
+		}()
+	}
+
+	var readErr error
+
+	count := make(chan int, 1)
+
+	go func() {
+		defer close(chunks)
+
+		n := 0
+
+		defer func() { count <- n }()
+
+		for {
+			var buf []byte
+
+			select {
+			case buf = <-free:
+			default:
+			}
+
+			buf, err := readChunk(br, buf, o.ChunkSize)
+			if err != nil {
+				readErr = err
+				return
+			}
+
+			if len(buf) == 0 {
+				return
+			}
+
+			select {
+			case chunks <- chunk{index: n, line: line, data: buf}:
+			case <-stop:
+				return
+			}
+
+			line += bytes.Count(buf, []byte{'\n'})
+			n++
+		}
+	}()
+
+	var (
+		parts   [][]chartype.Candle
+		err     error
+		total   = -1
+		handled int
+	)
+
+	for total < 0 || handled < total {
+		select {
+		case r := <-results:
+			handled++
+
+			if r.err != nil && err == nil {
+				err = r.err
+				close(stop)
+			}
+
+			for len(parts) <= r.index {
+				parts = append(parts, nil)
+			}
+
+			parts[r.index] = r.candles
+		case total = <-count:
+		}
+	}
+
+	if readErr != nil {
+		return nil, readErr
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	n := 0
+	for _, p := range parts {
+		n += len(p)
+	}
+
+	if n < lc.sizeHint {
+		n = lc.sizeHint
+	}
+
+	res := make([]chartype.Candle, 0, n)
+	for _, p := range parts {
+		res = append(res, p...)
+	}
+
+	return res, nil
+}
+
+// readChunk appends at least size bytes, or all remaining bytes, to
+// the buffer and then extends it to the end of the current line.
+func readChunk(br *bufio.Reader, buf []byte, size int) ([]byte, error) {
+	if cap(buf) < size {
+		buf = make([]byte, 0, size)
+	}
+
+	n, err := io.ReadFull(br, buf[:size])
+	buf = buf[:n]
+
+	switch err {
+	case nil:
+	case io.EOF, io.ErrUnexpectedEOF:
+		return buf, nil
+	default:
+		return nil, err
+	}
+
+	if buf[n-1] == '\n' {
+		return buf, nil
+	}
+
+	rest, err := readLine(br)
+	if err != nil {
+		return nil, err
+	}
+
+	return append(buf, rest...), nil
+}
+
+// readLine reads bytes until the end of the current line. The returned
+// slice is only valid until the next read.
+func readLine(br *bufio.Reader) ([]byte, error) {
+	var res []byte
+
+	for {
+		b, err := br.ReadSlice('\n')
+
+		switch err {
+		case nil, io.EOF:
+			if res == nil {
+				return b, nil
+			}
+
+			return append(res, b...), nil
+		case bufio.ErrBufferFull:
+			res = append(res, b...)
+		default:
+			return nil, err
+		}
+	}
+}
+
+// parseChunk parses all lines of the chunk and appends the candles to
+// dst.
+func parseChunk(dst []chartype.Candle, data []byte, o Options, p *chartype.Parser, line int) ([]chartype.Candle, error) {
+	var ff [maxFieldCount][]byte
+
+	n := bytes.Count(data, []byte{'\n'})
+	if len(data) > 0 && data[len(data)-1] != '\n' {
+		n++
+	}
+
+	dst = reserve(dst, n)
+
+	for len(data) > 0 {
+		var l []byte
+
+		if i := bytes.IndexByte(data, '\n'); i >= 0 {
+			l, data = data[:i], data[i+1:]
+		} else {
+			l, data = data, nil
+		}
+
+		if n := len(l); n > 0 && l[n-1] == '\r' {
+			l = l[:n-1]
+		}
+
+		if len(l) > 0 {
+			var c chartype.Candle
+
+			if err := parseLine(&c, l, ff[:], o, p); err != nil {
+				return nil, &ParseError{Line: line, Err: err}
+			}
+
+			dst = append(dst, c)
+		}
+
+		line++
+	}
+
+	return dst, nil
+}
+
+// reserve returns dst with room for at least n more candles. When dst
+// has to grow, its capacity is at least doubled.
+func reserve(dst []chartype.Candle, n int) []chartype.Candle {
+	if cap(dst)-len(dst) >= n {
+		return dst
+	}
+
+	size := len(dst) + n
+	if c := 2 * cap(dst); c > size {
+		size = c
+	}
+
+	res := make([]chartype.Candle, len(dst), size)
+	copy(res, dst)
+
+	return res
+}
+
+// parseLine splits the line into fields and parses them into the
+// candle.
+func parseLine(c *chartype.Candle, l []byte, ff [][]byte, o Options, p *chartype.Parser) error {
+	n := 0
+
+	for {
+		if n == len(ff) {
+			return ErrInvalidRecord
+		}
+
+		j := bytes.IndexByte(l, o.Comma)
+		if j < 0 {
+			ff[n] = l
+			n++
+
+			break
+		}
+
+		ff[n], l = l[:j], l[j+1:]
+		n++
+	}
+
+	if n < minFieldCount {
+		return ErrInvalidRecord
+	}
+
+	ts, err := parseTimestamp(ff[0], o.TimeUnit)
+	if err != nil {
+		return err
+	}
+
+	c.Timestamp = ts
+
+	return p.ParseInto(c, ff[1:n]...)
+}
+
+// parseTimestamp parses the timestamp field as an integer number of
+// time units or, if time unit is zero, as an RFC 3339 time.
+func parseTimestamp(b []byte, unit time.Duration) (time.Time, error) {
+	if unit == 0 {
+		return time.Parse(time.RFC3339Nano, string(b))
+	}
+
+	neg := len(b) > 0 && b[0] == '-'
+	if neg {
+		b = b[1:]
+	}
+
+	if len(b) == 0 || len(b) > maxTimestampDigits {
+		return time.Time{}, ErrInvalidRecord
+	}
+
+	var n int64
+
+	for _, ch := range b {
+		if ch < '0' || ch > '9' {
+			return time.Time{}, ErrInvalidRecord
+		}
+
+		n = n*10 + int64(ch-'0')
+	}
+
+	// the conversion to nanoseconds must not overflow
+	if n > math.MaxInt64/int64(unit) {
+		return time.Time{}, ErrInvalidRecord
+	}
+
+	if neg {
+		n = -n
+	}
+
+	return time.Unix(0, n*int64(unit)).UTC(), nil
+}
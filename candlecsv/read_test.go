@@ -0,0 +1,326 @@
+package candlecsv
+
+import (
+	"errors"
+	"io"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/jellydator/chartype"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// errReader returns the data and then the error.
+type errReader struct {
+	data string
+	err  error
+}
+
+func (er *errReader) Read(p []byte) (int, error) {
+	if er.data == "" {
+		return 0, er.err
+	}
+
+	n := copy(p, er.data)
+	er.data = er.data[n:]
+
+	return n, nil
+}
+
+func testCandle(ts int64, v string) chartype.Candle {
+	d := decimal.RequireFromString(v)
+
+	return chartype.Candle{
+		Timestamp: time.Unix(ts, 0).UTC(),
+		Open:      d,
+		High:      d,
+		Low:       d,
+		Close:     d,
+		Volume:    d,
+	}
+}
+
+func testOptionalCandle(ts int64, v, adj, delta string) chartype.Candle {
+	c := testCandle(ts, v)
+
+	if adj != "" {
+		d := decimal.RequireFromString(adj)
+		c.AdjClose = &d
+	}
+
+	if delta != "" {
+		d := decimal.RequireFromString(delta)
+		c.Delta = &d
+	}
+
+	return c
+}
+
+func testData(n int) (string, []chartype.Candle) {
+	var sb strings.Builder
+
+	cc := make([]chartype.Candle, n)
+
+	for i := 0; i < n; i++ {
+		v := strconv.Itoa(i) + ".5"
+		sb.WriteString(strconv.Itoa(i) + "," + v + "," + v + "," + v + "," + v + "," + v + "\n")
+		cc[i] = testCandle(int64(i), v)
+	}
+
+	return sb.String(), cc
+}
+
+func Test_ParseError(t *testing.T) {
+	pe := &ParseError{Line: 3, Err: ErrInvalidRecord}
+	assert.Equal(t, "line 3: invalid record", pe.Error())
+	assert.True(t, errors.Is(pe, ErrInvalidRecord))
+}
+
+func Test_Read(t *testing.T) {
+	data, candles := testData(200)
+
+	cc := map[string]struct {
+		Data    string
+		Reader  io.Reader
+		Options Options
+		Load    []LoadOption
+		Result  []chartype.Candle
+		Err     error
+		Line    int
+	}{
+		"Invalid options": {
+			Options: Options{Workers: -1},
+			Err:     ErrInvalidOptions,
+		},
+		"Invalid size hint": {
+			Load: []LoadOption{WithSizeHint(-1)},
+			Err:  ErrInvalidOptions,
+		},
+		"Header read error": {
+			Reader:  &errReader{data: "timestamp", err: assert.AnError},
+			Options: Options{Header: true},
+			Err:     assert.AnError,
+		},
+		"Chunk read error": {
+			Reader: &errReader{data: "1,2,3,4,5,6\n", err: assert.AnError},
+			Err:    assert.AnError,
+		},
+		"Line read error": {
+			Reader:  &errReader{data: strings.Repeat("1,2,3,4,5,6\n", 2)[:20], err: assert.AnError},
+			Options: Options{ChunkSize: 16},
+			Err:     assert.AnError,
+		},
+		"Parallel read error": {
+			Reader:  &errReader{data: data, err: assert.AnError},
+			Options: Options{TimeUnit: time.Second, ChunkSize: 64, Workers: 4},
+			Err:     assert.AnError,
+		},
+		"Too few fields": {
+			Data: "1,2,3,4,5\n",
+			Err:  ErrInvalidRecord,
+			Line: 1,
+		},
+		"Too many fields": {
+			Data:    "1,2,3,4,5,6\n\n1,2,3,4,5,6,7,8,9\n",
+			Options: Options{TimeUnit: time.Second},
+			Err:     ErrInvalidRecord,
+			Line:    3,
+		},
+		"Invalid RFC 3339 timestamp": {
+			Data: "1,2,3,4,5,6\n",
+			Err:  assert.AnError,
+			Line: 1,
+		},
+		"Invalid integer timestamp": {
+			Data:    "1x,2,3,4,5,6\n",
+			Options: Options{TimeUnit: time.Second},
+			Err:     ErrInvalidRecord,
+			Line:    1,
+		},
+		"Empty integer timestamp": {
+			Data:    "-,2,3,4,5,6\n",
+			Options: Options{TimeUnit: time.Second},
+			Err:     ErrInvalidRecord,
+			Line:    1,
+		},
+		"Too long integer timestamp": {
+			Data:    "1234567890123456789,2,3,4,5,6\n",
+			Options: Options{TimeUnit: time.Second},
+			Err:     ErrInvalidRecord,
+			Line:    1,
+		},
+		"Overflowing integer timestamp": {
+			Data:    "9223372037,2,3,4,5,6\n",
+			Options: Options{TimeUnit: time.Second},
+			Err:     ErrInvalidRecord,
+			Line:    1,
+		},
+		"Overflowing negative integer timestamp": {
+			Data:    "-9223372037,2,3,4,5,6\n",
+			Options: Options{TimeUnit: time.Second},
+			Err:     ErrInvalidRecord,
+			Line:    1,
+		},
+		"Invalid decimal": {
+			Data:    "timestamp,open,high,low,close,volume\n1,2,3,4,5,x",
+			Options: Options{Header: true, TimeUnit: time.Second},
+			Err:     assert.AnError,
+			Line:    2,
+		},
+		"Invalid decimal in parallel": {
+			Data:    data + "1,2,3,4,5,x\n" + data,
+			Options: Options{TimeUnit: time.Second, ChunkSize: 64, Workers: 4},
+			Err:     assert.AnError,
+			Line:    201,
+		},
+		"Successful RFC 3339 read": {
+			Data: "timestamp;open;high;low;close;volume\r\n" +
+				"1970-01-01T00:00:01Z;1;1;1;1;1\r\n\r\n" +
+				"1970-01-01T00:00:02Z;2.5;2.5;2.5;2.5;2.5",
+			Options: Options{Comma: ';', Header: true},
+			Result:  []chartype.Candle{testCandle(1, "1"), testCandle(2, "2.5")},
+		},
+		"Successful read with decimal commas": {
+			Data: "1;1.234,5;1.234,5;1.234,5;1.234,5;1.234,5\n" +
+				"2;2,5;2,5;2,5;2,5;2,5\n",
+			Options: Options{
+				Comma:    ';',
+				TimeUnit: time.Second,
+				ParseOptions: []chartype.ParseOption{
+					chartype.WithDecimalComma(),
+					chartype.WithThousandSeparators(),
+				},
+			},
+			Result: []chartype.Candle{testCandle(1, "1234.5"), testCandle(2, "2.5")},
+		},
+		"Successful read with adjusted close": {
+			Data:    "1,2,2,2,2,2,1.5\n2,3,3,3,3,3,\n",
+			Options: Options{TimeUnit: time.Second},
+			Result: []chartype.Candle{
+				testOptionalCandle(1, "2", "1.5", ""),
+				testCandle(2, "3"),
+			},
+		},
+		"Successful read with volume delta": {
+			Data:    "1,2,2,2,2,2,1.5,-0.5\n2,3,3,3,3,3,,1\n",
+			Options: Options{TimeUnit: time.Second},
+			Result: []chartype.Candle{
+				testOptionalCandle(1, "2", "1.5", "-0.5"),
+				testOptionalCandle(2, "3", "", "1"),
+			},
+		},
+		"Successful largest second read": {
+			Data:    "9223372036,1,1,1,1,1\n-9223372036,2,2,2,2,2\n",
+			Options: Options{TimeUnit: time.Second},
+			Result:  []chartype.Candle{testCandle(9223372036, "1"), testCandle(-9223372036, "2")},
+		},
+		"Successful millisecond read": {
+			Data:    "-1000,1,1,1,1,1\n2000,2,2,2,2,2\n",
+			Options: Options{TimeUnit: time.Millisecond},
+			Result:  []chartype.Candle{testCandle(-1, "1"), testCandle(2, "2")},
+		},
+		"Successful read of line-aligned chunks": {
+			Data:    "1,2,2,2,2,2.000\n2,3,3,3,3,3.000\n",
+			Options: Options{TimeUnit: time.Second, ChunkSize: 16},
+			Result:  []chartype.Candle{testCandle(1, "2"), testCandle(2, "3")},
+		},
+		"Successful sequential read of small chunks": {
+			Data:    data,
+			Options: Options{TimeUnit: time.Second, ChunkSize: 16},
+			Result:  candles,
+		},
+		"Successful parallel read": {
+			Data:    data,
+			Options: Options{TimeUnit: time.Second, ChunkSize: 64, Workers: 4},
+			Result:  candles,
+		},
+		"Successful sequential read with size hint": {
+			Data:    data,
+			Options: Options{TimeUnit: time.Second, ChunkSize: 64},
+			Load:    []LoadOption{WithSizeHint(200)},
+			Result:  candles,
+		},
+		"Successful parallel read with interning": {
+			Data: data,
+			Options: Options{
+				TimeUnit:     time.Second,
+				ChunkSize:    64,
+				Workers:      4,
+				ParseOptions: []chartype.ParseOption{chartype.WithInterning(16)},
+			},
+			Result: candles,
+		},
+		"Successful parallel read with size hint": {
+			Data:    data,
+			Options: Options{TimeUnit: time.Second, ChunkSize: 64, Workers: 4},
+			Load:    []LoadOption{WithSizeHint(300)},
+			Result:  candles,
+		},
+		"Successful empty read": {
+			Options: Options{Header: true, Workers: 2},
+		},
+	}
+
+	for cn, c := range cc {
+		c := c
+
+		t.Run(cn, func(t *testing.T) {
+			t.Parallel()
+
+			r := c.Reader
+			if r == nil {
+				r = strings.NewReader(c.Data)
+			}
+
+			res, err := Read(r, c.Options, c.Load...)
+
+			if c.Line > 0 {
+				var pe *ParseError
+
+				require.True(t, errors.As(err, &pe))
+				assert.Equal(t, c.Line, pe.Line)
+				equalError(t, c.Err, pe.Err)
+
+				return
+			}
+
+			equalError(t, c.Err, err)
+			if err != nil {
+				return
+			}
+
+			require.Len(t, res, len(c.Result))
+
+			for i := range c.Result {
+				assert.Equal(t, c.Result[i].Timestamp, res[i].Timestamp)
+				assert.Equal(t, c.Result[i].Close.String(), res[i].Close.String())
+				assert.Equal(t, c.Result[i].Volume.String(), res[i].Volume.String())
+				assert.Equal(t, c.Result[i].AdjClose, res[i].AdjClose)
+				assert.Equal(t, c.Result[i].Delta, res[i].Delta)
+			}
+		})
+	}
+}
+
+func Benchmark_Read(b *testing.B) {
+	data, _ := testData(10000)
+
+	for _, w := range []int{1, 4} {
+		for _, h := range []int{0, 10000} {
+			o := Options{TimeUnit: time.Second, ChunkSize: 64 << 10, Workers: w}
+
+			b.Run("workers="+strconv.Itoa(w)+"/hint="+strconv.Itoa(h), func(b *testing.B) {
+				b.SetBytes(int64(len(data)))
+				b.ReportAllocs()
+
+				for i := 0; i < b.N; i++ {
+					_, _ = Read(strings.NewReader(data), o, WithSizeHint(h))
+				}
+			})
+		}
+	}
+}
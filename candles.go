@@ -0,0 +1,5 @@
+package chartype
+
+// Candles is a series of candles ordered by timestamp in ascending
+// order. It provides helpers for working with the whole series.
+type Candles []Candle
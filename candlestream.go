@@ -0,0 +1,498 @@
+package chartype
+
+import (
+	"context"
+	"sync"
+)
+
+const (
+	// BackpressureBlock specifies that publishing waits until a slow
+	// subscriber has room for the update.
+	BackpressureBlock BackpressurePolicy = iota + 1
+
+	// BackpressureDropOldest specifies that the oldest update queued
+	// for a slow subscriber is dropped to make room for the new one.
+	BackpressureDropOldest
+
+	// BackpressureConflate specifies that the new update replaces the
+	// queued update of the same candle or the queued ticker. If there
+	// is none and the subscriber's queue is full, the oldest update is
+	// dropped.
+	BackpressureConflate
+)
+
+var (
+	// ErrInvalidBackpressurePolicy is returned when backpressure
+	// policy with invalid value is being used.
+	ErrInvalidBackpressurePolicy = newError(CodeInvalidArgument, "invalid backpressure policy")
+
+	// ErrStreamClosed is returned when a closed stream or subscription
+	// is being used.
+	ErrStreamClosed = newError(CodeClosed, "stream closed")
+)
+
+// BackpressurePolicy specifies how a stream treats subscribers that
+// do not keep up with published updates.
+// Can be included in configuration structures.
+type BackpressurePolicy int
+
+// Validate checks whether the backpressure policy is one of
+// supported policy types or not.
+func (bp BackpressurePolicy) Validate() error {
+	switch bp {
+	case BackpressureBlock, BackpressureDropOldest, BackpressureConflate:
+		return nil
+	default:
+		return ErrInvalidBackpressurePolicy
+	}
+}
+
+// MarshalText turns backpressure policy to appropriate string
+// representation.
+func (bp BackpressurePolicy) MarshalText() ([]byte, error) {
+	var v string
+
+	switch bp {
+	case BackpressureBlock:
+		v = "block"
+	case BackpressureDropOldest:
+		v = "drop_oldest"
+	case BackpressureConflate:
+		v = "conflate"
+	default:
+		return nil, ErrInvalidBackpressurePolicy
+	}
+
+	return []byte(v), nil
+}
+
+// UnmarshalText turns string to appropriate backpressure policy value.
+func (bp *BackpressurePolicy) UnmarshalText(d []byte) error {
+	switch string(d) {
+	case "block":
+		*bp = BackpressureBlock
+	case "drop_oldest":
+		*bp = BackpressureDropOldest
+	case "conflate":
+		*bp = BackpressureConflate
+	default:
+		return ErrInvalidBackpressurePolicy
+	}
+
+	return nil
+}
+
+// StreamUpdate holds a single update delivered to stream subscribers.
+// Exactly one of its fields is set.
+type StreamUpdate struct {
+	Candle   *Candle `json:"candle,omitempty" yaml:"candle,omitempty"`
+	Ticker   *Ticker `json:"ticker,omitempty" yaml:"ticker,omitempty"`
+	Snapshot *Packet `json:"snapshot,omitempty" yaml:"snapshot,omitempty"`
+}
+
+// StreamOption configures a candle stream created by NewCandleStream.
+type StreamOption func(*streamConfig)
+
+// streamConfig holds stream options' settings.
+type streamConfig struct {
+	snapshot bool
+	history  int
+}
+
+// WithSnapshot makes the stream retain up to n most recent candles
+// and the latest ticker and deliver them as a snapshot packet to
+// every new subscriber before any live update. Updates published
+// after the snapshot was taken are always delivered, and updates
+// included in it never are. N must be positive.
+func WithSnapshot(n int) StreamOption {
+	return func(sc *streamConfig) {
+		sc.snapshot = true
+		sc.history = n
+	}
+}
+
+// CandleStream fans out published candle and ticker updates to
+// subscribers. Each subscriber has its own queue of the stream's
+// buffer size; the stream's backpressure policy decides what happens
+// when the queue is full, so that one lagging subscriber does not
+// have to stall the publisher.
+//
+// Updates are delivered to every subscriber in the order they were
+// published.
+//
+// CandleStream is safe for concurrent use.
+type CandleStream struct {
+	size   int
+	policy BackpressurePolicy
+	config streamConfig
+
+	publishMu sync.Mutex
+
+	mu      sync.Mutex
+	subs    map[*Subscription]struct{}
+	candles []Candle
+	ticker  Ticker
+	dropped int
+	closed  bool
+}
+
+// NewCandleStream creates a new candle stream that queues up to size
+// updates per subscriber and handles full queues according to the
+// backpressure policy.
+func NewCandleStream(size int, bp BackpressurePolicy, opts ...StreamOption) (*CandleStream, error) {
+	if size <= 0 {
+		return nil, ErrInvalidBufferSize
+	}
+
+	if err := bp.Validate(); err != nil {
+		return nil, err
+	}
+
+	var sc streamConfig
+
+	for _, o := range opts {
+		o(&sc)
+	}
+
+	if sc.snapshot && sc.history <= 0 {
+		return nil, ErrInvalidLength
+	}
+
+	return &CandleStream{
+		size:   size,
+		policy: bp,
+		config: sc,
+		subs:   make(map[*Subscription]struct{}),
+	}, nil
+}
+
+// Publish delivers the candle to all current subscribers. With
+// BackpressureBlock it waits until every subscriber has room for it.
+func (cs *CandleStream) Publish(c Candle) error {
+	return cs.publish(StreamUpdate{Candle: &c})
+}
+
+// PublishTicker delivers the ticker to all current subscribers. With
+// BackpressureBlock it waits until every subscriber has room for it.
+func (cs *CandleStream) PublishTicker(t Ticker) error {
+	return cs.publish(StreamUpdate{Ticker: &t})
+}
+
+// publish delivers the update to subscribers registered at the time
+// of the call.
+func (cs *CandleStream) publish(u StreamUpdate) error {
+	cs.publishMu.Lock()
+	defer cs.publishMu.Unlock()
+
+	cs.mu.Lock()
+
+	if cs.closed {
+		cs.mu.Unlock()
+		return ErrStreamClosed
+	}
+
+	if cs.config.snapshot {
+		cs.retain(u)
+	}
+
+	subs := make([]*Subscription, 0, len(cs.subs))
+	for s := range cs.subs {
+		subs = append(subs, s)
+	}
+
+	cs.mu.Unlock()
+
+	var dropped int
+
+	for _, s := range subs {
+		if s.push(u, cs.size, cs.policy) {
+			dropped++
+		}
+	}
+
+	if dropped > 0 {
+		cs.mu.Lock()
+		cs.dropped += dropped
+		cs.mu.Unlock()
+	}
+
+	return nil
+}
+
+// retain records the update for snapshots. Candles replace retained
+// candles with equal timestamps and are otherwise inserted in
+// chronological order, evicting the oldest one once the history is
+// full. Mutex must be held.
+func (cs *CandleStream) retain(u StreamUpdate) {
+	if u.Ticker != nil {
+		cs.ticker = *u.Ticker
+		return
+	}
+
+	c := *u.Candle
+
+	i := len(cs.candles)
+	for i > 0 && !cs.candles[i-1].Timestamp.Before(c.Timestamp) {
+		i--
+
+		if cs.candles[i].Timestamp.Equal(c.Timestamp) {
+			cs.candles[i] = c
+			return
+		}
+	}
+
+	cs.candles = append(cs.candles, Candle{})
+	copy(cs.candles[i+1:], cs.candles[i:])
+	cs.candles[i] = c
+
+	if len(cs.candles) > cs.config.history {
+		cs.candles = append(cs.candles[:0], cs.candles[1:]...)
+	}
+}
+
+// Subscribe registers a new subscriber that receives updates
+// published from now on, preceded by a snapshot if the stream was
+// created with WithSnapshot.
+func (cs *CandleStream) Subscribe() (*Subscription, error) {
+	cs.mu.Lock()
+	defer cs.mu.Unlock()
+
+	if cs.closed {
+		return nil, ErrStreamClosed
+	}
+
+	s := &Subscription{
+		stream: cs,
+		ready:  make(chan struct{}, 1),
+		space:  make(chan struct{}, 1),
+		done:   make(chan struct{}),
+	}
+
+	if cs.config.snapshot {
+		s.snapshot = &Packet{
+			Ticker:  cs.ticker,
+			Candles: append([]Candle{}, cs.candles...),
+		}
+	}
+
+	cs.subs[s] = struct{}{}
+
+	return s, nil
+}
+
+// Subscribers returns the number of current subscribers.
+func (cs *CandleStream) Subscribers() int {
+	cs.mu.Lock()
+	defer cs.mu.Unlock()
+
+	return len(cs.subs)
+}
+
+// Dropped returns the total number of updates dropped from
+// subscribers' queues.
+func (cs *CandleStream) Dropped() int {
+	cs.mu.Lock()
+	defer cs.mu.Unlock()
+
+	return cs.dropped
+}
+
+// Close closes the stream and all of its subscriptions. Subscribers
+// receive their already queued updates before ErrStreamClosed.
+func (cs *CandleStream) Close() error {
+	cs.mu.Lock()
+
+	if cs.closed {
+		cs.mu.Unlock()
+		return ErrStreamClosed
+	}
+
+	cs.closed = true
+	subs := cs.subs
+	cs.subs = nil
+	cs.mu.Unlock()
+
+	for s := range subs {
+		s.close()
+	}
+
+	return nil
+}
+
+// Subscription receives updates of a candle stream.
+//
+// Subscription is safe for concurrent use.
+type Subscription struct {
+	stream *CandleStream
+
+	mu        sync.Mutex
+	snapshot  *Packet
+	queue     []StreamUpdate
+	dropped   int
+	conflated int
+	closed    bool
+
+	ready chan struct{}
+	space chan struct{}
+	done  chan struct{}
+}
+
+// Next returns the oldest queued update, waiting for one if the
+// queue is empty. The snapshot, if any, is returned first.
+// ErrStreamClosed is returned once the subscription is closed and its
+// queue is drained.
+func (s *Subscription) Next(ctx context.Context) (StreamUpdate, error) {
+	for {
+		s.mu.Lock()
+
+		if s.snapshot != nil {
+			p := s.snapshot
+			s.snapshot = nil
+			s.mu.Unlock()
+
+			return StreamUpdate{Snapshot: p}, nil
+		}
+
+		if len(s.queue) > 0 {
+			u := s.queue[0]
+			s.queue[0] = StreamUpdate{}
+			s.queue = s.queue[1:]
+			s.mu.Unlock()
+
+			notify(s.space)
+
+			return u, nil
+		}
+
+		closed := s.closed
+		s.mu.Unlock()
+
+		if closed {
+			return StreamUpdate{}, ErrStreamClosed
+		}
+
+		select {
+		case <-s.ready:
+		case <-s.done:
+		case <-ctx.Done():
+			return StreamUpdate{}, ctx.Err()
+		}
+	}
+}
+
+// Dropped returns the number of updates dropped from the
+// subscription's queue.
+func (s *Subscription) Dropped() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.dropped
+}
+
+// Conflated returns the number of queued updates replaced by newer
+// ones.
+func (s *Subscription) Conflated() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.conflated
+}
+
+// Close unregisters the subscription from its stream. Already queued
+// updates can still be received.
+func (s *Subscription) Close() error {
+	s.stream.mu.Lock()
+
+	if _, ok := s.stream.subs[s]; !ok {
+		s.stream.mu.Unlock()
+		return ErrStreamClosed
+	}
+
+	delete(s.stream.subs, s)
+	s.stream.mu.Unlock()
+
+	s.close()
+
+	return nil
+}
+
+// close marks the subscription as closed and wakes up its waiters.
+func (s *Subscription) close() {
+	s.mu.Lock()
+	s.closed = true
+	s.mu.Unlock()
+
+	close(s.done)
+}
+
+// push queues the update according to the backpressure policy and
+// reports whether a queued update was dropped to make room for it.
+func (s *Subscription) push(u StreamUpdate, size int, bp BackpressurePolicy) bool {
+	for {
+		s.mu.Lock()
+
+		if s.closed {
+			s.mu.Unlock()
+			return false
+		}
+
+		if bp == BackpressureConflate && s.conflate(u) {
+			s.mu.Unlock()
+			return false
+		}
+
+		if len(s.queue) < size {
+			s.queue = append(s.queue, u)
+			s.mu.Unlock()
+
+			notify(s.ready)
+
+			return false
+		}
+
+		if bp != BackpressureBlock {
+			s.queue[0] = StreamUpdate{}
+			s.queue = append(s.queue[1:], u)
+			s.dropped++
+			s.mu.Unlock()
+
+			return true
+		}
+
+		s.mu.Unlock()
+
+		select {
+		case <-s.space:
+		case <-s.done:
+		}
+	}
+}
+
+// conflate replaces the queued update of the same candle or the
+// queued ticker with the update and reports whether there was one.
+// Mutex must be held.
+func (s *Subscription) conflate(u StreamUpdate) bool {
+	for i := len(s.queue) - 1; i >= 0; i-- {
+		q := s.queue[i]
+
+		switch {
+		case u.Ticker != nil && q.Ticker != nil,
+			u.Candle != nil && q.Candle != nil && u.Candle.Timestamp.Equal(q.Candle.Timestamp):
+			s.queue[i] = u
+			s.conflated++
+
+			return true
+		}
+	}
+
+	return false
+}
+
+// notify signals the channel without blocking; a pending signal is
+// enough to wake up the waiter.
+func notify(ch chan struct{}) {
+	select {
+	case ch <- struct{}{}:
+	default:
+	}
+}
@@ -0,0 +1,322 @@
+package chartype
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_BackpressurePolicy_Validate(t *testing.T) {
+	cc := map[string]struct {
+		Policy BackpressurePolicy
+		Err    error
+	}{
+		"Invalid BackpressurePolicy": {
+			Policy: 70,
+			Err:    ErrInvalidBackpressurePolicy,
+		},
+		"Successful BackpressureBlock validation": {
+			Policy: BackpressureBlock,
+		},
+		"Successful BackpressureDropOldest validation": {
+			Policy: BackpressureDropOldest,
+		},
+		"Successful BackpressureConflate validation": {
+			Policy: BackpressureConflate,
+		},
+	}
+
+	for cn, c := range cc {
+		c := c
+
+		t.Run(cn, func(t *testing.T) {
+			t.Parallel()
+
+			equalError(t, c.Err, c.Policy.Validate())
+		})
+	}
+}
+
+func Test_BackpressurePolicy_MarshalText(t *testing.T) {
+	cc := map[string]struct {
+		Policy BackpressurePolicy
+		Text   string
+		Err    error
+	}{
+		"Invalid BackpressurePolicy": {
+			Policy: 70,
+			Err:    ErrInvalidBackpressurePolicy,
+		},
+		"Successful BackpressureBlock marshal": {
+			Policy: BackpressureBlock,
+			Text:   "block",
+		},
+		"Successful BackpressureDropOldest marshal": {
+			Policy: BackpressureDropOldest,
+			Text:   "drop_oldest",
+		},
+		"Successful BackpressureConflate marshal": {
+			Policy: BackpressureConflate,
+			Text:   "conflate",
+		},
+	}
+
+	for cn, c := range cc {
+		c := c
+
+		t.Run(cn, func(t *testing.T) {
+			t.Parallel()
+
+			d, err := c.Policy.MarshalText()
+			equalError(t, c.Err, err)
+			if err != nil {
+				return
+			}
+
+			assert.Equal(t, c.Text, string(d))
+		})
+	}
+}
+
+func Test_BackpressurePolicy_UnmarshalText(t *testing.T) {
+	cc := map[string]struct {
+		Text   string
+		Result BackpressurePolicy
+		Err    error
+	}{
+		"Invalid BackpressurePolicy": {
+			Text: "latest",
+			Err:  ErrInvalidBackpressurePolicy,
+		},
+		"Successful BackpressureBlock unmarshal": {
+			Text:   "block",
+			Result: BackpressureBlock,
+		},
+		"Successful BackpressureDropOldest unmarshal": {
+			Text:   "drop_oldest",
+			Result: BackpressureDropOldest,
+		},
+		"Successful BackpressureConflate unmarshal": {
+			Text:   "conflate",
+			Result: BackpressureConflate,
+		},
+	}
+
+	for cn, c := range cc {
+		c := c
+
+		t.Run(cn, func(t *testing.T) {
+			t.Parallel()
+
+			var bp BackpressurePolicy
+
+			err := bp.UnmarshalText([]byte(c.Text))
+			equalError(t, c.Err, err)
+			if err != nil {
+				return
+			}
+
+			assert.Equal(t, c.Result, bp)
+		})
+	}
+}
+
+func Test_NewCandleStream(t *testing.T) {
+	_, err := NewCandleStream(0, BackpressureBlock)
+	assert.Equal(t, ErrInvalidBufferSize, err)
+
+	_, err = NewCandleStream(1, 70)
+	assert.Equal(t, ErrInvalidBackpressurePolicy, err)
+
+	cs, err := NewCandleStream(1, BackpressureBlock)
+	require.NoError(t, err)
+	assert.Equal(t, 0, cs.Subscribers())
+}
+
+func Test_CandleStream_DropOldest(t *testing.T) {
+	tm := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	cs, err := NewCandleStream(2, BackpressureDropOldest)
+	require.NoError(t, err)
+
+	s, err := cs.Subscribe()
+	require.NoError(t, err)
+
+	for i := 0; i < 4; i++ {
+		require.NoError(t, cs.Publish(testCandle(tm.Add(time.Duration(i)*time.Minute), 1, 1, 1, 1, 1)))
+	}
+
+	assert.Equal(t, 2, s.Dropped())
+	assert.Equal(t, 0, s.Conflated())
+	assert.Equal(t, 2, cs.Dropped())
+
+	for i := 2; i < 4; i++ {
+		u, err := s.Next(context.Background())
+		require.NoError(t, err)
+		assert.Equal(t, tm.Add(time.Duration(i)*time.Minute), u.Candle.Timestamp)
+	}
+}
+
+func Test_CandleStream_Conflate(t *testing.T) {
+	tm := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	cs, err := NewCandleStream(2, BackpressureConflate)
+	require.NoError(t, err)
+
+	s, err := cs.Subscribe()
+	require.NoError(t, err)
+
+	require.NoError(t, cs.Publish(testCandle(tm, 1, 1, 1, 1, 1)))
+	require.NoError(t, cs.Publish(testCandle(tm, 1, 2, 1, 2, 2)))
+	require.NoError(t, cs.PublishTicker(Ticker{Last: decimal.NewFromInt(1)}))
+	require.NoError(t, cs.PublishTicker(Ticker{Last: decimal.NewFromInt(2)}))
+	require.NoError(t, cs.Publish(testCandle(tm.Add(time.Minute), 2, 2, 2, 2, 2)))
+
+	assert.Equal(t, 2, s.Conflated())
+	assert.Equal(t, 1, s.Dropped())
+
+	u, err := s.Next(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, "2", u.Ticker.Last.String())
+
+	u, err = s.Next(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, tm.Add(time.Minute), u.Candle.Timestamp)
+}
+
+func Test_CandleStream_Block(t *testing.T) {
+	tm := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	cs, err := NewCandleStream(1, BackpressureBlock)
+	require.NoError(t, err)
+
+	s, err := cs.Subscribe()
+	require.NoError(t, err)
+
+	require.NoError(t, cs.Publish(testCandle(tm, 1, 1, 1, 1, 1)))
+
+	published := make(chan error)
+
+	go func() {
+		published <- cs.Publish(testCandle(tm.Add(time.Minute), 1, 1, 1, 1, 1))
+	}()
+
+	select {
+	case <-published:
+		t.Fatal("publish did not block")
+	case <-time.After(20 * time.Millisecond):
+	}
+
+	u, err := s.Next(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, tm, u.Candle.Timestamp)
+	assert.NoError(t, <-published)
+
+	u, err = s.Next(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, tm.Add(time.Minute), u.Candle.Timestamp)
+	assert.Equal(t, 0, s.Dropped())
+
+	go func() {
+		published <- cs.Publish(testCandle(tm.Add(2*time.Minute), 1, 1, 1, 1, 1))
+		published <- cs.Publish(testCandle(tm.Add(3*time.Minute), 1, 1, 1, 1, 1))
+	}()
+
+	assert.NoError(t, <-published)
+	assert.NoError(t, s.Close())
+	assert.NoError(t, <-published)
+
+	assert.Equal(t, ErrStreamClosed, s.Close())
+	assert.Equal(t, 0, cs.Subscribers())
+}
+
+func Test_CandleStream_Close(t *testing.T) {
+	tm := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	cs, err := NewCandleStream(2, BackpressureBlock)
+	require.NoError(t, err)
+
+	s, err := cs.Subscribe()
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	_, err = s.Next(ctx)
+	assert.Equal(t, context.DeadlineExceeded, err)
+
+	require.NoError(t, cs.Publish(testCandle(tm, 1, 1, 1, 1, 1)))
+
+	next := make(chan error)
+
+	go func() {
+		_, err := s.Next(context.Background())
+		next <- err
+
+		_, err = s.Next(context.Background())
+		next <- err
+	}()
+
+	assert.NoError(t, <-next)
+	assert.NoError(t, cs.Close())
+	assert.Equal(t, ErrStreamClosed, <-next)
+
+	assert.Equal(t, ErrStreamClosed, cs.Close())
+	assert.Equal(t, ErrStreamClosed, cs.Publish(testCandle(tm, 1, 1, 1, 1, 1)))
+	assert.Equal(t, ErrStreamClosed, s.Close())
+
+	_, err = cs.Subscribe()
+	assert.Equal(t, ErrStreamClosed, err)
+}
+
+func Test_CandleStream_Snapshot(t *testing.T) {
+	tm := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	at := func(m int) time.Time {
+		return tm.Add(time.Duration(m) * time.Minute)
+	}
+
+	_, err := NewCandleStream(1, BackpressureBlock, WithSnapshot(0))
+	assert.Equal(t, ErrInvalidLength, err)
+
+	cs, err := NewCandleStream(5, BackpressureDropOldest, WithSnapshot(2))
+	require.NoError(t, err)
+
+	s1, err := cs.Subscribe()
+	require.NoError(t, err)
+
+	u, err := s1.Next(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, &Packet{Candles: []Candle{}}, u.Snapshot)
+
+	require.NoError(t, cs.Publish(testCandle(at(1), 1, 1, 1, 1, 1)))
+	require.NoError(t, cs.Publish(testCandle(at(0), 1, 1, 1, 1, 1)))
+	require.NoError(t, cs.Publish(testCandle(at(2), 2, 2, 2, 2, 2)))
+	require.NoError(t, cs.Publish(testCandle(at(3), 3, 3, 3, 3, 3)))
+	require.NoError(t, cs.Publish(testCandle(at(2), 2, 4, 2, 4, 4)))
+	require.NoError(t, cs.Publish(testCandle(at(1), 1, 1, 1, 1, 1)))
+	require.NoError(t, cs.Publish(testCandle(at(2).Add(time.Second), 6, 6, 6, 6, 6)))
+	require.NoError(t, cs.PublishTicker(Ticker{Last: decimal.NewFromInt(4)}))
+
+	s2, err := cs.Subscribe()
+	require.NoError(t, err)
+
+	require.NoError(t, cs.Publish(testCandle(at(4), 5, 5, 5, 5, 5)))
+
+	u, err = s2.Next(context.Background())
+	require.NoError(t, err)
+	require.NotNil(t, u.Snapshot)
+	assert.Equal(t, "4", u.Snapshot.Ticker.Last.String())
+	assertEqualCandles(t, []Candle{
+		testCandle(at(2).Add(time.Second), 6, 6, 6, 6, 6),
+		testCandle(at(3), 3, 3, 3, 3, 3),
+	}, u.Snapshot.Candles)
+
+	u, err = s2.Next(context.Background())
+	require.NoError(t, err)
+	assert.Nil(t, u.Snapshot)
+	assert.Equal(t, at(4), u.Candle.Timestamp)
+}
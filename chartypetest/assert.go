@@ -0,0 +1,127 @@
+package chartypetest
+
+import (
+	"strconv"
+
+	"github.com/jellydator/chartype"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestingT is the subset of *testing.T used by assertions.
+type TestingT interface {
+	Errorf(format string, args ...interface{})
+}
+
+// helper is implemented by *testing.T to exclude assertion frames
+// from failure locations.
+type helper interface {
+	Helper()
+}
+
+// AssertCandleEqual checks whether both candles have equal timestamps
+// and whether their fields differ by no more than the tolerance.
+// Differences are reported to t. The result of the check is returned.
+func AssertCandleEqual(t TestingT, exp, act chartype.Candle, tolerance float64) bool {
+	if h, ok := t.(helper); ok {
+		h.Helper()
+	}
+
+	return candleEqual(t, "", exp, act, decimal.NewFromFloat(tolerance))
+}
+
+// AssertCandlesEqual checks whether both series have equal lengths
+// and whether their candles are equal as checked by
+// AssertCandleEqual. Differences are reported to t. The result of the
+// check is returned.
+func AssertCandlesEqual(t TestingT, exp, act []chartype.Candle, tolerance float64) bool {
+	if h, ok := t.(helper); ok {
+		h.Helper()
+	}
+
+	if len(exp) != len(act) {
+		t.Errorf("candle count differs: expected %d, actual %d", len(exp), len(act))
+		return false
+	}
+
+	tol := decimal.NewFromFloat(tolerance)
+	res := true
+
+	for i := range exp {
+		if !candleEqual(t, "candle "+strconv.Itoa(i)+": ", exp[i], act[i], tol) {
+			res = false
+		}
+	}
+
+	return res
+}
+
+// AssertErrorEqual checks whether the actual error is equal to the
+// expected one or, if assert.AnError is expected, whether there is an
+// error at all. Nil expected error checks that there is no error.
+// Differences are reported to t. The result of the check is returned.
+func AssertErrorEqual(t TestingT, exp, act error) bool {
+	if h, ok := t.(helper); ok {
+		h.Helper()
+	}
+
+	switch {
+	case exp == assert.AnError: //nolint:goerr113 // direct check is needed
+		return assert.Error(t, act)
+	case exp != nil:
+		return assert.Equal(t, exp, act)
+	default:
+		return assert.NoError(t, act)
+	}
+}
+
+// candleEqual reports candles' differences prefixed with the string
+// and returns whether there are none.
+func candleEqual(t TestingT, prefix string, exp, act chartype.Candle, tol decimal.Decimal) bool {
+	res := true
+
+	if !exp.Timestamp.Equal(act.Timestamp) {
+		t.Errorf("%stimestamp differs: expected %s, actual %s", prefix, exp.Timestamp, act.Timestamp)
+		res = false
+	}
+
+	for cf := chartype.CandleOpen; cf <= chartype.CandleVolume; cf++ {
+		e, a := cf.Extract(exp), cf.Extract(act)
+
+		if e.Sub(a).Abs().GreaterThan(tol) {
+			t.Errorf("%s%s differs: expected %s, actual %s", prefix, fieldName(cf), e, a)
+			res = false
+		}
+	}
+
+	if !optionalEqual(t, prefix+"adj_close", exp.AdjClose, act.AdjClose, tol) {
+		res = false
+	}
+
+	if !optionalEqual(t, prefix+"delta", exp.Delta, act.Delta, tol) {
+		res = false
+	}
+
+	return res
+}
+
+// optionalEqual reports differences of the optional values prefixed
+// with the string and returns whether there are none.
+func optionalEqual(t TestingT, prefix string, exp, act *decimal.Decimal, tol decimal.Decimal) bool {
+	switch {
+	case (exp == nil) != (act == nil):
+		t.Errorf("%s presence differs: expected %t, actual %t", prefix, exp != nil, act != nil)
+		return false
+	case exp != nil && exp.Sub(*act).Abs().GreaterThan(tol):
+		t.Errorf("%s differs: expected %s, actual %s", prefix, exp, act)
+		return false
+	default:
+		return true
+	}
+}
+
+// fieldName returns candle field's text representation.
+func fieldName(cf chartype.CandleField) string {
+	d, _ := cf.MarshalText() //nolint:errcheck // only valid fields are used
+	return string(d)
+}
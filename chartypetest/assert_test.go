@@ -0,0 +1,153 @@
+package chartypetest
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/jellydator/chartype"
+	"github.com/stretchr/testify/assert"
+)
+
+// recorder records reported errors.
+type recorder struct {
+	errs []string
+}
+
+func (r *recorder) Errorf(format string, args ...interface{}) {
+	r.errs = append(r.errs, fmt.Sprintf(format, args...))
+}
+
+func Test_AssertCandleEqual(t *testing.T) {
+	tm := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	exp := NewCandle().At(tm).OHLC(1, 2, 0.5, 1.5).Volume(10).Build()
+
+	cc := map[string]struct {
+		Actual chartype.Candle
+		Errors []string
+	}{
+		"Different timestamp": {
+			Actual: NewCandle().At(tm.Add(time.Second)).OHLC(1, 2, 0.5, 1.5).Volume(10).Build(),
+			Errors: []string{
+				"timestamp differs: expected 2020-01-01 00:00:00 +0000 UTC, actual 2020-01-01 00:00:01 +0000 UTC",
+			},
+		},
+		"Fields beyond tolerance": {
+			Actual: NewCandle().At(tm).OHLC(1.02, 2, 0.5, 1.4).Volume(10.01).Build(),
+			Errors: []string{
+				"open differs: expected 1, actual 1.02",
+				"close differs: expected 1.5, actual 1.4",
+			},
+		},
+		"Different adjusted close presence": {
+			Actual: NewCandle().At(tm).OHLC(1, 2, 0.5, 1.5).Volume(10).AdjClose(1).Build(),
+			Errors: []string{"adj_close presence differs: expected false, actual true"},
+		},
+		"Different volume delta presence": {
+			Actual: NewCandle().At(tm).OHLC(1, 2, 0.5, 1.5).Volume(10).Delta(-2).Build(),
+			Errors: []string{"delta presence differs: expected false, actual true"},
+		},
+		"Equal within tolerance": {
+			Actual: NewCandle().At(tm.In(time.FixedZone("", 3600))).OHLC(1.01, 2, 0.5, 1.5).Volume(9.99).Build(),
+		},
+	}
+
+	for cn, c := range cc {
+		c := c
+
+		t.Run(cn, func(t *testing.T) {
+			t.Parallel()
+
+			var r recorder
+
+			assert.Equal(t, len(c.Errors) == 0, AssertCandleEqual(&r, exp, c.Actual, 0.01))
+			assert.Equal(t, c.Errors, r.errs)
+		})
+	}
+
+	assert.True(t, AssertCandleEqual(t, exp, exp, 0))
+}
+
+func Test_AssertCandlesEqual(t *testing.T) {
+	tm := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	base := NewCandle().OHLC(1, 1, 1, 1).AdjClose(1)
+	exp := []chartype.Candle{base.At(tm).Build(), base.At(tm.Add(time.Minute)).Build()}
+
+	cc := map[string]struct {
+		Actual []chartype.Candle
+		Errors []string
+	}{
+		"Different length": {
+			Actual: exp[:1],
+			Errors: []string{"candle count differs: expected 2, actual 1"},
+		},
+		"Different adjusted close": {
+			Actual: []chartype.Candle{exp[0], base.At(tm.Add(time.Minute)).AdjClose(2).Build()},
+			Errors: []string{"candle 1: adj_close differs: expected 1, actual 2"},
+		},
+		"Equal candles": {
+			Actual: []chartype.Candle{exp[0], base.At(tm.Add(time.Minute)).AdjClose(1.0001).Build()},
+		},
+	}
+
+	for cn, c := range cc {
+		c := c
+
+		t.Run(cn, func(t *testing.T) {
+			t.Parallel()
+
+			var r recorder
+
+			assert.Equal(t, len(c.Errors) == 0, AssertCandlesEqual(&r, exp, c.Actual, 0.001))
+			assert.Equal(t, c.Errors, r.errs)
+		})
+	}
+
+	assert.True(t, AssertCandlesEqual(t, exp, exp, 0))
+}
+
+func Test_AssertErrorEqual(t *testing.T) {
+	cc := map[string]struct {
+		Expected error
+		Actual   error
+		Failed   bool
+	}{
+		"Unexpected error": {
+			Actual: assert.AnError,
+			Failed: true,
+		},
+		"Missing error": {
+			Expected: assert.AnError,
+			Failed:   true,
+		},
+		"Different error": {
+			Expected: chartype.ErrInvalidPair,
+			Actual:   chartype.ErrInvalidInterval,
+			Failed:   true,
+		},
+		"No error": {},
+		"Any error": {
+			Expected: assert.AnError,
+			Actual:   chartype.ErrInvalidPair,
+		},
+		"Equal error": {
+			Expected: chartype.ErrInvalidPair,
+			Actual:   chartype.ErrInvalidPair,
+		},
+	}
+
+	for cn, c := range cc {
+		c := c
+
+		t.Run(cn, func(t *testing.T) {
+			t.Parallel()
+
+			var r recorder
+
+			assert.Equal(t, !c.Failed, AssertErrorEqual(&r, c.Expected, c.Actual))
+			assert.Equal(t, c.Failed, len(r.errs) > 0)
+		})
+	}
+
+	assert.True(t, AssertErrorEqual(t, nil, nil))
+}
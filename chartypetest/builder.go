@@ -0,0 +1,151 @@
+// Package chartypetest provides helpers for testing code built on
+// chartype: fluent builders of candles and tickers and assertions
+// that tolerate small numeric differences.
+package chartypetest
+
+import (
+	"time"
+
+	"github.com/jellydator/chartype"
+	"github.com/shopspring/decimal"
+)
+
+// CandleBuilder builds candles field by field. Each method returns
+// a modified copy, so partially configured builders can be reused as
+// templates.
+type CandleBuilder struct {
+	candle chartype.Candle
+}
+
+// NewCandle creates a new candle builder with all fields set to zero.
+func NewCandle() CandleBuilder {
+	return CandleBuilder{}
+}
+
+// At sets candle's timestamp.
+func (cb CandleBuilder) At(t time.Time) CandleBuilder {
+	cb.candle.Timestamp = t
+	return cb
+}
+
+// Open sets candle's open price.
+func (cb CandleBuilder) Open(v float64) CandleBuilder {
+	cb.candle.Open = decimal.NewFromFloat(v)
+	return cb
+}
+
+// High sets candle's high price.
+func (cb CandleBuilder) High(v float64) CandleBuilder {
+	cb.candle.High = decimal.NewFromFloat(v)
+	return cb
+}
+
+// Low sets candle's low price.
+func (cb CandleBuilder) Low(v float64) CandleBuilder {
+	cb.candle.Low = decimal.NewFromFloat(v)
+	return cb
+}
+
+// Close sets candle's close price.
+func (cb CandleBuilder) Close(v float64) CandleBuilder {
+	cb.candle.Close = decimal.NewFromFloat(v)
+	return cb
+}
+
+// OHLC sets candle's open, high, low and close prices.
+func (cb CandleBuilder) OHLC(o, h, l, c float64) CandleBuilder {
+	return cb.Open(o).High(h).Low(l).Close(c)
+}
+
+// Volume sets candle's volume.
+func (cb CandleBuilder) Volume(v float64) CandleBuilder {
+	cb.candle.Volume = decimal.NewFromFloat(v)
+	return cb
+}
+
+// AdjClose sets candle's adjusted close price.
+func (cb CandleBuilder) AdjClose(v float64) CandleBuilder {
+	d := decimal.NewFromFloat(v)
+	cb.candle.AdjClose = &d
+
+	return cb
+}
+
+// Delta sets candle's volume delta.
+func (cb CandleBuilder) Delta(v float64) CandleBuilder {
+	d := decimal.NewFromFloat(v)
+	cb.candle.Delta = &d
+
+	return cb
+}
+
+// Build returns the built candle.
+func (cb CandleBuilder) Build() chartype.Candle {
+	return cb.candle
+}
+
+// TickerBuilder builds tickers field by field. Each method returns
+// a modified copy, so partially configured builders can be reused as
+// templates.
+type TickerBuilder struct {
+	timestamp time.Time
+	ticker    chartype.Ticker
+}
+
+// NewTicker creates a new ticker builder with all fields set to zero.
+func NewTicker() TickerBuilder {
+	return TickerBuilder{}
+}
+
+// At sets the time the ticker was captured at, used by BuildAt.
+func (tb TickerBuilder) At(t time.Time) TickerBuilder {
+	tb.timestamp = t
+	return tb
+}
+
+// Last sets ticker's last price.
+func (tb TickerBuilder) Last(v float64) TickerBuilder {
+	tb.ticker.Last = decimal.NewFromFloat(v)
+	return tb
+}
+
+// Ask sets ticker's ask price.
+func (tb TickerBuilder) Ask(v float64) TickerBuilder {
+	tb.ticker.Ask = decimal.NewFromFloat(v)
+	return tb
+}
+
+// Bid sets ticker's bid price.
+func (tb TickerBuilder) Bid(v float64) TickerBuilder {
+	tb.ticker.Bid = decimal.NewFromFloat(v)
+	return tb
+}
+
+// Change sets ticker's 24 hour price change.
+func (tb TickerBuilder) Change(v float64) TickerBuilder {
+	tb.ticker.Change = decimal.NewFromFloat(v)
+	return tb
+}
+
+// PercentChange sets ticker's 24 hour price change in percentage
+// points, e.g. 3.5 for 3.5%.
+func (tb TickerBuilder) PercentChange(v float64) TickerBuilder {
+	tb.ticker.PercentChange = chartype.NewPercent(decimal.NewFromFloat(v))
+	return tb
+}
+
+// Volume sets ticker's volume.
+func (tb TickerBuilder) Volume(v float64) TickerBuilder {
+	tb.ticker.Volume = decimal.NewFromFloat(v)
+	return tb
+}
+
+// Build returns the built ticker.
+func (tb TickerBuilder) Build() chartype.Ticker {
+	return tb.ticker
+}
+
+// BuildAt returns the built ticker together with its timestamp.
+func (tb TickerBuilder) BuildAt() chartype.TickerAt {
+	return chartype.TickerAt{Timestamp: tb.timestamp, Ticker: tb.ticker}
+}
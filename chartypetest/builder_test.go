@@ -0,0 +1,65 @@
+package chartypetest
+
+import (
+	"testing"
+	"time"
+
+	"github.com/jellydator/chartype"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_CandleBuilder(t *testing.T) {
+	tm := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	base := NewCandle().At(tm).Volume(10)
+
+	c := base.Open(1).High(2.5).Low(0.5).Close(2).Build()
+	adj := decimal.NewFromFloat(1.9)
+
+	assert.Equal(t, chartype.Candle{
+		Timestamp: tm,
+		Open:      decimal.NewFromFloat(1),
+		High:      decimal.NewFromFloat(2.5),
+		Low:       decimal.NewFromFloat(0.5),
+		Close:     decimal.NewFromFloat(2),
+		Volume:    decimal.NewFromFloat(10),
+	}, c)
+	assert.Equal(t, "2.5", c.High.String())
+
+	assert.Equal(t, chartype.Candle{
+		Timestamp: tm,
+		Open:      decimal.NewFromFloat(1),
+		High:      decimal.NewFromFloat(2.5),
+		Low:       decimal.NewFromFloat(0.5),
+		Close:     decimal.NewFromFloat(2),
+		Volume:    decimal.NewFromFloat(10),
+		AdjClose:  &adj,
+	}, base.OHLC(1, 2.5, 0.5, 2).AdjClose(1.9).Build())
+
+	delta := decimal.NewFromFloat(-4)
+
+	assert.Equal(t, chartype.Candle{
+		Timestamp: tm,
+		Volume:    decimal.NewFromFloat(10),
+		Delta:     &delta,
+	}, base.Delta(-4).Build())
+
+	assert.Equal(t, chartype.Candle{Timestamp: tm, Volume: decimal.NewFromFloat(10)}, base.Build())
+}
+
+func Test_TickerBuilder(t *testing.T) {
+	tm := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	tb := NewTicker().Last(2).Ask(2.1).Bid(1.9).Change(-0.5).PercentChange(-20).Volume(100)
+
+	exp := chartype.Ticker{
+		Last:          decimal.NewFromFloat(2),
+		Ask:           decimal.NewFromFloat(2.1),
+		Bid:           decimal.NewFromFloat(1.9),
+		Change:        decimal.NewFromFloat(-0.5),
+		PercentChange: chartype.NewPercent(decimal.NewFromFloat(-20)),
+		Volume:        decimal.NewFromFloat(100),
+	}
+
+	assert.Equal(t, exp, tb.Build())
+	assert.Equal(t, chartype.TickerAt{Timestamp: tm, Ticker: exp}, tb.At(tm).BuildAt())
+}
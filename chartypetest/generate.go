@@ -0,0 +1,218 @@
+package chartypetest
+
+import (
+	"context"
+	"math/rand"
+	"reflect"
+	"time"
+
+	"github.com/jellydator/chartype"
+	"github.com/shopspring/decimal"
+)
+
+const (
+	// pricePlaces is the number of decimal places of generated
+	// prices and volumes.
+	pricePlaces = 2
+
+	// maxPriceUnits is the maximum generated price in units of the
+	// last decimal place.
+	maxPriceUnits = 1_000_000
+
+	// minTimestamp and maxTimestamp bound generated timestamps in
+	// Unix seconds: years 2000 to 2030.
+	minTimestamp = 946684800
+	maxTimestamp = 1893456000
+)
+
+// Random* functions draw all values from the provided source, so they
+// can be adapted to other property-based testing libraries by seeding
+// it from a drawn value, e.g. with rapid:
+//
+//	rapid.Custom(func(t *rapid.T) chartype.Candle {
+//		r := rand.New(rand.NewSource(rapid.Int64().Draw(t, "seed")))
+//		return chartypetest.RandomCandle(r, time.Unix(0, 0))
+//	})
+
+// RandomCandle returns a valid candle at the provided time: its low
+// is not above its open and close, its high is not below them and its
+// volume is not negative.
+func RandomCandle(r *rand.Rand, t time.Time) chartype.Candle {
+	return candleAround(r, t, r.Int63n(maxPriceUnits)+1)
+}
+
+// RandomInvalidCandle returns a candle at the provided time that
+// violates one of the rules satisfied by RandomCandle.
+func RandomInvalidCandle(r *rand.Rand, t time.Time) chartype.Candle {
+	c := RandomCandle(r, t)
+	d := randomUnits(r, maxPriceUnits).Add(decimal.New(1, -pricePlaces))
+
+	switch r.Intn(4) {
+	case 0:
+		c.High, c.Low = c.Low.Sub(d), c.High
+	case 1:
+		c.Open = c.High.Add(d)
+	case 2:
+		c.Close = c.Low.Sub(d)
+	default:
+		c.Volume = c.Volume.Neg().Sub(d)
+	}
+
+	return c
+}
+
+// RandomTicker returns a ticker whose bid is not above its ask, whose
+// last price is between them and whose volume is not negative.
+func RandomTicker(r *rand.Rand) chartype.Ticker {
+	bid := r.Int63n(maxPriceUnits) + 1
+	ask := bid + r.Int63n(bid/100+1)
+	last := bid + r.Int63n(ask-bid+1)
+	prev := r.Int63n(maxPriceUnits) + 1
+
+	return chartype.Ticker{
+		Last:   units(last),
+		Ask:    units(ask),
+		Bid:    units(bid),
+		Change: units(last - prev),
+		PercentChange: chartype.NewPercentFromRatio(
+			decimal.New(last-prev, 0).DivRound(decimal.New(prev, 0), 8)),
+		Volume: randomUnits(r, maxPriceUnits),
+	}
+}
+
+// RandomSeries returns n valid candles with consecutive interval
+// aligned timestamps starting at the first boundary not before the
+// provided time. Each candle opens at the previous candle's close.
+// Interval must be valid.
+func RandomSeries(r *rand.Rand, start time.Time, i chartype.Interval, n int) []chartype.Candle {
+	ts := i.Truncate(start)
+	if ts.Before(start) {
+		ts = ts.Add(i.Duration())
+	}
+
+	res := make([]chartype.Candle, n)
+	p := r.Int63n(maxPriceUnits) + 1
+
+	for j := range res {
+		c := candleAround(r, ts, p)
+		res[j] = c
+		p = c.Close.Shift(pricePlaces).IntPart()
+		ts = ts.Add(i.Duration())
+	}
+
+	return res
+}
+
+// RandomSource returns a source that serves requests with series
+// generated by RandomSeries, e.g. to run backtests or chart servers
+// without market data. Each request's series is generated from the
+// seed and the request's start, so equal requests get equal candles.
+func RandomSource(seed int64) chartype.Source {
+	return chartype.ReplaySource(chartype.CandleSourceFunc(func(_ context.Context, cr chartype.CandleRequest) ([]chartype.Candle, error) {
+		if err := cr.Validate(); err != nil {
+			return nil, err
+		}
+
+		ts := cr.Interval.Truncate(cr.Range.From)
+		if ts.Before(cr.Range.From) {
+			ts = ts.Add(cr.Interval.Duration())
+		}
+
+		var n int
+
+		for ; ts.Before(cr.Range.To) && (cr.Limit == 0 || n < cr.Limit); ts = ts.Add(cr.Interval.Duration()) {
+			n++
+		}
+
+		r := rand.New(rand.NewSource(seed ^ cr.Range.From.UnixNano())) //nolint:gosec // deterministic test data
+
+		return RandomSeries(r, cr.Range.From, cr.Interval, n), nil
+	}))
+}
+
+// ValidCandle is a candle generated by RandomCandle that can be used
+// with testing/quick.
+type ValidCandle struct {
+	chartype.Candle
+}
+
+// Generate implements quick.Generator.
+func (ValidCandle) Generate(r *rand.Rand, _ int) reflect.Value {
+	return reflect.ValueOf(ValidCandle{RandomCandle(r, randomTime(r))})
+}
+
+// InvalidCandle is a candle generated by RandomInvalidCandle that can
+// be used with testing/quick.
+type InvalidCandle struct {
+	chartype.Candle
+}
+
+// Generate implements quick.Generator.
+func (InvalidCandle) Generate(r *rand.Rand, _ int) reflect.Value {
+	return reflect.ValueOf(InvalidCandle{RandomInvalidCandle(r, randomTime(r))})
+}
+
+// ValidTicker is a ticker generated by RandomTicker that can be used
+// with testing/quick.
+type ValidTicker struct {
+	chartype.Ticker
+}
+
+// Generate implements quick.Generator.
+func (ValidTicker) Generate(r *rand.Rand, _ int) reflect.Value {
+	return reflect.ValueOf(ValidTicker{RandomTicker(r)})
+}
+
+// SortedSeries is a series of up to size one minute candles generated
+// by RandomSeries that can be used with testing/quick.
+type SortedSeries []chartype.Candle
+
+// Generate implements quick.Generator.
+func (SortedSeries) Generate(r *rand.Rand, size int) reflect.Value {
+	return reflect.ValueOf(SortedSeries(RandomSeries(r, randomTime(r), chartype.IntervalMinute, r.Intn(size+1))))
+}
+
+// candleAround returns a valid candle opening at the price in units
+// of the last decimal place.
+func candleAround(r *rand.Rand, t time.Time, open int64) chartype.Candle {
+	spread := open/20 + 1
+	cl := open + r.Int63n(2*spread+1) - spread
+
+	if cl < 1 {
+		cl = 1
+	}
+
+	low, high := open, cl
+	if low > high {
+		low, high = high, low
+	}
+
+	low -= r.Int63n(low)
+	high += r.Int63n(spread + 1)
+
+	return chartype.Candle{
+		Timestamp: t,
+		Open:      units(open),
+		High:      units(high),
+		Low:       units(low),
+		Close:     units(cl),
+		Volume:    randomUnits(r, maxPriceUnits),
+	}
+}
+
+// randomTime returns a random minute aligned UTC time.
+func randomTime(r *rand.Rand) time.Time {
+	s := minTimestamp + r.Int63n(maxTimestamp-minTimestamp)
+	return time.Unix(s-s%60, 0).UTC()
+}
+
+// randomUnits returns a random non-negative decimal below n units of
+// the last decimal place.
+func randomUnits(r *rand.Rand, n int64) decimal.Decimal {
+	return units(r.Int63n(n))
+}
+
+// units returns a decimal of v units of the last decimal place.
+func units(v int64) decimal.Decimal {
+	return decimal.New(v, -pricePlaces)
+}
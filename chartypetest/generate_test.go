@@ -0,0 +1,116 @@
+package chartypetest
+
+import (
+	"context"
+	"math/rand"
+	"testing"
+	"testing/quick"
+	"time"
+
+	"github.com/jellydator/chartype"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_ValidCandle(t *testing.T) {
+	assert.NoError(t, quick.Check(func(c ValidCandle) bool {
+		aa, err := chartype.Candles{c.Candle}.Anomalies(chartype.IntervalMinute)
+		return err == nil && len(aa) == 0 && c.Low.IsPositive()
+	}, nil))
+}
+
+func Test_InvalidCandle(t *testing.T) {
+	assert.NoError(t, quick.Check(func(c InvalidCandle) bool {
+		aa, err := chartype.Candles{c.Candle}.Anomalies(chartype.IntervalMinute)
+		return err == nil && len(aa) == 1 &&
+			(aa[0].Kind == chartype.AnomalyInvalidRange || aa[0].Kind == chartype.AnomalyNegativeVolume)
+	}, &quick.Config{MaxCount: 500}))
+}
+
+func Test_ValidTicker(t *testing.T) {
+	assert.NoError(t, quick.Check(func(tc ValidTicker) bool {
+		return tc.Bid.IsPositive() && tc.Bid.LessThanOrEqual(tc.Last) &&
+			tc.Last.LessThanOrEqual(tc.Ask) && !tc.Volume.IsNegative()
+	}, nil))
+}
+
+func Test_SortedSeries(t *testing.T) {
+	assert.NoError(t, quick.Check(func(ss SortedSeries) bool {
+		aa, err := chartype.Candles(ss).Anomalies(chartype.IntervalMinute)
+		if err != nil || len(aa) != 0 {
+			return false
+		}
+
+		for i := 1; i < len(ss); i++ {
+			if !ss[i].Open.Equal(ss[i-1].Close) || ss[i].Timestamp.Sub(ss[i-1].Timestamp) != time.Minute {
+				return false
+			}
+		}
+
+		return true
+	}, nil))
+}
+
+func Test_RandomSeries(t *testing.T) {
+	r := rand.New(rand.NewSource(1)) //nolint:gosec // deterministic test data
+	start := time.Date(2020, 1, 1, 0, 30, 0, 0, time.UTC)
+
+	ss := RandomSeries(r, start, chartype.IntervalHour, 3)
+	if assert.Len(t, ss, 3) {
+		assert.Equal(t, start.Add(30*time.Minute), ss[0].Timestamp)
+		assert.Equal(t, start.Add(150*time.Minute), ss[2].Timestamp)
+	}
+
+	ss = RandomSeries(r, start.Add(30*time.Minute), chartype.IntervalHour, 1)
+	if assert.Len(t, ss, 1) {
+		assert.Equal(t, start.Add(30*time.Minute), ss[0].Timestamp)
+	}
+
+	assert.Empty(t, RandomSeries(r, start, chartype.IntervalHour, 0))
+}
+
+func Test_RandomSource(t *testing.T) {
+	start := time.Date(2020, 1, 1, 0, 30, 0, 0, time.UTC)
+	cr := chartype.CandleRequest{
+		Pair:     chartype.Pair{Base: "BTC", Quote: "USD"},
+		Interval: chartype.IntervalHour,
+		Range:    chartype.TimeRange{From: start, To: start.Add(3 * time.Hour)},
+	}
+
+	src := RandomSource(1)
+
+	_, err := src.Candles(context.Background(), chartype.CandleRequest{})
+	assert.Equal(t, chartype.ErrInvalidPair, err)
+
+	cc, err := src.Candles(context.Background(), cr)
+	assert.NoError(t, err)
+
+	if assert.Len(t, cc, 3) {
+		assert.Equal(t, start.Add(30*time.Minute), cc[0].Timestamp)
+	}
+
+	res, err := src.Candles(context.Background(), cr)
+	assert.NoError(t, err)
+	assert.Equal(t, cc, res)
+
+	cr.Limit = 1
+
+	res, err = src.Candles(context.Background(), cr)
+	assert.NoError(t, err)
+	assert.Equal(t, cc[:1], res)
+
+	cr.Range.From = start.Add(30 * time.Minute)
+
+	res, err = src.Candles(context.Background(), cr)
+	assert.NoError(t, err)
+	assert.Len(t, res, 1)
+}
+
+func Test_candleAround(t *testing.T) {
+	r := rand.New(rand.NewSource(1)) //nolint:gosec // deterministic test data
+
+	for i := 0; i < 20; i++ {
+		c := candleAround(r, time.Time{}, 1)
+		assert.True(t, c.Close.IsPositive())
+		assert.True(t, c.Low.IsPositive())
+	}
+}
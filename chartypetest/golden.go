@@ -0,0 +1,157 @@
+package chartypetest
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/jellydator/chartype"
+	"github.com/jellydator/chartype/candlecsv"
+	"github.com/shopspring/decimal"
+)
+
+const (
+	// UpdateFlag is the name of the boolean test flag that, when
+	// defined by the test binary and set, makes AssertGolden
+	// overwrite golden files, e.g. `go test -update`.
+	UpdateFlag = "update"
+
+	// UpdateEnv is the environment variable that, when set to a
+	// non-empty value, makes AssertGolden overwrite golden files.
+	UpdateEnv = "UPDATE_GOLDEN"
+)
+
+// csvHeader is the header line of CSV fixtures.
+const csvHeader = "timestamp,open,high,low,close,volume,adj_close,delta\n"
+
+// LoadCandles loads candles from the fixture file. Files with ".json"
+// extension hold a JSON array of candles, files with ".csv"
+// extension hold a header line followed by RFC 3339 timestamp, open,
+// high, low, close, volume and optional adjusted close and volume
+// delta fields. The test fails immediately if the file cannot be
+// loaded.
+func LoadCandles(tb testing.TB, path string) []chartype.Candle {
+	tb.Helper()
+
+	d, err := ioutil.ReadFile(path)
+	if err != nil {
+		tb.Fatalf("reading fixture: %v", err)
+		return nil
+	}
+
+	var cc []chartype.Candle
+
+	switch filepath.Ext(path) {
+	case ".json":
+		err = json.Unmarshal(d, &cc)
+	case ".csv":
+		cc, err = candlecsv.Read(bytes.NewReader(d), candlecsv.Options{Header: true})
+	default:
+		tb.Fatalf("unsupported fixture format: %s", path)
+		return nil
+	}
+
+	if err != nil {
+		tb.Fatalf("decoding fixture %s: %v", path, err)
+		return nil
+	}
+
+	return cc
+}
+
+// SaveGolden saves candles to the fixture file in the format that
+// matches its extension, as described by LoadCandles. Missing
+// directories are created. The test fails immediately if the file
+// cannot be saved.
+func SaveGolden(tb testing.TB, path string, cc []chartype.Candle) {
+	tb.Helper()
+
+	var (
+		d   []byte
+		err error
+	)
+
+	switch filepath.Ext(path) {
+	case ".json":
+		d, err = json.MarshalIndent(cc, "", "\t")
+		d = append(d, '\n')
+	case ".csv":
+		d = encodeCSV(cc)
+	default:
+		tb.Fatalf("unsupported fixture format: %s", path)
+		return
+	}
+
+	if err != nil {
+		tb.Fatalf("encoding fixture %s: %v", path, err)
+		return
+	}
+
+	if err = os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		tb.Fatalf("creating fixture directory: %v", err)
+		return
+	}
+
+	if err = ioutil.WriteFile(path, d, 0o644); err != nil { //nolint:gosec // fixtures are not secret
+		tb.Fatalf("writing fixture: %v", err)
+	}
+}
+
+// AssertGolden compares candles with the ones stored in the golden
+// file using AssertCandlesEqual. If updating is requested with
+// UpdateFlag or UpdateEnv, the golden file is overwritten with the
+// candles instead. The result of the check is returned.
+func AssertGolden(tb testing.TB, path string, cc []chartype.Candle, tolerance float64) bool {
+	tb.Helper()
+
+	if updating() {
+		SaveGolden(tb, path, cc)
+		return true
+	}
+
+	return AssertCandlesEqual(tb, LoadCandles(tb, path), cc, tolerance)
+}
+
+// updating checks whether golden files should be overwritten.
+func updating() bool {
+	if f := flag.Lookup(UpdateFlag); f != nil {
+		if g, ok := f.Value.(flag.Getter); ok {
+			if v, ok := g.Get().(bool); ok && v {
+				return true
+			}
+		}
+	}
+
+	return os.Getenv(UpdateEnv) != ""
+}
+
+// encodeCSV encodes candles into CSV fixture's format.
+func encodeCSV(cc []chartype.Candle) []byte {
+	b := []byte(csvHeader)
+
+	for _, c := range cc {
+		b = c.Timestamp.AppendFormat(b, time.RFC3339Nano)
+
+		for _, v := range []string{c.Open.String(), c.High.String(), c.Low.String(), c.Close.String(), c.Volume.String()} {
+			b = append(b, ',')
+			b = append(b, v...)
+		}
+
+		for _, v := range []*decimal.Decimal{c.AdjClose, c.Delta} {
+			b = append(b, ',')
+
+			if v != nil {
+				b = append(b, v.String()...)
+			}
+		}
+
+		b = append(b, '\n')
+	}
+
+	return b
+}
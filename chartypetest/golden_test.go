@@ -0,0 +1,172 @@
+package chartypetest
+
+import (
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/jellydator/chartype"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fatalRecorder records fatal errors instead of stopping the test.
+type fatalRecorder struct {
+	testing.TB
+	fatals []string
+}
+
+func (fr *fatalRecorder) Fatalf(format string, args ...interface{}) {
+	fr.fatals = append(fr.fatals, fmt.Sprintf(format, args...))
+}
+
+func testDir(t *testing.T) string {
+	t.Helper()
+
+	dir, err := ioutil.TempDir("", "chartypetest")
+	require.NoError(t, err)
+
+	t.Cleanup(func() {
+		os.RemoveAll(dir)
+	})
+
+	return dir
+}
+
+func testSeries() []chartype.Candle {
+	tm := time.Date(2020, 1, 1, 0, 0, 0, 500, time.UTC)
+	base := NewCandle().OHLC(1, 2.5, 0.5, 2).Volume(10)
+
+	return []chartype.Candle{
+		base.At(tm).Delta(-4).Build(),
+		base.At(tm.Add(time.Minute)).Close(1.25).AdjClose(0.625).Build(),
+	}
+}
+
+func Test_SaveGolden_LoadCandles(t *testing.T) {
+	dir := testDir(t)
+
+	for _, name := range []string{"candles.json", "nested/candles.csv"} {
+		path := filepath.Join(dir, name)
+
+		SaveGolden(t, path, testSeries())
+		AssertCandlesEqual(t, testSeries(), LoadCandles(t, path), 0)
+	}
+
+	d, err := ioutil.ReadFile(filepath.Join(dir, "nested/candles.csv"))
+	require.NoError(t, err)
+	assert.Equal(t, "timestamp,open,high,low,close,volume,adj_close,delta\n"+
+		"2020-01-01T00:00:00.0000005Z,1,2.5,0.5,2,10,,-4\n"+
+		"2020-01-01T00:01:00.0000005Z,1,2.5,0.5,1.25,10,0.625,\n", string(d))
+}
+
+func Test_LoadCandles_Errors(t *testing.T) {
+	dir := testDir(t)
+
+	require.NoError(t, ioutil.WriteFile(filepath.Join(dir, "invalid.json"), []byte("{"), 0o644))
+	require.NoError(t, ioutil.WriteFile(filepath.Join(dir, "candles.txt"), []byte(""), 0o644))
+
+	cc := map[string]string{
+		"Missing file":       "reading fixture",
+		"invalid.json":       "decoding fixture",
+		"candles.txt":        "unsupported fixture format",
+		"missing/candles.js": "reading fixture",
+	}
+
+	for name, msg := range cc {
+		fr := &fatalRecorder{TB: t}
+
+		assert.Nil(t, LoadCandles(fr, filepath.Join(dir, name)))
+		require.Len(t, fr.fatals, 1, name)
+		assert.Contains(t, fr.fatals[0], msg)
+	}
+}
+
+func Test_SaveGolden_Errors(t *testing.T) {
+	dir := testDir(t)
+	file := filepath.Join(dir, "file")
+
+	require.NoError(t, ioutil.WriteFile(file, []byte(""), 0o644))
+	require.NoError(t, os.Mkdir(filepath.Join(dir, "dir.json"), 0o755))
+
+	cc := map[string]struct {
+		Path    string
+		Candles []chartype.Candle
+		Message string
+	}{
+		"Unsupported format": {
+			Path:    filepath.Join(dir, "candles.txt"),
+			Message: "unsupported fixture format",
+		},
+		"Encoding error": {
+			Path:    filepath.Join(dir, "candles.json"),
+			Candles: []chartype.Candle{{Timestamp: time.Date(10000, 1, 1, 0, 0, 0, 0, time.UTC)}},
+			Message: "encoding fixture",
+		},
+		"Directory error": {
+			Path:    filepath.Join(file, "candles.json"),
+			Message: "creating fixture directory",
+		},
+		"Write error": {
+			Path:    filepath.Join(dir, "dir.json"),
+			Message: "writing fixture",
+		},
+	}
+
+	for cn, c := range cc {
+		fr := &fatalRecorder{TB: t}
+
+		SaveGolden(fr, c.Path, c.Candles)
+		require.Len(t, fr.fatals, 1, cn)
+		assert.Contains(t, fr.fatals[0], c.Message, cn)
+	}
+}
+
+func Test_AssertGolden(t *testing.T) {
+	path := filepath.Join(testDir(t), "golden.json")
+	series := testSeries()
+
+	os.Setenv(UpdateEnv, "1")
+	assert.True(t, AssertGolden(t, path, series, 0))
+	os.Unsetenv(UpdateEnv)
+
+	assert.True(t, AssertGolden(t, path, series, 0))
+
+	var r recorder
+
+	fr := &fatalRecorder{TB: t}
+	tb := struct {
+		*fatalRecorder
+		*recorder
+	}{fr, &r}
+
+	assert.False(t, AssertGolden(tb, path, series[:1], 0))
+	assert.Equal(t, []string{"candle count differs: expected 2, actual 1"}, r.errs)
+
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	fs.Bool(UpdateFlag, true, "")
+
+	flag.CommandLine, fs = fs, flag.CommandLine
+	defer func() {
+		flag.CommandLine = fs
+	}()
+
+	assert.True(t, AssertGolden(t, path, series[:1], 0))
+	assert.Len(t, LoadCandles(t, path), 1)
+}
+
+func Test_updating(t *testing.T) {
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	fs.String(UpdateFlag, "true", "")
+
+	flag.CommandLine, fs = fs, flag.CommandLine
+	defer func() {
+		flag.CommandLine = fs
+	}()
+
+	assert.False(t, updating())
+}
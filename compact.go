@@ -0,0 +1,243 @@
+package chartype
+
+import (
+	"math/big"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+var (
+	// ErrUnrepresentableValue is returned when a candle's value cannot
+	// be stored exactly as a 64-bit integer at a compact series'
+	// exponent.
+	ErrUnrepresentableValue = newError(CodeInvalidArgument, "unrepresentable value")
+)
+
+// CompactSeries stores a candle series in fixed-point form: every
+// value is kept as an int64 mantissa sharing a single, per-series
+// decimal exponent, and timestamps are kept as Unix nanoseconds. For
+// feeds of uniform precision this takes a fraction of the memory of
+// decimal-based candles, which are reconstructed on access.
+//
+// Candles returned by a compact series have timestamps in UTC.
+//
+// CompactSeries is not safe for concurrent use.
+type CompactSeries struct {
+	exp      int32
+	ts       []int64
+	values   [5][]int64
+	adjClose optionalColumn
+	delta    optionalColumn
+}
+
+// optionalColumn stores mantissas of an optional candle value. Its
+// slices are allocated once the first value is set.
+type optionalColumn struct {
+	values []int64
+	has    []bool
+}
+
+// NewCompactSeries creates a new empty compact series storing values
+// as multiples of 10^exp, e.g. exp -2 stores cents.
+func NewCompactSeries(exp int32) *CompactSeries {
+	return &CompactSeries{exp: exp}
+}
+
+// CompactCandles creates a new compact series holding the candles.
+// The series' exponent is the lowest exponent of candles' values, so
+// that all of them are stored exactly.
+func CompactCandles(cc []Candle) (*CompactSeries, error) {
+	var exp int32
+
+	for i, c := range cc {
+		for j, v := range compactValues(c) {
+			if (i == 0 && j == 0) || v.Exponent() < exp {
+				exp = v.Exponent()
+			}
+		}
+
+		for _, v := range []*decimal.Decimal{c.AdjClose, c.Delta} {
+			if v != nil && v.Exponent() < exp {
+				exp = v.Exponent()
+			}
+		}
+	}
+
+	cs := NewCompactSeries(exp)
+	if err := cs.Append(cc...); err != nil {
+		return nil, err
+	}
+
+	return cs, nil
+}
+
+// Exponent returns the exponent shared by series' values.
+func (cs *CompactSeries) Exponent() int32 {
+	return cs.exp
+}
+
+// Append adds the candles to the end of the series. If any of
+// candles' values cannot be stored exactly, no candle is added and
+// ErrUnrepresentableValue is returned.
+func (cs *CompactSeries) Append(cc ...Candle) error {
+	type row struct {
+		values [5]int64
+		adj    *int64
+		delta  *int64
+	}
+
+	rr := make([]row, len(cc))
+
+	for i, c := range cc {
+		for j, v := range compactValues(c) {
+			m, ok := toFixed(v, cs.exp)
+			if !ok {
+				return ErrUnrepresentableValue
+			}
+
+			rr[i].values[j] = m
+		}
+
+		var ok bool
+
+		if rr[i].adj, ok = optionalToFixed(c.AdjClose, cs.exp); !ok {
+			return ErrUnrepresentableValue
+		}
+
+		if rr[i].delta, ok = optionalToFixed(c.Delta, cs.exp); !ok {
+			return ErrUnrepresentableValue
+		}
+	}
+
+	for i, r := range rr {
+		cs.ts = append(cs.ts, cc[i].Timestamp.UnixNano())
+
+		for j, m := range r.values {
+			cs.values[j] = append(cs.values[j], m)
+		}
+
+		cs.adjClose.append(r.adj, len(cs.ts)-1)
+		cs.delta.append(r.delta, len(cs.ts)-1)
+	}
+
+	return nil
+}
+
+// Len returns the number of candles in the series.
+func (cs *CompactSeries) Len() int {
+	return len(cs.ts)
+}
+
+// At returns the i-th candle of the series. It panics if i is out of
+// range.
+func (cs *CompactSeries) At(i int) Candle {
+	c := Candle{
+		Timestamp: time.Unix(0, cs.ts[i]).UTC(),
+		Open:      decimal.New(cs.values[0][i], cs.exp),
+		High:      decimal.New(cs.values[1][i], cs.exp),
+		Low:       decimal.New(cs.values[2][i], cs.exp),
+		Close:     decimal.New(cs.values[3][i], cs.exp),
+		Volume:    decimal.New(cs.values[4][i], cs.exp),
+	}
+
+	c.AdjClose = cs.adjClose.at(i, cs.exp)
+	c.Delta = cs.delta.at(i, cs.exp)
+
+	return c
+}
+
+// Candles returns all candles of the series.
+func (cs *CompactSeries) Candles() Candles {
+	res := make(Candles, len(cs.ts))
+	for i := range res {
+		res[i] = cs.At(i)
+	}
+
+	return res
+}
+
+// append adds the mantissa, or a missing value if it is nil, to the
+// column holding n values.
+func (oc *optionalColumn) append(m *int64, n int) {
+	if m != nil && oc.has == nil {
+		oc.values = make([]int64, n)
+		oc.has = make([]bool, n)
+	}
+
+	if oc.has == nil {
+		return
+	}
+
+	var v int64
+	if m != nil {
+		v = *m
+	}
+
+	oc.values = append(oc.values, v)
+	oc.has = append(oc.has, m != nil)
+}
+
+// at returns the i-th value of the column at the exponent or nil if
+// it is missing.
+func (oc optionalColumn) at(i int, exp int32) *decimal.Decimal {
+	if oc.has == nil || !oc.has[i] {
+		return nil
+	}
+
+	d := decimal.New(oc.values[i], exp)
+
+	return &d
+}
+
+// compactValues returns candle's values in the order they are stored
+// in a compact series.
+func compactValues(c Candle) [5]decimal.Decimal {
+	return [5]decimal.Decimal{c.Open, c.High, c.Low, c.Close, c.Volume}
+}
+
+// toFixed returns the mantissa of the value at the exponent and
+// whether the value is represented by it exactly.
+func toFixed(v decimal.Decimal, exp int32) (int64, bool) {
+	m := v.Coefficient()
+
+	switch e := v.Exponent(); {
+	case e > exp:
+		m.Mul(m, pow10(e-exp))
+	case e < exp:
+		var r big.Int
+
+		m.QuoRem(m, pow10(exp-e), &r)
+
+		if r.Sign() != 0 {
+			return 0, false
+		}
+	}
+
+	if !m.IsInt64() {
+		return 0, false
+	}
+
+	return m.Int64(), true
+}
+
+// optionalToFixed returns the mantissa of the optional value at the
+// exponent, or nil if the value is nil, and whether the value is
+// represented by it exactly.
+func optionalToFixed(v *decimal.Decimal, exp int32) (*int64, bool) {
+	if v == nil {
+		return nil, true
+	}
+
+	m, ok := toFixed(*v, exp)
+	if !ok {
+		return nil, false
+	}
+
+	return &m, true
+}
+
+// pow10 returns 10^n.
+func pow10(n int32) *big.Int {
+	return new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(n)), nil)
+}
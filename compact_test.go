@@ -0,0 +1,89 @@
+package chartype
+
+import (
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_CompactCandles(t *testing.T) {
+	tm := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	cs, err := CompactCandles(nil)
+	require.NoError(t, err)
+	assert.Equal(t, 0, cs.Len())
+	assert.Equal(t, int32(0), cs.Exponent())
+
+	c1 := testCandle(tm, 1, 2, 1, 2, 100)
+	c1.Close = decimal.RequireFromString("1.25")
+
+	c2 := testCandle(tm.Add(time.Minute), 2, 3, 2, 3, 50)
+	c2.AdjClose = func() *decimal.Decimal { d := decimal.RequireFromString("2.5"); return &d }()
+
+	cs, err = CompactCandles([]Candle{c1, c2})
+	require.NoError(t, err)
+	assert.Equal(t, int32(-2), cs.Exponent())
+	assert.Equal(t, 2, cs.Len())
+	assertEqualCandles(t, []Candle{c1, c2}, cs.Candles())
+
+	c3 := testCandle(tm.Add(2*time.Minute), 3, 3, 3, 3, 3)
+	require.NoError(t, cs.Append(c3))
+	assertEqualCandles(t, []Candle{c1, c2, c3}, cs.Candles())
+	assert.Equal(t, time.UTC, cs.At(2).Timestamp.Location())
+
+	bad := c3
+	bad.Close = decimal.RequireFromString("0.001")
+	assert.Equal(t, ErrUnrepresentableValue, cs.Append(c3, bad))
+	assert.Equal(t, 3, cs.Len())
+
+	c4 := testCandle(tm, 1, 1, 1, 1, 1)
+	c4.AdjClose = func() *decimal.Decimal { d := decimal.RequireFromString("0.125"); return &d }()
+
+	cs, err = CompactCandles([]Candle{c4})
+	require.NoError(t, err)
+	assert.Equal(t, int32(-3), cs.Exponent())
+
+	c5 := testCandle(tm, 1, 1, 1, 1, 1)
+	c5.Delta = func() *decimal.Decimal { d := decimal.RequireFromString("-0.0625"); return &d }()
+
+	cs, err = CompactCandles([]Candle{c5})
+	require.NoError(t, err)
+	assert.Equal(t, int32(-4), cs.Exponent())
+
+	c4.Volume = decimal.New(1, 30)
+	_, err = CompactCandles([]Candle{c4})
+	assert.Equal(t, ErrUnrepresentableValue, err)
+}
+
+func Test_CompactSeries_Append(t *testing.T) {
+	tm := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	adj := testCandle(tm, 1, 1, 1, 1, 1)
+	adj.AdjClose = func() *decimal.Decimal { d := decimal.RequireFromString("0.5"); return &d }()
+
+	delta := testCandle(tm, 1, 1, 1, 1, 1)
+	delta.Delta = func() *decimal.Decimal { d := decimal.RequireFromString("-0.5"); return &d }()
+
+	huge := testCandle(tm, 1, 1, 1, 1, 1)
+	huge.Volume = decimal.New(1, 30)
+
+	cs := NewCompactSeries(0)
+	assert.Equal(t, ErrUnrepresentableValue, cs.Append(adj))
+	assert.Equal(t, ErrUnrepresentableValue, cs.Append(delta))
+	assert.Equal(t, ErrUnrepresentableValue, cs.Append(huge))
+
+	cs = NewCompactSeries(-1)
+	plain := testCandle(tm, 10, 10, 10, 10, 10)
+	require.NoError(t, cs.Append(plain, adj, delta))
+
+	res := cs.Candles()
+	assertEqualCandles(t, []Candle{plain, adj, delta}, res)
+	assert.Nil(t, res[0].AdjClose)
+	assert.Equal(t, "0.5", res[1].AdjClose.String())
+	assert.Nil(t, res[2].AdjClose)
+	assert.Nil(t, res[1].Delta)
+	assert.Equal(t, "-0.5", res[2].Delta.String())
+}
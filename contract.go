@@ -0,0 +1,230 @@
+package chartype
+
+import (
+	"sort"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+const (
+	// AdjustDifference specifies that older contracts' prices are
+	// shifted by the price difference at each roll.
+	AdjustDifference Adjustment = iota + 1
+
+	// AdjustRatio specifies that older contracts' prices are
+	// multiplied by the price ratio at each roll.
+	AdjustRatio
+
+	// AdjustNone specifies that prices are not adjusted.
+	AdjustNone
+)
+
+var (
+	// ErrInvalidAdjustment is returned when adjustment with invalid
+	// value is being used.
+	ErrInvalidAdjustment = newError(CodeInvalidArgument, "invalid adjustment")
+
+	// ErrInvalidRollRule is returned when roll rule with negative
+	// offset is being used.
+	ErrInvalidRollRule = newError(CodeInvalidArgument, "invalid roll rule")
+)
+
+// Contract holds futures contract's metadata. Multiplier specifies
+// the contract's size in units of the underlying asset.
+type Contract struct {
+	Symbol     string          `json:"symbol" yaml:"symbol"`
+	Expiry     time.Time       `json:"expiry" yaml:"expiry"`
+	Multiplier decimal.Decimal `json:"multiplier" yaml:"multiplier"`
+}
+
+// Adjustment specifies how prices of a continuous series are
+// back-adjusted at contract rolls.
+// Can be included in configuration structures.
+type Adjustment int
+
+// Validate checks whether the adjustment is one of supported
+// adjustment types or not.
+func (a Adjustment) Validate() error {
+	switch a {
+	case AdjustDifference, AdjustRatio, AdjustNone:
+		return nil
+	default:
+		return ErrInvalidAdjustment
+	}
+}
+
+// MarshalText turns adjustment to appropriate string representation.
+func (a Adjustment) MarshalText() ([]byte, error) {
+	var v string
+
+	switch a {
+	case AdjustDifference:
+		v = "difference"
+	case AdjustRatio:
+		v = "ratio"
+	case AdjustNone:
+		v = "none"
+	default:
+		return nil, ErrInvalidAdjustment
+	}
+
+	return []byte(v), nil
+}
+
+// UnmarshalText turns string to appropriate adjustment value.
+func (a *Adjustment) UnmarshalText(d []byte) error {
+	switch string(d) {
+	case "difference":
+		*a = AdjustDifference
+	case "ratio":
+		*a = AdjustRatio
+	case "none":
+		*a = AdjustNone
+	default:
+		return ErrInvalidAdjustment
+	}
+
+	return nil
+}
+
+// RollRule specifies when the continuous series switches to the next
+// contract and how older prices are adjusted.
+type RollRule struct {
+	// Offset specifies how long before contract's expiry the series
+	// switches to the next contract.
+	Offset time.Duration `json:"offset" yaml:"offset"`
+
+	// Adjustment specifies how older prices are adjusted.
+	Adjustment Adjustment `json:"adjustment" yaml:"adjustment"`
+}
+
+// Validate checks whether roll rule's offset is not negative and
+// whether its adjustment is valid.
+func (rr RollRule) Validate() error {
+	if rr.Offset < 0 {
+		return ErrInvalidRollRule
+	}
+
+	return rr.Adjustment.Validate()
+}
+
+// Stitch combines contracts' candles into a single continuous series.
+// Each contract is used until its roll time (expiry minus roll rule's
+// offset), the last one until the end of its candles. Prices before
+// each roll are back-adjusted by the gap between the last candle of
+// the expiring contract and the next contract's candle at the same
+// or the nearest later time.
+//
+// Candles of each contract must be sorted by timestamp in ascending
+// order. Volumes are not adjusted.
+func Stitch(series map[Contract][]Candle, rr RollRule) ([]Candle, error) {
+	if err := rr.Validate(); err != nil {
+		return nil, err
+	}
+
+	kk := make([]Contract, 0, len(series))
+	for k := range series {
+		kk = append(kk, k)
+	}
+
+	sort.Slice(kk, func(i, j int) bool {
+		if kk[i].Expiry.Equal(kk[j].Expiry) {
+			return kk[i].Symbol < kk[j].Symbol
+		}
+
+		return kk[i].Expiry.Before(kk[j].Expiry)
+	})
+
+	segments := make([][]Candle, len(kk))
+
+	var from time.Time
+
+	for i, k := range kk {
+		cc := series[k]
+		start := sort.Search(len(cc), func(j int) bool {
+			return !cc[j].Timestamp.Before(from)
+		})
+
+		end := len(cc)
+
+		if i < len(kk)-1 {
+			roll := k.Expiry.Add(-rr.Offset)
+			end = sort.Search(len(cc), func(j int) bool {
+				return !cc[j].Timestamp.Before(roll)
+			})
+
+			from = roll
+		}
+
+		segments[i] = cc[start:end]
+	}
+
+	diff, ratio := decimal.Zero, decimal.NewFromInt(1)
+
+	for i := len(segments) - 2; i >= 0; i-- {
+		if len(segments[i]) == 0 {
+			continue
+		}
+
+		old := segments[i][len(segments[i])-1]
+
+		next, ok := candleAtOrAfter(series[kk[i+1]], old.Timestamp)
+		if !ok {
+			continue
+		}
+
+		switch rr.Adjustment {
+		case AdjustDifference:
+			diff = diff.Add(next.Close.Sub(old.Close))
+		case AdjustRatio:
+			if !old.Close.IsZero() {
+				ratio = ratio.Mul(next.Close.Div(old.Close))
+			}
+		}
+
+		adjusted := make([]Candle, len(segments[i]))
+		for j, c := range segments[i] {
+			adjusted[j] = adjustPrices(c, func(v decimal.Decimal) decimal.Decimal {
+				return v.Mul(ratio).Add(diff)
+			})
+		}
+
+		segments[i] = adjusted
+	}
+
+	var res []Candle
+	for _, s := range segments {
+		res = append(res, s...)
+	}
+
+	return res, nil
+}
+
+// candleAtOrAfter returns the first candle whose timestamp is not
+// before the provided time and whether it exists.
+func candleAtOrAfter(cc []Candle, t time.Time) (Candle, bool) {
+	i := searchCandles(cc, t)
+	if i == len(cc) {
+		return Candle{}, false
+	}
+
+	return cc[i], true
+}
+
+// adjustPrices replaces candle's prices, including the adjusted
+// close, with their values returned by the function. Volumes are not
+// prices and are left unchanged.
+func adjustPrices(c Candle, fn func(decimal.Decimal) decimal.Decimal) Candle {
+	c.Open = fn(c.Open)
+	c.High = fn(c.High)
+	c.Low = fn(c.Low)
+	c.Close = fn(c.Close)
+
+	if c.AdjClose != nil {
+		ac := fn(*c.AdjClose)
+		c.AdjClose = &ac
+	}
+
+	return c
+}
@@ -0,0 +1,217 @@
+package chartype
+
+import (
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Adjustment_Validate(t *testing.T) {
+	cc := map[string]struct {
+		Adjustment Adjustment
+		Err        error
+	}{
+		"Invalid Adjustment": {
+			Adjustment: 70,
+			Err:        ErrInvalidAdjustment,
+		},
+		"Successful AdjustDifference validation": {
+			Adjustment: AdjustDifference,
+		},
+		"Successful AdjustRatio validation": {
+			Adjustment: AdjustRatio,
+		},
+		"Successful AdjustNone validation": {
+			Adjustment: AdjustNone,
+		},
+	}
+
+	for cn, c := range cc {
+		c := c
+
+		t.Run(cn, func(t *testing.T) {
+			t.Parallel()
+
+			equalError(t, c.Err, c.Adjustment.Validate())
+		})
+	}
+}
+
+func Test_Adjustment_MarshalText(t *testing.T) {
+	cc := map[string]struct {
+		Adjustment Adjustment
+		Text       string
+		Err        error
+	}{
+		"Invalid Adjustment": {
+			Adjustment: 70,
+			Err:        ErrInvalidAdjustment,
+		},
+		"Successful AdjustDifference marshal": {
+			Adjustment: AdjustDifference,
+			Text:       "difference",
+		},
+		"Successful AdjustRatio marshal": {
+			Adjustment: AdjustRatio,
+			Text:       "ratio",
+		},
+		"Successful AdjustNone marshal": {
+			Adjustment: AdjustNone,
+			Text:       "none",
+		},
+	}
+
+	for cn, c := range cc {
+		c := c
+
+		t.Run(cn, func(t *testing.T) {
+			t.Parallel()
+
+			d, err := c.Adjustment.MarshalText()
+			equalError(t, c.Err, err)
+			if err != nil {
+				return
+			}
+
+			assert.Equal(t, c.Text, string(d))
+		})
+	}
+}
+
+func Test_Adjustment_UnmarshalText(t *testing.T) {
+	cc := map[string]struct {
+		Text   string
+		Result Adjustment
+		Err    error
+	}{
+		"Invalid Adjustment": {
+			Text: "x",
+			Err:  ErrInvalidAdjustment,
+		},
+		"Successful AdjustDifference unmarshal": {
+			Text:   "difference",
+			Result: AdjustDifference,
+		},
+		"Successful AdjustRatio unmarshal": {
+			Text:   "ratio",
+			Result: AdjustRatio,
+		},
+		"Successful AdjustNone unmarshal": {
+			Text:   "none",
+			Result: AdjustNone,
+		},
+	}
+
+	for cn, c := range cc {
+		c := c
+
+		t.Run(cn, func(t *testing.T) {
+			t.Parallel()
+
+			var a Adjustment
+
+			err := a.UnmarshalText([]byte(c.Text))
+			equalError(t, c.Err, err)
+			if err != nil {
+				return
+			}
+
+			assert.Equal(t, c.Result, a)
+		})
+	}
+}
+
+func Test_RollRule_Validate(t *testing.T) {
+	assert.Equal(t, ErrInvalidRollRule, RollRule{Offset: -1, Adjustment: AdjustNone}.Validate())
+	assert.Equal(t, ErrInvalidAdjustment, RollRule{}.Validate())
+	assert.NoError(t, RollRule{Offset: time.Hour, Adjustment: AdjustRatio}.Validate())
+}
+
+func Test_Stitch(t *testing.T) {
+	tm := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	at := func(d int) time.Time {
+		return tm.Add(time.Duration(d) * 24 * time.Hour)
+	}
+
+	candle := func(d int, p int64) Candle {
+		return testCandle(at(d), p, p, p, p, 1)
+	}
+
+	k1 := Contract{Symbol: "ESH0", Expiry: at(3), Multiplier: decimal.NewFromInt(50)}
+	k2 := Contract{Symbol: "ESM0", Expiry: at(6), Multiplier: decimal.NewFromInt(50)}
+	k3 := Contract{Symbol: "ESU0", Expiry: at(9), Multiplier: decimal.NewFromInt(50)}
+
+	series := map[Contract][]Candle{
+		k1: {candle(0, 10), candle(1, 11), candle(2, 12)},
+		k2: {candle(1, 22), candle(2, 15), candle(3, 16), candle(4, 17), candle(5, 18)},
+		k3: {candle(4, 34), candle(5, 36), candle(6, 40)},
+	}
+
+	cc := map[string]struct {
+		Series   map[Contract][]Candle
+		RollRule RollRule
+		Result   []int64
+		Err      error
+	}{
+		"Invalid roll rule": {
+			Err: ErrInvalidAdjustment,
+		},
+		"No contracts": {
+			RollRule: RollRule{Adjustment: AdjustNone},
+		},
+		"Successful stitch without adjustment": {
+			Series:   series,
+			RollRule: RollRule{Offset: 24 * time.Hour, Adjustment: AdjustNone},
+			Result:   []int64{10, 11, 15, 16, 17, 36, 40},
+		},
+		"Successful stitch with difference adjustment": {
+			Series:   series,
+			RollRule: RollRule{Offset: 24 * time.Hour, Adjustment: AdjustDifference},
+			Result:   []int64{38, 39, 32, 33, 34, 36, 40},
+		},
+		"Successful stitch with ratio adjustment": {
+			Series:   series,
+			RollRule: RollRule{Offset: 24 * time.Hour, Adjustment: AdjustRatio},
+			Result:   []int64{40, 44, 30, 32, 34, 36, 40},
+		},
+		"Successful stitch with empty segment and zero price": {
+			Series: map[Contract][]Candle{
+				k1: {candle(0, 0)},
+				k2: {candle(0, 7)},
+				k3: {candle(7, 5)},
+			},
+			RollRule: RollRule{Adjustment: AdjustRatio},
+			Result:   []int64{0, 5},
+		},
+		"Successful stitch with equal expiries and missing roll candle": {
+			Series: map[Contract][]Candle{
+				k1:                                  {candle(0, 1), candle(1, 2)},
+				{Symbol: "ESH1", Expiry: k1.Expiry}: {candle(0, 3)},
+			},
+			RollRule: RollRule{Adjustment: AdjustDifference},
+			Result:   []int64{1, 2},
+		},
+	}
+
+	for cn, c := range cc {
+		c := c
+
+		t.Run(cn, func(t *testing.T) {
+			t.Parallel()
+
+			res, err := Stitch(c.Series, c.RollRule)
+			equalError(t, c.Err, err)
+			if err != nil {
+				return
+			}
+
+			assert.Len(t, res, len(c.Result))
+
+			for i, p := range c.Result {
+				assert.Equal(t, decimal.NewFromInt(p).String(), res[i].Close.String(), "candle %d", i)
+			}
+		})
+	}
+}
@@ -0,0 +1,112 @@
+package chartype
+
+import (
+	"sort"
+	"time"
+)
+
+const (
+	// CorrectionLateTrade specifies that the candle was updated
+	// by a trade that arrived after the candle was closed.
+	CorrectionLateTrade CorrectionReason = iota + 1
+
+	// CorrectionMissingCandle specifies that the candle was not
+	// published before, because no data for its interval was
+	// received in time.
+	CorrectionMissingCandle
+
+	// CorrectionRevision specifies that the candle was revised by
+	// its source.
+	CorrectionRevision
+)
+
+var (
+	// ErrInvalidCorrectionReason is returned when correction reason
+	// with invalid value is being used.
+	ErrInvalidCorrectionReason = newError(CodeInvalidArgument, "invalid correction reason")
+)
+
+// CorrectionReason specifies why an already published candle was
+// corrected.
+type CorrectionReason int
+
+// Validate checks whether the correction reason is one of supported
+// reason types or not.
+func (cr CorrectionReason) Validate() error {
+	switch cr {
+	case CorrectionLateTrade, CorrectionMissingCandle, CorrectionRevision:
+		return nil
+	default:
+		return ErrInvalidCorrectionReason
+	}
+}
+
+// MarshalText turns correction reason to appropriate string
+// representation.
+func (cr CorrectionReason) MarshalText() ([]byte, error) {
+	var v string
+
+	switch cr {
+	case CorrectionLateTrade:
+		v = "late_trade"
+	case CorrectionMissingCandle:
+		v = "missing_candle"
+	case CorrectionRevision:
+		v = "revision"
+	default:
+		return nil, ErrInvalidCorrectionReason
+	}
+
+	return []byte(v), nil
+}
+
+// UnmarshalText turns string to appropriate correction reason
+// value.
+func (cr *CorrectionReason) UnmarshalText(d []byte) error {
+	switch string(d) {
+	case "late_trade":
+		*cr = CorrectionLateTrade
+	case "missing_candle":
+		*cr = CorrectionMissingCandle
+	case "revision":
+		*cr = CorrectionRevision
+	default:
+		return ErrInvalidCorrectionReason
+	}
+
+	return nil
+}
+
+// CandleCorrection is an event that replaces an already published
+// candle.
+type CandleCorrection struct {
+	// Timestamp specifies the timestamp of the corrected candle.
+	Timestamp time.Time `json:"timestamp" yaml:"timestamp"`
+
+	// Candle holds the new candle's values.
+	Candle Candle `json:"candle" yaml:"candle"`
+
+	// Reason specifies why the candle was corrected.
+	Reason CorrectionReason `json:"reason" yaml:"reason"`
+}
+
+// ApplyCorrection replaces the candle corrected by the provided
+// correction or, if there is no such candle, inserts it. Candles
+// must be sorted by timestamp in ascending order. The resulting slice
+// is returned.
+func ApplyCorrection(cc []Candle, c CandleCorrection) []Candle {
+	i := sort.Search(len(cc), func(i int) bool {
+		return !cc[i].Timestamp.Before(c.Timestamp)
+	})
+
+	if i < len(cc) && cc[i].Timestamp.Equal(c.Timestamp) {
+		cc[i] = c.Candle
+		return cc
+	}
+
+	cc = append(cc, Candle{})
+	copy(cc[i+1:], cc[i:])
+	cc[i] = c.Candle
+
+	return cc
+}
@@ -0,0 +1,187 @@
+package chartype
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_CorrectionReason_Validate(t *testing.T) {
+	cc := map[string]struct {
+		Reason CorrectionReason
+		Err    error
+	}{
+		"Invalid CorrectionReason": {
+			Reason: 70,
+			Err:    ErrInvalidCorrectionReason,
+		},
+		"Successful CorrectionLateTrade validation": {
+			Reason: CorrectionLateTrade,
+		},
+		"Successful CorrectionMissingCandle validation": {
+			Reason: CorrectionMissingCandle,
+		},
+		"Successful CorrectionRevision validation": {
+			Reason: CorrectionRevision,
+		},
+	}
+
+	for cn, c := range cc {
+		c := c
+
+		t.Run(cn, func(t *testing.T) {
+			t.Parallel()
+
+			err := c.Reason.Validate()
+			equalError(t, c.Err, err)
+		})
+	}
+}
+
+func Test_CorrectionReason_MarshalText(t *testing.T) {
+	cc := map[string]struct {
+		Reason CorrectionReason
+		Text   string
+		Err    error
+	}{
+		"Invalid CorrectionReason": {
+			Reason: 70,
+			Err:    ErrInvalidCorrectionReason,
+		},
+		"Successful CorrectionLateTrade marshal": {
+			Reason: CorrectionLateTrade,
+			Text:   "late_trade",
+		},
+		"Successful CorrectionMissingCandle marshal": {
+			Reason: CorrectionMissingCandle,
+			Text:   "missing_candle",
+		},
+		"Successful CorrectionRevision marshal": {
+			Reason: CorrectionRevision,
+			Text:   "revision",
+		},
+	}
+
+	for cn, c := range cc {
+		c := c
+
+		t.Run(cn, func(t *testing.T) {
+			t.Parallel()
+
+			d, err := c.Reason.MarshalText()
+			equalError(t, c.Err, err)
+			if err != nil {
+				return
+			}
+
+			assert.Equal(t, c.Text, string(d))
+		})
+	}
+}
+
+func Test_CorrectionReason_UnmarshalText(t *testing.T) {
+	cc := map[string]struct {
+		Text   string
+		Result CorrectionReason
+		Err    error
+	}{
+		"Invalid CorrectionReason": {
+			Text: "x",
+			Err:  ErrInvalidCorrectionReason,
+		},
+		"Successful CorrectionLateTrade unmarshal": {
+			Text:   "late_trade",
+			Result: CorrectionLateTrade,
+		},
+		"Successful CorrectionMissingCandle unmarshal": {
+			Text:   "missing_candle",
+			Result: CorrectionMissingCandle,
+		},
+		"Successful CorrectionRevision unmarshal": {
+			Text:   "revision",
+			Result: CorrectionRevision,
+		},
+	}
+
+	for cn, c := range cc {
+		c := c
+
+		t.Run(cn, func(t *testing.T) {
+			t.Parallel()
+
+			var cr CorrectionReason
+
+			err := cr.UnmarshalText([]byte(c.Text))
+			equalError(t, c.Err, err)
+			if err != nil {
+				return
+			}
+
+			assert.Equal(t, c.Result, cr)
+		})
+	}
+}
+
+func Test_CandleCorrection_JSON(t *testing.T) {
+	tm := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	c := testCorrection(testCandle(tm, 1, 2, 3, 4, 5), CorrectionRevision)
+
+	d, err := json.Marshal(c)
+	assert.NoError(t, err)
+	assert.Contains(t, string(d), `"reason":"revision"`)
+
+	var res CandleCorrection
+	assert.NoError(t, json.Unmarshal(d, &res))
+	assert.Equal(t, c, res)
+}
+
+func Test_ApplyCorrection(t *testing.T) {
+	tm := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	series := func() []Candle {
+		return []Candle{
+			testCandle(tm, 1, 1, 1, 1, 1),
+			testCandle(tm.Add(2*time.Minute), 3, 3, 3, 3, 3),
+		}
+	}
+
+	cc := map[string]struct {
+		Correction CandleCorrection
+		Result     []Candle
+	}{
+		"Replace existing candle": {
+			Correction: testCorrection(testCandle(tm, 5, 5, 5, 5, 5), CorrectionLateTrade),
+			Result: []Candle{
+				testCandle(tm, 5, 5, 5, 5, 5),
+				testCandle(tm.Add(2*time.Minute), 3, 3, 3, 3, 3),
+			},
+		},
+		"Insert missing candle": {
+			Correction: testCorrection(testCandle(tm.Add(time.Minute), 2, 2, 2, 2, 2), CorrectionMissingCandle),
+			Result: []Candle{
+				testCandle(tm, 1, 1, 1, 1, 1),
+				testCandle(tm.Add(time.Minute), 2, 2, 2, 2, 2),
+				testCandle(tm.Add(2*time.Minute), 3, 3, 3, 3, 3),
+			},
+		},
+		"Append missing candle": {
+			Correction: testCorrection(testCandle(tm.Add(3*time.Minute), 4, 4, 4, 4, 4), CorrectionMissingCandle),
+			Result: []Candle{
+				testCandle(tm, 1, 1, 1, 1, 1),
+				testCandle(tm.Add(2*time.Minute), 3, 3, 3, 3, 3),
+				testCandle(tm.Add(3*time.Minute), 4, 4, 4, 4, 4),
+			},
+		},
+	}
+
+	for cn, c := range cc {
+		c := c
+
+		t.Run(cn, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, c.Result, ApplyCorrection(series(), c.Correction))
+		})
+	}
+}
@@ -0,0 +1,72 @@
+package chartype
+
+import (
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+// RateSource provides exchange rates between currencies.
+type RateSource interface {
+	// Rate returns the price of one unit of the from currency in the
+	// to currency at the provided time. Zero time requests the latest
+	// known rate.
+	Rate(from, to string, t time.Time) (decimal.Decimal, error)
+}
+
+// RateFunc is an adapter that allows ordinary functions to be used
+// as rate sources.
+type RateFunc func(from, to string, t time.Time) (decimal.Decimal, error)
+
+// Rate calls the underlying function.
+func (rf RateFunc) Rate(from, to string, t time.Time) (decimal.Decimal, error) {
+	return rf(from, to, t)
+}
+
+// ConvertTo returns a copy of the packet with ticker's and candles'
+// prices converted to the provided quote currency. Packet's pair must
+// be set. Candles are converted at rates of their timestamps, ticker
+// at the latest rate. Volumes and percent change are denominated in
+// the base currency or unitless, so they are not converted.
+func (p Packet) ConvertTo(quote string, rates RateSource) (Packet, error) {
+	if p.Pair == nil {
+		return Packet{}, ErrInvalidPair
+	}
+
+	np := Pair{Base: p.Pair.Base, Quote: quote}
+	if err := np.Validate(); err != nil {
+		return Packet{}, err
+	}
+
+	res := Packet{
+		Pair:    &np,
+		Ticker:  p.Ticker,
+		Candles: make([]Candle, len(p.Candles)),
+	}
+
+	if quote == p.Pair.Quote {
+		copy(res.Candles, p.Candles)
+		return res, nil
+	}
+
+	r, err := rates.Rate(p.Pair.Quote, quote, time.Time{})
+	if err != nil {
+		return Packet{}, err
+	}
+
+	res.Ticker.Last = res.Ticker.Last.Mul(r)
+	res.Ticker.Ask = res.Ticker.Ask.Mul(r)
+	res.Ticker.Bid = res.Ticker.Bid.Mul(r)
+	res.Ticker.Change = res.Ticker.Change.Mul(r)
+
+	for i, c := range p.Candles {
+		r, err := rates.Rate(p.Pair.Quote, quote, c.Timestamp)
+		if err != nil {
+			return Packet{}, err
+		}
+
+		res.Candles[i] = c.Scale(r)
+	}
+
+	return res, nil
+}
@@ -0,0 +1,143 @@
+package chartype
+
+import (
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Packet_ConvertTo(t *testing.T) {
+	tm := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	packet := func() Packet {
+		c := testCandle(tm.Add(time.Hour), 1, 2, 3, 4, 5)
+		c.AdjClose = decimalPtr(3)
+
+		return Packet{
+			Pair: &Pair{Base: "BTC", Quote: "USD"},
+			Ticker: Ticker{
+				Last:          decimal.NewFromInt(10),
+				Ask:           decimal.NewFromInt(11),
+				Bid:           decimal.NewFromInt(9),
+				Change:        decimal.NewFromInt(1),
+				PercentChange: NewPercent(decimal.NewFromInt(10)),
+				Volume:        decimal.NewFromInt(100),
+			},
+			Candles: []Candle{
+				testCandle(tm, 1, 2, 3, 4, 5),
+				c,
+			},
+		}
+	}
+
+	rates := RateFunc(func(from, to string, t time.Time) (decimal.Decimal, error) {
+		if from != "USD" || to != "EUR" {
+			return decimal.Zero, assert.AnError
+		}
+
+		if t.IsZero() {
+			return decimal.NewFromInt(3), nil
+		}
+
+		return decimal.NewFromInt(int64(t.Hour() + 2)), nil
+	})
+
+	cc := map[string]struct {
+		Packet Packet
+		Quote  string
+		Rates  RateSource
+		Result Packet
+		Err    error
+	}{
+		"Missing pair": {
+			Packet: Packet{},
+			Quote:  "EUR",
+			Rates:  rates,
+			Err:    ErrInvalidPair,
+		},
+		"Invalid quote": {
+			Packet: packet(),
+			Quote:  "E_UR",
+			Rates:  rates,
+			Err:    ErrInvalidPair,
+		},
+		"Ticker rate error": {
+			Packet: packet(),
+			Quote:  "GBP",
+			Rates:  rates,
+			Err:    assert.AnError,
+		},
+		"Candle rate error": {
+			Packet: packet(),
+			Quote:  "EUR",
+			Rates: RateFunc(func(_, _ string, t time.Time) (decimal.Decimal, error) {
+				if t.IsZero() {
+					return decimal.NewFromInt(1), nil
+				}
+
+				return decimal.Zero, assert.AnError
+			}),
+			Err: assert.AnError,
+		},
+		"Successful conversion to the same quote": {
+			Packet: packet(),
+			Quote:  "USD",
+			Result: packet(),
+		},
+		"Successful conversion": {
+			Packet: packet(),
+			Quote:  "EUR",
+			Rates:  rates,
+			Result: func() Packet {
+				c := testCandle(tm.Add(time.Hour), 3, 6, 9, 12, 5)
+				c.AdjClose = decimalPtr(9)
+
+				return Packet{
+					Pair: &Pair{Base: "BTC", Quote: "EUR"},
+					Ticker: Ticker{
+						Last:          decimal.NewFromInt(30),
+						Ask:           decimal.NewFromInt(33),
+						Bid:           decimal.NewFromInt(27),
+						Change:        decimal.NewFromInt(3),
+						PercentChange: NewPercent(decimal.NewFromInt(10)),
+						Volume:        decimal.NewFromInt(100),
+					},
+					Candles: []Candle{
+						testCandle(tm, 2, 4, 6, 8, 5),
+						c,
+					},
+				}
+			}(),
+		},
+	}
+
+	for cn, c := range cc {
+		c := c
+
+		t.Run(cn, func(t *testing.T) {
+			t.Parallel()
+
+			res, err := c.Packet.ConvertTo(c.Quote, c.Rates)
+			equalError(t, c.Err, err)
+			if err != nil {
+				return
+			}
+
+			assert.Equal(t, c.Result.Pair, res.Pair)
+			assert.Equal(t, c.Result.Ticker, res.Ticker)
+			assert.Len(t, res.Candles, len(c.Result.Candles))
+
+			for i, rc := range c.Result.Candles {
+				assert.Equal(t, rc.Timestamp, res.Candles[i].Timestamp)
+				assert.Equal(t, rc.Open.String(), res.Candles[i].Open.String())
+				assert.Equal(t, rc.High.String(), res.Candles[i].High.String())
+				assert.Equal(t, rc.Low.String(), res.Candles[i].Low.String())
+				assert.Equal(t, rc.Close.String(), res.Candles[i].Close.String())
+				assert.Equal(t, rc.Volume.String(), res.Candles[i].Volume.String())
+				assert.Equal(t, rc.AdjustedClose().String(), res.Candles[i].AdjustedClose().String())
+			}
+		})
+	}
+}
@@ -0,0 +1,163 @@
+package chartype
+
+import (
+	"encoding/base64"
+	"encoding/binary"
+	"math"
+	"time"
+)
+
+const (
+	// Forward specifies that candles are iterated from the oldest
+	// to the newest.
+	Forward Direction = iota + 1
+
+	// Backward specifies that candles are iterated from the newest
+	// to the oldest.
+	Backward
+)
+
+// cursorVersion is the first byte of every encoded cursor. It allows
+// the cursor format to evolve without breaking issued tokens.
+const cursorVersion byte = 1
+
+// maxTime is the latest time whose nanosecond timestamp can be
+// represented.
+var maxTime = time.Unix(0, math.MaxInt64).UTC() //nolint:gochecknoglobals // time values cannot be declared as consts
+
+var (
+	// ErrInvalidDirection is returned when direction with invalid
+	// value is being used.
+	ErrInvalidDirection = newError(CodeInvalidArgument, "invalid direction")
+
+	// ErrInvalidCursor is returned when cursor token cannot be
+	// decoded or cursor with timestamp that cannot be encoded is
+	// being used.
+	ErrInvalidCursor = newError(CodeParseFailure, "invalid cursor")
+)
+
+// Direction specifies the order in which candles are iterated.
+type Direction int
+
+// Validate checks whether the direction is one of supported
+// direction types or not.
+func (d Direction) Validate() error {
+	switch d {
+	case Forward, Backward:
+		return nil
+	default:
+		return ErrInvalidDirection
+	}
+}
+
+// MarshalText turns direction to appropriate string
+// representation.
+func (d Direction) MarshalText() ([]byte, error) {
+	var v string
+
+	switch d {
+	case Forward:
+		v = "forward"
+	case Backward:
+		v = "backward"
+	default:
+		return nil, ErrInvalidDirection
+	}
+
+	return []byte(v), nil
+}
+
+// UnmarshalText turns string to appropriate direction value.
+func (d *Direction) UnmarshalText(b []byte) error {
+	switch string(b) {
+	case "forward", "f":
+		*d = Forward
+	case "backward", "b":
+		*d = Backward
+	default:
+		return ErrInvalidDirection
+	}
+
+	return nil
+}
+
+// Cursor points to a position in a candle series and is used to
+// continue paginated candle queries. Its text representation is
+// an opaque, URL-safe token.
+type Cursor struct {
+	// Last specifies the timestamp of the last candle returned.
+	Last time.Time
+
+	// Direction specifies in which direction the next page
+	// continues.
+	Direction Direction
+}
+
+// Validate checks whether the cursor's timestamp is set and can be
+// represented as Unix nanoseconds and whether its direction is valid.
+func (c Cursor) Validate() error {
+	if c.Last.IsZero() || c.Last.Before(minTime) || c.Last.After(maxTime) {
+		return ErrInvalidCursor
+	}
+
+	return c.Direction.Validate()
+}
+
+// Next returns the time range that the next page should cover,
+// given the range of the whole query.
+func (c Cursor) Next(tr TimeRange) TimeRange {
+	if c.Direction == Backward {
+		if c.Last.Before(tr.To) {
+			tr.To = c.Last
+		}
+
+		return tr
+	}
+
+	if from := c.Last.Add(time.Nanosecond); from.After(tr.From) {
+		tr.From = from
+	}
+
+	return tr
+}
+
+// MarshalText turns cursor to an opaque token.
+func (c Cursor) MarshalText() ([]byte, error) {
+	if err := c.Validate(); err != nil {
+		return nil, err
+	}
+
+	b := make([]byte, 10)
+	b[0] = cursorVersion
+	b[1] = byte(c.Direction)
+	binary.BigEndian.PutUint64(b[2:], uint64(c.Last.UnixNano()))
+
+	d := make([]byte, base64.RawURLEncoding.EncodedLen(len(b)))
+	base64.RawURLEncoding.Encode(d, b)
+
+	return d, nil
+}
+
+// UnmarshalText turns an opaque token to appropriate cursor value.
+// ErrInvalidCursor is returned for every malformed token.
+func (c *Cursor) UnmarshalText(d []byte) error {
+	b := make([]byte, base64.RawURLEncoding.DecodedLen(len(d)))
+
+	n, err := base64.RawURLEncoding.Decode(b, d)
+	if err != nil || n != 10 || b[0] != cursorVersion {
+		return ErrInvalidCursor
+	}
+
+	nc := Cursor{
+		Last:      time.Unix(0, int64(binary.BigEndian.Uint64(b[2:]))).UTC(),
+		Direction: Direction(b[1]),
+	}
+
+	if nc.Validate() != nil {
+		return ErrInvalidCursor
+	}
+
+	*c = nc
+
+	return nil
+}
@@ -0,0 +1,281 @@
+package chartype
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Direction_Validate(t *testing.T) {
+	cc := map[string]struct {
+		Direction Direction
+		Err       error
+	}{
+		"Invalid Direction": {
+			Direction: 70,
+			Err:       ErrInvalidDirection,
+		},
+		"Successful Forward validation": {
+			Direction: Forward,
+		},
+		"Successful Backward validation": {
+			Direction: Backward,
+		},
+	}
+
+	for cn, c := range cc {
+		c := c
+
+		t.Run(cn, func(t *testing.T) {
+			t.Parallel()
+
+			err := c.Direction.Validate()
+			equalError(t, c.Err, err)
+		})
+	}
+}
+
+func Test_Direction_MarshalText(t *testing.T) {
+	cc := map[string]struct {
+		Direction Direction
+		Text      string
+		Err       error
+	}{
+		"Invalid Direction": {
+			Direction: 70,
+			Err:       ErrInvalidDirection,
+		},
+		"Successful Forward marshal": {
+			Direction: Forward,
+			Text:      "forward",
+		},
+		"Successful Backward marshal": {
+			Direction: Backward,
+			Text:      "backward",
+		},
+	}
+
+	for cn, c := range cc {
+		c := c
+
+		t.Run(cn, func(t *testing.T) {
+			t.Parallel()
+
+			d, err := c.Direction.MarshalText()
+			equalError(t, c.Err, err)
+			if err != nil {
+				return
+			}
+
+			assert.Equal(t, c.Text, string(d))
+		})
+	}
+}
+
+func Test_Direction_UnmarshalText(t *testing.T) {
+	cc := map[string]struct {
+		Text   string
+		Result Direction
+		Err    error
+	}{
+		"Invalid Direction": {
+			Text: "down",
+			Err:  ErrInvalidDirection,
+		},
+		"Successful Forward unmarshal": {
+			Text:   "forward",
+			Result: Forward,
+		},
+		"Successful short Forward unmarshal": {
+			Text:   "f",
+			Result: Forward,
+		},
+		"Successful Backward unmarshal": {
+			Text:   "backward",
+			Result: Backward,
+		},
+		"Successful short Backward unmarshal": {
+			Text:   "b",
+			Result: Backward,
+		},
+	}
+
+	for cn, c := range cc {
+		c := c
+
+		t.Run(cn, func(t *testing.T) {
+			t.Parallel()
+
+			var d Direction
+
+			err := d.UnmarshalText([]byte(c.Text))
+			equalError(t, c.Err, err)
+			if err != nil {
+				return
+			}
+
+			assert.Equal(t, c.Result, d)
+		})
+	}
+}
+
+func Test_Cursor_Validate(t *testing.T) {
+	cc := map[string]struct {
+		Cursor Cursor
+		Err    error
+	}{
+		"Missing Last": {
+			Cursor: Cursor{Direction: Forward},
+			Err:    ErrInvalidCursor,
+		},
+		"Last before nanosecond range": {
+			Cursor: Cursor{Last: minTime.Add(-time.Nanosecond), Direction: Forward},
+			Err:    ErrInvalidCursor,
+		},
+		"Last after nanosecond range": {
+			Cursor: Cursor{Last: maxTime.Add(time.Nanosecond), Direction: Forward},
+			Err:    ErrInvalidCursor,
+		},
+		"Invalid Direction": {
+			Cursor: Cursor{Last: time.Unix(1, 0)},
+			Err:    ErrInvalidDirection,
+		},
+		"Successful validation": {
+			Cursor: Cursor{Last: time.Unix(1, 0), Direction: Backward},
+		},
+		"Successful validation of the latest time": {
+			Cursor: Cursor{Last: maxTime, Direction: Forward},
+		},
+	}
+
+	for cn, c := range cc {
+		c := c
+
+		t.Run(cn, func(t *testing.T) {
+			t.Parallel()
+
+			err := c.Cursor.Validate()
+			equalError(t, c.Err, err)
+		})
+	}
+}
+
+func Test_Cursor_Next(t *testing.T) {
+	tm := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	tr := TimeRange{From: tm, To: tm.Add(time.Hour)}
+
+	cc := map[string]struct {
+		Cursor Cursor
+		Result TimeRange
+	}{
+		"Forward within range": {
+			Cursor: Cursor{Last: tm.Add(10 * time.Minute), Direction: Forward},
+			Result: TimeRange{From: tm.Add(10*time.Minute + time.Nanosecond), To: tm.Add(time.Hour)},
+		},
+		"Forward before range": {
+			Cursor: Cursor{Last: tm.Add(-time.Minute), Direction: Forward},
+			Result: tr,
+		},
+		"Backward within range": {
+			Cursor: Cursor{Last: tm.Add(50 * time.Minute), Direction: Backward},
+			Result: TimeRange{From: tm, To: tm.Add(50 * time.Minute)},
+		},
+		"Backward after range": {
+			Cursor: Cursor{Last: tm.Add(2 * time.Hour), Direction: Backward},
+			Result: tr,
+		},
+	}
+
+	for cn, c := range cc {
+		c := c
+
+		t.Run(cn, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, c.Result, c.Cursor.Next(tr))
+		})
+	}
+}
+
+func Test_Cursor_MarshalText(t *testing.T) {
+	cc := map[string]struct {
+		Cursor Cursor
+		Err    error
+	}{
+		"Invalid Cursor": {
+			Cursor: Cursor{Direction: Forward},
+			Err:    ErrInvalidCursor,
+		},
+		"Overflowing Last": {
+			Cursor: Cursor{Last: time.Date(2300, 1, 1, 0, 0, 0, 0, time.UTC), Direction: Forward},
+			Err:    ErrInvalidCursor,
+		},
+		"Successful marshal": {
+			Cursor: Cursor{Last: time.Date(2020, 1, 1, 0, 0, 0, 5, time.UTC), Direction: Backward},
+		},
+	}
+
+	for cn, c := range cc {
+		c := c
+
+		t.Run(cn, func(t *testing.T) {
+			t.Parallel()
+
+			d, err := c.Cursor.MarshalText()
+			equalError(t, c.Err, err)
+			if err != nil {
+				return
+			}
+
+			var res Cursor
+			assert.NoError(t, res.UnmarshalText(d))
+			assert.Equal(t, c.Cursor, res)
+		})
+	}
+}
+
+func Test_Cursor_UnmarshalText(t *testing.T) {
+	cc := map[string]struct {
+		Text string
+		Err  error
+	}{
+		"Invalid encoding": {
+			Text: "!!!",
+			Err:  ErrInvalidCursor,
+		},
+		"Invalid length": {
+			Text: "AQE",
+			Err:  ErrInvalidCursor,
+		},
+		"Invalid version": {
+			Text: "AgEAAAAAAAAAAQ",
+			Err:  ErrInvalidCursor,
+		},
+		"Invalid Direction": {
+			Text: "AQMAAAAAAAAAAQ",
+			Err:  ErrInvalidCursor,
+		},
+		"Successful unmarshal": {
+			Text: "AQEAAAAAAAAAAQ",
+		},
+	}
+
+	for cn, c := range cc {
+		c := c
+
+		t.Run(cn, func(t *testing.T) {
+			t.Parallel()
+
+			var res Cursor
+
+			err := res.UnmarshalText([]byte(c.Text))
+			equalError(t, c.Err, err)
+			if err != nil {
+				return
+			}
+
+			assert.Equal(t, Cursor{Last: time.Unix(0, 1).UTC(), Direction: Forward}, res)
+		})
+	}
+}
@@ -0,0 +1,54 @@
+package chartype
+
+// Decimate reduces the number of candles to at most maxPoints for
+// display by splitting them into maxPoints/2 buckets of consecutive
+// candles and keeping only the candles with the lowest and highest
+// values of the candle field in each bucket, so that visual extremes
+// are preserved. Kept candles are returned in their original order.
+// If there are no more than maxPoints candles, a copy of them is
+// returned. maxPoints must be at least 2.
+func Decimate(cc []Candle, cf CandleField, maxPoints int) ([]Candle, error) {
+	if maxPoints < 2 {
+		return nil, ErrInvalidLength
+	}
+
+	if err := cf.Validate(); err != nil {
+		return nil, err
+	}
+
+	if len(cc) <= maxPoints {
+		return append([]Candle(nil), cc...), nil
+	}
+
+	n := maxPoints / 2
+	res := make([]Candle, 0, maxPoints)
+
+	for j := 0; j < n; j++ {
+		from, to := j*len(cc)/n, (j+1)*len(cc)/n
+		low, high := from, from
+
+		for i := from + 1; i < to; i++ {
+			v := cf.Extract(cc[i])
+
+			if v.LessThan(cf.Extract(cc[low])) {
+				low = i
+			}
+
+			if v.GreaterThan(cf.Extract(cc[high])) {
+				high = i
+			}
+		}
+
+		if low > high {
+			low, high = high, low
+		}
+
+		res = append(res, cc[low])
+
+		if high != low {
+			res = append(res, cc[high])
+		}
+	}
+
+	return res, nil
+}
@@ -0,0 +1,72 @@
+package chartype
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Decimate(t *testing.T) {
+	tm := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	series := make([]Candle, 9)
+	for i, v := range []int64{5, 1, 9, 4, 4, 4, 7, 2, 3} {
+		series[i] = testCandle(tm.Add(time.Duration(i)*time.Minute), v, v, v, v, 1)
+	}
+
+	cc := map[string]struct {
+		Candles   []Candle
+		Field     CandleField
+		MaxPoints int
+		Result    []Candle
+		Err       error
+	}{
+		"Invalid max points": {
+			Candles:   series,
+			Field:     CandleClose,
+			MaxPoints: 1,
+			Err:       ErrInvalidLength,
+		},
+		"Invalid candle field": {
+			Candles:   series,
+			Field:     70,
+			MaxPoints: 4,
+			Err:       ErrInvalidCandleField,
+		},
+		"Fewer candles than max points": {
+			Candles:   series[:3],
+			Field:     CandleClose,
+			MaxPoints: 4,
+			Result:    series[:3],
+		},
+		"Successful decimation": {
+			Candles:   series,
+			Field:     CandleClose,
+			MaxPoints: 7,
+			Result:    []Candle{series[1], series[2], series[3], series[6], series[7]},
+		},
+	}
+
+	for cn, c := range cc {
+		c := c
+
+		t.Run(cn, func(t *testing.T) {
+			t.Parallel()
+
+			res, err := Decimate(c.Candles, c.Field, c.MaxPoints)
+			equalError(t, c.Err, err)
+			if err != nil {
+				return
+			}
+
+			assert.Equal(t, c.Result, res)
+		})
+	}
+
+	res, err := Decimate(series[:2], CandleClose, 2)
+	assert.NoError(t, err)
+
+	res[0].Close = res[1].Close
+	assert.NotEqual(t, series[0], res[0])
+}
@@ -0,0 +1,136 @@
+package chartype
+
+import (
+	"errors"
+
+	"github.com/shopspring/decimal"
+)
+
+const (
+	// CodeUnknown specifies that the error did not originate from
+	// this package.
+	CodeUnknown ErrorCode = "UNKNOWN"
+
+	// CodeInvalidArgument specifies that an invalid value, such as
+	// a pair, an interval or a configuration option, was provided.
+	CodeInvalidArgument ErrorCode = "INVALID_ARGUMENT"
+
+	// CodeInvalidField specifies that an unknown candle or ticker field
+	// or an invalid number of fields was provided.
+	CodeInvalidField ErrorCode = "INVALID_FIELD"
+
+	// CodeParseFailure specifies that the input could not be parsed.
+	CodeParseFailure ErrorCode = "PARSE_FAILURE"
+
+	// CodeNotFound specifies that the requested candle or market does
+	// not exist.
+	CodeNotFound ErrorCode = "NOT_FOUND"
+
+	// CodeOutOfOrder specifies that the data was not provided in
+	// chronological order.
+	CodeOutOfOrder ErrorCode = "OUT_OF_ORDER"
+
+	// CodeIncompatible specifies that values, such as currencies or
+	// intervals, cannot be combined.
+	CodeIncompatible ErrorCode = "INCOMPATIBLE"
+
+	// CodeInsufficientLiquidity specifies that the order book cannot
+	// fill the requested amount.
+	CodeInsufficientLiquidity ErrorCode = "INSUFFICIENT_LIQUIDITY"
+
+	// CodeMinNotional specifies that the order's notional value is
+	// below the market's minimum.
+	CodeMinNotional ErrorCode = "MIN_NOTIONAL"
+
+	// CodeClosed specifies that the resource has already been closed.
+	CodeClosed ErrorCode = "CLOSED"
+
+	// CodeUnavailable specifies that no data source could serve the
+	// request.
+	CodeUnavailable ErrorCode = "UNAVAILABLE"
+
+	// CodeDataLoss specifies that candles were corrupted while being
+	// retrieved or stored.
+	CodeDataLoss ErrorCode = "DATA_LOSS"
+
+	// CodeCrossedBook specifies that the order book's best bid is not
+	// lower than its best ask.
+	CodeCrossedBook ErrorCode = "CROSSED_BOOK"
+
+	// CodeGapDetected specifies that messages of a sequenced feed were
+	// lost.
+	CodeGapDetected ErrorCode = "GAP_DETECTED"
+
+	// CodeBufferFull specifies that a bounded buffer could not hold
+	// more messages and the message was dropped.
+	CodeBufferFull ErrorCode = "BUFFER_FULL"
+)
+
+// ErrorCode is a stable machine-readable identifier of an error's
+// category that can be mapped to API error codes.
+type ErrorCode string
+
+// Error is an error carrying an error code. All errors of this
+// package can be retrieved as *Error with errors.As.
+type Error struct {
+	// Code specifies the error's category.
+	Code ErrorCode
+
+	// Message specifies the error's description.
+	Message string
+
+	// Err specifies the underlying error, if any.
+	Err error
+}
+
+// Error returns the error's description.
+func (e *Error) Error() string {
+	return e.Message
+}
+
+// Unwrap returns the underlying error.
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+// Code returns the error code attached to the error or any error it
+// wraps. CodeUnknown is returned if there is none and an empty code is
+// returned if the error is nil.
+func Code(err error) ErrorCode {
+	if err == nil {
+		return ""
+	}
+
+	var e *Error
+	if !errors.As(err, &e) {
+		return CodeUnknown
+	}
+
+	return e.Code
+}
+
+// newError creates a new error with the provided code and message.
+func newError(c ErrorCode, msg string) error {
+	return &Error{Code: c, Message: msg}
+}
+
+// parseFailure wraps the error with CodeParseFailure.
+func parseFailure(err error) error {
+	return &Error{Code: CodeParseFailure, Message: err.Error(), Err: err}
+}
+
+// invalidArgument wraps the error with CodeInvalidArgument.
+func invalidArgument(err error) error {
+	return &Error{Code: CodeInvalidArgument, Message: err.Error(), Err: err}
+}
+
+// decimalFromString parses the string into a decimal. Errors are
+// wrapped with CodeParseFailure.
+func decimalFromString(s string) (decimal.Decimal, error) {
+	d, err := decimal.NewFromString(s)
+	if err != nil {
+		return decimal.Decimal{}, parseFailure(err)
+	}
+
+	return d, nil
+}
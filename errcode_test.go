@@ -0,0 +1,123 @@
+package chartype
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_Error_Error(t *testing.T) {
+	err := &Error{Code: CodeNotFound, Message: "candle not found"}
+	assert.Equal(t, "candle not found", err.Error())
+}
+
+func Test_Error_Unwrap(t *testing.T) {
+	assert.Nil(t, (&Error{}).Unwrap())
+
+	err := parseFailure(assert.AnError)
+	assert.True(t, errors.Is(err, assert.AnError))
+	assert.Equal(t, assert.AnError.Error(), err.Error())
+}
+
+func Test_Code(t *testing.T) {
+	cc := map[string]struct {
+		Err    error
+		Result ErrorCode
+	}{
+		"Nil error": {},
+		"Unknown error": {
+			Err:    assert.AnError,
+			Result: CodeUnknown,
+		},
+		"Invalid argument": {
+			Err:    ErrInvalidInterval,
+			Result: CodeInvalidArgument,
+		},
+		"Invalid field": {
+			Err:    ErrInvalidCandleField,
+			Result: CodeInvalidField,
+		},
+		"Not found": {
+			Err:    ErrCandleNotFound,
+			Result: CodeNotFound,
+		},
+		"Out of order": {
+			Err:    ErrUnorderedCandle,
+			Result: CodeOutOfOrder,
+		},
+		"Incompatible": {
+			Err:    ErrCurrencyMismatch,
+			Result: CodeIncompatible,
+		},
+		"Insufficient liquidity": {
+			Err:    ErrInsufficientLiquidity,
+			Result: CodeInsufficientLiquidity,
+		},
+		"Min notional": {
+			Err:    ErrMinNotional,
+			Result: CodeMinNotional,
+		},
+		"Closed": {
+			Err:    ErrStoreClosed,
+			Result: CodeClosed,
+		},
+		"Unavailable": {
+			Err:    ErrSourceUnavailable,
+			Result: CodeUnavailable,
+		},
+		"Data loss": {
+			Err:    ErrChecksumMismatch,
+			Result: CodeDataLoss,
+		},
+		"Crossed book": {
+			Err:    ErrCrossedBook,
+			Result: CodeCrossedBook,
+		},
+		"Gap detected": {
+			Err:    ErrSequenceGap,
+			Result: CodeGapDetected,
+		},
+		"Buffer full": {
+			Err:    ErrBufferFull,
+			Result: CodeBufferFull,
+		},
+		"Wrapped error": {
+			Err:    fmt.Errorf("loading: %w", ErrInvalidPair),
+			Result: CodeInvalidArgument,
+		},
+	}
+
+	for cn, c := range cc {
+		c := c
+
+		t.Run(cn, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, c.Result, Code(c.Err))
+		})
+	}
+}
+
+func Test_ParseFailure(t *testing.T) {
+	_, err := ParseCandle(time.Time{}, "1", "x", "1", "1", "1")
+	require.Error(t, err)
+
+	var e *Error
+
+	require.True(t, errors.As(err, &e))
+	assert.Equal(t, CodeParseFailure, e.Code)
+	assert.NotNil(t, e.Err)
+
+	var p Price
+
+	assert.Equal(t, CodeParseFailure, Code(p.UnmarshalText([]byte("x"))))
+	assert.Equal(t, CodeInvalidArgument, Code(p.UnmarshalText([]byte("-1"))))
+
+	var cr CandleRequest
+
+	assert.Equal(t, CodeParseFailure, Code(cr.UnmarshalText([]byte("%"))))
+}
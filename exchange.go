@@ -0,0 +1,211 @@
+package chartype
+
+import (
+	"strconv"
+	"time"
+)
+
+const (
+	// ExchangeBinance specifies Binance exchange.
+	ExchangeBinance Exchange = iota + 1
+
+	// ExchangeCoinbase specifies Coinbase exchange.
+	ExchangeCoinbase
+
+	// ExchangeKraken specifies Kraken exchange.
+	ExchangeKraken
+
+	// ExchangeBitstamp specifies Bitstamp exchange.
+	ExchangeBitstamp
+)
+
+var (
+	// ErrInvalidExchange is returned when exchange with invalid value
+	// is being used.
+	ErrInvalidExchange = newError(CodeInvalidArgument, "invalid exchange")
+
+	// ErrUnsupportedInterval is returned when interval that is not
+	// natively supported by an exchange is being used.
+	ErrUnsupportedInterval = newError(CodeIncompatible, "unsupported interval")
+)
+
+// ExchangeInfo holds an exchange's capabilities and conventions.
+type ExchangeInfo struct {
+	// Intervals specifies candle intervals natively supported by the
+	// exchange, in ascending order.
+	Intervals []Interval `json:"intervals" yaml:"intervals"`
+
+	// SymbolStyle specifies the notation of exchange's symbols.
+	SymbolStyle SymbolStyle `json:"symbol_style" yaml:"symbol_style"`
+
+	// MakerTaker specifies whether the exchange charges different
+	// fees for orders that add liquidity and orders that take it.
+	MakerTaker bool `json:"maker_taker" yaml:"maker_taker"`
+}
+
+//nolint:gochecknoglobals // map literals cannot be declared as consts
+var exchangeInfos = map[Exchange]ExchangeInfo{
+	ExchangeBinance: {
+		Intervals:   minutes(1, 3, 5, 15, 30, 60, 120, 240, 360, 480, 720, 1440, 4320, 10080),
+		SymbolStyle: SymbolConcat,
+		MakerTaker:  true,
+	},
+	ExchangeCoinbase: {
+		Intervals:   minutes(1, 5, 15, 60, 360, 1440),
+		SymbolStyle: SymbolDash,
+		MakerTaker:  true,
+	},
+	ExchangeKraken: {
+		Intervals:   minutes(1, 5, 15, 30, 60, 240, 1440, 10080, 21600),
+		SymbolStyle: SymbolSlash,
+		MakerTaker:  true,
+	},
+	ExchangeBitstamp: {
+		Intervals:   minutes(1, 3, 5, 15, 30, 60, 120, 240, 360, 720, 1440, 4320),
+		SymbolStyle: SymbolConcat,
+		MakerTaker:  true,
+	},
+}
+
+// Exchange specifies a trading venue.
+// Can be included in configuration structures.
+type Exchange int
+
+// Validate checks whether the exchange is one of supported exchanges
+// or not.
+func (e Exchange) Validate() error {
+	if _, ok := exchangeInfos[e]; !ok {
+		return ErrInvalidExchange
+	}
+
+	return nil
+}
+
+// Info returns the exchange's capabilities and conventions.
+func (e Exchange) Info() (ExchangeInfo, error) {
+	ei, ok := exchangeInfos[e]
+	if !ok {
+		return ExchangeInfo{}, ErrInvalidExchange
+	}
+
+	ei.Intervals = append([]Interval(nil), ei.Intervals...)
+
+	return ei, nil
+}
+
+// SupportsInterval checks whether the exchange natively provides
+// candles of the interval.
+func (e Exchange) SupportsInterval(i Interval) bool {
+	for _, ni := range exchangeInfos[e].Intervals {
+		if ni == i {
+			return true
+		}
+	}
+
+	return false
+}
+
+// NativeInterval returns interval's representation used by the
+// exchange's API, e.g. "1h" for Binance, "60" (minutes) for Kraken
+// and "3600" (seconds) for Coinbase and Bitstamp.
+func (e Exchange) NativeInterval(i Interval) (string, error) {
+	if err := e.Validate(); err != nil {
+		return "", err
+	}
+
+	if !e.SupportsInterval(i) {
+		return "", ErrUnsupportedInterval
+	}
+
+	switch e {
+	case ExchangeBinance:
+		return i.String(), nil
+	case ExchangeKraken:
+		return strconv.FormatInt(int64(i.Duration()/time.Minute), 10), nil
+	default:
+		return strconv.FormatInt(int64(i.Duration()/time.Second), 10), nil
+	}
+}
+
+// ParseNativeInterval turns interval's representation used by the
+// exchange's API to appropriate interval value.
+func (e Exchange) ParseNativeInterval(s string) (Interval, error) {
+	if err := e.Validate(); err != nil {
+		return 0, err
+	}
+
+	for _, i := range exchangeInfos[e].Intervals {
+		if v, _ := e.NativeInterval(i); v == s { //nolint:errcheck // native intervals are supported
+			return i, nil
+		}
+	}
+
+	return 0, ErrUnsupportedInterval
+}
+
+// FormatSymbol returns pair's symbol in the exchange's notation.
+func (e Exchange) FormatSymbol(p Pair) (string, error) {
+	if err := e.Validate(); err != nil {
+		return "", err
+	}
+
+	return FormatSymbol(p, exchangeInfos[e].SymbolStyle)
+}
+
+// String returns exchange's string representation, e.g. "binance".
+func (e Exchange) String() string {
+	d, err := e.MarshalText()
+	if err != nil {
+		return ""
+	}
+
+	return string(d)
+}
+
+// MarshalText turns exchange to appropriate string representation.
+func (e Exchange) MarshalText() ([]byte, error) {
+	var v string
+
+	switch e {
+	case ExchangeBinance:
+		v = "binance"
+	case ExchangeCoinbase:
+		v = "coinbase"
+	case ExchangeKraken:
+		v = "kraken"
+	case ExchangeBitstamp:
+		v = "bitstamp"
+	default:
+		return nil, ErrInvalidExchange
+	}
+
+	return []byte(v), nil
+}
+
+// UnmarshalText turns string to appropriate exchange value.
+func (e *Exchange) UnmarshalText(d []byte) error {
+	switch string(d) {
+	case "binance":
+		*e = ExchangeBinance
+	case "coinbase":
+		*e = ExchangeCoinbase
+	case "kraken":
+		*e = ExchangeKraken
+	case "bitstamp":
+		*e = ExchangeBitstamp
+	default:
+		return ErrInvalidExchange
+	}
+
+	return nil
+}
+
+// minutes returns intervals of the provided numbers of minutes.
+func minutes(mm ...int) []Interval {
+	res := make([]Interval, len(mm))
+	for i, m := range mm {
+		res[i] = Interval(time.Duration(m) * time.Minute)
+	}
+
+	return res
+}
@@ -0,0 +1,269 @@
+package chartype
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_Exchange_Validate(t *testing.T) {
+	cc := map[string]struct {
+		Exchange Exchange
+		Err      error
+	}{
+		"Invalid Exchange": {
+			Exchange: 70,
+			Err:      ErrInvalidExchange,
+		},
+		"Successful ExchangeBinance validation": {
+			Exchange: ExchangeBinance,
+		},
+		"Successful ExchangeCoinbase validation": {
+			Exchange: ExchangeCoinbase,
+		},
+		"Successful ExchangeKraken validation": {
+			Exchange: ExchangeKraken,
+		},
+		"Successful ExchangeBitstamp validation": {
+			Exchange: ExchangeBitstamp,
+		},
+	}
+
+	for cn, c := range cc {
+		c := c
+
+		t.Run(cn, func(t *testing.T) {
+			t.Parallel()
+
+			equalError(t, c.Err, c.Exchange.Validate())
+		})
+	}
+}
+
+func Test_Exchange_Info(t *testing.T) {
+	_, err := Exchange(70).Info()
+	assert.Equal(t, ErrInvalidExchange, err)
+
+	ei, err := ExchangeCoinbase.Info()
+	require.NoError(t, err)
+	assert.Equal(t, ExchangeInfo{
+		Intervals: []Interval{
+			IntervalMinute, Interval(5 * time.Minute), Interval(15 * time.Minute),
+			IntervalHour, Interval(6 * time.Hour), IntervalDay,
+		},
+		SymbolStyle: SymbolDash,
+		MakerTaker:  true,
+	}, ei)
+
+	ei.Intervals[0] = IntervalWeek
+	assert.False(t, ExchangeCoinbase.SupportsInterval(IntervalWeek))
+}
+
+func Test_Exchange_SupportsInterval(t *testing.T) {
+	assert.True(t, ExchangeBinance.SupportsInterval(Interval(3*time.Minute)))
+	assert.False(t, ExchangeCoinbase.SupportsInterval(Interval(3*time.Minute)))
+	assert.True(t, ExchangeKraken.SupportsInterval(IntervalWeek))
+	assert.False(t, Exchange(70).SupportsInterval(IntervalMinute))
+}
+
+func Test_Exchange_NativeInterval(t *testing.T) {
+	cc := map[string]struct {
+		Exchange Exchange
+		Interval Interval
+		Text     string
+		Err      error
+	}{
+		"Invalid Exchange": {
+			Exchange: 70,
+			Interval: IntervalMinute,
+			Err:      ErrInvalidExchange,
+		},
+		"Unsupported interval": {
+			Exchange: ExchangeCoinbase,
+			Interval: IntervalWeek,
+			Err:      ErrUnsupportedInterval,
+		},
+		"Binance interval": {
+			Exchange: ExchangeBinance,
+			Interval: Interval(3 * 24 * time.Hour),
+			Text:     "3d",
+		},
+		"Coinbase interval": {
+			Exchange: ExchangeCoinbase,
+			Interval: IntervalMinute,
+			Text:     "60",
+		},
+		"Kraken interval": {
+			Exchange: ExchangeKraken,
+			Interval: IntervalMinute,
+			Text:     "1",
+		},
+		"Bitstamp interval": {
+			Exchange: ExchangeBitstamp,
+			Interval: IntervalHour,
+			Text:     "3600",
+		},
+	}
+
+	for cn, c := range cc {
+		c := c
+
+		t.Run(cn, func(t *testing.T) {
+			t.Parallel()
+
+			s, err := c.Exchange.NativeInterval(c.Interval)
+			equalError(t, c.Err, err)
+			if err != nil {
+				return
+			}
+
+			assert.Equal(t, c.Text, s)
+
+			i, err := c.Exchange.ParseNativeInterval(s)
+			assert.NoError(t, err)
+			assert.Equal(t, c.Interval, i)
+		})
+	}
+}
+
+func Test_Exchange_ParseNativeInterval(t *testing.T) {
+	_, err := Exchange(70).ParseNativeInterval("1m")
+	assert.Equal(t, ErrInvalidExchange, err)
+
+	_, err = ExchangeKraken.ParseNativeInterval("1m")
+	assert.Equal(t, ErrUnsupportedInterval, err)
+
+	for _, e := range []Exchange{ExchangeBinance, ExchangeCoinbase, ExchangeKraken, ExchangeBitstamp} {
+		ei, err := e.Info()
+		require.NoError(t, err)
+
+		for _, i := range ei.Intervals {
+			s, err := e.NativeInterval(i)
+			require.NoError(t, err)
+
+			res, err := e.ParseNativeInterval(s)
+			assert.NoError(t, err)
+			assert.Equal(t, i, res, "%v %s", e, s)
+		}
+	}
+}
+
+func Test_Exchange_FormatSymbol(t *testing.T) {
+	p := Pair{Base: "BTC", Quote: "USD"}
+
+	_, err := Exchange(70).FormatSymbol(p)
+	assert.Equal(t, ErrInvalidExchange, err)
+
+	_, err = ExchangeBinance.FormatSymbol(Pair{})
+	assert.Equal(t, ErrInvalidPair, err)
+
+	for e, s := range map[Exchange]string{
+		ExchangeBinance:  "BTCUSD",
+		ExchangeCoinbase: "BTC-USD",
+		ExchangeKraken:   "BTC/USD",
+		ExchangeBitstamp: "BTCUSD",
+	} {
+		res, err := e.FormatSymbol(p)
+		assert.NoError(t, err)
+		assert.Equal(t, s, res)
+	}
+}
+
+func Test_Exchange_String(t *testing.T) {
+	assert.Equal(t, "kraken", ExchangeKraken.String())
+	assert.Equal(t, "", Exchange(70).String())
+}
+
+func Test_Exchange_MarshalText(t *testing.T) {
+	cc := map[string]struct {
+		Exchange Exchange
+		Text     string
+		Err      error
+	}{
+		"Invalid Exchange": {
+			Exchange: 70,
+			Err:      ErrInvalidExchange,
+		},
+		"Successful ExchangeBinance marshal": {
+			Exchange: ExchangeBinance,
+			Text:     "binance",
+		},
+		"Successful ExchangeCoinbase marshal": {
+			Exchange: ExchangeCoinbase,
+			Text:     "coinbase",
+		},
+		"Successful ExchangeKraken marshal": {
+			Exchange: ExchangeKraken,
+			Text:     "kraken",
+		},
+		"Successful ExchangeBitstamp marshal": {
+			Exchange: ExchangeBitstamp,
+			Text:     "bitstamp",
+		},
+	}
+
+	for cn, c := range cc {
+		c := c
+
+		t.Run(cn, func(t *testing.T) {
+			t.Parallel()
+
+			d, err := c.Exchange.MarshalText()
+			equalError(t, c.Err, err)
+			if err != nil {
+				return
+			}
+
+			assert.Equal(t, c.Text, string(d))
+		})
+	}
+}
+
+func Test_Exchange_UnmarshalText(t *testing.T) {
+	cc := map[string]struct {
+		Text   string
+		Result Exchange
+		Err    error
+	}{
+		"Invalid Exchange": {
+			Text: "mtgox",
+			Err:  ErrInvalidExchange,
+		},
+		"Successful ExchangeBinance unmarshal": {
+			Text:   "binance",
+			Result: ExchangeBinance,
+		},
+		"Successful ExchangeCoinbase unmarshal": {
+			Text:   "coinbase",
+			Result: ExchangeCoinbase,
+		},
+		"Successful ExchangeKraken unmarshal": {
+			Text:   "kraken",
+			Result: ExchangeKraken,
+		},
+		"Successful ExchangeBitstamp unmarshal": {
+			Text:   "bitstamp",
+			Result: ExchangeBitstamp,
+		},
+	}
+
+	for cn, c := range cc {
+		c := c
+
+		t.Run(cn, func(t *testing.T) {
+			t.Parallel()
+
+			var e Exchange
+
+			err := e.UnmarshalText([]byte(c.Text))
+			equalError(t, c.Err, err)
+			if err != nil {
+				return
+			}
+
+			assert.Equal(t, c.Result, e)
+		})
+	}
+}
@@ -0,0 +1,381 @@
+package chartype
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/csv"
+	"errors"
+	"io"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+const (
+	// StoreFormatFrames specifies newline-delimited candle frames.
+	StoreFormatFrames StoreFormat = iota + 1
+
+	// StoreFormatCSV specifies CSV records with a header, holding
+	// pair, interval, RFC 3339 timestamp, open, high, low, close,
+	// volume and optional adjusted close and delta fields.
+	StoreFormatCSV
+)
+
+// importBatchSize is the maximum number of candles written to a store
+// at once during imports.
+const importBatchSize = 1000
+
+var (
+	// ErrInvalidStoreFormat is returned when store format with
+	// invalid value is being used.
+	ErrInvalidStoreFormat = newError(CodeInvalidArgument, "invalid store format")
+
+	// ErrNoSeries is returned when a store without SeriesLister is
+	// exported without any series being provided.
+	ErrNoSeries = newError(CodeInvalidArgument, "no series")
+
+	// ErrInvalidRecord is returned when a CSV record with invalid
+	// header or number of fields is being imported.
+	ErrInvalidRecord = newError(CodeParseFailure, "invalid record")
+)
+
+// csvHeader holds the names of CSV store format fields.
+var csvHeader = []string{ //nolint:gochecknoglobals // lookup table
+	"pair", "interval", "timestamp", "open", "high", "low", "close",
+	"volume", "adj_close", "delta",
+}
+
+// StoreFormat specifies how store contents are encoded by ExportStore
+// and ImportStore.
+// Can be included in configuration structures.
+type StoreFormat int
+
+// Validate checks whether the store format is one of supported
+// format types or not.
+func (sf StoreFormat) Validate() error {
+	switch sf {
+	case StoreFormatFrames, StoreFormatCSV:
+		return nil
+	default:
+		return ErrInvalidStoreFormat
+	}
+}
+
+// MarshalText turns store format to appropriate string
+// representation.
+func (sf StoreFormat) MarshalText() ([]byte, error) {
+	var v string
+
+	switch sf {
+	case StoreFormatFrames:
+		v = "frames"
+	case StoreFormatCSV:
+		v = "csv"
+	default:
+		return nil, ErrInvalidStoreFormat
+	}
+
+	return []byte(v), nil
+}
+
+// UnmarshalText turns string to appropriate store format value.
+func (sf *StoreFormat) UnmarshalText(d []byte) error {
+	switch string(d) {
+	case "frames":
+		*sf = StoreFormatFrames
+	case "csv":
+		*sf = StoreFormatCSV
+	default:
+		return ErrInvalidStoreFormat
+	}
+
+	return nil
+}
+
+// Series identifies a single candle series of a store.
+type Series struct {
+	Pair     Pair     `json:"pair" yaml:"pair"`
+	Interval Interval `json:"interval" yaml:"interval"`
+}
+
+// SeriesLister is implemented by candle stores that can enumerate
+// their series.
+type SeriesLister interface {
+	// Series returns all non-empty series of the store.
+	Series() ([]Series, error)
+}
+
+// ExportStore writes candles of the series within the time range
+// from the store to the writer in the format. All series of the store
+// are exported if none are provided and the store implements
+// SeriesLister. Every candle carries its pair and interval, so the
+// output can be imported into any store with ImportStore.
+func ExportStore(cs CandleStore, sf StoreFormat, w io.Writer, tr TimeRange, ss ...Series) error {
+	if err := sf.Validate(); err != nil {
+		return err
+	}
+
+	if err := tr.Validate(); err != nil {
+		return err
+	}
+
+	if len(ss) == 0 {
+		sl, ok := cs.(SeriesLister)
+		if !ok {
+			return ErrNoSeries
+		}
+
+		var err error
+
+		if ss, err = sl.Series(); err != nil {
+			return err
+		}
+	}
+
+	bw := bufio.NewWriter(w)
+
+	var cw *csv.Writer
+
+	if sf == StoreFormatCSV {
+		cw = csv.NewWriter(bw)
+		cw.Write(csvHeader) //nolint:errcheck // write errors are reported after flushing
+	}
+
+	for _, s := range ss {
+		cc, err := cs.Range(s.Pair, s.Interval, tr.From, tr.To)
+		if err != nil {
+			return err
+		}
+
+		for _, c := range cc {
+			if sf == StoreFormatCSV {
+				err = cw.Write(csvRecord(s, c))
+			} else {
+				err = writeFrame(bw, s, c)
+			}
+
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	if cw != nil {
+		cw.Flush()
+
+		if err := cw.Error(); err != nil {
+			return err
+		}
+	}
+
+	return bw.Flush()
+}
+
+// ImportStore reads candles in the format from the reader and writes
+// them to their series in the store in batches. Candles read before
+// an invalid one are kept in the store.
+func ImportStore(cs CandleStore, sf StoreFormat, r io.Reader) error {
+	if err := sf.Validate(); err != nil {
+		return err
+	}
+
+	var (
+		cur   Series
+		batch []Candle
+	)
+
+	flush := func() error {
+		if len(batch) == 0 {
+			return nil
+		}
+
+		err := cs.Put(cur.Pair, cur.Interval, batch...)
+		batch = nil
+
+		return err
+	}
+
+	add := func(s Series, c Candle) error {
+		if s != cur || len(batch) >= importBatchSize {
+			if err := flush(); err != nil {
+				return err
+			}
+
+			cur = s
+		}
+
+		batch = append(batch, c)
+
+		return nil
+	}
+
+	var err error
+
+	if sf == StoreFormatCSV {
+		err = readCSVRecords(r, add)
+	} else {
+		err = readFrames(r, add)
+	}
+
+	if err != nil {
+		return err
+	}
+
+	return flush()
+}
+
+// writeFrame writes the candle of the series as a single frame line.
+func writeFrame(w io.Writer, s Series, c Candle) error {
+	d, err := EncodeCandleFrame(s.Pair, s.Interval, c)
+	if err != nil {
+		return err
+	}
+
+	_, err = w.Write(append(d, '\n'))
+
+	return err
+}
+
+// readFrames reads candle frame lines and passes their candles to the
+// function. Empty lines are skipped.
+func readFrames(r io.Reader, fn func(Series, Candle) error) error {
+	br := bufio.NewReader(r)
+
+	for {
+		l, err := br.ReadBytes('\n')
+		if err != nil && !errors.Is(err, io.EOF) {
+			return err
+		}
+
+		if l = bytes.TrimSpace(l); len(l) > 0 {
+			f, ferr := DecodeFrame(l)
+			if ferr != nil {
+				return ferr
+			}
+
+			c, ferr := f.Candle()
+			if ferr != nil {
+				return ferr
+			}
+
+			if ferr = fn(Series{Pair: f.Pair, Interval: *f.Interval}, c); ferr != nil {
+				return ferr
+			}
+		}
+
+		if err != nil {
+			return nil
+		}
+	}
+}
+
+// csvRecord returns the CSV record of the candle of the series.
+func csvRecord(s Series, c Candle) []string {
+	optional := func(d *decimal.Decimal) string {
+		if d == nil {
+			return ""
+		}
+
+		return d.String()
+	}
+
+	return []string{
+		s.Pair.String(), s.Interval.String(),
+		c.Timestamp.Format(time.RFC3339Nano),
+		c.Open.String(), c.High.String(), c.Low.String(),
+		c.Close.String(), c.Volume.String(),
+		optional(c.AdjClose), optional(c.Delta),
+	}
+}
+
+// readCSVRecords reads CSV records and passes their candles to the
+// function.
+func readCSVRecords(r io.Reader, fn func(Series, Candle) error) error {
+	cr := csv.NewReader(r)
+	cr.FieldsPerRecord = len(csvHeader)
+	cr.ReuseRecord = true
+
+	for line := 0; ; line++ {
+		rec, err := cr.Read()
+
+		switch {
+		case errors.Is(err, io.EOF):
+			if line == 0 {
+				return ErrInvalidRecord
+			}
+
+			return nil
+		case errors.Is(err, csv.ErrFieldCount):
+			return ErrInvalidRecord
+		case err != nil:
+			return parseFailure(err)
+		}
+
+		if line == 0 {
+			for j, h := range csvHeader {
+				if rec[j] != h {
+					return ErrInvalidRecord
+				}
+			}
+
+			continue
+		}
+
+		s, c, err := parseCSVRecord(rec)
+		if err != nil {
+			return err
+		}
+
+		if err = fn(s, c); err != nil {
+			return err
+		}
+	}
+}
+
+// parseCSVRecord parses the CSV record into a candle and its series.
+func parseCSVRecord(rec []string) (Series, Candle, error) {
+	var s Series
+
+	if err := s.Pair.UnmarshalText([]byte(rec[0])); err != nil {
+		return Series{}, Candle{}, err
+	}
+
+	if err := s.Interval.UnmarshalText([]byte(rec[1])); err != nil {
+		return Series{}, Candle{}, err
+	}
+
+	t, err := time.Parse(time.RFC3339Nano, rec[2])
+	if err != nil {
+		return Series{}, Candle{}, parseFailure(err)
+	}
+
+	c, err := ParseCandle(t, rec[3], rec[4], rec[5], rec[6], rec[7])
+	if err != nil {
+		return Series{}, Candle{}, err
+	}
+
+	if c.AdjClose, err = parseOptionalDecimal(rec[8]); err != nil {
+		return Series{}, Candle{}, err
+	}
+
+	if c.Delta, err = parseOptionalDecimal(rec[9]); err != nil {
+		return Series{}, Candle{}, err
+	}
+
+	return s, c, nil
+}
+
+// parseOptionalDecimal parses the value into a decimal. Nil is
+// returned if the value is empty.
+func parseOptionalDecimal(v string) (*decimal.Decimal, error) {
+	if v == "" {
+		return nil, nil
+	}
+
+	d, err := decimal.NewFromString(v)
+	if err != nil {
+		return nil, parseFailure(err)
+	}
+
+	return &d, nil
+}
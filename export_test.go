@@ -0,0 +1,376 @@
+package chartype
+
+import (
+	"bytes"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// errWriter is a writer that always fails.
+type errWriter struct{}
+
+func (errWriter) Write([]byte) (int, error) {
+	return 0, assert.AnError
+}
+
+// errReader returns the data and then the error.
+type errReader struct {
+	data string
+	err  error
+}
+
+func (er *errReader) Read(p []byte) (int, error) {
+	if er.data == "" {
+		return 0, er.err
+	}
+
+	n := copy(p, er.data)
+	er.data = er.data[n:]
+
+	return n, nil
+}
+
+// listStore is a candle store with a fixed series list.
+type listStore struct {
+	CandleStore
+	series []Series
+	err    error
+}
+
+func (ls listStore) Series() ([]Series, error) {
+	return ls.series, ls.err
+}
+
+func Test_StoreFormat_Validate(t *testing.T) {
+	cc := map[string]struct {
+		Format StoreFormat
+		Err    error
+	}{
+		"Invalid StoreFormat": {
+			Format: 70,
+			Err:    ErrInvalidStoreFormat,
+		},
+		"Successful StoreFormatFrames validation": {
+			Format: StoreFormatFrames,
+		},
+		"Successful StoreFormatCSV validation": {
+			Format: StoreFormatCSV,
+		},
+	}
+
+	for cn, c := range cc {
+		c := c
+
+		t.Run(cn, func(t *testing.T) {
+			t.Parallel()
+
+			equalError(t, c.Err, c.Format.Validate())
+		})
+	}
+}
+
+func Test_StoreFormat_MarshalText(t *testing.T) {
+	cc := map[string]struct {
+		Format StoreFormat
+		Text   string
+		Err    error
+	}{
+		"Invalid StoreFormat": {
+			Format: 70,
+			Err:    ErrInvalidStoreFormat,
+		},
+		"Successful StoreFormatFrames marshal": {
+			Format: StoreFormatFrames,
+			Text:   "frames",
+		},
+		"Successful StoreFormatCSV marshal": {
+			Format: StoreFormatCSV,
+			Text:   "csv",
+		},
+	}
+
+	for cn, c := range cc {
+		c := c
+
+		t.Run(cn, func(t *testing.T) {
+			t.Parallel()
+
+			d, err := c.Format.MarshalText()
+			equalError(t, c.Err, err)
+			if err != nil {
+				return
+			}
+
+			assert.Equal(t, c.Text, string(d))
+		})
+	}
+}
+
+func Test_StoreFormat_UnmarshalText(t *testing.T) {
+	cc := map[string]struct {
+		Text   string
+		Result StoreFormat
+		Err    error
+	}{
+		"Invalid StoreFormat": {
+			Text: "x",
+			Err:  ErrInvalidStoreFormat,
+		},
+		"Successful StoreFormatFrames unmarshal": {
+			Text:   "frames",
+			Result: StoreFormatFrames,
+		},
+		"Successful StoreFormatCSV unmarshal": {
+			Text:   "csv",
+			Result: StoreFormatCSV,
+		},
+	}
+
+	for cn, c := range cc {
+		c := c
+
+		t.Run(cn, func(t *testing.T) {
+			t.Parallel()
+
+			var sf StoreFormat
+
+			err := sf.UnmarshalText([]byte(c.Text))
+			equalError(t, c.Err, err)
+			if err != nil {
+				return
+			}
+
+			assert.Equal(t, c.Result, sf)
+		})
+	}
+}
+
+func Test_ExportStore(t *testing.T) {
+	tm := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	tr := TimeRange{From: tm, To: tm.Add(time.Hour)}
+	btc, eth := Pair{Base: "BTC", Quote: "USD"}, Pair{Base: "ETH", Quote: "USD"}
+
+	ms := NewMemoryStore()
+	require.NoError(t, ms.Put(btc, IntervalMinute,
+		testCandle(tm, 1, 2, 1, 2, 10),
+		testBuiltCandle(tm.Add(time.Minute), 2, 3, 1, 1, 5, -2),
+	))
+	require.NoError(t, ms.Put(eth, IntervalHour, testCandle(tm, 3, 3, 3, 3, 3)))
+
+	var buf bytes.Buffer
+
+	assert.Equal(t, ErrInvalidStoreFormat, ExportStore(ms, 70, &buf, tr))
+	assert.Equal(t, ErrInvalidTimeRange, ExportStore(ms, StoreFormatCSV, &buf, TimeRange{}))
+	assert.Equal(t, ErrNoSeries, ExportStore(struct{ CandleStore }{ms}, StoreFormatCSV, &buf, tr))
+	assert.Equal(t, assert.AnError, ExportStore(listStore{CandleStore: ms, err: assert.AnError}, StoreFormatCSV, &buf, tr))
+	assert.Equal(t, assert.AnError, ExportStore(&faultyStore{MemoryStore: ms, rangeErr: assert.AnError}, StoreFormatCSV, &buf, tr))
+	assert.Equal(t, assert.AnError, ExportStore(ms, StoreFormatCSV, errWriter{}, tr))
+	assert.Equal(t, assert.AnError, ExportStore(ms, StoreFormatFrames, errWriter{}, tr))
+	assert.Empty(t, buf.String())
+
+	require.NoError(t, ExportStore(ms, StoreFormatCSV, &buf, tr))
+	assert.Equal(t, "pair,interval,timestamp,open,high,low,close,volume,adj_close,delta\n"+
+		"BTC_USD,1m,2020-01-01T00:00:00Z,1,2,1,2,10,,\n"+
+		"BTC_USD,1m,2020-01-01T00:01:00Z,2,3,1,1,5,,-2\n"+
+		"ETH_USD,1h,2020-01-01T00:00:00Z,3,3,3,3,3,,\n", buf.String())
+
+	buf.Reset()
+
+	require.NoError(t, ExportStore(ms, StoreFormatFrames, &buf, tr, Series{Pair: eth, Interval: IntervalHour}))
+	assert.Equal(t, 1, strings.Count(buf.String(), "\n"))
+
+	// encode errors of large exports are returned while writing
+	var series []Candle
+	for i := 0; i < 60; i++ {
+		series = append(series, testCandle(tm.Add(time.Duration(i)*time.Minute), 1, 1, 1, 1, 1))
+	}
+
+	large := NewMemoryStore()
+	require.NoError(t, large.Put(btc, IntervalMinute, series...))
+
+	assert.Equal(t, assert.AnError, ExportStore(large, StoreFormatCSV, errWriter{}, tr))
+	assert.Equal(t, assert.AnError, ExportStore(large, StoreFormatFrames, errWriter{}, tr))
+
+	assert.Equal(t, ErrInvalidPair, writeFrame(&buf, Series{}, Candle{}))
+}
+
+func Test_ImportStore(t *testing.T) {
+	tm := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	tr := TimeRange{From: tm, To: tm.Add(2 * time.Hour)}
+	btc, eth := Pair{Base: "BTC", Quote: "USD"}, Pair{Base: "ETH", Quote: "USD"}
+
+	var series []Candle
+	for i := 0; i < importBatchSize+10; i++ {
+		series = append(series, testBuiltCandle(tm.Add(time.Duration(i)*time.Second), 1, 2, 1, 2, 3, 1))
+	}
+
+	src := NewMemoryStore()
+	require.NoError(t, src.Put(btc, IntervalMinute, series...))
+	require.NoError(t, src.Put(eth, IntervalHour, testCandle(tm, 3, 3, 3, 3, 3)))
+
+	assert.Equal(t, ErrInvalidStoreFormat, ImportStore(src, 70, strings.NewReader("")))
+
+	for _, sf := range []StoreFormat{StoreFormatCSV, StoreFormatFrames} {
+		var buf bytes.Buffer
+
+		require.NoError(t, ExportStore(src, sf, &buf, tr))
+
+		dst := NewMemoryStore()
+		require.NoError(t, ImportStore(dst, sf, &buf))
+
+		for _, s := range []Series{{Pair: btc, Interval: IntervalMinute}, {Pair: eth, Interval: IntervalHour}} {
+			exp, err := src.Range(s.Pair, s.Interval, tr.From, tr.To)
+			require.NoError(t, err)
+
+			res, err := dst.Range(s.Pair, s.Interval, tr.From, tr.To)
+			require.NoError(t, err)
+			assertEqualCandles(t, exp, res)
+		}
+	}
+
+	// put errors
+	assert.Equal(t, assert.AnError, ImportStore(&faultyStore{MemoryStore: src, putErr: assert.AnError},
+		StoreFormatFrames, strings.NewReader(testFrames(t, btc, IntervalMinute, series...))))
+	assert.Equal(t, assert.AnError, ImportStore(&faultyStore{MemoryStore: src, putErr: assert.AnError},
+		StoreFormatCSV, strings.NewReader(csvText("BTC_USD,1m,2020-01-01T00:00:00Z,1,1,1,1,1,,"))))
+}
+
+func Test_readFrames(t *testing.T) {
+	tm := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	p := Pair{Base: "BTC", Quote: "USD"}
+
+	ticker, err := EncodeTickerFrame(p, Ticker{})
+	require.NoError(t, err)
+
+	cc := map[string]struct {
+		Reader *errReader
+		Count  int
+		Err    error
+	}{
+		"Read error": {
+			Reader: &errReader{err: assert.AnError},
+			Err:    assert.AnError,
+		},
+		"Invalid frame": {
+			Reader: &errReader{data: "{\n", err: io.EOF},
+			Err:    ErrInvalidFrame,
+		},
+		"Non-candle frame": {
+			Reader: &errReader{data: string(ticker), err: io.EOF},
+			Err:    ErrInvalidFrameType,
+		},
+		"Callback error": {
+			Reader: &errReader{data: testFrames(t, p, IntervalMinute, testCandle(tm, 1, 1, 1, 1, 1)), err: io.EOF},
+			Count:  1,
+			Err:    assert.AnError,
+		},
+		"Empty lines are skipped": {
+			Reader: &errReader{data: "\n\n", err: io.EOF},
+		},
+	}
+
+	for cn, c := range cc {
+		c := c
+
+		t.Run(cn, func(t *testing.T) {
+			t.Parallel()
+
+			var n int
+
+			err := readFrames(c.Reader, func(Series, Candle) error {
+				n++
+				return assert.AnError
+			})
+			equalError(t, c.Err, err)
+			assert.Equal(t, c.Count, n)
+		})
+	}
+}
+
+func Test_readCSVRecords(t *testing.T) {
+	cc := map[string]struct {
+		Text string
+		Err  error
+	}{
+		"Missing header": {
+			Err: ErrInvalidRecord,
+		},
+		"Invalid header": {
+			Text: "a,b,c,d,e,f,g,h,i,j\n",
+			Err:  ErrInvalidRecord,
+		},
+		"Invalid number of fields": {
+			Text: csvText("BTC_USD,1m"),
+			Err:  ErrInvalidRecord,
+		},
+		"Invalid quotes": {
+			Text: csvText(`BTC_USD,1m,"x"x,1,1,1,1,1,,`),
+			Err:  assert.AnError,
+		},
+		"Invalid pair": {
+			Text: csvText("BTC,1m,2020-01-01T00:00:00Z,1,1,1,1,1,,"),
+			Err:  ErrInvalidPair,
+		},
+		"Invalid interval": {
+			Text: csvText("BTC_USD,x,2020-01-01T00:00:00Z,1,1,1,1,1,,"),
+			Err:  ErrInvalidInterval,
+		},
+		"Invalid timestamp": {
+			Text: csvText("BTC_USD,1m,x,1,1,1,1,1,,"),
+			Err:  assert.AnError,
+		},
+		"Invalid candle": {
+			Text: csvText("BTC_USD,1m,2020-01-01T00:00:00Z,x,1,1,1,1,,"),
+			Err:  assert.AnError,
+		},
+		"Invalid adjusted close": {
+			Text: csvText("BTC_USD,1m,2020-01-01T00:00:00Z,1,1,1,1,1,x,"),
+			Err:  assert.AnError,
+		},
+		"Invalid delta": {
+			Text: csvText("BTC_USD,1m,2020-01-01T00:00:00Z,1,1,1,1,1,,x"),
+			Err:  assert.AnError,
+		},
+		"Callback error": {
+			Text: csvText("BTC_USD,1m,2020-01-01T00:00:00Z,1,1,1,1,1,1,1"),
+			Err:  assert.AnError,
+		},
+	}
+
+	for cn, c := range cc {
+		c := c
+
+		t.Run(cn, func(t *testing.T) {
+			t.Parallel()
+
+			err := readCSVRecords(strings.NewReader(c.Text), func(Series, Candle) error {
+				return assert.AnError
+			})
+
+			equalError(t, c.Err, err)
+		})
+	}
+}
+
+// testFrames encodes the candles of the series as frame lines.
+func testFrames(t *testing.T, p Pair, i Interval, cc ...Candle) string {
+	t.Helper()
+
+	var buf bytes.Buffer
+
+	for _, c := range cc {
+		require.NoError(t, writeFrame(&buf, Series{Pair: p, Interval: i}, c))
+	}
+
+	return buf.String()
+}
+
+// csvText prepends the CSV store format header to the records.
+func csvText(rr ...string) string {
+	return strings.Join(csvHeader, ",") + "\n" + strings.Join(rr, "\n") + "\n"
+}
@@ -0,0 +1,244 @@
+package chartype
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+)
+
+var (
+	// ErrNoSources is returned when a composite source is created
+	// without any sources.
+	ErrNoSources = newError(CodeInvalidArgument, "no sources")
+
+	// ErrSourceUnavailable is returned when none of the sources could
+	// serve the request.
+	ErrSourceUnavailable = newError(CodeUnavailable, "source unavailable")
+)
+
+// FailoverHooks holds optional functions called on failover source
+// events, e.g. to log outages and collect metrics.
+type FailoverHooks struct {
+	// OnFailure is called when the source with the index fails to
+	// serve a request or its health check fails.
+	OnFailure func(source int, err error)
+
+	// OnSwitch is called when a request is served by a different
+	// source than the previous one. From is -1 for the first served
+	// request.
+	OnSwitch func(from, to int)
+}
+
+// FailoverSource is a candle source that serves requests from the
+// first healthy source of several ones, ordered by priority. A source
+// that fails a request is considered unhealthy and skipped until the
+// cooldown elapses or until its health check, if it implements
+// HealthChecker, succeeds, so requests switch back to the preferred
+// source once it recovers.
+//
+// FailoverSource is safe for concurrent use.
+type FailoverSource struct {
+	sources  []CandleSource
+	cooldown time.Duration
+	hooks    FailoverHooks
+	now      func() time.Time
+
+	mu     sync.Mutex
+	active int
+	down   []time.Time
+}
+
+// NewFailoverSource creates a new failover source on top of the
+// sources, ordered from the most to the least preferred one, that
+// skips failed sources for the cooldown.
+func NewFailoverSource(cooldown time.Duration, h FailoverHooks, ss ...CandleSource) (*FailoverSource, error) {
+	if len(ss) == 0 {
+		return nil, ErrNoSources
+	}
+
+	if cooldown < 0 {
+		return nil, ErrInvalidDuration
+	}
+
+	return &FailoverSource{
+		sources:  ss,
+		cooldown: cooldown,
+		hooks:    h,
+		now:      time.Now,
+		active:   -1,
+		down:     make([]time.Time, len(ss)),
+	}, nil
+}
+
+// Candles returns candles matching the request from the first healthy
+// source that serves it. ErrSourceUnavailable is returned if every
+// source is unhealthy or fails.
+func (fs *FailoverSource) Candles(ctx context.Context, cr CandleRequest) ([]Candle, error) {
+	if err := cr.Validate(); err != nil {
+		return nil, err
+	}
+
+	for i, s := range fs.sources {
+		if !fs.healthy(i) {
+			continue
+		}
+
+		cc, err := s.Candles(ctx, cr)
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil, ctx.Err()
+			}
+
+			fs.fail(i, err)
+
+			continue
+		}
+
+		fs.restore(i)
+		fs.switchTo(i)
+
+		return cc, nil
+	}
+
+	return nil, ErrSourceUnavailable
+}
+
+// Stream retrieves candles matching the request and replays them.
+func (fs *FailoverSource) Stream(ctx context.Context, cr CandleRequest) (*Subscription, error) {
+	return streamCandles(ctx, fs, cr)
+}
+
+// Check runs health checks of unhealthy sources that implement
+// HealthChecker and marks the ones that pass as healthy again.
+func (fs *FailoverSource) Check(ctx context.Context) {
+	for i, s := range fs.sources {
+		hc, ok := s.(HealthChecker)
+		if !ok || fs.healthy(i) {
+			continue
+		}
+
+		if err := hc.CheckHealth(ctx); err != nil {
+			fs.fail(i, err)
+			continue
+		}
+
+		fs.restore(i)
+	}
+}
+
+// Active returns the index of the source that served the last
+// request or -1 if no request was served yet.
+func (fs *FailoverSource) Active() int {
+	fs.mu.Lock()
+	defer fs.mu.Unlock()
+
+	return fs.active
+}
+
+// Healthy returns whether each of the sources is currently considered
+// healthy.
+func (fs *FailoverSource) Healthy() []bool {
+	res := make([]bool, len(fs.sources))
+	for i := range res {
+		res[i] = fs.healthy(i)
+	}
+
+	return res
+}
+
+// Follow polls the sources for the pair's interval candles starting
+// at the provided time and calls the function with every closed
+// candle in chronological order, until the context is done or the
+// function returns an error. Each poll requests candles since the
+// last emitted one, so candles missed while all sources were
+// unavailable are backfilled once one of them recovers.
+func (fs *FailoverSource) Follow(ctx context.Context, p Pair, i Interval, from time.Time, poll time.Duration, fn func(Candle) error) error {
+	if _, err := newSeriesKey(p, i); err != nil {
+		return err
+	}
+
+	if from.IsZero() {
+		return ErrInvalidTimeRange
+	}
+
+	if poll <= 0 {
+		return ErrInvalidDuration
+	}
+
+	t := time.NewTicker(poll)
+	defer t.Stop()
+
+	next := from
+
+	for {
+		if to := i.Truncate(fs.now()); next.Before(to) {
+			cc, err := fs.Candles(ctx, CandleRequest{
+				Pair:     p,
+				Interval: i,
+				Range:    TimeRange{From: next, To: to},
+			})
+
+			if err != nil && !errors.Is(err, ErrSourceUnavailable) {
+				return err
+			}
+
+			for _, c := range cc {
+				if c.Timestamp.Before(next) || !c.Timestamp.Before(to) {
+					continue
+				}
+
+				if err = fn(c); err != nil {
+					return err
+				}
+
+				next = c.Timestamp.Add(i.Duration())
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-t.C:
+		}
+	}
+}
+
+// healthy checks whether the source is not in its cooldown.
+func (fs *FailoverSource) healthy(i int) bool {
+	fs.mu.Lock()
+	defer fs.mu.Unlock()
+
+	return !fs.now().Before(fs.down[i])
+}
+
+// fail marks the source as unhealthy for the cooldown.
+func (fs *FailoverSource) fail(i int, err error) {
+	fs.mu.Lock()
+	fs.down[i] = fs.now().Add(fs.cooldown)
+	fs.mu.Unlock()
+
+	if fs.hooks.OnFailure != nil {
+		fs.hooks.OnFailure(i, err)
+	}
+}
+
+// restore marks the source as healthy.
+func (fs *FailoverSource) restore(i int) {
+	fs.mu.Lock()
+	defer fs.mu.Unlock()
+
+	fs.down[i] = time.Time{}
+}
+
+// switchTo records the source as the active one.
+func (fs *FailoverSource) switchTo(i int) {
+	fs.mu.Lock()
+	prev := fs.active
+	fs.active = i
+	fs.mu.Unlock()
+
+	if prev != i && fs.hooks.OnSwitch != nil {
+		fs.hooks.OnSwitch(prev, i)
+	}
+}
@@ -0,0 +1,341 @@
+package chartype
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// testSource is a candle source serving a fixed candle series.
+type testSource struct {
+	mu      sync.Mutex
+	candles []Candle
+	err     error
+	health  error
+	calls   int
+}
+
+func (ts *testSource) Candles(_ context.Context, cr CandleRequest) ([]Candle, error) {
+	ts.mu.Lock()
+	defer ts.mu.Unlock()
+
+	ts.calls++
+
+	if ts.err != nil {
+		return nil, ts.err
+	}
+
+	var res []Candle
+
+	for _, c := range ts.candles {
+		if cr.Range.Contains(c.Timestamp) {
+			res = append(res, c)
+		}
+	}
+
+	return limitCandles(res, cr.Limit), nil
+}
+
+func (ts *testSource) CheckHealth(context.Context) error {
+	ts.mu.Lock()
+	defer ts.mu.Unlock()
+
+	return ts.health
+}
+
+func (ts *testSource) setErr(err error) {
+	ts.mu.Lock()
+	ts.err = err
+	ts.mu.Unlock()
+}
+
+func (ts *testSource) Calls() int {
+	ts.mu.Lock()
+	defer ts.mu.Unlock()
+
+	return ts.calls
+}
+
+// plainSource is a candle source without health checks.
+type plainSource struct {
+	CandleSource
+}
+
+func Test_NewFailoverSource(t *testing.T) {
+	cc := map[string]struct {
+		Cooldown time.Duration
+		Sources  []CandleSource
+		Err      error
+	}{
+		"No sources": {
+			Cooldown: time.Minute,
+			Err:      ErrNoSources,
+		},
+		"Invalid cooldown": {
+			Cooldown: -1,
+			Sources:  []CandleSource{&testSource{}},
+			Err:      ErrInvalidDuration,
+		},
+		"Successful creation": {
+			Cooldown: time.Minute,
+			Sources:  []CandleSource{&testSource{}},
+		},
+	}
+
+	for cn, c := range cc {
+		c := c
+
+		t.Run(cn, func(t *testing.T) {
+			t.Parallel()
+
+			fs, err := NewFailoverSource(c.Cooldown, FailoverHooks{}, c.Sources...)
+			assert.Equal(t, c.Err, err)
+			if err != nil {
+				return
+			}
+
+			assert.Equal(t, -1, fs.Active())
+			assert.Equal(t, []bool{true}, fs.Healthy())
+		})
+	}
+}
+
+func Test_FailoverSource_Candles(t *testing.T) {
+	tm := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	now := tm
+
+	primary := &testSource{candles: []Candle{testCandle(tm, 1, 1, 1, 1, 1)}}
+	secondary := &testSource{candles: []Candle{testCandle(tm, 2, 2, 2, 2, 2)}}
+
+	var (
+		failures []int
+		switches [][2]int
+	)
+
+	fs, err := NewFailoverSource(time.Minute, FailoverHooks{
+		OnFailure: func(s int, err error) {
+			assert.Equal(t, assert.AnError, err)
+			failures = append(failures, s)
+		},
+		OnSwitch: func(from, to int) {
+			switches = append(switches, [2]int{from, to})
+		},
+	}, primary, secondary)
+	require.NoError(t, err)
+
+	fs.now = func() time.Time { return now }
+
+	cr := CandleRequest{
+		Pair:     Pair{Base: "BTC", Quote: "USD"},
+		Interval: IntervalMinute,
+		Range:    TimeRange{From: tm, To: tm.Add(time.Hour)},
+	}
+
+	_, err = fs.Candles(context.Background(), CandleRequest{})
+	assert.Equal(t, ErrInvalidPair, err)
+
+	res, err := fs.Candles(context.Background(), cr)
+	require.NoError(t, err)
+	assert.Equal(t, primary.candles, res)
+	assert.Equal(t, 0, fs.Active())
+
+	primary.setErr(assert.AnError)
+
+	res, err = fs.Candles(context.Background(), cr)
+	require.NoError(t, err)
+	assert.Equal(t, secondary.candles, res)
+	assert.Equal(t, 1, fs.Active())
+	assert.Equal(t, []bool{false, true}, fs.Healthy())
+
+	// primary is skipped during its cooldown
+	_, err = fs.Candles(context.Background(), cr)
+	require.NoError(t, err)
+	assert.Equal(t, 2, primary.Calls())
+
+	secondary.setErr(assert.AnError)
+
+	_, err = fs.Candles(context.Background(), cr)
+	assert.Equal(t, ErrSourceUnavailable, err)
+
+	_, err = fs.Candles(context.Background(), cr)
+	assert.Equal(t, ErrSourceUnavailable, err)
+	assert.Equal(t, 2, primary.Calls())
+	assert.Equal(t, 3, secondary.Calls())
+
+	// primary is retried after its cooldown
+	primary.setErr(nil)
+	now = now.Add(time.Minute)
+
+	res, err = fs.Candles(context.Background(), cr)
+	require.NoError(t, err)
+	assert.Equal(t, primary.candles, res)
+	assert.Equal(t, 0, fs.Active())
+
+	assert.Equal(t, []int{0, 1}, failures)
+	assert.Equal(t, [][2]int{{-1, 0}, {0, 1}, {1, 0}}, switches)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	primary.setErr(context.Canceled)
+
+	_, err = fs.Candles(ctx, cr)
+	assert.Equal(t, context.Canceled, err)
+	assert.Equal(t, []bool{true, true}, fs.Healthy())
+}
+
+func Test_FailoverSource_Check(t *testing.T) {
+	tm := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	checked := &testSource{err: assert.AnError, health: assert.AnError}
+	plain := &plainSource{CandleSource: &testSource{err: assert.AnError}}
+
+	var failures int
+
+	fs, err := NewFailoverSource(time.Minute, FailoverHooks{
+		OnFailure: func(int, error) { failures++ },
+	}, checked, plain)
+	require.NoError(t, err)
+
+	fs.now = func() time.Time { return tm }
+
+	_, err = fs.Candles(context.Background(), CandleRequest{
+		Pair:     Pair{Base: "BTC", Quote: "USD"},
+		Interval: IntervalMinute,
+		Range:    TimeRange{From: tm, To: tm.Add(time.Hour)},
+	})
+	assert.Equal(t, ErrSourceUnavailable, err)
+	assert.Equal(t, 2, failures)
+
+	fs.Check(context.Background())
+	assert.Equal(t, []bool{false, false}, fs.Healthy())
+	assert.Equal(t, 3, failures)
+
+	checked.mu.Lock()
+	checked.health = nil
+	checked.mu.Unlock()
+
+	fs.Check(context.Background())
+	assert.Equal(t, []bool{true, false}, fs.Healthy())
+	assert.Equal(t, 3, failures)
+}
+
+func Test_FailoverSource_Follow(t *testing.T) {
+	tm := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	p := Pair{Base: "BTC", Quote: "USD"}
+
+	var series []Candle
+	for i := 0; i < 5; i++ {
+		series = append(series, testCandle(tm.Add(time.Duration(i)*time.Minute), 1, 1, 1, 1, 1))
+	}
+
+	src := &testSource{candles: series}
+
+	fs, err := NewFailoverSource(0, FailoverHooks{}, src)
+	require.NoError(t, err)
+
+	var mu sync.Mutex
+
+	now := tm.Add(90 * time.Second)
+	fs.now = func() time.Time {
+		mu.Lock()
+		defer mu.Unlock()
+
+		return now
+	}
+
+	advance := func(d time.Duration) {
+		mu.Lock()
+		now = now.Add(d)
+		mu.Unlock()
+	}
+
+	err = fs.Follow(context.Background(), Pair{}, IntervalMinute, tm, time.Millisecond, nil)
+	assert.Equal(t, ErrInvalidPair, err)
+
+	err = fs.Follow(context.Background(), p, IntervalMinute, time.Time{}, time.Millisecond, nil)
+	assert.Equal(t, ErrInvalidTimeRange, err)
+
+	err = fs.Follow(context.Background(), p, IntervalMinute, tm, 0, nil)
+	assert.Equal(t, ErrInvalidDuration, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	out := make(chan Candle)
+	done := make(chan error, 1)
+
+	go func() {
+		done <- fs.Follow(ctx, p, IntervalMinute, tm, time.Millisecond, func(c Candle) error {
+			out <- c
+			return nil
+		})
+	}()
+
+	// only closed candles are emitted
+	assert.Equal(t, series[0], <-out)
+
+	src.setErr(assert.AnError)
+	advance(3 * time.Minute)
+	time.Sleep(10 * time.Millisecond)
+	src.setErr(nil)
+
+	// candles missed during the outage are backfilled
+	assert.Equal(t, series[1], <-out)
+	assert.Equal(t, series[2], <-out)
+	assert.Equal(t, series[3], <-out)
+
+	cancel()
+	assert.Equal(t, context.Canceled, <-done)
+
+	err = fs.Follow(context.Background(), p, IntervalMinute, tm, time.Millisecond, func(Candle) error {
+		return assert.AnError
+	})
+	assert.Equal(t, assert.AnError, err)
+
+	src.setErr(context.Canceled)
+
+	ctx, cancel = context.WithCancel(context.Background())
+	cancel()
+
+	err = fs.Follow(ctx, p, IntervalMinute, tm, time.Millisecond, nil)
+	assert.Equal(t, context.Canceled, err)
+
+	// candles outside the requested range are skipped
+	fs, err = NewFailoverSource(0, FailoverHooks{}, CandleSourceFunc(func(context.Context, CandleRequest) ([]Candle, error) {
+		return series, nil
+	}))
+	require.NoError(t, err)
+
+	fs.now = func() time.Time { return tm.Add(150 * time.Second) }
+
+	var res []Candle
+
+	err = fs.Follow(context.Background(), p, IntervalMinute, tm.Add(time.Minute), time.Millisecond, func(c Candle) error {
+		res = append(res, c)
+		return assert.AnError
+	})
+	assert.Equal(t, assert.AnError, err)
+	assert.Equal(t, []Candle{series[1]}, res)
+}
+
+func Test_FailoverSource_Stream(t *testing.T) {
+	tm := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	src := &testSource{candles: []Candle{testCandle(tm, 1, 1, 1, 1, 1)}}
+
+	fs, err := NewFailoverSource(time.Minute, FailoverHooks{}, src)
+	require.NoError(t, err)
+
+	sub, err := fs.Stream(context.Background(), CandleRequest{
+		Pair:     Pair{Base: "BTC", Quote: "USD"},
+		Interval: IntervalMinute,
+		Range:    TimeRange{From: tm, To: tm.Add(time.Hour)},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, src.candles, drainCandles(t, sub))
+}
@@ -0,0 +1,136 @@
+package chartype
+
+import (
+	"sync"
+	"time"
+)
+
+const (
+	// SequenceInOrder specifies that the message has the expected
+	// sequence number.
+	SequenceInOrder SequenceStatus = iota + 1
+
+	// SequenceStale specifies that the message's sequence number is
+	// lower than the expected one, i.e. it is a duplicate or arrived
+	// late, and should be ignored.
+	SequenceStale
+
+	// SequenceGap specifies that the message's sequence number is
+	// higher than the expected one, i.e. messages were lost and the
+	// consumer should resynchronize its state.
+	SequenceGap
+)
+
+var (
+	// ErrSequenceGap is returned when messages of a sequenced feed
+	// were lost.
+	ErrSequenceGap = newError(CodeGapDetected, "sequence gap detected")
+)
+
+// SequenceStatus specifies how a message's sequence number relates
+// to the expected one.
+type SequenceStatus int
+
+// SequenceTracker tracks sequence numbers of feed messages and
+// detects duplicates and gaps. The first tracked number is accepted
+// as is unless the expected one is set with Reset.
+//
+// SequenceTracker is not safe for concurrent use.
+type SequenceTracker struct {
+	next   uint64
+	synced bool
+	gaps   int
+}
+
+// NewSequenceTracker creates a new sequence tracker.
+func NewSequenceTracker() *SequenceTracker {
+	return &SequenceTracker{}
+}
+
+// Track checks the message's sequence number against the expected
+// one. The next expected number is advanced past in-order and gap
+// messages, so that tracking continues after a gap is reported.
+func (st *SequenceTracker) Track(seq uint64) SequenceStatus {
+	switch {
+	case !st.synced || seq == st.next:
+		st.synced = true
+		st.next = seq + 1
+
+		return SequenceInOrder
+	case seq < st.next:
+		return SequenceStale
+	default:
+		st.next = seq + 1
+		st.gaps++
+
+		return SequenceGap
+	}
+}
+
+// Next returns the next expected sequence number and whether it is
+// known.
+func (st *SequenceTracker) Next() (uint64, bool) {
+	return st.next, st.synced
+}
+
+// Reset sets the next expected sequence number, e.g. after the
+// consumer resynchronizes from a snapshot.
+func (st *SequenceTracker) Reset(next uint64) {
+	st.next = next
+	st.synced = true
+}
+
+// Gaps returns the number of detected gaps.
+func (st *SequenceTracker) Gaps() int {
+	return st.gaps
+}
+
+// Watchdog calls a function when no heartbeat is received within
+// the timeout, e.g. to reconnect a silent feed. After the function is
+// called, the next heartbeat rearms the watchdog.
+//
+// Watchdog is safe for concurrent use.
+type Watchdog struct {
+	timeout time.Duration
+
+	mu      sync.Mutex
+	timer   *time.Timer
+	stopped bool
+}
+
+// NewWatchdog creates a new started watchdog that calls the function
+// in its own goroutine whenever the timeout elapses without a
+// heartbeat.
+func NewWatchdog(timeout time.Duration, onTimeout func()) (*Watchdog, error) {
+	if timeout <= 0 {
+		return nil, ErrInvalidDuration
+	}
+
+	return &Watchdog{
+		timeout: timeout,
+		timer:   time.AfterFunc(timeout, onTimeout),
+	}, nil
+}
+
+// Beat records a heartbeat and restarts the timeout. It has no effect
+// after the watchdog is stopped.
+func (w *Watchdog) Beat() {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if w.stopped {
+		return
+	}
+
+	w.timer.Reset(w.timeout)
+}
+
+// Stop stops the watchdog. The function is not called after Stop
+// returns unless it has already been started.
+func (w *Watchdog) Stop() {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	w.stopped = true
+	w.timer.Stop()
+}
@@ -0,0 +1,91 @@
+package chartype
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_SequenceTracker(t *testing.T) {
+	st := NewSequenceTracker()
+
+	_, ok := st.Next()
+	assert.False(t, ok)
+
+	assert.Equal(t, SequenceInOrder, st.Track(10))
+	assert.Equal(t, SequenceInOrder, st.Track(11))
+	assert.Equal(t, SequenceStale, st.Track(11))
+	assert.Equal(t, SequenceStale, st.Track(5))
+	assert.Equal(t, SequenceGap, st.Track(14))
+	assert.Equal(t, SequenceInOrder, st.Track(15))
+	assert.Equal(t, 1, st.Gaps())
+
+	next, ok := st.Next()
+	assert.True(t, ok)
+	assert.Equal(t, uint64(16), next)
+
+	st.Reset(100)
+	assert.Equal(t, SequenceStale, st.Track(16))
+	assert.Equal(t, SequenceInOrder, st.Track(100))
+
+	st = NewSequenceTracker()
+	st.Reset(3)
+	assert.Equal(t, SequenceGap, st.Track(4))
+}
+
+func Test_NewWatchdog(t *testing.T) {
+	_, err := NewWatchdog(0, func() {})
+	assert.Equal(t, ErrInvalidDuration, err)
+}
+
+func Test_Watchdog(t *testing.T) {
+	timeouts := make(chan struct{}, 1)
+
+	w, err := NewWatchdog(20*time.Millisecond, func() {
+		timeouts <- struct{}{}
+	})
+	require.NoError(t, err)
+
+	select {
+	case <-timeouts:
+	case <-time.After(time.Second):
+		t.Fatal("watchdog did not time out")
+	}
+
+	w.Beat()
+
+	select {
+	case <-timeouts:
+	case <-time.After(time.Second):
+		t.Fatal("watchdog was not rearmed")
+	}
+
+	w.Stop()
+	w.Beat()
+
+	select {
+	case <-timeouts:
+		t.Fatal("stopped watchdog timed out")
+	case <-time.After(50 * time.Millisecond):
+	}
+}
+
+func Test_Watchdog_Beat(t *testing.T) {
+	timeouts := make(chan struct{}, 1)
+
+	w, err := NewWatchdog(200*time.Millisecond, func() {
+		timeouts <- struct{}{}
+	})
+	require.NoError(t, err)
+
+	defer w.Stop()
+
+	for i := 0; i < 15; i++ {
+		time.Sleep(20 * time.Millisecond)
+		w.Beat()
+	}
+
+	assert.Empty(t, timeouts)
+}
@@ -0,0 +1,301 @@
+// Package flatbuf provides a FlatBuffers schema for chartype's
+// structures, zero-copy accessors generated from it and converters
+// between both representations.
+package flatbuf
+
+import (
+	"time"
+
+	flatbuffers "github.com/google/flatbuffers/go"
+	"github.com/jellydator/chartype"
+	"github.com/shopspring/decimal"
+)
+
+var (
+	// ErrInvalidBuffer is returned when provided buffer is too short
+	// to contain a FlatBuffers root table.
+	ErrInvalidBuffer = &chartype.Error{
+		Code:    chartype.CodeParseFailure,
+		Message: "invalid buffer",
+	}
+)
+
+// BuildCandle serializes the candle into the builder and returns
+// its offset.
+func BuildCandle(b *flatbuffers.Builder, c chartype.Candle) flatbuffers.UOffsetT {
+	o := b.CreateString(c.Open.String())
+	h := b.CreateString(c.High.String())
+	l := b.CreateString(c.Low.String())
+	cl := b.CreateString(c.Close.String())
+	v := b.CreateString(c.Volume.String())
+
+	var adj, delta flatbuffers.UOffsetT
+	if c.AdjClose != nil {
+		adj = b.CreateString(c.AdjClose.String())
+	}
+
+	if c.Delta != nil {
+		delta = b.CreateString(c.Delta.String())
+	}
+
+	CandleStart(b)
+	CandleAddTimestamp(b, c.Timestamp.UnixNano())
+	CandleAddOpen(b, o)
+	CandleAddHigh(b, h)
+	CandleAddLow(b, l)
+	CandleAddClose(b, cl)
+	CandleAddVolume(b, v)
+
+	if c.AdjClose != nil {
+		CandleAddAdjClose(b, adj)
+	}
+
+	if c.Delta != nil {
+		CandleAddDelta(b, delta)
+	}
+
+	return CandleEnd(b)
+}
+
+// BuildTicker serializes the ticker into the builder and returns
+// its offset.
+func BuildTicker(b *flatbuffers.Builder, t chartype.Ticker) flatbuffers.UOffsetT {
+	l := b.CreateString(t.Last.String())
+	a := b.CreateString(t.Ask.String())
+	bd := b.CreateString(t.Bid.String())
+	c := b.CreateString(t.Change.String())
+	pc := b.CreateString(t.PercentChange.Points().String())
+	v := b.CreateString(t.Volume.String())
+
+	TickerStart(b)
+	TickerAddLast(b, l)
+	TickerAddAsk(b, a)
+	TickerAddBid(b, bd)
+	TickerAddChange(b, c)
+	TickerAddPercentChange(b, pc)
+	TickerAddVolume(b, v)
+
+	return TickerEnd(b)
+}
+
+// BuildTrade serializes the trade into the builder and returns
+// its offset.
+func BuildTrade(b *flatbuffers.Builder, t chartype.Trade) flatbuffers.UOffsetT {
+	id := b.CreateString(t.ID)
+	p := b.CreateString(t.Price.String())
+	a := b.CreateString(t.Amount.String())
+
+	TradeStart(b)
+	TradeAddId(b, id)
+	TradeAddTimestamp(b, t.Timestamp.UnixNano())
+	TradeAddPrice(b, p)
+	TradeAddAmount(b, a)
+
+	// side values match chartype's side constants
+	TradeAddSide(b, Side(t.Side))
+
+	return TradeEnd(b)
+}
+
+// BuildPacket serializes the packet into the builder and returns
+// its offset.
+func BuildPacket(b *flatbuffers.Builder, p chartype.Packet) flatbuffers.UOffsetT {
+	t := BuildTicker(b, p.Ticker)
+
+	oo := make([]flatbuffers.UOffsetT, len(p.Candles))
+	for i, c := range p.Candles {
+		oo[i] = BuildCandle(b, c)
+	}
+
+	PacketStartCandlesVector(b, len(oo))
+
+	for i := len(oo) - 1; i >= 0; i-- {
+		b.PrependUOffsetT(oo[i])
+	}
+
+	cc := b.EndVector(len(oo))
+
+	PacketStart(b)
+	PacketAddTicker(b, t)
+	PacketAddCandles(b, cc)
+
+	return PacketEnd(b)
+}
+
+// MarshalPacket serializes the packet into a finished FlatBuffers
+// buffer that can be read with GetRootAsPacket.
+func MarshalPacket(p chartype.Packet) []byte {
+	b := flatbuffers.NewBuilder(256 * (len(p.Candles) + 1))
+	b.Finish(BuildPacket(b, p))
+
+	return b.FinishedBytes()
+}
+
+// MarshalCandle serializes the candle into a finished FlatBuffers
+// buffer that can be read with GetRootAsCandle.
+func MarshalCandle(c chartype.Candle) []byte {
+	b := flatbuffers.NewBuilder(256)
+	b.Finish(BuildCandle(b, c))
+
+	return b.FinishedBytes()
+}
+
+// UnmarshalCandle deserializes a finished FlatBuffers buffer into
+// a new candle. Only buffers from trusted sources should be provided.
+func UnmarshalCandle(d []byte) (chartype.Candle, error) {
+	if len(d) < flatbuffers.SizeUOffsetT {
+		return chartype.Candle{}, ErrInvalidBuffer
+	}
+
+	return ToCandle(GetRootAsCandle(d, 0))
+}
+
+// MarshalTicker serializes the ticker into a finished FlatBuffers
+// buffer that can be read with GetRootAsTicker.
+func MarshalTicker(t chartype.Ticker) []byte {
+	b := flatbuffers.NewBuilder(256)
+	b.Finish(BuildTicker(b, t))
+
+	return b.FinishedBytes()
+}
+
+// UnmarshalTicker deserializes a finished FlatBuffers buffer into
+// a new ticker. Only buffers from trusted sources should be provided.
+func UnmarshalTicker(d []byte) (chartype.Ticker, error) {
+	if len(d) < flatbuffers.SizeUOffsetT {
+		return chartype.Ticker{}, ErrInvalidBuffer
+	}
+
+	return ToTicker(GetRootAsTicker(d, 0))
+}
+
+// MarshalTrade serializes the trade into a finished FlatBuffers
+// buffer that can be read with GetRootAsTrade.
+func MarshalTrade(t chartype.Trade) []byte {
+	b := flatbuffers.NewBuilder(128)
+	b.Finish(BuildTrade(b, t))
+
+	return b.FinishedBytes()
+}
+
+// UnmarshalTrade deserializes a finished FlatBuffers buffer into
+// a new trade. Only buffers from trusted sources should be provided.
+func UnmarshalTrade(d []byte) (chartype.Trade, error) {
+	if len(d) < flatbuffers.SizeUOffsetT {
+		return chartype.Trade{}, ErrInvalidBuffer
+	}
+
+	return ToTrade(GetRootAsTrade(d, 0))
+}
+
+// UnmarshalPacket deserializes a finished FlatBuffers buffer into a
+// new packet. FlatBuffers does not verify buffers on read, so only
+// buffers from trusted sources should be provided.
+func UnmarshalPacket(d []byte) (chartype.Packet, error) {
+	if len(d) < flatbuffers.SizeUOffsetT {
+		return chartype.Packet{}, ErrInvalidBuffer
+	}
+
+	return ToPacket(GetRootAsPacket(d, 0))
+}
+
+// ToCandle copies values from the FlatBuffers candle into a new
+// chartype candle.
+func ToCandle(c *Candle) (chartype.Candle, error) {
+	res, err := chartype.ParseCandle(
+		time.Unix(0, c.Timestamp()).UTC(),
+		string(c.Open()),
+		string(c.High()),
+		string(c.Low()),
+		string(c.Close()),
+		string(c.Volume()),
+	)
+	if err != nil {
+		return chartype.Candle{}, err
+	}
+
+	if res.AdjClose, err = optionalDecimal(c.AdjClose()); err != nil {
+		return chartype.Candle{}, err
+	}
+
+	if res.Delta, err = optionalDecimal(c.Delta()); err != nil {
+		return chartype.Candle{}, err
+	}
+
+	return res, nil
+}
+
+// ToTicker copies values from the FlatBuffers ticker into a new
+// chartype ticker.
+func ToTicker(t *Ticker) (chartype.Ticker, error) {
+	return chartype.ParseTicker(
+		string(t.Last()),
+		string(t.Ask()),
+		string(t.Bid()),
+		string(t.Change()),
+		string(t.PercentChange()),
+		string(t.Volume()),
+	)
+}
+
+// ToTrade copies values from the FlatBuffers trade into a new
+// chartype trade.
+func ToTrade(t *Trade) (chartype.Trade, error) {
+	res, err := chartype.ParseTrade(
+		time.Unix(0, t.Timestamp()).UTC(),
+		string(t.Price()),
+		string(t.Amount()),
+		chartype.Side(t.Side()),
+	)
+	if err != nil {
+		return chartype.Trade{}, err
+	}
+
+	res.ID = string(t.Id())
+
+	return res, nil
+}
+
+// ToPacket copies values from the FlatBuffers packet into a new
+// chartype packet. Missing ticker results in a zero ticker.
+func ToPacket(p *Packet) (chartype.Packet, error) {
+	var (
+		res chartype.Packet
+		err error
+	)
+
+	if t := p.Ticker(nil); t != nil {
+		if res.Ticker, err = ToTicker(t); err != nil {
+			return chartype.Packet{}, err
+		}
+	}
+
+	res.Candles = make([]chartype.Candle, p.CandlesLength())
+
+	var c Candle
+
+	for i := range res.Candles {
+		p.Candles(&c, i)
+
+		if res.Candles[i], err = ToCandle(&c); err != nil {
+			return chartype.Packet{}, err
+		}
+	}
+
+	return res, nil
+}
+
+// optionalDecimal parses the stored string as a decimal. Nil is
+// returned if the value is not stored.
+func optionalDecimal(b []byte) (*decimal.Decimal, error) {
+	if b == nil {
+		return nil, nil
+	}
+
+	d, err := decimal.NewFromString(string(b))
+	if err != nil {
+		return nil, err
+	}
+
+	return &d, nil
+}
@@ -0,0 +1,276 @@
+package flatbuf
+
+import (
+	"testing"
+	"time"
+
+	flatbuffers "github.com/google/flatbuffers/go"
+	"github.com/jellydator/chartype"
+	"github.com/jellydator/chartype/chartypetest"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+)
+
+func testPacket() chartype.Packet {
+	adj := decimal.RequireFromString("7.5")
+	delta := decimal.RequireFromString("-2.5")
+
+	return chartype.Packet{
+		Ticker: chartype.Ticker{
+			Last:          decimal.NewFromInt(1),
+			Ask:           decimal.NewFromInt(2),
+			Bid:           decimal.NewFromInt(3),
+			Change:        decimal.NewFromInt(4),
+			PercentChange: chartype.NewPercent(decimal.RequireFromString("5.5")),
+			Volume:        decimal.NewFromInt(6),
+		},
+		Candles: []chartype.Candle{
+			{
+				Timestamp: time.Date(2020, 1, 1, 0, 0, 0, 1, time.UTC),
+				Open:      decimal.NewFromInt(1),
+				High:      decimal.NewFromInt(2),
+				Low:       decimal.NewFromInt(3),
+				Close:     decimal.NewFromInt(4),
+				Volume:    decimal.RequireFromString("0.123456789"),
+			},
+			{
+				Timestamp: time.Date(2020, 1, 1, 0, 1, 0, 0, time.UTC),
+				Open:      decimal.NewFromInt(5),
+				High:      decimal.NewFromInt(6),
+				Low:       decimal.NewFromInt(7),
+				Close:     decimal.NewFromInt(8),
+				Volume:    decimal.NewFromInt(9),
+				AdjClose:  &adj,
+				Delta:     &delta,
+			},
+		},
+	}
+}
+
+func Test_MarshalPacket(t *testing.T) {
+	p := testPacket()
+	d := MarshalPacket(p)
+
+	fp := GetRootAsPacket(d, 0)
+	assert.Equal(t, d, fp.Table().Bytes)
+	assert.Equal(t, 2, fp.CandlesLength())
+
+	var fc Candle
+
+	assert.True(t, fp.Candles(&fc, 1))
+	assert.Equal(t, "8", string(fc.Close()))
+	assert.Equal(t, fp.Table().Bytes, fc.Table().Bytes)
+	assert.Equal(t, "5.5", string(fp.Ticker(nil).PercentChange()))
+	assert.Equal(t, d, fp.Ticker(nil).Table().Bytes)
+
+	res, err := UnmarshalPacket(d)
+	assert.NoError(t, err)
+	assert.Equal(t, p, res)
+}
+
+func Test_UnmarshalPacket(t *testing.T) {
+	invalidCandle := func() []byte {
+		b := flatbuffers.NewBuilder(0)
+		o := b.CreateString("-")
+
+		CandleStart(b)
+		CandleAddOpen(b, o)
+		c := CandleEnd(b)
+
+		PacketStartCandlesVector(b, 1)
+		b.PrependUOffsetT(c)
+		cc := b.EndVector(1)
+
+		PacketStart(b)
+		PacketAddCandles(b, cc)
+		b.Finish(PacketEnd(b))
+
+		return b.FinishedBytes()
+	}
+
+	invalidTicker := func() []byte {
+		b := flatbuffers.NewBuilder(0)
+		l := b.CreateString("-")
+
+		TickerStart(b)
+		TickerAddLast(b, l)
+		tk := TickerEnd(b)
+
+		PacketStart(b)
+		PacketAddTicker(b, tk)
+		b.Finish(PacketEnd(b))
+
+		return b.FinishedBytes()
+	}
+
+	empty := func() []byte {
+		b := flatbuffers.NewBuilder(0)
+
+		PacketStart(b)
+		b.Finish(PacketEnd(b))
+
+		return b.FinishedBytes()
+	}
+
+	cc := map[string]struct {
+		Data   []byte
+		Result chartype.Packet
+		Err    error
+	}{
+		"Too short buffer": {
+			Data: []byte{1},
+			Err:  ErrInvalidBuffer,
+		},
+		"Invalid candle": {
+			Data: invalidCandle(),
+			Err:  assert.AnError,
+		},
+		"Invalid ticker": {
+			Data: invalidTicker(),
+			Err:  assert.AnError,
+		},
+		"Successful unmarshal of empty packet": {
+			Data:   empty(),
+			Result: chartype.Packet{Candles: []chartype.Candle{}},
+		},
+		"Successful unmarshal": {
+			Data:   MarshalPacket(testPacket()),
+			Result: testPacket(),
+		},
+	}
+
+	for cn, c := range cc {
+		c := c
+
+		t.Run(cn, func(t *testing.T) {
+			t.Parallel()
+
+			res, err := UnmarshalPacket(c.Data)
+			chartypetest.AssertErrorEqual(t, c.Err, err)
+			if err != nil {
+				return
+			}
+
+			assert.Equal(t, c.Result, res)
+		})
+	}
+}
+
+func Test_EmptyTables(t *testing.T) {
+	b := flatbuffers.NewBuilder(0)
+
+	CandleStart(b)
+	b.Finish(CandleEnd(b))
+
+	fc := GetRootAsCandle(b.FinishedBytes(), 0)
+	assert.Zero(t, fc.Timestamp())
+	assert.Nil(t, fc.Open())
+	assert.Nil(t, fc.AdjClose())
+	assert.Nil(t, fc.Delta())
+
+	b = flatbuffers.NewBuilder(0)
+
+	TickerStart(b)
+	b.Finish(TickerEnd(b))
+
+	ft := GetRootAsTicker(b.FinishedBytes(), 0)
+	assert.Nil(t, ft.Last())
+
+	b = flatbuffers.NewBuilder(0)
+
+	TradeStart(b)
+	b.Finish(TradeEnd(b))
+
+	ftr := GetRootAsTrade(b.FinishedBytes(), 0)
+	assert.Nil(t, ftr.Id())
+	assert.Nil(t, ftr.Price())
+	assert.Nil(t, ftr.Amount())
+	assert.Zero(t, ftr.Timestamp())
+	assert.Equal(t, SideUnknown, ftr.Side())
+
+	b = flatbuffers.NewBuilder(0)
+
+	PacketStart(b)
+	b.Finish(PacketEnd(b))
+
+	fp := GetRootAsPacket(b.FinishedBytes(), 0)
+	assert.Nil(t, fp.Ticker(nil))
+	assert.False(t, fp.Candles(&Candle{}, 0))
+	assert.Zero(t, fp.CandlesLength())
+}
+
+func Test_MarshalCandle(t *testing.T) {
+	c := testPacket().Candles[0]
+
+	res, err := UnmarshalCandle(MarshalCandle(c))
+	assert.NoError(t, err)
+	assert.Equal(t, c, res)
+
+	c = testPacket().Candles[1]
+
+	res, err = UnmarshalCandle(MarshalCandle(c))
+	assert.NoError(t, err)
+	assert.Equal(t, c, res)
+
+	_, err = UnmarshalCandle(nil)
+	assert.Equal(t, ErrInvalidBuffer, err)
+
+	for _, add := range []func(*flatbuffers.Builder, flatbuffers.UOffsetT){CandleAddAdjClose, CandleAddDelta} {
+		b := flatbuffers.NewBuilder(0)
+		v := b.CreateString("1")
+		inv := b.CreateString("-")
+
+		CandleStart(b)
+		CandleAddOpen(b, v)
+		CandleAddHigh(b, v)
+		CandleAddLow(b, v)
+		CandleAddClose(b, v)
+		CandleAddVolume(b, v)
+		add(b, inv)
+		b.Finish(CandleEnd(b))
+
+		_, err = UnmarshalCandle(b.FinishedBytes())
+		assert.Error(t, err)
+	}
+}
+
+func Test_MarshalTicker(t *testing.T) {
+	tk := testPacket().Ticker
+
+	res, err := UnmarshalTicker(MarshalTicker(tk))
+	assert.NoError(t, err)
+	assert.Equal(t, tk, res)
+
+	_, err = UnmarshalTicker(nil)
+	assert.Equal(t, ErrInvalidBuffer, err)
+}
+
+func Test_MarshalTrade(t *testing.T) {
+	tr := chartype.Trade{
+		ID:        "42",
+		Timestamp: time.Unix(1, 2).UTC(),
+		Price:     decimal.NewFromInt(3),
+		Amount:    decimal.RequireFromString("0.4"),
+		Side:      chartype.SideSell,
+	}
+
+	d := MarshalTrade(tr)
+	ft := GetRootAsTrade(d, 0)
+	assert.Equal(t, SideSell, ft.Side())
+	assert.Equal(t, d, ft.Table().Bytes)
+
+	res, err := UnmarshalTrade(d)
+	assert.NoError(t, err)
+	assert.Equal(t, tr, res)
+
+	_, err = UnmarshalTrade(nil)
+	assert.Equal(t, ErrInvalidBuffer, err)
+
+	_, err = UnmarshalTrade(MarshalTrade(chartype.Trade{}))
+	assert.Equal(t, chartype.ErrInvalidSide, err)
+}
+
+func Test_Side_String(t *testing.T) {
+	assert.Equal(t, "Buy", SideBuy.String())
+	assert.Equal(t, "Side(7)", Side(7).String())
+}
@@ -0,0 +1,116 @@
+package chartype
+
+import (
+	"sort"
+
+	"github.com/shopspring/decimal"
+)
+
+var (
+	// ErrInvalidTickSize is returned when negative tick size is being
+	// used.
+	ErrInvalidTickSize = newError(CodeInvalidArgument, "invalid tick size")
+)
+
+// FootprintLevel holds the volumes traded at a single price level of
+// a footprint candle.
+type FootprintLevel struct {
+	Price decimal.Decimal `json:"price" yaml:"price"`
+	Buy   decimal.Decimal `json:"buy" yaml:"buy"`
+	Sell  decimal.Decimal `json:"sell" yaml:"sell"`
+}
+
+// Delta returns the difference between level's buy and sell volumes.
+func (fl FootprintLevel) Delta() decimal.Decimal {
+	return fl.Buy.Sub(fl.Sell)
+}
+
+// FootprintCandle is a candle that additionally records buy and sell
+// volumes traded at each of its price levels, as used by footprint
+// and order flow charts. Levels are ordered by price in ascending
+// order.
+type FootprintCandle struct {
+	Candle `yaml:",inline"`
+
+	Levels []FootprintLevel `json:"levels" yaml:"levels"`
+}
+
+// Reduce returns the plain candle of the footprint candle.
+func (fc FootprintCandle) Reduce() Candle {
+	return fc.Candle
+}
+
+// Footprints aggregates the trades into interval-long footprint
+// candles. Trade prices are rounded down to a multiple of the tick
+// size to form price levels; zero tick size groups trades by their
+// exact prices.
+func (tt Trades) Footprints(i Interval, tick decimal.Decimal) ([]FootprintCandle, error) {
+	if tick.IsNegative() {
+		return nil, ErrInvalidTickSize
+	}
+
+	cc, err := tt.Candles(i)
+	if err != nil {
+		return nil, err
+	}
+
+	res := make([]FootprintCandle, len(cc))
+	for j, c := range cc {
+		res[j].Candle = c
+	}
+
+	var (
+		j      int
+		levels map[string]*FootprintLevel
+	)
+
+	flush := func() {
+		ll := make([]FootprintLevel, 0, len(levels))
+		for _, l := range levels {
+			ll = append(ll, *l)
+		}
+
+		sort.Slice(ll, func(a, b int) bool {
+			return ll[a].Price.LessThan(ll[b].Price)
+		})
+
+		res[j].Levels = ll
+	}
+
+	for k, t := range tt {
+		if ts := i.Truncate(t.Timestamp); k == 0 || !ts.Equal(res[j].Timestamp) {
+			if k > 0 {
+				flush()
+				j++
+			}
+
+			levels = make(map[string]*FootprintLevel)
+		}
+
+		p := t.Price
+		if !tick.IsZero() {
+			p = p.Div(tick).Floor().Mul(tick)
+		}
+
+		key := p.String()
+
+		l, ok := levels[key]
+		if !ok {
+			l = &FootprintLevel{Price: p, Buy: decimal.Zero, Sell: decimal.Zero}
+			levels[key] = l
+		}
+
+		switch t.Side {
+		case SideBuy:
+			l.Buy = l.Buy.Add(t.Amount)
+		case SideSell:
+			l.Sell = l.Sell.Add(t.Amount)
+		}
+	}
+
+	if len(tt) > 0 {
+		flush()
+	}
+
+	return res, nil
+}
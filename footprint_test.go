@@ -0,0 +1,98 @@
+package chartype
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+)
+
+func Test_FootprintLevel_Delta(t *testing.T) {
+	fl := FootprintLevel{Buy: decimal.NewFromInt(2), Sell: decimal.NewFromInt(5)}
+	assert.Equal(t, "-3", fl.Delta().String())
+}
+
+func Test_Trades_Footprints(t *testing.T) {
+	tm := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	tt := Trades{
+		testSideTrade(tm, 10, 1, SideBuy),
+		testSideTrade(tm.Add(time.Second), 11, 2, SideSell),
+		testSideTrade(tm.Add(2*time.Second), 10, 3, SideSell),
+		testSideTrade(tm.Add(3*time.Second), 12, 4, SideBuy),
+		testSideTrade(tm.Add(2*time.Minute), 13, 5, SideBuy),
+	}
+
+	_, err := tt.Footprints(IntervalMinute, decimal.NewFromInt(-1))
+	assert.Equal(t, ErrInvalidTickSize, err)
+
+	_, err = tt.Footprints(0, decimal.Zero)
+	assert.Equal(t, ErrInvalidInterval, err)
+
+	res, err := Trades{}.Footprints(IntervalMinute, decimal.Zero)
+	assert.NoError(t, err)
+	assert.Empty(t, res)
+
+	level := func(p, b, s int64) FootprintLevel {
+		return FootprintLevel{Price: decimal.NewFromInt(p), Buy: decimal.NewFromInt(b), Sell: decimal.NewFromInt(s)}
+	}
+
+	assertLevels := func(t *testing.T, exp, act []FootprintLevel) {
+		t.Helper()
+
+		require.Len(t, act, len(exp))
+
+		for i := range exp {
+			assert.True(t, exp[i].Price.Equal(act[i].Price), "price %d", i)
+			assert.True(t, exp[i].Buy.Equal(act[i].Buy), "buy %d", i)
+			assert.True(t, exp[i].Sell.Equal(act[i].Sell), "sell %d", i)
+		}
+	}
+
+	res, err = tt.Footprints(IntervalMinute, decimal.Zero)
+	require.NoError(t, err)
+	require.Len(t, res, 2)
+
+	assertEqualCandles(t, []Candle{
+		testBuiltCandle(tm, 10, 12, 10, 12, 10, 0),
+		testBuiltCandle(tm.Add(2*time.Minute), 13, 13, 13, 13, 5, 5),
+	}, []Candle{res[0].Reduce(), res[1].Reduce()})
+	assertLevels(t, []FootprintLevel{level(10, 1, 3), level(11, 0, 2), level(12, 4, 0)}, res[0].Levels)
+	assertLevels(t, []FootprintLevel{level(13, 5, 0)}, res[1].Levels)
+
+	res, err = tt.Footprints(IntervalMinute, decimal.NewFromInt(2))
+	require.NoError(t, err)
+	assertLevels(t, []FootprintLevel{level(10, 1, 5), level(12, 4, 0)}, res[0].Levels)
+	assertLevels(t, []FootprintLevel{level(12, 5, 0)}, res[1].Levels)
+}
+
+func Test_FootprintCandle_Encoding(t *testing.T) {
+	tm := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	fc := FootprintCandle{
+		Candle: testCandle(tm, 1, 2, 1, 2, 3),
+		Levels: []FootprintLevel{{Price: decimal.NewFromInt(1), Buy: decimal.NewFromInt(2), Sell: decimal.NewFromInt(1)}},
+	}
+
+	d, err := json.Marshal(fc)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"timestamp":"2020-01-01T00:00:00Z","open":"1","high":"2","low":"1","close":"2","volume":"3",
+		"levels":[{"price":"1","buy":"2","sell":"1"}]}`, string(d))
+
+	var res FootprintCandle
+
+	require.NoError(t, json.Unmarshal(d, &res))
+	assertEqualCandles(t, []Candle{fc.Candle}, []Candle{res.Candle})
+	assert.Equal(t, "2", res.Levels[0].Buy.String())
+
+	d, err = yaml.Marshal(fc)
+	require.NoError(t, err)
+
+	res = FootprintCandle{}
+
+	require.NoError(t, yaml.Unmarshal(d, &res))
+	assertEqualCandles(t, []Candle{fc.Candle}, []Candle{res.Candle})
+	assert.Equal(t, "1", res.Levels[0].Sell.String())
+}
@@ -0,0 +1,238 @@
+package chartype
+
+import "encoding/json"
+
+const (
+	// FrameCandle specifies a frame carrying a single candle.
+	FrameCandle FrameType = iota + 1
+
+	// FrameTicker specifies a frame carrying a ticker.
+	FrameTicker
+
+	// FrameTrade specifies a frame carrying a single trade.
+	FrameTrade
+
+	// FrameSnapshot specifies a frame carrying a packet of recent
+	// candles and the latest ticker.
+	FrameSnapshot
+)
+
+var (
+	// ErrInvalidFrameType is returned when frame type with invalid
+	// value is being used or when frame's payload is accessed as a
+	// different type.
+	ErrInvalidFrameType = newError(CodeInvalidArgument, "invalid frame type")
+
+	// ErrInvalidFrame is returned when a frame cannot be decoded or
+	// its interval does not match its type.
+	ErrInvalidFrame = newError(CodeParseFailure, "invalid frame")
+)
+
+// FrameType specifies which structure a frame carries.
+// Can be included in configuration structures.
+type FrameType int
+
+// Validate checks whether the frame type is one of supported frame
+// types or not.
+func (ft FrameType) Validate() error {
+	switch ft {
+	case FrameCandle, FrameTicker, FrameTrade, FrameSnapshot:
+		return nil
+	default:
+		return ErrInvalidFrameType
+	}
+}
+
+// MarshalText turns frame type to appropriate string representation.
+func (ft FrameType) MarshalText() ([]byte, error) {
+	var v string
+
+	switch ft {
+	case FrameCandle:
+		v = "candle"
+	case FrameTicker:
+		v = "ticker"
+	case FrameTrade:
+		v = "trade"
+	case FrameSnapshot:
+		v = "snapshot"
+	default:
+		return nil, ErrInvalidFrameType
+	}
+
+	return []byte(v), nil
+}
+
+// UnmarshalText turns string to appropriate frame type value.
+func (ft *FrameType) UnmarshalText(d []byte) error {
+	switch string(d) {
+	case "candle":
+		*ft = FrameCandle
+	case "ticker":
+		*ft = FrameTicker
+	case "trade":
+		*ft = FrameTrade
+	case "snapshot":
+		*ft = FrameSnapshot
+	default:
+		return ErrInvalidFrameType
+	}
+
+	return nil
+}
+
+// Frame is a typed envelope of chart data sent over websockets and
+// similar message-based transports, e.g.:
+//
+//	{"type":"candle","pair":"BTC_USD","interval":"1m","payload":{...}}
+//
+// Interval is set only by candle and snapshot frames.
+type Frame struct {
+	Type     FrameType       `json:"type"`
+	Pair     Pair            `json:"pair"`
+	Interval *Interval       `json:"interval,omitempty"`
+	Payload  json.RawMessage `json:"payload"`
+}
+
+// EncodeCandleFrame encodes the candle of the pair's interval series
+// as a frame.
+func EncodeCandleFrame(p Pair, i Interval, c Candle) ([]byte, error) {
+	return encodeFrame(FrameCandle, p, &i, c)
+}
+
+// EncodeTickerFrame encodes the pair's ticker as a frame.
+func EncodeTickerFrame(p Pair, t Ticker) ([]byte, error) {
+	return encodeFrame(FrameTicker, p, nil, t)
+}
+
+// EncodeTradeFrame encodes the pair's trade as a frame.
+func EncodeTradeFrame(p Pair, t Trade) ([]byte, error) {
+	return encodeFrame(FrameTrade, p, nil, t)
+}
+
+// EncodeSnapshotFrame encodes the packet of the pair's interval
+// series as a frame. Packet's pair is ignored.
+func EncodeSnapshotFrame(p Pair, i Interval, pk Packet) ([]byte, error) {
+	pk.Pair = nil
+	return encodeFrame(FrameSnapshot, p, &i, pk)
+}
+
+// EncodeUpdateFrame encodes the stream update of the pair's interval
+// series as a candle, ticker or snapshot frame.
+func EncodeUpdateFrame(p Pair, i Interval, u StreamUpdate) ([]byte, error) {
+	switch {
+	case u.Candle != nil:
+		return EncodeCandleFrame(p, i, *u.Candle)
+	case u.Ticker != nil:
+		return EncodeTickerFrame(p, *u.Ticker)
+	case u.Snapshot != nil:
+		return EncodeSnapshotFrame(p, i, *u.Snapshot)
+	default:
+		return nil, ErrInvalidFrameType
+	}
+}
+
+// encodeFrame validates frame's header and encodes it together with
+// the payload.
+func encodeFrame(ft FrameType, p Pair, i *Interval, v interface{}) ([]byte, error) {
+	if err := p.Validate(); err != nil {
+		return nil, err
+	}
+
+	if i != nil {
+		if err := i.Validate(); err != nil {
+			return nil, err
+		}
+	}
+
+	d, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+
+	return json.Marshal(Frame{Type: ft, Pair: p, Interval: i, Payload: d})
+}
+
+// DecodeFrame decodes frame's header. Its payload is decoded by the
+// method matching frame's type.
+func DecodeFrame(d []byte) (Frame, error) {
+	var f Frame
+
+	if err := json.Unmarshal(d, &f); err != nil {
+		return Frame{}, ErrInvalidFrame
+	}
+
+	switch f.Type {
+	case FrameCandle, FrameSnapshot:
+		if f.Interval == nil {
+			return Frame{}, ErrInvalidFrame
+		}
+	default:
+		if f.Interval != nil {
+			return Frame{}, ErrInvalidFrame
+		}
+	}
+
+	return f, nil
+}
+
+// Candle decodes the payload of a candle frame.
+func (f Frame) Candle() (Candle, error) {
+	var c Candle
+
+	if err := f.decode(FrameCandle, &c); err != nil {
+		return Candle{}, err
+	}
+
+	return c, nil
+}
+
+// Ticker decodes the payload of a ticker frame.
+func (f Frame) Ticker() (Ticker, error) {
+	var t Ticker
+
+	if err := f.decode(FrameTicker, &t); err != nil {
+		return Ticker{}, err
+	}
+
+	return t, nil
+}
+
+// Trade decodes the payload of a trade frame.
+func (f Frame) Trade() (Trade, error) {
+	var t Trade
+
+	if err := f.decode(FrameTrade, &t); err != nil {
+		return Trade{}, err
+	}
+
+	return t, nil
+}
+
+// Snapshot decodes the payload of a snapshot frame. Packet's pair is
+// set to frame's pair.
+func (f Frame) Snapshot() (Packet, error) {
+	var pk Packet
+
+	if err := f.decode(FrameSnapshot, &pk); err != nil {
+		return Packet{}, err
+	}
+
+	p := f.Pair
+	pk.Pair = &p
+
+	return pk, nil
+}
+
+// decode checks frame's type and decodes its payload.
+func (f Frame) decode(ft FrameType, v interface{}) error {
+	if f.Type != ft {
+		return ErrInvalidFrameType
+	}
+
+	if err := json.Unmarshal(f.Payload, v); err != nil {
+		return ErrInvalidFrame
+	}
+
+	return nil
+}
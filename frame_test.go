@@ -0,0 +1,273 @@
+package chartype
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_FrameType_Validate(t *testing.T) {
+	cc := map[string]struct {
+		Type FrameType
+		Err  error
+	}{
+		"Invalid FrameType": {
+			Type: 70,
+			Err:  ErrInvalidFrameType,
+		},
+		"Successful FrameCandle validation": {
+			Type: FrameCandle,
+		},
+		"Successful FrameTicker validation": {
+			Type: FrameTicker,
+		},
+		"Successful FrameTrade validation": {
+			Type: FrameTrade,
+		},
+		"Successful FrameSnapshot validation": {
+			Type: FrameSnapshot,
+		},
+	}
+
+	for cn, c := range cc {
+		c := c
+
+		t.Run(cn, func(t *testing.T) {
+			t.Parallel()
+
+			equalError(t, c.Err, c.Type.Validate())
+		})
+	}
+}
+
+func Test_FrameType_MarshalText(t *testing.T) {
+	cc := map[string]struct {
+		Type FrameType
+		Text string
+		Err  error
+	}{
+		"Invalid FrameType": {
+			Type: 70,
+			Err:  ErrInvalidFrameType,
+		},
+		"Successful FrameCandle marshal": {
+			Type: FrameCandle,
+			Text: "candle",
+		},
+		"Successful FrameTicker marshal": {
+			Type: FrameTicker,
+			Text: "ticker",
+		},
+		"Successful FrameTrade marshal": {
+			Type: FrameTrade,
+			Text: "trade",
+		},
+		"Successful FrameSnapshot marshal": {
+			Type: FrameSnapshot,
+			Text: "snapshot",
+		},
+	}
+
+	for cn, c := range cc {
+		c := c
+
+		t.Run(cn, func(t *testing.T) {
+			t.Parallel()
+
+			d, err := c.Type.MarshalText()
+			equalError(t, c.Err, err)
+			if err != nil {
+				return
+			}
+
+			assert.Equal(t, c.Text, string(d))
+		})
+	}
+}
+
+func Test_FrameType_UnmarshalText(t *testing.T) {
+	cc := map[string]struct {
+		Text   string
+		Result FrameType
+		Err    error
+	}{
+		"Invalid FrameType": {
+			Text: "order",
+			Err:  ErrInvalidFrameType,
+		},
+		"Successful FrameCandle unmarshal": {
+			Text:   "candle",
+			Result: FrameCandle,
+		},
+		"Successful FrameTicker unmarshal": {
+			Text:   "ticker",
+			Result: FrameTicker,
+		},
+		"Successful FrameTrade unmarshal": {
+			Text:   "trade",
+			Result: FrameTrade,
+		},
+		"Successful FrameSnapshot unmarshal": {
+			Text:   "snapshot",
+			Result: FrameSnapshot,
+		},
+	}
+
+	for cn, c := range cc {
+		c := c
+
+		t.Run(cn, func(t *testing.T) {
+			t.Parallel()
+
+			var ft FrameType
+
+			err := ft.UnmarshalText([]byte(c.Text))
+			equalError(t, c.Err, err)
+			if err != nil {
+				return
+			}
+
+			assert.Equal(t, c.Result, ft)
+		})
+	}
+}
+
+func Test_Frame_RoundTrip(t *testing.T) {
+	tm := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	p := Pair{Base: "BTC", Quote: "USD"}
+	c := testCandle(tm, 1, 2, 1, 2, 3)
+	tk := Ticker{Last: decimal.NewFromInt(2), Volume: decimal.NewFromInt(3)}
+	tr := testTrade(tm, 2, 1)
+
+	d, err := EncodeCandleFrame(p, IntervalMinute, c)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"type":"candle","pair":"BTC_USD","interval":"1m","payload":{
+		"timestamp":"2020-01-01T00:00:00Z","open":"1","high":"2","low":"1","close":"2","volume":"3"}}`, string(d))
+
+	f, err := DecodeFrame(d)
+	require.NoError(t, err)
+	assert.Equal(t, FrameCandle, f.Type)
+	assert.Equal(t, p, f.Pair)
+	assert.Equal(t, IntervalMinute, *f.Interval)
+
+	rc, err := f.Candle()
+	require.NoError(t, err)
+	assertEqualCandles(t, []Candle{c}, []Candle{rc})
+
+	_, err = f.Ticker()
+	assert.Equal(t, ErrInvalidFrameType, err)
+
+	d, err = EncodeUpdateFrame(p, IntervalMinute, StreamUpdate{Ticker: &tk})
+	require.NoError(t, err)
+
+	f, err = DecodeFrame(d)
+	require.NoError(t, err)
+	assert.Nil(t, f.Interval)
+
+	rt, err := f.Ticker()
+	require.NoError(t, err)
+	assert.True(t, tk.Last.Equal(rt.Last))
+	assert.True(t, tk.Volume.Equal(rt.Volume))
+
+	d, err = EncodeTradeFrame(p, tr)
+	require.NoError(t, err)
+
+	f, err = DecodeFrame(d)
+	require.NoError(t, err)
+
+	rtr, err := f.Trade()
+	require.NoError(t, err)
+	assert.Equal(t, tr.Side, rtr.Side)
+	assert.True(t, tr.Price.Equal(rtr.Price))
+	assert.True(t, tr.Timestamp.Equal(rtr.Timestamp))
+
+	q := Pair{Base: "ETH", Quote: "USD"}
+
+	d, err = EncodeUpdateFrame(p, IntervalHour, StreamUpdate{Snapshot: &Packet{Pair: &q, Ticker: tk, Candles: []Candle{c}}})
+	require.NoError(t, err)
+
+	f, err = DecodeFrame(d)
+	require.NoError(t, err)
+
+	pk, err := f.Snapshot()
+	require.NoError(t, err)
+	assert.Equal(t, &p, pk.Pair)
+	assertEqualCandles(t, []Candle{c}, pk.Candles)
+
+	d, err = EncodeUpdateFrame(p, IntervalMinute, StreamUpdate{Candle: &c})
+	require.NoError(t, err)
+
+	f, err = DecodeFrame(d)
+	require.NoError(t, err)
+	assert.Equal(t, FrameCandle, f.Type)
+}
+
+func Test_EncodeFrame(t *testing.T) {
+	p := Pair{Base: "BTC", Quote: "USD"}
+
+	_, err := EncodeTickerFrame(Pair{}, Ticker{})
+	assert.Equal(t, ErrInvalidPair, err)
+
+	_, err = EncodeCandleFrame(p, 0, Candle{})
+	assert.Equal(t, ErrInvalidInterval, err)
+
+	_, err = EncodeUpdateFrame(p, IntervalMinute, StreamUpdate{})
+	assert.Equal(t, ErrInvalidFrameType, err)
+
+	_, err = EncodeTradeFrame(p, Trade{Side: 70})
+	assert.True(t, errors.Is(err, ErrInvalidSide))
+}
+
+func Test_DecodeFrame(t *testing.T) {
+	cc := map[string]struct {
+		Data string
+		Err  error
+	}{
+		"Invalid JSON": {
+			Data: `{`,
+			Err:  ErrInvalidFrame,
+		},
+		"Invalid type": {
+			Data: `{"type":"order","pair":"BTC_USD","payload":{}}`,
+			Err:  ErrInvalidFrame,
+		},
+		"Candle without interval": {
+			Data: `{"type":"candle","pair":"BTC_USD","payload":{}}`,
+			Err:  ErrInvalidFrame,
+		},
+		"Ticker with interval": {
+			Data: `{"type":"ticker","pair":"BTC_USD","interval":"1m","payload":{}}`,
+			Err:  ErrInvalidFrame,
+		},
+		"Successful decode": {
+			Data: `{"type":"snapshot","pair":"BTC_USD","interval":"1m","payload":{}}`,
+		},
+	}
+
+	for cn, c := range cc {
+		c := c
+
+		t.Run(cn, func(t *testing.T) {
+			t.Parallel()
+
+			_, err := DecodeFrame([]byte(c.Data))
+			equalError(t, c.Err, err)
+		})
+	}
+
+	f, err := DecodeFrame([]byte(`{"type":"trade","pair":"BTC_USD","payload":[]}`))
+	require.NoError(t, err)
+
+	_, err = f.Trade()
+	assert.Equal(t, ErrInvalidFrame, err)
+
+	_, err = f.Candle()
+	assert.Equal(t, ErrInvalidFrameType, err)
+
+	_, err = f.Snapshot()
+	assert.Equal(t, ErrInvalidFrameType, err)
+}
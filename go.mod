@@ -3,6 +3,9 @@ module github.com/jellydator/chartype
 go 1.13
 
 require (
+	github.com/google/flatbuffers v1.12.1
 	github.com/shopspring/decimal v0.0.0-20200227202807-02e2044944cc
 	github.com/stretchr/testify v1.6.0
+	go.etcd.io/bbolt v1.3.5
+	gopkg.in/yaml.v3 v3.0.0-20200313102051-9f266ea9e77c
 )
@@ -0,0 +1,164 @@
+package chartype
+
+import "time"
+
+const (
+	// ViolationDuplicate specifies a candle with the same timestamp
+	// as the last accepted candle.
+	ViolationDuplicate ViolationKind = iota + 1
+
+	// ViolationRegression specifies a candle older than the last
+	// accepted candle.
+	ViolationRegression
+)
+
+var (
+	// ErrInvalidViolationKind is returned when violation kind with
+	// invalid value is being used.
+	ErrInvalidViolationKind = newError(CodeInvalidArgument, "invalid violation kind")
+)
+
+// ViolationKind specifies how a candle broke stream's ordering.
+// Can be included in configuration structures.
+type ViolationKind int
+
+// Validate checks whether the violation kind is one of supported
+// kind types or not.
+func (vk ViolationKind) Validate() error {
+	switch vk {
+	case ViolationDuplicate, ViolationRegression:
+		return nil
+	default:
+		return ErrInvalidViolationKind
+	}
+}
+
+// MarshalText turns violation kind to appropriate string
+// representation.
+func (vk ViolationKind) MarshalText() ([]byte, error) {
+	var v string
+
+	switch vk {
+	case ViolationDuplicate:
+		v = "duplicate"
+	case ViolationRegression:
+		v = "regression"
+	default:
+		return nil, ErrInvalidViolationKind
+	}
+
+	return []byte(v), nil
+}
+
+// UnmarshalText turns string to appropriate violation kind value.
+func (vk *ViolationKind) UnmarshalText(d []byte) error {
+	switch string(d) {
+	case "duplicate":
+		*vk = ViolationDuplicate
+	case "regression":
+		*vk = ViolationRegression
+	default:
+		return ErrInvalidViolationKind
+	}
+
+	return nil
+}
+
+// Violation describes a candle dropped by a stream guard.
+type Violation struct {
+	// Kind specifies how the candle broke stream's ordering.
+	Kind ViolationKind `json:"kind" yaml:"kind"`
+
+	// Candle specifies the dropped candle.
+	Candle Candle `json:"candle" yaml:"candle"`
+
+	// Last specifies the timestamp of the last accepted candle.
+	Last time.Time `json:"last" yaml:"last"`
+}
+
+// StreamGuard forwards candles of a stream to the next handler only
+// if they are newer than the last forwarded candle, protecting
+// downstream state from feed replays and reconnect overlaps. Dropped
+// candles are reported to the violation handler. Corrections bypass
+// the ordering check.
+//
+// StreamGuard is not safe for concurrent use.
+type StreamGuard struct {
+	next        func(Candle)
+	onViolation func(Violation)
+	last        *time.Time
+	counts      map[ViolationKind]int
+}
+
+// NewStreamGuard creates a new stream guard that forwards accepted
+// candles to the next handler and reports dropped ones to the
+// violation handler. Next handler must not be nil, violation handler
+// is optional.
+func NewStreamGuard(next func(Candle), onViolation func(Violation)) *StreamGuard {
+	return &StreamGuard{
+		next:        next,
+		onViolation: onViolation,
+		counts:      make(map[ViolationKind]int),
+	}
+}
+
+// Add forwards the candle to the next handler if it is newer than the
+// last forwarded one and reports whether it was forwarded.
+func (sg *StreamGuard) Add(c Candle) bool {
+	if sg.last != nil && !c.Timestamp.After(*sg.last) {
+		vk := ViolationRegression
+		if c.Timestamp.Equal(*sg.last) {
+			vk = ViolationDuplicate
+		}
+
+		sg.counts[vk]++
+
+		if sg.onViolation != nil {
+			sg.onViolation(Violation{Kind: vk, Candle: c, Last: *sg.last})
+		}
+
+		return false
+	}
+
+	sg.accept(c)
+
+	return true
+}
+
+// AddCorrection forwards the corrected candle to the next handler
+// regardless of its timestamp.
+func (sg *StreamGuard) AddCorrection(cc CandleCorrection) {
+	sg.accept(cc.Candle)
+}
+
+// Last returns the timestamp of the newest forwarded candle and
+// whether there is one.
+func (sg *StreamGuard) Last() (time.Time, bool) {
+	if sg.last == nil {
+		return time.Time{}, false
+	}
+
+	return *sg.last, true
+}
+
+// Violations returns the number of dropped candles of each violation
+// kind.
+func (sg *StreamGuard) Violations() map[ViolationKind]int {
+	res := make(map[ViolationKind]int, len(sg.counts))
+	for vk, n := range sg.counts {
+		res[vk] = n
+	}
+
+	return res
+}
+
+// accept forwards the candle and advances the last timestamp if the
+// candle is newer.
+func (sg *StreamGuard) accept(c Candle) {
+	if sg.last == nil || c.Timestamp.After(*sg.last) {
+		ts := c.Timestamp
+		sg.last = &ts
+	}
+
+	sg.next(c)
+}
@@ -0,0 +1,171 @@
+package chartype
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_ViolationKind_Validate(t *testing.T) {
+	cc := map[string]struct {
+		Kind ViolationKind
+		Err  error
+	}{
+		"Invalid ViolationKind": {
+			Kind: 70,
+			Err:  ErrInvalidViolationKind,
+		},
+		"Successful ViolationDuplicate validation": {
+			Kind: ViolationDuplicate,
+		},
+		"Successful ViolationRegression validation": {
+			Kind: ViolationRegression,
+		},
+	}
+
+	for cn, c := range cc {
+		c := c
+
+		t.Run(cn, func(t *testing.T) {
+			t.Parallel()
+
+			equalError(t, c.Err, c.Kind.Validate())
+		})
+	}
+}
+
+func Test_ViolationKind_MarshalText(t *testing.T) {
+	cc := map[string]struct {
+		Kind ViolationKind
+		Text string
+		Err  error
+	}{
+		"Invalid ViolationKind": {
+			Kind: 70,
+			Err:  ErrInvalidViolationKind,
+		},
+		"Successful ViolationDuplicate marshal": {
+			Kind: ViolationDuplicate,
+			Text: "duplicate",
+		},
+		"Successful ViolationRegression marshal": {
+			Kind: ViolationRegression,
+			Text: "regression",
+		},
+	}
+
+	for cn, c := range cc {
+		c := c
+
+		t.Run(cn, func(t *testing.T) {
+			t.Parallel()
+
+			d, err := c.Kind.MarshalText()
+			equalError(t, c.Err, err)
+			if err != nil {
+				return
+			}
+
+			assert.Equal(t, c.Text, string(d))
+		})
+	}
+}
+
+func Test_ViolationKind_UnmarshalText(t *testing.T) {
+	cc := map[string]struct {
+		Text   string
+		Result ViolationKind
+		Err    error
+	}{
+		"Invalid ViolationKind": {
+			Text: "gap",
+			Err:  ErrInvalidViolationKind,
+		},
+		"Successful ViolationDuplicate unmarshal": {
+			Text:   "duplicate",
+			Result: ViolationDuplicate,
+		},
+		"Successful ViolationRegression unmarshal": {
+			Text:   "regression",
+			Result: ViolationRegression,
+		},
+	}
+
+	for cn, c := range cc {
+		c := c
+
+		t.Run(cn, func(t *testing.T) {
+			t.Parallel()
+
+			var vk ViolationKind
+
+			err := vk.UnmarshalText([]byte(c.Text))
+			equalError(t, c.Err, err)
+			if err != nil {
+				return
+			}
+
+			assert.Equal(t, c.Result, vk)
+		})
+	}
+}
+
+func Test_StreamGuard(t *testing.T) {
+	tm := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	at := func(m int) time.Time {
+		return tm.Add(time.Duration(m) * time.Minute)
+	}
+
+	var (
+		forwarded  []Candle
+		violations []Violation
+	)
+
+	sg := NewStreamGuard(func(c Candle) {
+		forwarded = append(forwarded, c)
+	}, func(v Violation) {
+		violations = append(violations, v)
+	})
+
+	_, ok := sg.Last()
+	assert.False(t, ok)
+
+	assert.True(t, sg.Add(testCandle(at(1), 1, 1, 1, 1, 1)))
+	assert.True(t, sg.Add(testCandle(at(2), 2, 2, 2, 2, 2)))
+	assert.False(t, sg.Add(testCandle(at(2), 3, 3, 3, 3, 3)))
+	assert.False(t, sg.Add(testCandle(at(0), 4, 4, 4, 4, 4)))
+
+	sg.AddCorrection(CandleCorrection{Timestamp: at(1), Candle: testCandle(at(1), 5, 5, 5, 5, 5), Reason: CorrectionRevision})
+	sg.AddCorrection(CandleCorrection{Timestamp: at(3), Candle: testCandle(at(3), 6, 6, 6, 6, 6), Reason: CorrectionMissingCandle})
+
+	assert.True(t, sg.Add(testCandle(at(4), 7, 7, 7, 7, 7)))
+
+	assert.Equal(t, []Candle{
+		testCandle(at(1), 1, 1, 1, 1, 1),
+		testCandle(at(2), 2, 2, 2, 2, 2),
+		testCandle(at(1), 5, 5, 5, 5, 5),
+		testCandle(at(3), 6, 6, 6, 6, 6),
+		testCandle(at(4), 7, 7, 7, 7, 7),
+	}, forwarded)
+
+	assert.Equal(t, []Violation{
+		{Kind: ViolationDuplicate, Candle: testCandle(at(2), 3, 3, 3, 3, 3), Last: at(2)},
+		{Kind: ViolationRegression, Candle: testCandle(at(0), 4, 4, 4, 4, 4), Last: at(2)},
+	}, violations)
+
+	vv := sg.Violations()
+	assert.Equal(t, map[ViolationKind]int{ViolationDuplicate: 1, ViolationRegression: 1}, vv)
+
+	vv[ViolationDuplicate] = 10
+	assert.Equal(t, 1, sg.Violations()[ViolationDuplicate])
+
+	last, ok := sg.Last()
+	assert.True(t, ok)
+	assert.Equal(t, at(4), last)
+
+	sg = NewStreamGuard(func(Candle) {}, nil)
+	assert.True(t, sg.Add(testCandle(at(1), 1, 1, 1, 1, 1)))
+	assert.False(t, sg.Add(testCandle(at(1), 1, 1, 1, 1, 1)))
+	assert.Equal(t, map[ViolationKind]int{ViolationDuplicate: 1}, sg.Violations())
+}
@@ -0,0 +1,171 @@
+package chartype
+
+import (
+	"crypto/sha256"
+	"sort"
+	"strconv"
+	"time"
+)
+
+// ChunkHash holds the digest of candles within a single chunk of
+// time.
+type ChunkHash struct {
+	Range TimeRange `json:"range" yaml:"range"`
+	Hash  [32]byte  `json:"hash" yaml:"hash"`
+}
+
+// Hash computes a canonical SHA-256 digest of the candles. Candles
+// are hashed in timestamp order and decimals are formatted without
+// trailing zeros, so equal histories produce equal digests regardless
+// of their order or decimal representation. Adjusted close and volume
+// delta values are included if they are set.
+func Hash(cc []Candle) [32]byte {
+	sorted := make([]Candle, len(cc))
+	copy(sorted, cc)
+
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].Timestamp.Before(sorted[j].Timestamp)
+	})
+
+	h := sha256.New()
+
+	var b []byte
+
+	for _, c := range sorted {
+		b = strconv.AppendInt(b[:0], c.Timestamp.UnixNano(), 10)
+		b = append(b, '|')
+		b = append(b, c.Open.String()...)
+		b = append(b, '|')
+		b = append(b, c.High.String()...)
+		b = append(b, '|')
+		b = append(b, c.Low.String()...)
+		b = append(b, '|')
+		b = append(b, c.Close.String()...)
+		b = append(b, '|')
+		b = append(b, c.Volume.String()...)
+
+		// optional values are tagged, so that digests of candles
+		// without them stay the same
+		if c.AdjClose != nil {
+			b = append(b, "|a"...)
+			b = append(b, c.AdjClose.String()...)
+		}
+
+		if c.Delta != nil {
+			b = append(b, "|d"...)
+			b = append(b, c.Delta.String()...)
+		}
+
+		b = append(b, '\n')
+
+		h.Write(b) //nolint:errcheck // hash writes never fail
+	}
+
+	var res [32]byte
+
+	copy(res[:], h.Sum(nil))
+
+	return res
+}
+
+// HashChunks groups the candles into span-long chunks aligned to span
+// boundaries (e.g. days) and hashes each of them. Only chunks that
+// contain candles are returned, ordered by time.
+func HashChunks(cc []Candle, span time.Duration) ([]ChunkHash, error) {
+	if span <= 0 {
+		return nil, ErrInvalidDuration
+	}
+
+	groups := make(map[int64][]Candle)
+
+	for _, c := range cc {
+		k := c.Timestamp.Truncate(span).UnixNano()
+		groups[k] = append(groups[k], c)
+	}
+
+	res := make([]ChunkHash, 0, len(groups))
+
+	for k, g := range groups {
+		from := time.Unix(0, k).UTC()
+
+		res = append(res, ChunkHash{
+			Range: TimeRange{From: from, To: from.Add(span)},
+			Hash:  Hash(g),
+		})
+	}
+
+	sort.Slice(res, func(i, j int) bool {
+		return res[i].Range.From.Before(res[j].Range.From)
+	})
+
+	return res, nil
+}
+
+// MerkleRoot combines chunk digests into a single root digest by
+// pairwise hashing. The last digest of an odd-length level is paired
+// with itself. Zero digest is returned if there are no chunks.
+func MerkleRoot(hh []ChunkHash) [32]byte {
+	if len(hh) == 0 {
+		return [32]byte{}
+	}
+
+	level := make([][32]byte, len(hh))
+	for i, h := range hh {
+		level[i] = h.Hash
+	}
+
+	for len(level) > 1 {
+		next := make([][32]byte, 0, (len(level)+1)/2)
+
+		for i := 0; i < len(level); i += 2 {
+			var pair [64]byte
+
+			copy(pair[:32], level[i][:])
+
+			if i+1 < len(level) {
+				copy(pair[32:], level[i+1][:])
+			} else {
+				copy(pair[32:], level[i][:])
+			}
+
+			next = append(next, sha256.Sum256(pair[:]))
+		}
+
+		level = next
+	}
+
+	return level[0]
+}
+
+// DiffChunks compares two sets of chunk digests and returns time
+// ranges of chunks that are missing from either set or whose digests
+// differ, ordered by time.
+func DiffChunks(hh1, hh2 []ChunkHash) []TimeRange {
+	digests := make(map[int64]ChunkHash, len(hh1))
+	for _, h := range hh1 {
+		digests[h.Range.From.UnixNano()] = h
+	}
+
+	var res []TimeRange
+
+	for _, h := range hh2 {
+		k := h.Range.From.UnixNano()
+
+		h1, ok := digests[k]
+		if !ok || h1.Hash != h.Hash {
+			res = append(res, h.Range)
+		}
+
+		delete(digests, k)
+	}
+
+	for _, h := range digests {
+		res = append(res, h.Range)
+	}
+
+	sort.Slice(res, func(i, j int) bool {
+		return res[i].From.Before(res[j].From)
+	})
+
+	return res
+}
@@ -0,0 +1,114 @@
+package chartype
+
+import (
+	"crypto/sha256"
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Hash(t *testing.T) {
+	tm := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	c1 := testCandle(tm, 1, 2, 1, 2, 3)
+	c2 := testCandle(tm.Add(time.Minute), 2, 3, 2, 3, 4)
+
+	h := Hash([]Candle{c1, c2})
+	assert.NotEqual(t, [32]byte{}, h)
+
+	// order does not matter
+	assert.Equal(t, h, Hash([]Candle{c2, c1}))
+
+	// decimal representation does not matter
+	c3 := c1
+	c3.Open = decimal.RequireFromString("1.000")
+	assert.Equal(t, h, Hash([]Candle{c3, c2}))
+
+	// values do matter
+	c3.Open = decimal.RequireFromString("1.001")
+	assert.NotEqual(t, h, Hash([]Candle{c3, c2}))
+
+	// adjusted close values do matter
+	c3 = c1
+	c3.AdjClose = &c3.Close
+	assert.NotEqual(t, h, Hash([]Candle{c3, c2}))
+
+	// volume delta values do matter and are not mistaken for adjusted
+	// close values
+	c4 := c1
+	c4.Delta = &c4.Close
+	assert.NotEqual(t, h, Hash([]Candle{c4, c2}))
+	assert.NotEqual(t, Hash([]Candle{c3, c2}), Hash([]Candle{c4, c2}))
+
+	// timestamps do matter
+	c3 = c1
+	c3.Timestamp = c3.Timestamp.Add(time.Second)
+	assert.NotEqual(t, h, Hash([]Candle{c3, c2}))
+
+	assert.Equal(t, sha256.Sum256(nil), Hash(nil))
+}
+
+func Test_HashChunks(t *testing.T) {
+	tm := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	_, err := HashChunks(nil, 0)
+	assert.Equal(t, ErrInvalidDuration, err)
+
+	c1 := testCandle(tm.Add(25*time.Hour), 1, 1, 1, 1, 1)
+	c2 := testCandle(tm, 2, 2, 2, 2, 2)
+	c3 := testCandle(tm.Add(time.Hour), 3, 3, 3, 3, 3)
+
+	hh, err := HashChunks([]Candle{c1, c2, c3}, 24*time.Hour)
+	assert.NoError(t, err)
+	assert.Equal(t, []ChunkHash{
+		{
+			Range: TimeRange{From: tm, To: tm.Add(24 * time.Hour)},
+			Hash:  Hash([]Candle{c2, c3}),
+		},
+		{
+			Range: TimeRange{From: tm.Add(24 * time.Hour), To: tm.Add(48 * time.Hour)},
+			Hash:  Hash([]Candle{c1}),
+		},
+	}, hh)
+}
+
+func Test_MerkleRoot(t *testing.T) {
+	pair := func(h1, h2 [32]byte) [32]byte {
+		return sha256.Sum256(append(h1[:], h2[:]...))
+	}
+
+	h1 := ChunkHash{Hash: sha256.Sum256([]byte("1"))}
+	h2 := ChunkHash{Hash: sha256.Sum256([]byte("2"))}
+	h3 := ChunkHash{Hash: sha256.Sum256([]byte("3"))}
+
+	assert.Equal(t, [32]byte{}, MerkleRoot(nil))
+	assert.Equal(t, h1.Hash, MerkleRoot([]ChunkHash{h1}))
+	assert.Equal(t, pair(h1.Hash, h2.Hash), MerkleRoot([]ChunkHash{h1, h2}))
+	assert.Equal(t, pair(pair(h1.Hash, h2.Hash), pair(h3.Hash, h3.Hash)),
+		MerkleRoot([]ChunkHash{h1, h2, h3}))
+}
+
+func Test_DiffChunks(t *testing.T) {
+	tm := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	rng := func(d int) TimeRange {
+		from := tm.Add(time.Duration(d) * 24 * time.Hour)
+		return TimeRange{From: from, To: from.Add(24 * time.Hour)}
+	}
+
+	hh1 := []ChunkHash{
+		{Range: rng(0), Hash: [32]byte{1}},
+		{Range: rng(1), Hash: [32]byte{2}},
+		{Range: rng(3), Hash: [32]byte{4}},
+	}
+
+	hh2 := []ChunkHash{
+		{Range: rng(0), Hash: [32]byte{1}},
+		{Range: rng(1), Hash: [32]byte{3}},
+		{Range: rng(2), Hash: [32]byte{3}},
+	}
+
+	assert.Empty(t, DiffChunks(hh1, hh1))
+	assert.Equal(t, []TimeRange{rng(1), rng(2), rng(3)}, DiffChunks(hh1, hh2))
+}
@@ -0,0 +1,65 @@
+package chartype
+
+import (
+	"sort"
+	"time"
+)
+
+// CandleIndex provides constant-time lookups of candles by timestamp
+// and logarithmic-time nearest candle lookups.
+type CandleIndex struct {
+	candles   Candles
+	positions map[int64]int
+}
+
+// Index creates a new index of the candles. Candles must be sorted
+// by timestamp in ascending order and must not be modified while the
+// index is used. If timestamps repeat, the last candle is indexed.
+func (cc Candles) Index() *CandleIndex {
+	pp := make(map[int64]int, len(cc))
+	for i, c := range cc {
+		pp[c.Timestamp.UnixNano()] = i
+	}
+
+	return &CandleIndex{candles: cc, positions: pp}
+}
+
+// Position returns the position of the candle with the provided
+// timestamp and whether it exists.
+func (ci *CandleIndex) Position(t time.Time) (int, bool) {
+	i, ok := ci.positions[t.UnixNano()]
+	return i, ok
+}
+
+// Get returns the candle with the provided timestamp and whether it
+// exists.
+func (ci *CandleIndex) Get(t time.Time) (Candle, bool) {
+	i, ok := ci.Position(t)
+	if !ok {
+		return Candle{}, false
+	}
+
+	return ci.candles[i], true
+}
+
+// Nearest returns the candle whose timestamp is the closest to the
+// provided one and whether the index is not empty. The older candle
+// is returned if two candles are equally close.
+func (ci *CandleIndex) Nearest(t time.Time) (Candle, bool) {
+	if len(ci.candles) == 0 {
+		return Candle{}, false
+	}
+
+	i := sort.Search(len(ci.candles), func(i int) bool {
+		return !ci.candles[i].Timestamp.Before(t)
+	})
+
+	switch {
+	case i == len(ci.candles):
+		i--
+	case i > 0 && t.Sub(ci.candles[i-1].Timestamp) <= ci.candles[i].Timestamp.Sub(t):
+		i--
+	}
+
+	return ci.candles[i], true
+}
@@ -0,0 +1,82 @@
+package chartype
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_CandleIndex_Get(t *testing.T) {
+	tm := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	ci := Candles{
+		testCandle(tm, 1, 1, 1, 1, 1),
+		testCandle(tm.Add(time.Minute), 2, 2, 2, 2, 2),
+	}.Index()
+
+	c, ok := ci.Get(tm.Add(time.Minute).In(time.FixedZone("X", 3600)))
+	assert.True(t, ok)
+	assert.Equal(t, testCandle(tm.Add(time.Minute), 2, 2, 2, 2, 2), c)
+
+	i, ok := ci.Position(tm)
+	assert.True(t, ok)
+	assert.Equal(t, 0, i)
+
+	_, ok = ci.Get(tm.Add(time.Second))
+	assert.False(t, ok)
+}
+
+func Test_CandleIndex_Nearest(t *testing.T) {
+	tm := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	_, ok := Candles{}.Index().Nearest(tm)
+	assert.False(t, ok)
+
+	ci := Candles{
+		testCandle(tm, 1, 1, 1, 1, 1),
+		testCandle(tm.Add(time.Minute), 2, 2, 2, 2, 2),
+		testCandle(tm.Add(3*time.Minute), 3, 3, 3, 3, 3),
+	}.Index()
+
+	cc := map[string]struct {
+		Time   time.Time
+		Result time.Time
+	}{
+		"Before first": {
+			Time:   tm.Add(-time.Hour),
+			Result: tm,
+		},
+		"After last": {
+			Time:   tm.Add(time.Hour),
+			Result: tm.Add(3 * time.Minute),
+		},
+		"Exact match": {
+			Time:   tm.Add(time.Minute),
+			Result: tm.Add(time.Minute),
+		},
+		"Closer to newer": {
+			Time:   tm.Add(150 * time.Second),
+			Result: tm.Add(3 * time.Minute),
+		},
+		"Closer to older": {
+			Time:   tm.Add(20 * time.Second),
+			Result: tm,
+		},
+		"Equally close": {
+			Time:   tm.Add(2 * time.Minute),
+			Result: tm.Add(time.Minute),
+		},
+	}
+
+	for cn, c := range cc {
+		c := c
+
+		t.Run(cn, func(t *testing.T) {
+			t.Parallel()
+
+			res, ok := ci.Nearest(c.Time)
+			assert.True(t, ok)
+			assert.Equal(t, c.Result, res.Timestamp)
+		})
+	}
+}
@@ -0,0 +1,117 @@
+// Package influx provides InfluxDB line protocol encoding of
+// chartype's structures.
+package influx
+
+import (
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/jellydator/chartype"
+)
+
+var (
+	// ErrInvalidMeasurement is returned when empty measurement name
+	// is being used.
+	ErrInvalidMeasurement = &chartype.Error{
+		Code:    chartype.CodeInvalidArgument,
+		Message: "invalid measurement",
+	}
+)
+
+var (
+	// measurementEscaper escapes special characters in measurement
+	// names.
+	measurementEscaper = strings.NewReplacer( //nolint:gochecknoglobals // stateless replacer
+		`,`, `\,`,
+		` `, `\ `,
+		"\n", `\n`,
+	)
+
+	// keyEscaper escapes special characters in tag keys, tag values
+	// and field keys.
+	keyEscaper = strings.NewReplacer( //nolint:gochecknoglobals // stateless replacer
+		`,`, `\,`,
+		`=`, `\=`,
+		` `, `\ `,
+		"\n", `\n`,
+	)
+)
+
+// WriteLineProtocol writes candles to w in InfluxDB line protocol,
+// one line per candle. Tags are sorted by key and tags with empty
+// keys or values are skipped, as line protocol does not allow them.
+// Candle values are written as float fields and timestamps are
+// written with nanosecond precision. The adj_close and delta fields
+// are written only for candles that have an adjusted close and a
+// volume delta value respectively.
+func WriteLineProtocol(w io.Writer, measurement string, cc []chartype.Candle, tags map[string]string) error {
+	if measurement == "" {
+		return ErrInvalidMeasurement
+	}
+
+	prefix := linePrefix(measurement, tags)
+	b := make([]byte, 0, 128)
+
+	for _, c := range cc {
+		b = append(b[:0], prefix...)
+		b = append(b, " open="...)
+		b = append(b, c.Open.String()...)
+		b = append(b, ",high="...)
+		b = append(b, c.High.String()...)
+		b = append(b, ",low="...)
+		b = append(b, c.Low.String()...)
+		b = append(b, ",close="...)
+		b = append(b, c.Close.String()...)
+		b = append(b, ",volume="...)
+		b = append(b, c.Volume.String()...)
+
+		if c.AdjClose != nil {
+			b = append(b, ",adj_close="...)
+			b = append(b, c.AdjClose.String()...)
+		}
+
+		if c.Delta != nil {
+			b = append(b, ",delta="...)
+			b = append(b, c.Delta.String()...)
+		}
+
+		b = append(b, ' ')
+		b = strconv.AppendInt(b, c.Timestamp.UnixNano(), 10)
+		b = append(b, '\n')
+
+		if _, err := w.Write(b); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// linePrefix returns escaped measurement name followed by sorted,
+// escaped tag set.
+func linePrefix(measurement string, tags map[string]string) string {
+	kk := make([]string, 0, len(tags))
+
+	for k, v := range tags {
+		if k != "" && v != "" {
+			kk = append(kk, k)
+		}
+	}
+
+	sort.Strings(kk)
+
+	var sb strings.Builder
+
+	sb.WriteString(measurementEscaper.Replace(measurement))
+
+	for _, k := range kk {
+		sb.WriteByte(',')
+		sb.WriteString(keyEscaper.Replace(k))
+		sb.WriteByte('=')
+		sb.WriteString(keyEscaper.Replace(tags[k]))
+	}
+
+	return sb.String()
+}
@@ -0,0 +1,94 @@
+package influx
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/jellydator/chartype"
+	"github.com/jellydator/chartype/chartypetest"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+)
+
+type errWriter struct{}
+
+func (errWriter) Write([]byte) (int, error) {
+	return 0, errors.New("write failed")
+}
+
+func Test_WriteLineProtocol(t *testing.T) {
+	adj := decimal.RequireFromString("2.75")
+	delta := decimal.RequireFromString("-40")
+
+	cc := []chartype.Candle{
+		{
+			Timestamp: time.Unix(1577836800, 5),
+			Open:      decimal.NewFromInt(1),
+			High:      decimal.RequireFromString("2.5"),
+			Low:       decimal.RequireFromString("0.5"),
+			Close:     decimal.NewFromInt(2),
+			Volume:    decimal.NewFromInt(100),
+			Delta:     &delta,
+		},
+		{
+			Timestamp: time.Unix(1577836860, 0),
+			Open:      decimal.NewFromInt(2),
+			High:      decimal.NewFromInt(3),
+			Low:       decimal.NewFromInt(1),
+			Close:     decimal.NewFromInt(3),
+			Volume:    decimal.Zero,
+			AdjClose:  &adj,
+		},
+	}
+
+	tcc := map[string]struct {
+		Measurement string
+		Tags        map[string]string
+		Text        string
+		Err         error
+	}{
+		"Invalid measurement": {
+			Err: ErrInvalidMeasurement,
+		},
+		"Successful write without tags": {
+			Measurement: "candles",
+			Text: "candles open=1,high=2.5,low=0.5,close=2,volume=100,delta=-40 1577836800000000005\n" +
+				"candles open=2,high=3,low=1,close=3,volume=0,adj_close=2.75 1577836860000000000\n",
+		},
+		"Successful write with escaped tags": {
+			Measurement: "my candles,x",
+			Tags: map[string]string{
+				"pair":     "BTC_USDT",
+				"exchange": "big exchange",
+				"a=b":      "c,d",
+				"empty":    "",
+			},
+			Text: `my\ candles\,x,a\=b=c\,d,exchange=big\ exchange,pair=BTC_USDT ` +
+				"open=1,high=2.5,low=0.5,close=2,volume=100,delta=-40 1577836800000000005\n" +
+				`my\ candles\,x,a\=b=c\,d,exchange=big\ exchange,pair=BTC_USDT ` +
+				"open=2,high=3,low=1,close=3,volume=0,adj_close=2.75 1577836860000000000\n",
+		},
+	}
+
+	for cn, c := range tcc {
+		c := c
+
+		t.Run(cn, func(t *testing.T) {
+			t.Parallel()
+
+			var buf bytes.Buffer
+
+			err := WriteLineProtocol(&buf, c.Measurement, cc, c.Tags)
+			chartypetest.AssertErrorEqual(t, c.Err, err)
+			if err != nil {
+				return
+			}
+
+			assert.Equal(t, c.Text, buf.String())
+		})
+	}
+
+	assert.Error(t, WriteLineProtocol(errWriter{}, "candles", cc, nil))
+}
@@ -0,0 +1,214 @@
+package chartype
+
+import (
+	"math"
+	"strconv"
+	"time"
+)
+
+const (
+	// IntervalMinute specifies a one minute interval.
+	IntervalMinute = Interval(time.Minute)
+
+	// IntervalHour specifies a one hour interval.
+	IntervalHour = Interval(time.Hour)
+
+	// IntervalDay specifies a one day interval.
+	IntervalDay = Interval(24 * time.Hour)
+
+	// IntervalWeek specifies a one week interval.
+	IntervalWeek = Interval(7 * 24 * time.Hour)
+)
+
+var (
+	// ErrInvalidInterval is returned when interval with invalid
+	// value is being used.
+	ErrInvalidInterval = newError(CodeInvalidArgument, "invalid interval")
+
+	// ErrIncompatibleIntervals is returned when candles of one
+	// interval cannot be resampled into candles of another one.
+	ErrIncompatibleIntervals = newError(CodeIncompatible, "incompatible intervals")
+)
+
+// intervalUnits holds interval's text units ordered from the largest
+// to the smallest one.
+var intervalUnits = []struct { //nolint:gochecknoglobals // lookup table
+	Suffix string
+	Size   Interval
+}{
+	{Suffix: "w", Size: IntervalWeek},
+	{Suffix: "d", Size: IntervalDay},
+	{Suffix: "h", Size: IntervalHour},
+	{Suffix: "m", Size: IntervalMinute},
+	{Suffix: "s", Size: Interval(time.Second)},
+}
+
+// Interval specifies the timeframe a single candle covers.
+// Can be included in configuration structures.
+type Interval time.Duration
+
+// Duration returns interval's value as time.Duration.
+func (i Interval) Duration() time.Duration {
+	return time.Duration(i)
+}
+
+// Validate checks whether the interval is positive and consists of
+// whole seconds.
+func (i Interval) Validate() error {
+	if i <= 0 || i%Interval(time.Second) != 0 {
+		return ErrInvalidInterval
+	}
+
+	return nil
+}
+
+// ValidateResample checks whether both intervals are valid and
+// whether candles of the interval can be resampled into the
+// destination interval's candles, i.e. whether the destination
+// interval is a multiple of it.
+func (i Interval) ValidateResample(dst Interval) error {
+	if err := i.Validate(); err != nil {
+		return err
+	}
+
+	if err := dst.Validate(); err != nil {
+		return err
+	}
+
+	if !dst.DivisibleBy(i) {
+		return ErrIncompatibleIntervals
+	}
+
+	return nil
+}
+
+// DivisibleBy checks whether both intervals are valid and whether the
+// interval is a multiple of the other one, i.e. whether the other
+// interval's candles can be aggregated into the interval's candles.
+func (i Interval) DivisibleBy(other Interval) bool {
+	return i.Validate() == nil && other.Validate() == nil && i%other == 0
+}
+
+// Count returns the number of interval-long buckets that cover the
+// time range, as produced by TimeRange.Buckets. Zero is returned if
+// the interval is invalid or the time range is empty.
+func (i Interval) Count(tr TimeRange) int {
+	if i.Validate() != nil || !tr.From.Before(tr.To) {
+		return 0
+	}
+
+	span := tr.To.Sub(i.Truncate(tr.From))
+
+	return int((span + i.Duration() - 1) / i.Duration())
+}
+
+// String returns interval's string representation, e.g. "15m".
+func (i Interval) String() string {
+	if i.Validate() != nil {
+		return i.Duration().String()
+	}
+
+	u := intervalUnits[len(intervalUnits)-1]
+
+	for _, cu := range intervalUnits {
+		if i%cu.Size == 0 {
+			u = cu
+			break
+		}
+	}
+
+	return strconv.FormatInt(int64(i/u.Size), 10) + u.Suffix
+}
+
+// Truncate returns the start of the interval-long bucket the
+// provided time belongs to.
+func (i Interval) Truncate(t time.Time) time.Time {
+	return t.Truncate(i.Duration())
+}
+
+// TruncateOffset returns the start of the interval-long bucket the
+// provided time belongs to when buckets are anchored at the offset
+// from their usual boundaries, e.g. daily buckets starting at 22:00
+// UTC use an offset of 22 or -2 hours. Offsets that differ by a
+// multiple of the interval produce the same buckets.
+func (i Interval) TruncateOffset(t time.Time, offset time.Duration) time.Time {
+	return t.Add(-offset).Truncate(i.Duration()).Add(offset)
+}
+
+// TruncateLocal returns the start of the interval-long bucket the
+// provided time belongs to when buckets of intervals that are whole
+// days follow the location's calendar: they start at the local
+// midnight shifted by the offset in wall clock time, e.g. daily
+// buckets starting at 17:00 New York time use New York's location and
+// an offset of -7 hours all year round. Weekly buckets start on
+// Mondays. Such buckets are 23 or 25 hours long on days of daylight
+// saving time transitions. Other intervals are truncated as by
+// TruncateOffset. UTC is used if the location is nil.
+func (i Interval) TruncateLocal(t time.Time, offset time.Duration, loc *time.Location) time.Time {
+	if i%IntervalDay != 0 {
+		return i.TruncateOffset(t, offset)
+	}
+
+	if loc == nil {
+		loc = time.UTC
+	}
+
+	// wall clock time is truncated as if it was UTC time, which has
+	// no daylight saving time transitions
+	t = t.In(loc)
+	w := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC).
+		Add(wallClock(t) - offset).
+		Truncate(i.Duration())
+
+	return time.Date(w.Year(), w.Month(), w.Day(), 0, 0, 0, int(offset), loc)
+}
+
+// MarshalText turns interval to appropriate string representation.
+func (i Interval) MarshalText() ([]byte, error) {
+	if err := i.Validate(); err != nil {
+		return nil, err
+	}
+
+	return []byte(i.String()), nil
+}
+
+// UnmarshalText turns string, such as "1m", "4h" or "1w", to
+// appropriate interval value.
+func (i *Interval) UnmarshalText(d []byte) error {
+	if len(d) < 2 {
+		return ErrInvalidInterval
+	}
+
+	n, err := strconv.ParseInt(string(d[:len(d)-1]), 10, 64)
+	if err != nil || n <= 0 {
+		return ErrInvalidInterval
+	}
+
+	suffix := string(d[len(d)-1:])
+
+	for _, u := range intervalUnits {
+		if u.Suffix != suffix {
+			continue
+		}
+
+		// the multiplication must not overflow
+		v := Interval(n) * u.Size
+		if n > math.MaxInt64/int64(u.Size) || v.Validate() != nil {
+			return ErrInvalidInterval
+		}
+
+		*i = v
+
+		return nil
+	}
+
+	return ErrInvalidInterval
+}
+
+// wallClock returns the time of day shown by a clock in the time's
+// location, which differs from the time elapsed since the local
+// midnight on days of daylight saving time transitions.
+func wallClock(t time.Time) time.Duration {
+	return time.Duration(t.Hour())*time.Hour + time.Duration(t.Minute())*time.Minute +
+		time.Duration(t.Second())*time.Second + time.Duration(t.Nanosecond())
+}
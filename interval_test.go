@@ -0,0 +1,468 @@
+package chartype
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Interval_Duration(t *testing.T) {
+	assert.Equal(t, time.Hour, IntervalHour.Duration())
+}
+
+func Test_Interval_Validate(t *testing.T) {
+	cc := map[string]struct {
+		Interval Interval
+		Err      error
+	}{
+		"Zero Interval": {
+			Err: ErrInvalidInterval,
+		},
+		"Negative Interval": {
+			Interval: -IntervalMinute,
+			Err:      ErrInvalidInterval,
+		},
+		"Fractional second Interval": {
+			Interval: Interval(1500 * time.Millisecond),
+			Err:      ErrInvalidInterval,
+		},
+		"Successful validation": {
+			Interval: 15 * IntervalMinute,
+		},
+	}
+
+	for cn, c := range cc {
+		c := c
+
+		t.Run(cn, func(t *testing.T) {
+			t.Parallel()
+
+			err := c.Interval.Validate()
+			equalError(t, c.Err, err)
+		})
+	}
+}
+
+func Test_Interval_ValidateResample(t *testing.T) {
+	cc := map[string]struct {
+		Interval Interval
+		Dst      Interval
+		Err      error
+	}{
+		"Invalid Interval": {
+			Dst: IntervalHour,
+			Err: ErrInvalidInterval,
+		},
+		"Invalid destination Interval": {
+			Interval: IntervalMinute,
+			Err:      ErrInvalidInterval,
+		},
+		"Shorter destination Interval": {
+			Interval: IntervalHour,
+			Dst:      IntervalMinute,
+			Err:      ErrIncompatibleIntervals,
+		},
+		"Not a multiple destination Interval": {
+			Interval: 7 * IntervalMinute,
+			Dst:      IntervalHour,
+			Err:      ErrIncompatibleIntervals,
+		},
+		"Successful validation": {
+			Interval: 15 * IntervalMinute,
+			Dst:      IntervalDay,
+		},
+	}
+
+	for cn, c := range cc {
+		c := c
+
+		t.Run(cn, func(t *testing.T) {
+			t.Parallel()
+
+			err := c.Interval.ValidateResample(c.Dst)
+			equalError(t, c.Err, err)
+		})
+	}
+}
+
+func Test_Interval_DivisibleBy(t *testing.T) {
+	cc := map[string]struct {
+		Interval Interval
+		Other    Interval
+		Result   bool
+	}{
+		"Invalid interval": {
+			Interval: 0,
+			Other:    IntervalMinute,
+		},
+		"Invalid other interval": {
+			Interval: IntervalHour,
+			Other:    Interval(time.Millisecond),
+		},
+		"Not a multiple": {
+			Interval: Interval(7 * time.Minute),
+			Other:    Interval(2 * time.Minute),
+		},
+		"Multiple": {
+			Interval: Interval(7 * time.Minute),
+			Other:    IntervalMinute,
+			Result:   true,
+		},
+		"Equal intervals": {
+			Interval: IntervalHour,
+			Other:    IntervalHour,
+			Result:   true,
+		},
+	}
+
+	for cn, c := range cc {
+		c := c
+
+		t.Run(cn, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, c.Result, c.Interval.DivisibleBy(c.Other))
+		})
+	}
+}
+
+func Test_Interval_Count(t *testing.T) {
+	tm := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	cc := map[string]struct {
+		Interval Interval
+		Range    TimeRange
+		Result   int
+	}{
+		"Invalid interval": {
+			Interval: 0,
+			Range:    TimeRange{From: tm, To: tm.Add(time.Hour)},
+		},
+		"Empty range": {
+			Interval: IntervalMinute,
+			Range:    TimeRange{From: tm, To: tm},
+		},
+		"Aligned range": {
+			Interval: IntervalMinute,
+			Range:    TimeRange{From: tm, To: tm.Add(time.Hour)},
+			Result:   60,
+		},
+		"Unaligned range": {
+			Interval: IntervalHour,
+			Range:    TimeRange{From: tm.Add(30 * time.Minute), To: tm.Add(150 * time.Minute)},
+			Result:   3,
+		},
+	}
+
+	for cn, c := range cc {
+		c := c
+
+		t.Run(cn, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, c.Result, c.Interval.Count(c.Range))
+
+			if c.Result == 0 {
+				return
+			}
+
+			bi, err := c.Range.Buckets(c.Interval.Duration())
+			assert.NoError(t, err)
+
+			var n int
+			for bi.Next() {
+				n++
+			}
+
+			assert.Equal(t, n, c.Result)
+		})
+	}
+}
+
+func Test_Interval_String(t *testing.T) {
+	cc := map[string]struct {
+		Interval Interval
+		Text     string
+	}{
+		"Invalid Interval": {
+			Interval: Interval(1500 * time.Millisecond),
+			Text:     "1.5s",
+		},
+		"Seconds": {
+			Interval: Interval(90 * time.Second),
+			Text:     "90s",
+		},
+		"Minutes": {
+			Interval: 15 * IntervalMinute,
+			Text:     "15m",
+		},
+		"Hours": {
+			Interval: 4 * IntervalHour,
+			Text:     "4h",
+		},
+		"Days": {
+			Interval: 3 * IntervalDay,
+			Text:     "3d",
+		},
+		"Weeks": {
+			Interval: IntervalWeek,
+			Text:     "1w",
+		},
+	}
+
+	for cn, c := range cc {
+		c := c
+
+		t.Run(cn, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, c.Text, c.Interval.String())
+		})
+	}
+}
+
+func Test_Interval_Truncate(t *testing.T) {
+	tm := time.Date(2020, 1, 1, 10, 47, 3, 0, time.UTC)
+	assert.Equal(t, time.Date(2020, 1, 1, 10, 45, 0, 0, time.UTC), (15 * IntervalMinute).Truncate(tm))
+}
+
+func Test_Interval_TruncateOffset(t *testing.T) {
+	tm := time.Date(2020, 1, 1, 10, 47, 3, 0, time.UTC)
+
+	cc := map[string]struct {
+		Interval Interval
+		Offset   time.Duration
+		Result   time.Time
+	}{
+		"No offset": {
+			Interval: 4 * IntervalHour,
+			Result:   time.Date(2020, 1, 1, 8, 0, 0, 0, time.UTC),
+		},
+		"Positive offset": {
+			Interval: 4 * IntervalHour,
+			Offset:   2 * time.Hour,
+			Result:   time.Date(2020, 1, 1, 10, 0, 0, 0, time.UTC),
+		},
+		"Negative offset": {
+			Interval: IntervalDay,
+			Offset:   -2 * time.Hour,
+			Result:   time.Date(2019, 12, 31, 22, 0, 0, 0, time.UTC),
+		},
+		"Offset longer than interval": {
+			Interval: IntervalDay,
+			Offset:   46 * time.Hour,
+			Result:   time.Date(2019, 12, 31, 22, 0, 0, 0, time.UTC),
+		},
+		"Time at bucket start": {
+			Interval: IntervalDay,
+			Offset:   10*time.Hour + 47*time.Minute + 3*time.Second,
+			Result:   tm,
+		},
+	}
+
+	for cn, c := range cc {
+		c := c
+
+		t.Run(cn, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, c.Result, c.Interval.TruncateOffset(tm, c.Offset))
+		})
+	}
+}
+
+func Test_Interval_TruncateLocal(t *testing.T) {
+	ny := testLocation(t, "America/New_York")
+	utc := func(mo time.Month, d, h int) time.Time {
+		return time.Date(2020, mo, d, h, 0, 0, 0, time.UTC)
+	}
+
+	cc := map[string]struct {
+		Interval Interval
+		Offset   time.Duration
+		Location *time.Location
+		Time     time.Time
+		Result   time.Time
+	}{
+		"Sub-day interval": {
+			Interval: 4 * IntervalHour,
+			Offset:   2 * time.Hour,
+			Location: ny,
+			Time:     utc(3, 8, 11),
+			Result:   utc(3, 8, 10),
+		},
+		"Missing location": {
+			Interval: IntervalDay,
+			Offset:   -2 * time.Hour,
+			Time:     utc(3, 8, 11),
+			Result:   utc(3, 7, 22),
+		},
+		"Day before spring DST transition": {
+			Interval: IntervalDay,
+			Location: ny,
+			Time:     utc(3, 8, 4),
+			Result:   utc(3, 7, 5),
+		},
+		"Day of spring DST transition": {
+			Interval: IntervalDay,
+			Location: ny,
+			Time:     utc(3, 9, 3),
+			Result:   utc(3, 8, 5),
+		},
+		"Day after spring DST transition": {
+			Interval: IntervalDay,
+			Location: ny,
+			Time:     utc(3, 9, 4),
+			Result:   utc(3, 9, 4),
+		},
+		"Day of autumn DST transition": {
+			Interval: IntervalDay,
+			Location: ny,
+			Time:     utc(11, 2, 4),
+			Result:   utc(11, 1, 4),
+		},
+		"Day after autumn DST transition": {
+			Interval: IntervalDay,
+			Location: ny,
+			Time:     utc(11, 2, 5),
+			Result:   utc(11, 2, 5),
+		},
+		"Offset day before DST transition": {
+			Interval: IntervalDay,
+			Offset:   -7 * time.Hour,
+			Location: ny,
+			Time:     utc(3, 8, 20),
+			Result:   utc(3, 7, 22),
+		},
+		"Offset day after DST transition": {
+			Interval: IntervalDay,
+			Offset:   -7 * time.Hour,
+			Location: ny,
+			Time:     utc(3, 8, 21),
+			Result:   utc(3, 8, 21),
+		},
+		"Week before DST transition": {
+			Interval: IntervalWeek,
+			Location: ny,
+			Time:     utc(3, 8, 12),
+			Result:   utc(3, 2, 5),
+		},
+		"Week after DST transition": {
+			Interval: IntervalWeek,
+			Location: ny,
+			Time:     utc(3, 11, 12),
+			Result:   utc(3, 9, 4),
+		},
+	}
+
+	for cn, c := range cc {
+		c := c
+
+		t.Run(cn, func(t *testing.T) {
+			t.Parallel()
+
+			res := c.Interval.TruncateLocal(c.Time, c.Offset, c.Location)
+			assert.True(t, c.Result.Equal(res), "expected %s, actual %s", c.Result, res)
+		})
+	}
+}
+
+func Test_Interval_MarshalText(t *testing.T) {
+	cc := map[string]struct {
+		Interval Interval
+		Text     string
+		Err      error
+	}{
+		"Invalid Interval": {
+			Err: ErrInvalidInterval,
+		},
+		"Successful marshal": {
+			Interval: 4 * IntervalHour,
+			Text:     "4h",
+		},
+	}
+
+	for cn, c := range cc {
+		c := c
+
+		t.Run(cn, func(t *testing.T) {
+			t.Parallel()
+
+			d, err := c.Interval.MarshalText()
+			equalError(t, c.Err, err)
+			if err != nil {
+				return
+			}
+
+			assert.Equal(t, c.Text, string(d))
+		})
+	}
+}
+
+func Test_Interval_UnmarshalText(t *testing.T) {
+	cc := map[string]struct {
+		Text   string
+		Result Interval
+		Err    error
+	}{
+		"Too short": {
+			Text: "m",
+			Err:  ErrInvalidInterval,
+		},
+		"Invalid number": {
+			Text: "xm",
+			Err:  ErrInvalidInterval,
+		},
+		"Zero number": {
+			Text: "0m",
+			Err:  ErrInvalidInterval,
+		},
+		"Overflowing number": {
+			Text: "15251w",
+			Err:  ErrInvalidInterval,
+		},
+		"Invalid unit": {
+			Text: "1y",
+			Err:  ErrInvalidInterval,
+		},
+		"Successful seconds unmarshal": {
+			Text:   "30s",
+			Result: Interval(30 * time.Second),
+		},
+		"Successful minutes unmarshal": {
+			Text:   "5m",
+			Result: 5 * IntervalMinute,
+		},
+		"Successful hours unmarshal": {
+			Text:   "12h",
+			Result: 12 * IntervalHour,
+		},
+		"Successful days unmarshal": {
+			Text:   "1d",
+			Result: IntervalDay,
+		},
+		"Successful weeks unmarshal": {
+			Text:   "2w",
+			Result: 2 * IntervalWeek,
+		},
+	}
+
+	for cn, c := range cc {
+		c := c
+
+		t.Run(cn, func(t *testing.T) {
+			t.Parallel()
+
+			var i Interval
+
+			err := i.UnmarshalText([]byte(c.Text))
+			equalError(t, c.Err, err)
+			if err != nil {
+				return
+			}
+
+			assert.Equal(t, c.Result, i)
+		})
+	}
+}
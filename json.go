@@ -0,0 +1,406 @@
+package chartype
+
+import (
+	"encoding/json"
+	"strconv"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+const (
+	// TimeRFC3339Nano specifies that timestamps are encoded as RFC
+	// 3339 strings with nanoseconds, as encoding/json does.
+	TimeRFC3339Nano TimeFormat = iota + 1
+
+	// TimeRFC3339 specifies that timestamps are encoded as RFC 3339
+	// strings without fractional seconds.
+	TimeRFC3339
+
+	// TimeUnix specifies that timestamps are encoded as numbers of
+	// seconds since the Unix epoch.
+	TimeUnix
+
+	// TimeUnixMilli specifies that timestamps are encoded as numbers
+	// of milliseconds since the Unix epoch.
+	TimeUnixMilli
+
+	// TimeUnixNano specifies that timestamps are encoded as numbers
+	// of nanoseconds since the Unix epoch.
+	TimeUnixNano
+
+	// TimeLayout specifies that timestamps are encoded as strings
+	// formatted with a custom layout.
+	TimeLayout
+)
+
+var (
+	// ErrInvalidTimeFormat is returned when time format with invalid
+	// value is being used.
+	ErrInvalidTimeFormat = newError(CodeInvalidArgument, "invalid time format")
+
+	// ErrInvalidJSONOptions is returned when JSON options with
+	// invalid candle fields, a field that is both omitted and nulled
+	// or a missing time layout are being used.
+	ErrInvalidJSONOptions = newError(CodeInvalidArgument, "invalid json options")
+)
+
+// TimeFormat specifies how timestamps are encoded to JSON.
+// Can be included in configuration structures.
+type TimeFormat int
+
+// Validate checks whether the time format is one of supported format
+// types or not.
+func (tf TimeFormat) Validate() error {
+	switch tf {
+	case TimeRFC3339Nano, TimeRFC3339, TimeUnix, TimeUnixMilli, TimeUnixNano, TimeLayout:
+		return nil
+	default:
+		return ErrInvalidTimeFormat
+	}
+}
+
+// MarshalText turns time format to appropriate string
+// representation.
+func (tf TimeFormat) MarshalText() ([]byte, error) {
+	var v string
+
+	switch tf {
+	case TimeRFC3339Nano:
+		v = "rfc3339nano"
+	case TimeRFC3339:
+		v = "rfc3339"
+	case TimeUnix:
+		v = "unix"
+	case TimeUnixMilli:
+		v = "unix_milli"
+	case TimeUnixNano:
+		v = "unix_nano"
+	case TimeLayout:
+		v = "layout"
+	default:
+		return nil, ErrInvalidTimeFormat
+	}
+
+	return []byte(v), nil
+}
+
+// UnmarshalText turns string to appropriate time format value.
+func (tf *TimeFormat) UnmarshalText(d []byte) error {
+	switch string(d) {
+	case "rfc3339nano":
+		*tf = TimeRFC3339Nano
+	case "rfc3339":
+		*tf = TimeRFC3339
+	case "unix":
+		*tf = TimeUnix
+	case "unix_milli":
+		*tf = TimeUnixMilli
+	case "unix_nano":
+		*tf = TimeUnixNano
+	case "layout":
+		*tf = TimeLayout
+	default:
+		return ErrInvalidTimeFormat
+	}
+
+	return nil
+}
+
+// unitsPerSecond returns the number of Unix time format's units in a
+// second.
+func (tf TimeFormat) unitsPerSecond() int64 {
+	switch tf {
+	case TimeUnixMilli:
+		return int64(time.Second / time.Millisecond)
+	case TimeUnixNano:
+		return int64(time.Second)
+	default:
+		return 1
+	}
+}
+
+// JSONOptions specifies how candles are encoded to and decoded from
+// JSON. Its zero value encodes and decodes each candle the same way
+// as encoding/json does.
+// Can be included in configuration structures.
+type JSONOptions struct {
+	// OmitZero specifies candle fields that are omitted when their
+	// values are zero, e.g. volume of synthetic series. Missing
+	// adjusted close and volume delta are treated as zero.
+	OmitZero []CandleField `json:"omit_zero" yaml:"omit_zero"`
+
+	// NullZero specifies candle fields that are encoded as null when
+	// their values are zero, so that unknown values can be told
+	// apart from omitted ones. Missing adjusted close and volume
+	// delta are treated as zero.
+	NullZero []CandleField `json:"null_zero" yaml:"null_zero"`
+
+	// TimeFormat specifies how timestamps are encoded. Zero value
+	// is the same as TimeRFC3339Nano.
+	TimeFormat TimeFormat `json:"time_format" yaml:"time_format"`
+
+	// TimeLayout specifies the layout, as accepted by time.Format,
+	// used when time format is TimeLayout.
+	TimeLayout string `json:"time_layout" yaml:"time_layout"`
+}
+
+// Validate checks whether all JSON options' candle fields are valid,
+// whether no field is both omitted and nulled and whether the time
+// format is valid and has a layout if it needs one.
+func (o JSONOptions) Validate() error {
+	if o.TimeFormat != 0 {
+		if err := o.TimeFormat.Validate(); err != nil {
+			return err
+		}
+	}
+
+	if o.TimeFormat == TimeLayout && o.TimeLayout == "" {
+		return ErrInvalidJSONOptions
+	}
+
+	for _, cf := range o.OmitZero {
+		if err := cf.Validate(); err != nil {
+			return err
+		}
+
+		if containsCandleField(o.NullZero, cf) {
+			return ErrInvalidJSONOptions
+		}
+	}
+
+	for _, cf := range o.NullZero {
+		if err := cf.Validate(); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// MarshalCandleJSON encodes the candle to JSON using the provided
+// options.
+func MarshalCandleJSON(c Candle, o JSONOptions) ([]byte, error) {
+	if err := o.Validate(); err != nil {
+		return nil, err
+	}
+
+	return o.appendCandle(nil, c)
+}
+
+// MarshalCandlesJSON encodes the candles to a JSON array using the
+// provided options.
+func MarshalCandlesJSON(cc []Candle, o JSONOptions) ([]byte, error) {
+	if err := o.Validate(); err != nil {
+		return nil, err
+	}
+
+	b := []byte{'['}
+
+	for i, c := range cc {
+		if i > 0 {
+			b = append(b, ',')
+		}
+
+		var err error
+
+		b, err = o.appendCandle(b, c)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return append(b, ']'), nil
+}
+
+// UnmarshalCandleJSON decodes the candle from JSON using the provided
+// options. Null values are decoded as zero values.
+func UnmarshalCandleJSON(d []byte, o JSONOptions) (Candle, error) {
+	if err := o.Validate(); err != nil {
+		return Candle{}, err
+	}
+
+	var jc jsonCandle
+	if err := json.Unmarshal(d, &jc); err != nil {
+		return Candle{}, parseFailure(err)
+	}
+
+	return o.decodeCandle(jc)
+}
+
+// UnmarshalCandlesJSON decodes the candles from a JSON array using the
+// provided options. Null values are decoded as zero values.
+func UnmarshalCandlesJSON(d []byte, o JSONOptions) ([]Candle, error) {
+	if err := o.Validate(); err != nil {
+		return nil, err
+	}
+
+	var jcc []jsonCandle
+	if err := json.Unmarshal(d, &jcc); err != nil {
+		return nil, parseFailure(err)
+	}
+
+	res := make([]Candle, len(jcc))
+
+	for i, jc := range jcc {
+		c, err := o.decodeCandle(jc)
+		if err != nil {
+			return nil, err
+		}
+
+		res[i] = c
+	}
+
+	return res, nil
+}
+
+// jsonCandle is a candle whose timestamp is decoded separately.
+type jsonCandle struct {
+	Candle
+	Timestamp json.RawMessage `json:"timestamp"`
+}
+
+// decodeCandle returns the JSON candle with its timestamp decoded.
+func (o JSONOptions) decodeCandle(jc jsonCandle) (Candle, error) {
+	t, err := o.parseTime(jc.Timestamp)
+	if err != nil {
+		return Candle{}, err
+	}
+
+	c := jc.Candle
+	c.Timestamp = t
+
+	return c, nil
+}
+
+// parseTime decodes the timestamp's JSON representation. Missing
+// and null timestamps are decoded as zero time.
+func (o JSONOptions) parseTime(d []byte) (time.Time, error) {
+	if len(d) == 0 || string(d) == "null" {
+		return time.Time{}, nil
+	}
+
+	switch o.TimeFormat {
+	case TimeUnix, TimeUnixMilli, TimeUnixNano:
+		n, err := strconv.ParseInt(string(unquote(d)), 10, 64)
+		if err != nil {
+			return time.Time{}, parseFailure(err)
+		}
+
+		u := o.TimeFormat.unitsPerSecond()
+
+		return time.Unix(n/u, n%u*(int64(time.Second)/u)).UTC(), nil
+	case TimeRFC3339, TimeLayout:
+		var s string
+		if err := json.Unmarshal(d, &s); err != nil {
+			return time.Time{}, parseFailure(err)
+		}
+
+		t, err := time.Parse(o.layout(), s)
+		if err != nil {
+			return time.Time{}, parseFailure(err)
+		}
+
+		return t, nil
+	default:
+		var t time.Time
+		if err := t.UnmarshalJSON(d); err != nil {
+			return time.Time{}, parseFailure(err)
+		}
+
+		return t, nil
+	}
+}
+
+// appendTime appends the timestamp's JSON representation to the byte
+// slice.
+func (o JSONOptions) appendTime(b []byte, t time.Time) ([]byte, error) {
+	switch o.TimeFormat {
+	case TimeUnix, TimeUnixMilli, TimeUnixNano:
+		u := o.TimeFormat.unitsPerSecond()
+		n := t.Unix()*u + int64(t.Nanosecond())/(int64(time.Second)/u)
+
+		return strconv.AppendInt(b, n, 10), nil
+	case TimeRFC3339, TimeLayout:
+		d, _ := json.Marshal(t.Format(o.layout())) //nolint:errcheck // strings are always encodable
+
+		return append(b, d...), nil
+	default:
+		d, err := t.MarshalJSON()
+		if err != nil {
+			return nil, err
+		}
+
+		return append(b, d...), nil
+	}
+}
+
+// layout returns the layout of string time formats.
+func (o JSONOptions) layout() string {
+	if o.TimeFormat == TimeLayout {
+		return o.TimeLayout
+	}
+
+	return time.RFC3339
+}
+
+// appendCandle appends the candle's JSON object to the byte slice.
+func (o JSONOptions) appendCandle(b []byte, c Candle) ([]byte, error) {
+	b = append(b, `{"timestamp":`...)
+
+	b, err := o.appendTime(b, c.Timestamp)
+	if err != nil {
+		return nil, err
+	}
+
+	for cf := CandleOpen; cf <= CandleDelta; cf++ {
+		var v *decimal.Decimal
+
+		switch cf {
+		case CandleAdjClose:
+			v = c.AdjClose
+		case CandleDelta:
+			v = c.Delta
+		default:
+			d := cf.Extract(c)
+			v = &d
+		}
+
+		zero := v == nil || v.IsZero()
+
+		switch {
+		case zero && containsCandleField(o.OmitZero, cf):
+			continue
+		case zero && containsCandleField(o.NullZero, cf):
+			b = appendJSONKey(b, cf)
+			b = append(b, "null"...)
+		case v != nil:
+			b = appendJSONKey(b, cf)
+			d, _ := v.MarshalJSON() //nolint:errcheck // decimals are always encodable
+			b = append(b, d...)
+		}
+	}
+
+	return append(b, '}'), nil
+}
+
+// appendJSONKey appends a comma and the candle field's quoted name
+// followed by a colon to the byte slice.
+func appendJSONKey(b []byte, cf CandleField) []byte {
+	b = append(b, ',', '"')
+	b = append(b, candleFieldTexts[cf]...)
+
+	return append(b, '"', ':')
+}
+
+// containsCandleField checks whether the candle field is in the
+// slice.
+func containsCandleField(cff []CandleField, cf CandleField) bool {
+	for _, f := range cff {
+		if f == cf {
+			return true
+		}
+	}
+
+	return false
+}
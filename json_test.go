@@ -0,0 +1,545 @@
+package chartype
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_TimeFormat_Validate(t *testing.T) {
+	cc := map[string]struct {
+		Format TimeFormat
+		Err    error
+	}{
+		"Invalid TimeFormat": {
+			Format: 70,
+			Err:    ErrInvalidTimeFormat,
+		},
+		"Successful TimeRFC3339Nano validation": {
+			Format: TimeRFC3339Nano,
+		},
+		"Successful TimeRFC3339 validation": {
+			Format: TimeRFC3339,
+		},
+		"Successful TimeUnix validation": {
+			Format: TimeUnix,
+		},
+		"Successful TimeUnixMilli validation": {
+			Format: TimeUnixMilli,
+		},
+		"Successful TimeUnixNano validation": {
+			Format: TimeUnixNano,
+		},
+		"Successful TimeLayout validation": {
+			Format: TimeLayout,
+		},
+	}
+
+	for cn, c := range cc {
+		c := c
+
+		t.Run(cn, func(t *testing.T) {
+			t.Parallel()
+
+			equalError(t, c.Err, c.Format.Validate())
+		})
+	}
+}
+
+func Test_TimeFormat_MarshalText(t *testing.T) {
+	cc := map[string]struct {
+		Format TimeFormat
+		Text   string
+		Err    error
+	}{
+		"Invalid TimeFormat": {
+			Format: 70,
+			Err:    ErrInvalidTimeFormat,
+		},
+		"Successful TimeRFC3339Nano marshal": {
+			Format: TimeRFC3339Nano,
+			Text:   "rfc3339nano",
+		},
+		"Successful TimeRFC3339 marshal": {
+			Format: TimeRFC3339,
+			Text:   "rfc3339",
+		},
+		"Successful TimeUnix marshal": {
+			Format: TimeUnix,
+			Text:   "unix",
+		},
+		"Successful TimeUnixMilli marshal": {
+			Format: TimeUnixMilli,
+			Text:   "unix_milli",
+		},
+		"Successful TimeUnixNano marshal": {
+			Format: TimeUnixNano,
+			Text:   "unix_nano",
+		},
+		"Successful TimeLayout marshal": {
+			Format: TimeLayout,
+			Text:   "layout",
+		},
+	}
+
+	for cn, c := range cc {
+		c := c
+
+		t.Run(cn, func(t *testing.T) {
+			t.Parallel()
+
+			d, err := c.Format.MarshalText()
+			equalError(t, c.Err, err)
+			if err != nil {
+				return
+			}
+
+			assert.Equal(t, c.Text, string(d))
+		})
+	}
+}
+
+func Test_TimeFormat_UnmarshalText(t *testing.T) {
+	cc := map[string]struct {
+		Text   string
+		Result TimeFormat
+		Err    error
+	}{
+		"Invalid TimeFormat": {
+			Text: "x",
+			Err:  ErrInvalidTimeFormat,
+		},
+		"Successful TimeRFC3339Nano unmarshal": {
+			Text:   "rfc3339nano",
+			Result: TimeRFC3339Nano,
+		},
+		"Successful TimeRFC3339 unmarshal": {
+			Text:   "rfc3339",
+			Result: TimeRFC3339,
+		},
+		"Successful TimeUnix unmarshal": {
+			Text:   "unix",
+			Result: TimeUnix,
+		},
+		"Successful TimeUnixMilli unmarshal": {
+			Text:   "unix_milli",
+			Result: TimeUnixMilli,
+		},
+		"Successful TimeUnixNano unmarshal": {
+			Text:   "unix_nano",
+			Result: TimeUnixNano,
+		},
+		"Successful TimeLayout unmarshal": {
+			Text:   "layout",
+			Result: TimeLayout,
+		},
+	}
+
+	for cn, c := range cc {
+		c := c
+
+		t.Run(cn, func(t *testing.T) {
+			t.Parallel()
+
+			var tf TimeFormat
+
+			err := tf.UnmarshalText([]byte(c.Text))
+			equalError(t, c.Err, err)
+			if err != nil {
+				return
+			}
+
+			assert.Equal(t, c.Result, tf)
+		})
+	}
+}
+
+func Test_JSONOptions_Validate(t *testing.T) {
+	cc := map[string]struct {
+		Options JSONOptions
+		Err     error
+	}{
+		"Invalid omitted field": {
+			Options: JSONOptions{OmitZero: []CandleField{70}},
+			Err:     ErrInvalidCandleField,
+		},
+		"Invalid nulled field": {
+			Options: JSONOptions{NullZero: []CandleField{70}},
+			Err:     ErrInvalidCandleField,
+		},
+		"Invalid time format": {
+			Options: JSONOptions{TimeFormat: 70},
+			Err:     ErrInvalidTimeFormat,
+		},
+		"Missing time layout": {
+			Options: JSONOptions{TimeFormat: TimeLayout},
+			Err:     ErrInvalidJSONOptions,
+		},
+		"Field both omitted and nulled": {
+			Options: JSONOptions{
+				OmitZero: []CandleField{CandleOpen, CandleVolume},
+				NullZero: []CandleField{CandleVolume},
+			},
+			Err: ErrInvalidJSONOptions,
+		},
+		"Successful validation": {
+			Options: JSONOptions{
+				OmitZero:   []CandleField{CandleVolume},
+				NullZero:   []CandleField{CandleAdjClose},
+				TimeFormat: TimeLayout,
+				TimeLayout: "2006-01-02",
+			},
+		},
+	}
+
+	for cn, c := range cc {
+		c := c
+
+		t.Run(cn, func(t *testing.T) {
+			t.Parallel()
+
+			equalError(t, c.Err, c.Options.Validate())
+		})
+	}
+}
+
+func Test_MarshalCandleJSON(t *testing.T) {
+	tm := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	c := testCandle(tm, 1, 2, 0, 2, 0)
+
+	cc := map[string]struct {
+		Candle  Candle
+		Options JSONOptions
+		Result  string
+		Err     error
+	}{
+		"Invalid options": {
+			Options: JSONOptions{NullZero: []CandleField{70}},
+			Err:     ErrInvalidCandleField,
+		},
+		"Invalid timestamp": {
+			Candle: Candle{Timestamp: time.Date(10000, 1, 1, 0, 0, 0, 0, time.UTC)},
+			Err:    assert.AnError,
+		},
+		"Successful marshal with zero options": {
+			Candle: c,
+			Result: `{"timestamp":"2020-01-01T00:00:00Z","open":"1","high":"2","low":"0","close":"2","volume":"0"}`,
+		},
+		"Successful marshal with omitted zero fields": {
+			Candle: c,
+			Options: JSONOptions{
+				OmitZero: []CandleField{CandleLow, CandleVolume, CandleAdjClose},
+			},
+			Result: `{"timestamp":"2020-01-01T00:00:00Z","open":"1","high":"2","close":"2"}`,
+		},
+		"Successful marshal with nulled zero fields": {
+			Candle: c,
+			Options: JSONOptions{
+				OmitZero: []CandleField{CandleLow},
+				NullZero: []CandleField{CandleVolume, CandleAdjClose},
+			},
+			Result: `{"timestamp":"2020-01-01T00:00:00Z","open":"1","high":"2","close":"2","volume":null,"adj_close":null}`,
+		},
+		"Successful marshal with RFC 3339 nano timestamp": {
+			Candle:  Candle{Timestamp: tm.Add(time.Millisecond)},
+			Options: JSONOptions{TimeFormat: TimeRFC3339Nano},
+			Result:  `{"timestamp":"2020-01-01T00:00:00.001Z","open":"0","high":"0","low":"0","close":"0","volume":"0"}`,
+		},
+		"Successful marshal with RFC 3339 timestamp": {
+			Candle:  Candle{Timestamp: tm.Add(time.Millisecond)},
+			Options: JSONOptions{TimeFormat: TimeRFC3339},
+			Result:  `{"timestamp":"2020-01-01T00:00:00Z","open":"0","high":"0","low":"0","close":"0","volume":"0"}`,
+		},
+		"Successful marshal with Unix timestamp": {
+			Candle:  Candle{Timestamp: tm.Add(time.Millisecond)},
+			Options: JSONOptions{TimeFormat: TimeUnix},
+			Result:  `{"timestamp":1577836800,"open":"0","high":"0","low":"0","close":"0","volume":"0"}`,
+		},
+		"Successful marshal with Unix milliseconds timestamp": {
+			Candle:  Candle{Timestamp: tm.Add(time.Millisecond + time.Microsecond)},
+			Options: JSONOptions{TimeFormat: TimeUnixMilli},
+			Result:  `{"timestamp":1577836800001,"open":"0","high":"0","low":"0","close":"0","volume":"0"}`,
+		},
+		"Successful marshal with Unix nanoseconds timestamp": {
+			Candle:  Candle{Timestamp: tm.Add(time.Nanosecond)},
+			Options: JSONOptions{TimeFormat: TimeUnixNano},
+			Result:  `{"timestamp":1577836800000000001,"open":"0","high":"0","low":"0","close":"0","volume":"0"}`,
+		},
+		"Successful marshal with custom layout timestamp": {
+			Candle:  Candle{Timestamp: tm},
+			Options: JSONOptions{TimeFormat: TimeLayout, TimeLayout: `2006-01-02 "15h"`},
+			Result:  `{"timestamp":"2020-01-01 \"00h\"","open":"0","high":"0","low":"0","close":"0","volume":"0"}`,
+		},
+		"Successful marshal with non-zero nulled fields": {
+			Candle: func() Candle {
+				c := testCandle(tm, 1, 2, 1, 2, 3)
+				c.AdjClose = decimalPtr(1)
+
+				return c
+			}(),
+			Options: JSONOptions{
+				NullZero: []CandleField{CandleVolume, CandleAdjClose},
+			},
+			Result: `{"timestamp":"2020-01-01T00:00:00Z","open":"1","high":"2","low":"1","close":"2","volume":"3","adj_close":"1"}`,
+		},
+		"Successful marshal with volume delta": {
+			Candle:  testBuiltCandle(tm, 1, 2, 1, 2, 3, -1),
+			Options: JSONOptions{NullZero: []CandleField{CandleAdjClose}},
+			Result:  `{"timestamp":"2020-01-01T00:00:00Z","open":"1","high":"2","low":"1","close":"2","volume":"3","adj_close":null,"delta":"-1"}`,
+		},
+		"Successful marshal with nulled missing volume delta": {
+			Candle:  testCandle(tm, 1, 2, 1, 2, 3),
+			Options: JSONOptions{NullZero: []CandleField{CandleDelta}},
+			Result:  `{"timestamp":"2020-01-01T00:00:00Z","open":"1","high":"2","low":"1","close":"2","volume":"3","delta":null}`,
+		},
+	}
+
+	for cn, c := range cc {
+		c := c
+
+		t.Run(cn, func(t *testing.T) {
+			t.Parallel()
+
+			res, err := MarshalCandleJSON(c.Candle, c.Options)
+			equalError(t, c.Err, err)
+			if err != nil {
+				return
+			}
+
+			assert.Equal(t, c.Result, string(res))
+		})
+	}
+}
+
+func Test_MarshalCandlesJSON(t *testing.T) {
+	tm := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	adj := testCandle(tm.Add(time.Hour), 1, 2, 1, 2, 3)
+	adj.AdjClose = decimalPtr(1)
+
+	series := []Candle{testCandle(tm, 1, 2, 0, 2, 0), adj}
+
+	cc := map[string]struct {
+		Candles []Candle
+		Options JSONOptions
+		Result  string
+		Err     error
+	}{
+		"Invalid options": {
+			Options: JSONOptions{OmitZero: []CandleField{70}},
+			Err:     ErrInvalidCandleField,
+		},
+		"Invalid timestamp": {
+			Candles: []Candle{{Timestamp: time.Date(-1, 1, 1, 0, 0, 0, 0, time.UTC)}},
+			Err:     assert.AnError,
+		},
+		"Successful marshal of no candles": {
+			Result: `[]`,
+		},
+		"Successful marshal with zero options": {
+			Candles: series,
+			Result: func() string {
+				d, err := json.Marshal(series)
+				assert.NoError(t, err)
+
+				return string(d)
+			}(),
+		},
+		"Successful marshal with omitted zero fields": {
+			Candles: series,
+			Options: JSONOptions{OmitZero: []CandleField{CandleVolume}},
+			Result: `[{"timestamp":"2020-01-01T00:00:00Z","open":"1","high":"2","low":"0","close":"2"},` +
+				`{"timestamp":"2020-01-01T01:00:00Z","open":"1","high":"2","low":"1","close":"2","volume":"3","adj_close":"1"}]`,
+		},
+	}
+
+	for cn, c := range cc {
+		c := c
+
+		t.Run(cn, func(t *testing.T) {
+			t.Parallel()
+
+			res, err := MarshalCandlesJSON(c.Candles, c.Options)
+			equalError(t, c.Err, err)
+			if err != nil {
+				return
+			}
+
+			assert.Equal(t, c.Result, string(res))
+		})
+	}
+}
+
+func Test_UnmarshalCandleJSON(t *testing.T) {
+	tm := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	cc := map[string]struct {
+		JSON    string
+		Options JSONOptions
+		Result  Candle
+		Err     error
+	}{
+		"Invalid options": {
+			JSON:    `{}`,
+			Options: JSONOptions{TimeFormat: 70},
+			Err:     ErrInvalidTimeFormat,
+		},
+		"Invalid JSON": {
+			JSON: `{`,
+			Err:  assert.AnError,
+		},
+		"Invalid RFC 3339 nano timestamp": {
+			JSON: `{"timestamp":"x"}`,
+			Err:  assert.AnError,
+		},
+		"Invalid Unix timestamp": {
+			JSON:    `{"timestamp":"x"}`,
+			Options: JSONOptions{TimeFormat: TimeUnix},
+			Err:     assert.AnError,
+		},
+		"Invalid layout timestamp type": {
+			JSON:    `{"timestamp":1}`,
+			Options: JSONOptions{TimeFormat: TimeRFC3339},
+			Err:     assert.AnError,
+		},
+		"Invalid layout timestamp": {
+			JSON:    `{"timestamp":"2020-01-01T00:00:00Z"}`,
+			Options: JSONOptions{TimeFormat: TimeLayout, TimeLayout: "2006-01-02"},
+			Err:     assert.AnError,
+		},
+		"Successful unmarshal with null values": {
+			JSON: `{"timestamp":null,"open":"1","high":"2","low":null,"close":"2","volume":null,"adj_close":null}`,
+			Result: Candle{
+				Open:  decimal.NewFromInt(1),
+				High:  decimal.NewFromInt(2),
+				Close: decimal.NewFromInt(2),
+			},
+		},
+		"Successful unmarshal with RFC 3339 nano timestamp": {
+			JSON: `{"timestamp":"2020-01-01T00:00:00.001Z","open":"1","high":"2","low":"1","close":"2","volume":"3","adj_close":"1"}`,
+			Result: func() Candle {
+				c := testCandle(tm.Add(time.Millisecond), 1, 2, 1, 2, 3)
+				c.AdjClose = decimalPtr(1)
+
+				return c
+			}(),
+		},
+		"Successful unmarshal with RFC 3339 timestamp": {
+			JSON:    `{"timestamp":"2020-01-01T02:00:00+02:00"}`,
+			Options: JSONOptions{TimeFormat: TimeRFC3339},
+			Result:  Candle{Timestamp: time.Date(2020, 1, 1, 2, 0, 0, 0, time.FixedZone("", 2*60*60))},
+		},
+		"Successful unmarshal with Unix timestamp": {
+			JSON:    `{"timestamp":1577836800}`,
+			Options: JSONOptions{TimeFormat: TimeUnix},
+			Result:  Candle{Timestamp: tm},
+		},
+		"Successful unmarshal with quoted Unix milliseconds timestamp": {
+			JSON:    `{"timestamp":"1577836800001"}`,
+			Options: JSONOptions{TimeFormat: TimeUnixMilli},
+			Result:  Candle{Timestamp: tm.Add(time.Millisecond)},
+		},
+		"Successful unmarshal with negative Unix milliseconds timestamp": {
+			JSON:    `{"timestamp":-1}`,
+			Options: JSONOptions{TimeFormat: TimeUnixMilli},
+			Result:  Candle{Timestamp: time.Unix(0, 0).UTC().Add(-time.Millisecond)},
+		},
+		"Successful unmarshal with Unix nanoseconds timestamp": {
+			JSON:    `{"timestamp":1577836800000000001}`,
+			Options: JSONOptions{TimeFormat: TimeUnixNano},
+			Result:  Candle{Timestamp: tm.Add(time.Nanosecond)},
+		},
+		"Successful unmarshal with custom layout timestamp": {
+			JSON:    `{"timestamp":"2020-01-01"}`,
+			Options: JSONOptions{TimeFormat: TimeLayout, TimeLayout: "2006-01-02"},
+			Result:  Candle{Timestamp: tm},
+		},
+	}
+
+	for cn, c := range cc {
+		c := c
+
+		t.Run(cn, func(t *testing.T) {
+			t.Parallel()
+
+			res, err := UnmarshalCandleJSON([]byte(c.JSON), c.Options)
+			equalError(t, c.Err, err)
+			if err != nil {
+				return
+			}
+
+			assert.True(t, c.Result.Timestamp.Equal(res.Timestamp))
+			c.Result.Timestamp, res.Timestamp = time.Time{}, time.Time{}
+			assert.Equal(t, c.Result, res)
+		})
+	}
+}
+
+func Test_UnmarshalCandlesJSON(t *testing.T) {
+	tm := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	cc := map[string]struct {
+		JSON    string
+		Options JSONOptions
+		Result  []Candle
+		Err     error
+	}{
+		"Invalid options": {
+			JSON:    `[]`,
+			Options: JSONOptions{TimeFormat: TimeLayout},
+			Err:     ErrInvalidJSONOptions,
+		},
+		"Invalid JSON": {
+			JSON: `{}`,
+			Err:  assert.AnError,
+		},
+		"Invalid timestamp": {
+			JSON:    `[{"timestamp":1},{"timestamp":"x"}]`,
+			Options: JSONOptions{TimeFormat: TimeUnix},
+			Err:     assert.AnError,
+		},
+		"Successful unmarshal": {
+			JSON:    `[{"timestamp":1577836800,"close":"1"},{"timestamp":1577840400,"close":"2"}]`,
+			Options: JSONOptions{TimeFormat: TimeUnix},
+			Result: []Candle{
+				{Timestamp: tm, Close: decimal.NewFromInt(1)},
+				{Timestamp: tm.Add(time.Hour), Close: decimal.NewFromInt(2)},
+			},
+		},
+	}
+
+	for cn, c := range cc {
+		c := c
+
+		t.Run(cn, func(t *testing.T) {
+			t.Parallel()
+
+			res, err := UnmarshalCandlesJSON([]byte(c.JSON), c.Options)
+			equalError(t, c.Err, err)
+			if err != nil {
+				return
+			}
+
+			assert.Equal(t, c.Result, res)
+		})
+	}
+}
+
+func Test_JSON_RoundTrip(t *testing.T) {
+	tm := time.Date(2020, 1, 1, 0, 0, 0, 123000000, time.UTC)
+	cc := []Candle{testCandle(tm, 1, 2, 1, 2, 3), testCandle(tm.Add(time.Hour), 2, 3, 1, 2, 0)}
+	cc[1].Volume = decimal.Decimal{}
+
+	for _, tf := range []TimeFormat{TimeRFC3339Nano, TimeUnixMilli, TimeUnixNano} {
+		o := JSONOptions{OmitZero: []CandleField{CandleVolume}, TimeFormat: tf}
+
+		d, err := MarshalCandlesJSON(cc, o)
+		assert.NoError(t, err)
+
+		res, err := UnmarshalCandlesJSON(d, o)
+		assert.NoError(t, err)
+		assert.Equal(t, cc, res)
+	}
+}
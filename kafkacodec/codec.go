@@ -0,0 +1,351 @@
+// Package kafkacodec provides a common Kafka message framing for
+// chartype's structures: payload encoding in one of the supported
+// formats and partition keys derived from pair and interval.
+//
+// Messages are client-agnostic and should be mapped to the
+// message type of the Kafka client in use.
+package kafkacodec
+
+import (
+	"bytes"
+	"encoding/json"
+
+	"github.com/jellydator/chartype"
+	"github.com/jellydator/chartype/avro"
+	"github.com/jellydator/chartype/flatbuf"
+)
+
+const (
+	// FormatJSON specifies that payloads are encoded as JSON.
+	FormatJSON Format = iota + 1
+
+	// FormatFlatBuffers specifies that payloads are encoded as
+	// FlatBuffers tables defined in the flatbuf package.
+	FormatFlatBuffers
+
+	// FormatAvro specifies that payloads are Avro binary encoded
+	// records defined in the avro package.
+	FormatAvro
+)
+
+// ContentTypeHeader is the name of the header that holds payload's
+// media type.
+const ContentTypeHeader = "content-type"
+
+// keySeparator separates pair and interval in message keys.
+const keySeparator = ":"
+
+var (
+	// ErrInvalidFormat is returned when format with invalid value
+	// is being used.
+	ErrInvalidFormat = &chartype.Error{
+		Code:    chartype.CodeInvalidArgument,
+		Message: "invalid format",
+	}
+
+	// ErrInvalidKey is returned when message key cannot be parsed.
+	ErrInvalidKey = &chartype.Error{
+		Code:    chartype.CodeParseFailure,
+		Message: "invalid key",
+	}
+
+	// ErrInvalidSchemaID is returned when Avro payload's schema ID
+	// does not match the configured one.
+	ErrInvalidSchemaID = &chartype.Error{
+		Code:    chartype.CodeIncompatible,
+		Message: "invalid schema id",
+	}
+)
+
+// Format specifies message payload's encoding.
+// Can be included in configuration structures.
+type Format int
+
+// Validate checks whether the format is one of supported format
+// types or not.
+func (f Format) Validate() error {
+	switch f {
+	case FormatJSON, FormatFlatBuffers, FormatAvro:
+		return nil
+	default:
+		return ErrInvalidFormat
+	}
+}
+
+// ContentType returns format's media type.
+func (f Format) ContentType() string {
+	switch f {
+	case FormatJSON:
+		return "application/json"
+	case FormatFlatBuffers:
+		return "application/x-flatbuffers"
+	case FormatAvro:
+		return "application/avro"
+	default:
+		return ""
+	}
+}
+
+// MarshalText turns format to appropriate string representation.
+func (f Format) MarshalText() ([]byte, error) {
+	var v string
+
+	switch f {
+	case FormatJSON:
+		v = "json"
+	case FormatFlatBuffers:
+		v = "flatbuffers"
+	case FormatAvro:
+		v = "avro"
+	default:
+		return nil, ErrInvalidFormat
+	}
+
+	return []byte(v), nil
+}
+
+// UnmarshalText turns string to appropriate format value.
+func (f *Format) UnmarshalText(d []byte) error {
+	switch string(d) {
+	case "json":
+		*f = FormatJSON
+	case "flatbuffers", "fb":
+		*f = FormatFlatBuffers
+	case "avro":
+		*f = FormatAvro
+	default:
+		return ErrInvalidFormat
+	}
+
+	return nil
+}
+
+// Message is a client-agnostic Kafka message.
+type Message struct {
+	Key     []byte
+	Value   []byte
+	Headers map[string]string
+}
+
+// CandleKey returns partition key of the pair's candle series,
+// e.g. "BTC_USDT:1h". All candles of a series share a partition and
+// thus retain their order.
+func CandleKey(p chartype.Pair, i chartype.Interval) []byte {
+	return []byte(p.String() + keySeparator + i.String())
+}
+
+// ParseCandleKey parses partition key produced by CandleKey.
+func ParseCandleKey(k []byte) (chartype.Pair, chartype.Interval, error) {
+	kk := bytes.Split(k, []byte(keySeparator))
+	if len(kk) != 2 {
+		return chartype.Pair{}, 0, ErrInvalidKey
+	}
+
+	var (
+		p chartype.Pair
+		i chartype.Interval
+	)
+
+	if err := p.UnmarshalText(kk[0]); err != nil {
+		return chartype.Pair{}, 0, err
+	}
+
+	if err := i.UnmarshalText(kk[1]); err != nil {
+		return chartype.Pair{}, 0, err
+	}
+
+	return p, i, nil
+}
+
+// Codec encodes and decodes Kafka messages.
+type Codec struct {
+	// Format specifies payload's encoding.
+	Format Format
+
+	// CandleSchemaID, TickerSchemaID and TradeSchemaID specify
+	// schema registry IDs of Avro payloads. When set, Avro payloads
+	// use the schema registry wire format.
+	CandleSchemaID uint32
+	TickerSchemaID uint32
+	TradeSchemaID  uint32
+}
+
+// EncodeCandle returns a message holding the candle of the pair's
+// interval-long series.
+func (c Codec) EncodeCandle(p chartype.Pair, i chartype.Interval, cd chartype.Candle) (Message, error) {
+	if err := p.Validate(); err != nil {
+		return Message{}, err
+	}
+
+	if err := i.Validate(); err != nil {
+		return Message{}, err
+	}
+
+	return c.message(CandleKey(p, i), c.CandleSchemaID, cd, func() ([]byte, error) {
+		return flatbuf.MarshalCandle(cd), nil
+	}, func() ([]byte, error) {
+		return avro.EncodeCandle(nil, cd), nil
+	})
+}
+
+// DecodeCandle decodes message produced by EncodeCandle.
+func (c Codec) DecodeCandle(m Message) (chartype.Pair, chartype.Interval, chartype.Candle, error) {
+	p, i, err := ParseCandleKey(m.Key)
+	if err != nil {
+		return chartype.Pair{}, 0, chartype.Candle{}, err
+	}
+
+	var cd chartype.Candle
+
+	err = c.payload(m.Value, c.CandleSchemaID, &cd, func(d []byte) (err error) {
+		cd, err = flatbuf.UnmarshalCandle(d)
+		return err
+	}, func(d []byte) (err error) {
+		cd, err = avro.DecodeCandle(d)
+		return err
+	})
+	if err != nil {
+		return chartype.Pair{}, 0, chartype.Candle{}, err
+	}
+
+	return p, i, cd, nil
+}
+
+// EncodeTicker returns a message holding the pair's ticker. The
+// pair is used as message's key.
+func (c Codec) EncodeTicker(p chartype.Pair, t chartype.Ticker) (Message, error) {
+	k, err := p.MarshalText()
+	if err != nil {
+		return Message{}, err
+	}
+
+	return c.message(k, c.TickerSchemaID, t, func() ([]byte, error) {
+		return flatbuf.MarshalTicker(t), nil
+	}, func() ([]byte, error) {
+		return avro.EncodeTicker(nil, t), nil
+	})
+}
+
+// DecodeTicker decodes message produced by EncodeTicker.
+func (c Codec) DecodeTicker(m Message) (chartype.Pair, chartype.Ticker, error) {
+	var (
+		p chartype.Pair
+		t chartype.Ticker
+	)
+
+	if err := p.UnmarshalText(m.Key); err != nil {
+		return chartype.Pair{}, chartype.Ticker{}, err
+	}
+
+	err := c.payload(m.Value, c.TickerSchemaID, &t, func(d []byte) (err error) {
+		t, err = flatbuf.UnmarshalTicker(d)
+		return err
+	}, func(d []byte) (err error) {
+		t, err = avro.DecodeTicker(d)
+		return err
+	})
+	if err != nil {
+		return chartype.Pair{}, chartype.Ticker{}, err
+	}
+
+	return p, t, nil
+}
+
+// EncodeTrade returns a message holding the pair's trade. The pair
+// is used as message's key. Trade's side must be valid.
+func (c Codec) EncodeTrade(p chartype.Pair, t chartype.Trade) (Message, error) {
+	k, err := p.MarshalText()
+	if err != nil {
+		return Message{}, err
+	}
+
+	return c.message(k, c.TradeSchemaID, t, func() ([]byte, error) {
+		return flatbuf.MarshalTrade(t), nil
+	}, func() ([]byte, error) {
+		return avro.EncodeTrade(nil, t)
+	})
+}
+
+// DecodeTrade decodes message produced by EncodeTrade.
+func (c Codec) DecodeTrade(m Message) (chartype.Pair, chartype.Trade, error) {
+	var (
+		p chartype.Pair
+		t chartype.Trade
+	)
+
+	if err := p.UnmarshalText(m.Key); err != nil {
+		return chartype.Pair{}, chartype.Trade{}, err
+	}
+
+	err := c.payload(m.Value, c.TradeSchemaID, &t, func(d []byte) (err error) {
+		t, err = flatbuf.UnmarshalTrade(d)
+		return err
+	}, func(d []byte) (err error) {
+		t, err = avro.DecodeTrade(d)
+		return err
+	})
+	if err != nil {
+		return chartype.Pair{}, chartype.Trade{}, err
+	}
+
+	return p, t, nil
+}
+
+// message encodes value in codec's format and wraps it into
+// a message with the provided key.
+func (c Codec) message(k []byte, schemaID uint32, v interface{}, fb, av func() ([]byte, error)) (Message, error) {
+	var (
+		d   []byte
+		err error
+	)
+
+	switch c.Format {
+	case FormatJSON:
+		d, err = json.Marshal(v)
+	case FormatFlatBuffers:
+		d, err = fb()
+	case FormatAvro:
+		if d, err = av(); err == nil && schemaID != 0 {
+			d = avro.Frame(schemaID, d)
+		}
+	default:
+		return Message{}, ErrInvalidFormat
+	}
+
+	if err != nil {
+		return Message{}, err
+	}
+
+	return Message{
+		Key:     k,
+		Value:   d,
+		Headers: map[string]string{ContentTypeHeader: c.Format.ContentType()},
+	}, nil
+}
+
+// payload decodes data encoded in codec's format into v.
+func (c Codec) payload(d []byte, schemaID uint32, v interface{}, fb, av func([]byte) error) error {
+	switch c.Format {
+	case FormatJSON:
+		return json.Unmarshal(d, v)
+	case FormatFlatBuffers:
+		return fb(d)
+	case FormatAvro:
+		if schemaID != 0 {
+			id, ad, err := avro.Unframe(d)
+			if err != nil {
+				return err
+			}
+
+			if id != schemaID {
+				return ErrInvalidSchemaID
+			}
+
+			d = ad
+		}
+
+		return av(d)
+	default:
+		return ErrInvalidFormat
+	}
+}
@@ -0,0 +1,327 @@
+package kafkacodec
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/jellydator/chartype"
+	"github.com/jellydator/chartype/avro"
+	"github.com/jellydator/chartype/chartypetest"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+)
+
+var testPair = chartype.Pair{Base: "BTC", Quote: "USDT"} //nolint:gochecknoglobals // test data
+
+func Test_Format_Validate(t *testing.T) {
+	assert.Equal(t, ErrInvalidFormat, Format(70).Validate())
+	assert.NoError(t, FormatJSON.Validate())
+	assert.NoError(t, FormatFlatBuffers.Validate())
+	assert.NoError(t, FormatAvro.Validate())
+}
+
+func Test_Format_ContentType(t *testing.T) {
+	assert.Equal(t, "application/json", FormatJSON.ContentType())
+	assert.Equal(t, "application/x-flatbuffers", FormatFlatBuffers.ContentType())
+	assert.Equal(t, "application/avro", FormatAvro.ContentType())
+	assert.Equal(t, "", Format(70).ContentType())
+}
+
+func Test_Format_MarshalText(t *testing.T) {
+	cc := map[string]struct {
+		Format Format
+		Text   string
+		Err    error
+	}{
+		"Invalid Format": {
+			Format: 70,
+			Err:    ErrInvalidFormat,
+		},
+		"Successful FormatJSON marshal": {
+			Format: FormatJSON,
+			Text:   "json",
+		},
+		"Successful FormatFlatBuffers marshal": {
+			Format: FormatFlatBuffers,
+			Text:   "flatbuffers",
+		},
+		"Successful FormatAvro marshal": {
+			Format: FormatAvro,
+			Text:   "avro",
+		},
+	}
+
+	for cn, c := range cc {
+		c := c
+
+		t.Run(cn, func(t *testing.T) {
+			t.Parallel()
+
+			d, err := c.Format.MarshalText()
+			chartypetest.AssertErrorEqual(t, c.Err, err)
+			if err != nil {
+				return
+			}
+
+			assert.Equal(t, c.Text, string(d))
+		})
+	}
+}
+
+func Test_Format_UnmarshalText(t *testing.T) {
+	cc := map[string]struct {
+		Text   string
+		Result Format
+		Err    error
+	}{
+		"Invalid Format": {
+			Text: "xml",
+			Err:  ErrInvalidFormat,
+		},
+		"Successful FormatJSON unmarshal": {
+			Text:   "json",
+			Result: FormatJSON,
+		},
+		"Successful FormatFlatBuffers unmarshal": {
+			Text:   "flatbuffers",
+			Result: FormatFlatBuffers,
+		},
+		"Successful short FormatFlatBuffers unmarshal": {
+			Text:   "fb",
+			Result: FormatFlatBuffers,
+		},
+		"Successful FormatAvro unmarshal": {
+			Text:   "avro",
+			Result: FormatAvro,
+		},
+	}
+
+	for cn, c := range cc {
+		c := c
+
+		t.Run(cn, func(t *testing.T) {
+			t.Parallel()
+
+			var f Format
+
+			err := f.UnmarshalText([]byte(c.Text))
+			chartypetest.AssertErrorEqual(t, c.Err, err)
+			if err != nil {
+				return
+			}
+
+			assert.Equal(t, c.Result, f)
+		})
+	}
+}
+
+func Test_ParseCandleKey(t *testing.T) {
+	cc := map[string]struct {
+		Key      string
+		Pair     chartype.Pair
+		Interval chartype.Interval
+		Err      error
+	}{
+		"Missing separator": {
+			Key: "BTC_USDT",
+			Err: ErrInvalidKey,
+		},
+		"Invalid pair": {
+			Key: "BTC:1h",
+			Err: chartype.ErrInvalidPair,
+		},
+		"Invalid interval": {
+			Key: "BTC_USDT:1y",
+			Err: chartype.ErrInvalidInterval,
+		},
+		"Successful parse": {
+			Key:      "BTC_USDT:1h",
+			Pair:     testPair,
+			Interval: chartype.IntervalHour,
+		},
+	}
+
+	for cn, c := range cc {
+		c := c
+
+		t.Run(cn, func(t *testing.T) {
+			t.Parallel()
+
+			p, i, err := ParseCandleKey([]byte(c.Key))
+			chartypetest.AssertErrorEqual(t, c.Err, err)
+			if err != nil {
+				return
+			}
+
+			assert.Equal(t, c.Pair, p)
+			assert.Equal(t, c.Interval, i)
+		})
+	}
+}
+
+func testCodecs() map[string]Codec {
+	return map[string]Codec{
+		"JSON":        {Format: FormatJSON},
+		"FlatBuffers": {Format: FormatFlatBuffers},
+		"Avro":        {Format: FormatAvro},
+		"Framed Avro": {
+			Format:         FormatAvro,
+			CandleSchemaID: 1,
+			TickerSchemaID: 2,
+			TradeSchemaID:  3,
+		},
+	}
+}
+
+func Test_Codec_Candle(t *testing.T) {
+	cd := chartype.Candle{
+		Timestamp: time.Unix(60, 0).UTC(),
+		Open:      decimal.NewFromInt(1),
+		High:      decimal.NewFromInt(2),
+		Low:       decimal.NewFromInt(3),
+		Close:     decimal.NewFromInt(4),
+		Volume:    decimal.RequireFromString("0.5"),
+	}
+
+	adj := decimal.RequireFromString("3.5")
+	acd := cd
+	acd.AdjClose = &adj
+
+	delta := decimal.RequireFromString("-0.25")
+	dcd := acd
+	dcd.Delta = &delta
+
+	for cn, c := range testCodecs() {
+		c := c
+
+		t.Run(cn, func(t *testing.T) {
+			t.Parallel()
+
+			for _, cd := range []chartype.Candle{cd, acd, dcd} {
+				m, err := c.EncodeCandle(testPair, chartype.IntervalHour, cd)
+				assert.NoError(t, err)
+				assert.Equal(t, "BTC_USDT:1h", string(m.Key))
+				assert.Equal(t, c.Format.ContentType(), m.Headers[ContentTypeHeader])
+
+				p, i, res, err := c.DecodeCandle(m)
+				assert.NoError(t, err)
+				assert.Equal(t, testPair, p)
+				assert.Equal(t, chartype.IntervalHour, i)
+				assert.Equal(t, cd, res)
+			}
+		})
+	}
+}
+
+func Test_Codec_Ticker(t *testing.T) {
+	tk := chartype.Ticker{
+		Last:          decimal.NewFromInt(1),
+		Ask:           decimal.NewFromInt(2),
+		Bid:           decimal.NewFromInt(3),
+		Change:        decimal.NewFromInt(4),
+		PercentChange: chartype.NewPercent(decimal.NewFromInt(5)),
+		Volume:        decimal.NewFromInt(6),
+	}
+
+	for cn, c := range testCodecs() {
+		c := c
+
+		t.Run(cn, func(t *testing.T) {
+			t.Parallel()
+
+			m, err := c.EncodeTicker(testPair, tk)
+			assert.NoError(t, err)
+			assert.Equal(t, "BTC_USDT", string(m.Key))
+
+			p, res, err := c.DecodeTicker(m)
+			assert.NoError(t, err)
+			assert.Equal(t, testPair, p)
+			assert.Equal(t, tk, res)
+		})
+	}
+}
+
+func Test_Codec_Trade(t *testing.T) {
+	tr := chartype.Trade{
+		ID:        "1",
+		Timestamp: time.Unix(60, 0).UTC(),
+		Price:     decimal.NewFromInt(1),
+		Amount:    decimal.NewFromInt(2),
+		Side:      chartype.SideSell,
+	}
+
+	for cn, c := range testCodecs() {
+		c := c
+
+		t.Run(cn, func(t *testing.T) {
+			t.Parallel()
+
+			m, err := c.EncodeTrade(testPair, tr)
+			assert.NoError(t, err)
+			assert.Equal(t, "BTC_USDT", string(m.Key))
+
+			p, res, err := c.DecodeTrade(m)
+			assert.NoError(t, err)
+			assert.Equal(t, testPair, p)
+			assert.Equal(t, tr, res)
+		})
+	}
+}
+
+func Test_Codec_EncodeErrors(t *testing.T) {
+	json := Codec{Format: FormatJSON}
+
+	_, err := json.EncodeCandle(chartype.Pair{}, chartype.IntervalHour, chartype.Candle{})
+	assert.Equal(t, chartype.ErrInvalidPair, err)
+
+	_, err = json.EncodeCandle(testPair, 0, chartype.Candle{})
+	assert.Equal(t, chartype.ErrInvalidInterval, err)
+
+	_, err = Codec{}.EncodeCandle(testPair, chartype.IntervalHour, chartype.Candle{})
+	assert.Equal(t, ErrInvalidFormat, err)
+
+	_, err = json.EncodeTicker(chartype.Pair{}, chartype.Ticker{})
+	assert.Equal(t, chartype.ErrInvalidPair, err)
+
+	_, err = json.EncodeTrade(chartype.Pair{}, chartype.Trade{})
+	assert.Equal(t, chartype.ErrInvalidPair, err)
+
+	_, err = json.EncodeTrade(testPair, chartype.Trade{})
+	assert.True(t, errors.Is(err, chartype.ErrInvalidSide))
+
+	_, err = Codec{Format: FormatAvro}.EncodeTrade(testPair, chartype.Trade{})
+	assert.Equal(t, chartype.ErrInvalidSide, err)
+}
+
+func Test_Codec_DecodeErrors(t *testing.T) {
+	json := Codec{Format: FormatJSON}
+	avroc := Codec{Format: FormatAvro, CandleSchemaID: 1}
+
+	_, _, _, err := json.DecodeCandle(Message{Key: []byte("x")})
+	assert.Equal(t, ErrInvalidKey, err)
+
+	_, _, _, err = json.DecodeCandle(Message{Key: []byte("BTC_USDT:1h"), Value: []byte("{")})
+	assert.Error(t, err)
+
+	_, _, _, err = Codec{}.DecodeCandle(Message{Key: []byte("BTC_USDT:1h")})
+	assert.Equal(t, ErrInvalidFormat, err)
+
+	_, _, _, err = avroc.DecodeCandle(Message{Key: []byte("BTC_USDT:1h"), Value: []byte{1}})
+	assert.Equal(t, avro.ErrInvalidData, err)
+
+	_, _, _, err = avroc.DecodeCandle(Message{Key: []byte("BTC_USDT:1h"), Value: avro.Frame(2, nil)})
+	assert.Equal(t, ErrInvalidSchemaID, err)
+
+	_, _, err = json.DecodeTicker(Message{Key: []byte("x")})
+	assert.Equal(t, chartype.ErrInvalidPair, err)
+
+	_, _, err = json.DecodeTicker(Message{Key: []byte("BTC_USDT"), Value: []byte("{")})
+	assert.Error(t, err)
+
+	_, _, err = json.DecodeTrade(Message{Key: []byte("x")})
+	assert.Equal(t, chartype.ErrInvalidPair, err)
+
+	_, _, err = json.DecodeTrade(Message{Key: []byte("BTC_USDT"), Value: []byte("{")})
+	assert.Error(t, err)
+}
@@ -0,0 +1,47 @@
+package chartype
+
+import "bytes"
+
+// LenientCandleField is a candle field whose text unmarshaling
+// ignores letter case and surrounding whitespace, e.g. " Close ".
+// Can be included in configuration structures.
+type LenientCandleField struct {
+	CandleField
+}
+
+// UnmarshalText turns case-insensitive string to appropriate candle
+// field value.
+func (cf *LenientCandleField) UnmarshalText(d []byte) error {
+	return cf.CandleField.UnmarshalText(lenientText(d))
+}
+
+// LenientTickerField is a ticker field whose text unmarshaling
+// ignores letter case and surrounding whitespace, e.g. " Last ".
+// Can be included in configuration structures.
+type LenientTickerField struct {
+	TickerField
+}
+
+// UnmarshalText turns case-insensitive string to appropriate ticker
+// field value.
+func (tf *LenientTickerField) UnmarshalText(d []byte) error {
+	return tf.TickerField.UnmarshalText(lenientText(d))
+}
+
+// LenientInterval is an interval whose text unmarshaling ignores
+// letter case and surrounding whitespace, e.g. " 4H ".
+// Can be included in configuration structures.
+type LenientInterval struct {
+	Interval
+}
+
+// UnmarshalText turns case-insensitive string to appropriate interval
+// value.
+func (i *LenientInterval) UnmarshalText(d []byte) error {
+	return i.Interval.UnmarshalText(lenientText(d))
+}
+
+// lenientText trims surrounding whitespace and lowercases the text.
+func lenientText(d []byte) []byte {
+	return bytes.ToLower(bytes.TrimSpace(d))
+}
@@ -0,0 +1,157 @@
+package chartype
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/yaml.v3"
+)
+
+func Test_LenientCandleField_UnmarshalText(t *testing.T) {
+	cc := map[string]struct {
+		Text   string
+		Result CandleField
+		Err    error
+	}{
+		"Invalid CandleField": {
+			Text: "clos e",
+			Err:  ErrInvalidCandleField,
+		},
+		"Successful plain form unmarshal": {
+			Text:   "close",
+			Result: CandleClose,
+		},
+		"Successful mixed case unmarshal": {
+			Text:   "Close",
+			Result: CandleClose,
+		},
+		"Successful padded upper case unmarshal": {
+			Text:   " HIGH\t",
+			Result: CandleHigh,
+		},
+		"Successful short form unmarshal": {
+			Text:   " AC ",
+			Result: CandleAdjClose,
+		},
+	}
+
+	for cn, c := range cc {
+		c := c
+
+		t.Run(cn, func(t *testing.T) {
+			t.Parallel()
+
+			var cf LenientCandleField
+
+			err := cf.UnmarshalText([]byte(c.Text))
+			equalError(t, c.Err, err)
+			if err != nil {
+				return
+			}
+
+			assert.Equal(t, c.Result, cf.CandleField)
+		})
+	}
+}
+
+func Test_LenientTickerField_UnmarshalText(t *testing.T) {
+	cc := map[string]struct {
+		Text   string
+		Result TickerField
+		Err    error
+	}{
+		"Invalid TickerField": {
+			Text: "x",
+			Err:  ErrInvalidTickerField,
+		},
+		"Successful plain form unmarshal": {
+			Text:   "last",
+			Result: TickerLast,
+		},
+		"Successful padded mixed case unmarshal": {
+			Text:   " Percent_Change ",
+			Result: TickerPercentChange,
+		},
+	}
+
+	for cn, c := range cc {
+		c := c
+
+		t.Run(cn, func(t *testing.T) {
+			t.Parallel()
+
+			var tf LenientTickerField
+
+			err := tf.UnmarshalText([]byte(c.Text))
+			equalError(t, c.Err, err)
+			if err != nil {
+				return
+			}
+
+			assert.Equal(t, c.Result, tf.TickerField)
+		})
+	}
+}
+
+func Test_LenientInterval_UnmarshalText(t *testing.T) {
+	cc := map[string]struct {
+		Text   string
+		Result Interval
+		Err    error
+	}{
+		"Invalid Interval": {
+			Text: "4 h",
+			Err:  ErrInvalidInterval,
+		},
+		"Successful plain form unmarshal": {
+			Text:   "4h",
+			Result: IntervalHour * 4,
+		},
+		"Successful padded upper case unmarshal": {
+			Text:   " 1D ",
+			Result: IntervalDay,
+		},
+	}
+
+	for cn, c := range cc {
+		c := c
+
+		t.Run(cn, func(t *testing.T) {
+			t.Parallel()
+
+			var i LenientInterval
+
+			err := i.UnmarshalText([]byte(c.Text))
+			equalError(t, c.Err, err)
+			if err != nil {
+				return
+			}
+
+			assert.Equal(t, c.Result, i.Interval)
+		})
+	}
+}
+
+func Test_YAML_Lenient(t *testing.T) {
+	type config struct {
+		CandleField LenientCandleField `yaml:"candle_field"`
+		TickerField LenientTickerField `yaml:"ticker_field"`
+		Interval    LenientInterval    `yaml:"interval"`
+	}
+
+	var cfg config
+
+	err := yaml.Unmarshal([]byte("candle_field: Close\n"+
+		"ticker_field: \" BID \"\n"+
+		"interval: 4H\n"), &cfg)
+	assert.NoError(t, err)
+	assert.Equal(t, config{
+		CandleField: LenientCandleField{CandleClose},
+		TickerField: LenientTickerField{TickerBid},
+		Interval:    LenientInterval{IntervalHour * 4},
+	}, cfg)
+
+	d, err := yaml.Marshal(cfg)
+	assert.NoError(t, err)
+	assert.Equal(t, "candle_field: close\nticker_field: bid\ninterval: 4h\n", string(d))
+}
@@ -0,0 +1,172 @@
+package chartype
+
+import (
+	"sync"
+
+	"github.com/shopspring/decimal"
+)
+
+var (
+	// ErrInvalidMarketInfo is returned when market info with negative
+	// filter values is being used.
+	ErrInvalidMarketInfo = newError(CodeInvalidArgument, "invalid market info")
+
+	// ErrUnknownMarket is returned when no market info is registered
+	// for the requested exchange and pair.
+	ErrUnknownMarket = newError(CodeNotFound, "unknown market")
+
+	// ErrMinNotional is returned when order's notional value is below
+	// the market's minimum.
+	ErrMinNotional = newError(CodeMinNotional, "notional value below minimum")
+)
+
+// MarketInfo holds exchange's rounding rules and filters of a single
+// market. Zero values disable the corresponding rule.
+type MarketInfo struct {
+	// TickSize specifies the smallest price increment.
+	TickSize decimal.Decimal `json:"tick_size" yaml:"tick_size"`
+
+	// StepSize specifies the smallest quantity increment.
+	StepSize decimal.Decimal `json:"step_size" yaml:"step_size"`
+
+	// MinNotional specifies the smallest allowed price and quantity
+	// product.
+	MinNotional decimal.Decimal `json:"min_notional" yaml:"min_notional"`
+
+	// PricePrecision specifies the maximum number of price's decimal
+	// places.
+	PricePrecision int32 `json:"price_precision" yaml:"price_precision"`
+
+	// QuantityPrecision specifies the maximum number of quantity's
+	// decimal places.
+	QuantityPrecision int32 `json:"quantity_precision" yaml:"quantity_precision"`
+}
+
+// Validate checks whether all market info's values are non-negative.
+func (mi MarketInfo) Validate() error {
+	if mi.TickSize.IsNegative() || mi.StepSize.IsNegative() ||
+		mi.MinNotional.IsNegative() || mi.PricePrecision < 0 ||
+		mi.QuantityPrecision < 0 {
+		return ErrInvalidMarketInfo
+	}
+
+	return nil
+}
+
+// NormalizePrice rounds the price to the nearest tick and limits
+// its decimal places.
+func (mi MarketInfo) NormalizePrice(p decimal.Decimal) decimal.Decimal {
+	if !mi.TickSize.IsZero() {
+		p = p.Div(mi.TickSize).Round(0).Mul(mi.TickSize)
+	}
+
+	if mi.PricePrecision > 0 {
+		p = p.Round(mi.PricePrecision)
+	}
+
+	return p
+}
+
+// NormalizeQuantity truncates the quantity down to a whole number of
+// steps and limits its decimal places.
+func (mi MarketInfo) NormalizeQuantity(q decimal.Decimal) decimal.Decimal {
+	if !mi.StepSize.IsZero() {
+		q = q.Div(mi.StepSize).Floor().Mul(mi.StepSize)
+	}
+
+	if mi.QuantityPrecision > 0 {
+		q = q.Truncate(mi.QuantityPrecision)
+	}
+
+	return q
+}
+
+// Normalize returns a copy of the candle with its prices and volume
+// normalized.
+func (mi MarketInfo) Normalize(c Candle) Candle {
+	c.Open = mi.NormalizePrice(c.Open)
+	c.High = mi.NormalizePrice(c.High)
+	c.Low = mi.NormalizePrice(c.Low)
+	c.Close = mi.NormalizePrice(c.Close)
+	c.Volume = mi.NormalizeQuantity(c.Volume)
+
+	return c
+}
+
+// CheckNotional checks whether the product of price and quantity
+// is not below the market's minimum notional value.
+func (mi MarketInfo) CheckNotional(p, q decimal.Decimal) error {
+	if p.Mul(q).LessThan(mi.MinNotional) {
+		return ErrMinNotional
+	}
+
+	return nil
+}
+
+// marketKey identifies a market in the registry.
+type marketKey struct {
+	exchange string
+	pair     Pair
+}
+
+// MarketRegistry holds market infos keyed by exchange and pair.
+// It is safe for concurrent use.
+type MarketRegistry struct {
+	mu      sync.RWMutex
+	markets map[marketKey]MarketInfo
+}
+
+// NewMarketRegistry creates a new empty market registry.
+func NewMarketRegistry() *MarketRegistry {
+	return &MarketRegistry{markets: make(map[marketKey]MarketInfo)}
+}
+
+// Set validates and registers the market info of the exchange's
+// pair, replacing the previous one.
+func (mr *MarketRegistry) Set(exchange string, p Pair, mi MarketInfo) error {
+	if err := p.Validate(); err != nil {
+		return err
+	}
+
+	if err := mi.Validate(); err != nil {
+		return err
+	}
+
+	mr.mu.Lock()
+	mr.markets[marketKey{exchange: exchange, pair: p}] = mi
+	mr.mu.Unlock()
+
+	return nil
+}
+
+// Get returns the market info of the exchange's pair and whether
+// it was registered.
+func (mr *MarketRegistry) Get(exchange string, p Pair) (MarketInfo, bool) {
+	mr.mu.RLock()
+	mi, ok := mr.markets[marketKey{exchange: exchange, pair: p}]
+	mr.mu.RUnlock()
+
+	return mi, ok
+}
+
+// NormalizePrice normalizes the price using the exchange's pair
+// market info.
+func (mr *MarketRegistry) NormalizePrice(exchange string, p Pair, v decimal.Decimal) (decimal.Decimal, error) {
+	mi, ok := mr.Get(exchange, p)
+	if !ok {
+		return decimal.Decimal{}, ErrUnknownMarket
+	}
+
+	return mi.NormalizePrice(v), nil
+}
+
+// Normalize normalizes the candle using the exchange's pair market
+// info.
+func (mr *MarketRegistry) Normalize(exchange string, p Pair, c Candle) (Candle, error) {
+	mi, ok := mr.Get(exchange, p)
+	if !ok {
+		return Candle{}, ErrUnknownMarket
+	}
+
+	return mi.Normalize(c), nil
+}
@@ -0,0 +1,195 @@
+package chartype
+
+import (
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_MarketInfo_Validate(t *testing.T) {
+	cc := map[string]struct {
+		MarketInfo MarketInfo
+		Err        error
+	}{
+		"Negative TickSize": {
+			MarketInfo: MarketInfo{TickSize: decimal.NewFromInt(-1)},
+			Err:        ErrInvalidMarketInfo,
+		},
+		"Negative StepSize": {
+			MarketInfo: MarketInfo{StepSize: decimal.NewFromInt(-1)},
+			Err:        ErrInvalidMarketInfo,
+		},
+		"Negative MinNotional": {
+			MarketInfo: MarketInfo{MinNotional: decimal.NewFromInt(-1)},
+			Err:        ErrInvalidMarketInfo,
+		},
+		"Negative PricePrecision": {
+			MarketInfo: MarketInfo{PricePrecision: -1},
+			Err:        ErrInvalidMarketInfo,
+		},
+		"Negative QuantityPrecision": {
+			MarketInfo: MarketInfo{QuantityPrecision: -1},
+			Err:        ErrInvalidMarketInfo,
+		},
+		"Successful validation": {
+			MarketInfo: testMarketInfo(),
+		},
+	}
+
+	for cn, c := range cc {
+		c := c
+
+		t.Run(cn, func(t *testing.T) {
+			t.Parallel()
+
+			equalError(t, c.Err, c.MarketInfo.Validate())
+		})
+	}
+}
+
+func testMarketInfo() MarketInfo {
+	return MarketInfo{
+		TickSize:          decimal.RequireFromString("0.05"),
+		StepSize:          decimal.RequireFromString("0.001"),
+		MinNotional:       decimal.NewFromInt(10),
+		PricePrecision:    2,
+		QuantityPrecision: 3,
+	}
+}
+
+func Test_MarketInfo_NormalizePrice(t *testing.T) {
+	cc := map[string]struct {
+		MarketInfo MarketInfo
+		Price      string
+		Result     string
+	}{
+		"No rules": {
+			Price:  "1.23456",
+			Result: "1.23456",
+		},
+		"Rounded to tick": {
+			MarketInfo: MarketInfo{TickSize: decimal.RequireFromString("0.05")},
+			Price:      "1.23456",
+			Result:     "1.25",
+		},
+		"Rounded to precision": {
+			MarketInfo: MarketInfo{PricePrecision: 3},
+			Price:      "1.23456",
+			Result:     "1.235",
+		},
+		"Rounded to tick and precision": {
+			MarketInfo: testMarketInfo(),
+			Price:      "1.22",
+			Result:     "1.2",
+		},
+	}
+
+	for cn, c := range cc {
+		c := c
+
+		t.Run(cn, func(t *testing.T) {
+			t.Parallel()
+
+			res := c.MarketInfo.NormalizePrice(decimal.RequireFromString(c.Price))
+			assert.Equal(t, c.Result, res.String())
+		})
+	}
+}
+
+func Test_MarketInfo_NormalizeQuantity(t *testing.T) {
+	cc := map[string]struct {
+		MarketInfo MarketInfo
+		Quantity   string
+		Result     string
+	}{
+		"No rules": {
+			Quantity: "1.23456",
+			Result:   "1.23456",
+		},
+		"Truncated to step": {
+			MarketInfo: MarketInfo{StepSize: decimal.RequireFromString("0.05")},
+			Quantity:   "1.29",
+			Result:     "1.25",
+		},
+		"Truncated to precision": {
+			MarketInfo: MarketInfo{QuantityPrecision: 3},
+			Quantity:   "1.23456",
+			Result:     "1.234",
+		},
+		"Truncated to step and precision": {
+			MarketInfo: testMarketInfo(),
+			Quantity:   "1.23456",
+			Result:     "1.234",
+		},
+	}
+
+	for cn, c := range cc {
+		c := c
+
+		t.Run(cn, func(t *testing.T) {
+			t.Parallel()
+
+			res := c.MarketInfo.NormalizeQuantity(decimal.RequireFromString(c.Quantity))
+			assert.Equal(t, c.Result, res.String())
+		})
+	}
+}
+
+func Test_MarketInfo_Normalize(t *testing.T) {
+	tm := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	c := testMarketInfo().Normalize(Candle{
+		Timestamp: tm,
+		Open:      decimal.RequireFromString("1.01"),
+		High:      decimal.RequireFromString("1.33"),
+		Low:       decimal.RequireFromString("0.97"),
+		Close:     decimal.RequireFromString("1.12"),
+		Volume:    decimal.RequireFromString("10.0009"),
+	})
+
+	assert.Equal(t, tm, c.Timestamp)
+	assert.Equal(t, "1", c.Open.String())
+	assert.Equal(t, "1.35", c.High.String())
+	assert.Equal(t, "0.95", c.Low.String())
+	assert.Equal(t, "1.1", c.Close.String())
+	assert.Equal(t, "10", c.Volume.String())
+}
+
+func Test_MarketInfo_CheckNotional(t *testing.T) {
+	mi := testMarketInfo()
+
+	assert.Equal(t, ErrMinNotional, mi.CheckNotional(decimal.NewFromInt(3), decimal.NewFromInt(3)))
+	assert.NoError(t, mi.CheckNotional(decimal.NewFromInt(2), decimal.NewFromInt(5)))
+}
+
+func Test_MarketRegistry(t *testing.T) {
+	p := Pair{Base: "BTC", Quote: "USDT"}
+	mr := NewMarketRegistry()
+
+	assert.Equal(t, ErrInvalidPair, mr.Set("binance", Pair{}, testMarketInfo()))
+	assert.Equal(t, ErrInvalidMarketInfo, mr.Set("binance", p, MarketInfo{PricePrecision: -1}))
+	assert.NoError(t, mr.Set("binance", p, testMarketInfo()))
+
+	mi, ok := mr.Get("binance", p)
+	assert.True(t, ok)
+	assert.Equal(t, testMarketInfo(), mi)
+
+	_, ok = mr.Get("kraken", p)
+	assert.False(t, ok)
+
+	_, err := mr.NormalizePrice("kraken", p, decimal.NewFromInt(1))
+	assert.Equal(t, ErrUnknownMarket, err)
+
+	v, err := mr.NormalizePrice("binance", p, decimal.RequireFromString("1.23"))
+	assert.NoError(t, err)
+	assert.Equal(t, "1.25", v.String())
+
+	_, err = mr.Normalize("kraken", p, Candle{})
+	assert.Equal(t, ErrUnknownMarket, err)
+
+	c, err := mr.Normalize("binance", p, Candle{Close: decimal.RequireFromString("1.23")})
+	assert.NoError(t, err)
+	assert.Equal(t, "1.25", c.Close.String())
+}
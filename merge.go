@@ -0,0 +1,241 @@
+package chartype
+
+import (
+	"github.com/shopspring/decimal"
+)
+
+const (
+	// MergeIncoming specifies that the incoming candle's value is
+	// used.
+	MergeIncoming MergeRule = iota + 1
+
+	// MergeExisting specifies that the existing candle's value is
+	// kept.
+	MergeExisting
+
+	// MergeRicher specifies that the value of the more complete
+	// candle is used. A candle with non-zero volume is more complete
+	// than one without it; otherwise the candle with the wider
+	// high-low range is. Incoming candle wins ties.
+	MergeRicher
+
+	// MergeMax specifies that the greater of both values is used.
+	MergeMax
+
+	// MergeMin specifies that the lesser of both values is used.
+	MergeMin
+)
+
+var (
+	// ErrInvalidMergeRule is returned when merge rule with invalid
+	// value is being used.
+	ErrInvalidMergeRule = newError(CodeInvalidArgument, "invalid merge rule")
+)
+
+// MergeRule specifies how a single field of two candles with equal
+// timestamps is merged.
+// Can be included in configuration structures.
+type MergeRule int
+
+// Validate checks whether the merge rule is one of supported rule
+// types or not.
+func (mr MergeRule) Validate() error {
+	switch mr {
+	case MergeIncoming, MergeExisting, MergeRicher, MergeMax, MergeMin:
+		return nil
+	default:
+		return ErrInvalidMergeRule
+	}
+}
+
+// MarshalText turns merge rule to appropriate string representation.
+func (mr MergeRule) MarshalText() ([]byte, error) {
+	var v string
+
+	switch mr {
+	case MergeIncoming:
+		v = "incoming"
+	case MergeExisting:
+		v = "existing"
+	case MergeRicher:
+		v = "richer"
+	case MergeMax:
+		v = "max"
+	case MergeMin:
+		v = "min"
+	default:
+		return nil, ErrInvalidMergeRule
+	}
+
+	return []byte(v), nil
+}
+
+// UnmarshalText turns string to appropriate merge rule value.
+func (mr *MergeRule) UnmarshalText(d []byte) error {
+	switch string(d) {
+	case "incoming":
+		*mr = MergeIncoming
+	case "existing":
+		*mr = MergeExisting
+	case "richer":
+		*mr = MergeRicher
+	case "max":
+		*mr = MergeMax
+	case "min":
+		*mr = MergeMin
+	default:
+		return ErrInvalidMergeRule
+	}
+
+	return nil
+}
+
+// MergePolicy specifies merge rules of every candle's field.
+// Can be included in configuration structures.
+type MergePolicy struct {
+	Open   MergeRule `json:"open" yaml:"open"`
+	High   MergeRule `json:"high" yaml:"high"`
+	Low    MergeRule `json:"low" yaml:"low"`
+	Close  MergeRule `json:"close" yaml:"close"`
+	Volume MergeRule `json:"volume" yaml:"volume"`
+}
+
+// LastWinsPolicy returns a merge policy that replaces existing
+// candles with incoming ones.
+func LastWinsPolicy() MergePolicy {
+	return MergePolicy{
+		Open:   MergeIncoming,
+		High:   MergeIncoming,
+		Low:    MergeIncoming,
+		Close:  MergeIncoming,
+		Volume: MergeIncoming,
+	}
+}
+
+// RicherPolicy returns a merge policy that keeps the more complete
+// of both candles.
+func RicherPolicy() MergePolicy {
+	return MergePolicy{
+		Open:   MergeRicher,
+		High:   MergeRicher,
+		Low:    MergeRicher,
+		Close:  MergeRicher,
+		Volume: MergeRicher,
+	}
+}
+
+// Validate checks whether all merge policy's rules are valid.
+func (mp MergePolicy) Validate() error {
+	for _, mr := range []MergeRule{mp.Open, mp.High, mp.Low, mp.Close, mp.Volume} {
+		if err := mr.Validate(); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// MergeCandle merges two candles with equal timestamps field by
+// field. Adjusted close is merged using the close rule and volume
+// delta using the volume rule; if one of them is set on only one of
+// the candles, max and min rules keep that value.
+// Merge policy must be valid.
+func (mp MergePolicy) MergeCandle(existing, incoming Candle) Candle {
+	richer := incoming
+	if richerCandle(existing, incoming) {
+		richer = existing
+	}
+
+	pick := func(mr MergeRule, e, i, r decimal.Decimal) decimal.Decimal {
+		switch mr {
+		case MergeExisting:
+			return e
+		case MergeRicher:
+			return r
+		case MergeMax:
+			return decimal.Max(e, i)
+		case MergeMin:
+			return decimal.Min(e, i)
+		default:
+			return i
+		}
+	}
+
+	pickOptional := func(mr MergeRule, e, i, r *decimal.Decimal) *decimal.Decimal {
+		switch mr {
+		case MergeExisting:
+			return e
+		case MergeRicher:
+			return r
+		case MergeMax, MergeMin:
+			if e == nil {
+				return i
+			}
+
+			if i == nil {
+				return e
+			}
+
+			v := pick(mr, *e, *i, decimal.Zero)
+
+			return &v
+		default:
+			return i
+		}
+	}
+
+	return Candle{
+		Timestamp: incoming.Timestamp,
+		Open:      pick(mp.Open, existing.Open, incoming.Open, richer.Open),
+		High:      pick(mp.High, existing.High, incoming.High, richer.High),
+		Low:       pick(mp.Low, existing.Low, incoming.Low, richer.Low),
+		Close:     pick(mp.Close, existing.Close, incoming.Close, richer.Close),
+		AdjClose:  pickOptional(mp.Close, existing.AdjClose, incoming.AdjClose, richer.AdjClose),
+		Volume:    pick(mp.Volume, existing.Volume, incoming.Volume, richer.Volume),
+		Delta:     pickOptional(mp.Volume, existing.Delta, incoming.Delta, richer.Delta),
+	}
+}
+
+// richerCandle checks whether candle c1 is more complete than c2.
+func richerCandle(c1, c2 Candle) bool {
+	if c1.Volume.IsZero() != c2.Volume.IsZero() {
+		return c2.Volume.IsZero()
+	}
+
+	return c1.High.Sub(c1.Low).GreaterThan(c2.High.Sub(c2.Low))
+}
+
+// Merge combines existing and incoming candles into a single series.
+// Both series must be sorted by timestamp in ascending order and
+// contain no duplicate timestamps. Candles with equal timestamps are
+// merged using the merge policy.
+func Merge(existing, incoming []Candle, mp MergePolicy) ([]Candle, error) {
+	if err := mp.Validate(); err != nil {
+		return nil, err
+	}
+
+	res := make([]Candle, 0, len(existing)+len(incoming))
+
+	var i, j int
+
+	for i < len(existing) && j < len(incoming) {
+		e, in := existing[i], incoming[j]
+
+		switch {
+		case e.Timestamp.Before(in.Timestamp):
+			res = append(res, e)
+			i++
+		case in.Timestamp.Before(e.Timestamp):
+			res = append(res, in)
+			j++
+		default:
+			res = append(res, mp.MergeCandle(e, in))
+			i++
+			j++
+		}
+	}
+
+	res = append(res, existing[i:]...)
+
+	return append(res, incoming[j:]...), nil
+}
@@ -0,0 +1,297 @@
+package chartype
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_MergeRule_Validate(t *testing.T) {
+	cc := map[string]struct {
+		Rule MergeRule
+		Err  error
+	}{
+		"Invalid MergeRule": {
+			Rule: 70,
+			Err:  ErrInvalidMergeRule,
+		},
+		"Successful MergeIncoming validation": {
+			Rule: MergeIncoming,
+		},
+		"Successful MergeExisting validation": {
+			Rule: MergeExisting,
+		},
+		"Successful MergeRicher validation": {
+			Rule: MergeRicher,
+		},
+		"Successful MergeMax validation": {
+			Rule: MergeMax,
+		},
+		"Successful MergeMin validation": {
+			Rule: MergeMin,
+		},
+	}
+
+	for cn, c := range cc {
+		c := c
+
+		t.Run(cn, func(t *testing.T) {
+			t.Parallel()
+
+			equalError(t, c.Err, c.Rule.Validate())
+		})
+	}
+}
+
+func Test_MergeRule_MarshalText(t *testing.T) {
+	cc := map[string]struct {
+		Rule MergeRule
+		Text string
+		Err  error
+	}{
+		"Invalid MergeRule": {
+			Rule: 70,
+			Err:  ErrInvalidMergeRule,
+		},
+		"Successful MergeIncoming marshal": {
+			Rule: MergeIncoming,
+			Text: "incoming",
+		},
+		"Successful MergeExisting marshal": {
+			Rule: MergeExisting,
+			Text: "existing",
+		},
+		"Successful MergeRicher marshal": {
+			Rule: MergeRicher,
+			Text: "richer",
+		},
+		"Successful MergeMax marshal": {
+			Rule: MergeMax,
+			Text: "max",
+		},
+		"Successful MergeMin marshal": {
+			Rule: MergeMin,
+			Text: "min",
+		},
+	}
+
+	for cn, c := range cc {
+		c := c
+
+		t.Run(cn, func(t *testing.T) {
+			t.Parallel()
+
+			d, err := c.Rule.MarshalText()
+			equalError(t, c.Err, err)
+			if err != nil {
+				return
+			}
+
+			assert.Equal(t, c.Text, string(d))
+		})
+	}
+}
+
+func Test_MergeRule_UnmarshalText(t *testing.T) {
+	cc := map[string]struct {
+		Text   string
+		Result MergeRule
+		Err    error
+	}{
+		"Invalid MergeRule": {
+			Text: "x",
+			Err:  ErrInvalidMergeRule,
+		},
+		"Successful MergeIncoming unmarshal": {
+			Text:   "incoming",
+			Result: MergeIncoming,
+		},
+		"Successful MergeExisting unmarshal": {
+			Text:   "existing",
+			Result: MergeExisting,
+		},
+		"Successful MergeRicher unmarshal": {
+			Text:   "richer",
+			Result: MergeRicher,
+		},
+		"Successful MergeMax unmarshal": {
+			Text:   "max",
+			Result: MergeMax,
+		},
+		"Successful MergeMin unmarshal": {
+			Text:   "min",
+			Result: MergeMin,
+		},
+	}
+
+	for cn, c := range cc {
+		c := c
+
+		t.Run(cn, func(t *testing.T) {
+			t.Parallel()
+
+			var mr MergeRule
+
+			err := mr.UnmarshalText([]byte(c.Text))
+			equalError(t, c.Err, err)
+			if err != nil {
+				return
+			}
+
+			assert.Equal(t, c.Result, mr)
+		})
+	}
+}
+
+func Test_MergePolicy_MergeCandle(t *testing.T) {
+	tm := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	withDelta := func(c Candle, d int64) Candle {
+		c.Delta = decimalPtr(d)
+		return c
+	}
+
+	withAdjClose := func(c Candle, ac int64) Candle {
+		c.AdjClose = decimalPtr(ac)
+		return c
+	}
+
+	volumeRule := func(mr MergeRule) MergePolicy {
+		mp := LastWinsPolicy()
+		mp.Volume = mr
+
+		return mp
+	}
+
+	cc := map[string]struct {
+		Policy   MergePolicy
+		Existing Candle
+		Incoming Candle
+		Result   Candle
+	}{
+		"Last wins": {
+			Policy:   LastWinsPolicy(),
+			Existing: testCandle(tm, 1, 5, 1, 2, 10),
+			Incoming: testCandle(tm, 2, 2, 2, 2, 0),
+			Result:   testCandle(tm, 2, 2, 2, 2, 0),
+		},
+		"Richer by volume": {
+			Policy:   RicherPolicy(),
+			Existing: testCandle(tm, 2, 2, 2, 2, 1),
+			Incoming: testCandle(tm, 1, 5, 1, 2, 0),
+			Result:   testCandle(tm, 2, 2, 2, 2, 1),
+		},
+		"Richer by range": {
+			Policy:   RicherPolicy(),
+			Existing: testCandle(tm, 1, 5, 1, 2, 1),
+			Incoming: testCandle(tm, 2, 3, 2, 3, 1),
+			Result:   testCandle(tm, 1, 5, 1, 2, 1),
+		},
+		"Richer tie": {
+			Policy:   RicherPolicy(),
+			Existing: testCandle(tm, 1, 2, 1, 2, 1),
+			Incoming: testCandle(tm, 2, 3, 2, 3, 1),
+			Result:   testCandle(tm, 2, 3, 2, 3, 1),
+		},
+		"Last wins with delta": {
+			Policy:   LastWinsPolicy(),
+			Existing: withDelta(testCandle(tm, 1, 5, 1, 2, 10), 4),
+			Incoming: withDelta(testCandle(tm, 2, 2, 2, 2, 3), -1),
+			Result:   withDelta(testCandle(tm, 2, 2, 2, 2, 3), -1),
+		},
+		"Richer with delta": {
+			Policy:   RicherPolicy(),
+			Existing: withDelta(testCandle(tm, 2, 2, 2, 2, 1), 1),
+			Incoming: testCandle(tm, 1, 5, 1, 2, 0),
+			Result:   withDelta(testCandle(tm, 2, 2, 2, 2, 1), 1),
+		},
+		"Existing delta": {
+			Policy:   volumeRule(MergeExisting),
+			Existing: withDelta(testCandle(tm, 1, 1, 1, 1, 1), 1),
+			Incoming: withDelta(testCandle(tm, 2, 2, 2, 2, 2), 2),
+			Result:   withDelta(testCandle(tm, 2, 2, 2, 2, 1), 1),
+		},
+		"Max delta": {
+			Policy:   volumeRule(MergeMax),
+			Existing: withDelta(testCandle(tm, 1, 1, 1, 1, 1), 3),
+			Incoming: withDelta(testCandle(tm, 2, 2, 2, 2, 2), 2),
+			Result:   withDelta(testCandle(tm, 2, 2, 2, 2, 2), 3),
+		},
+		"Min delta": {
+			Policy:   volumeRule(MergeMin),
+			Existing: withDelta(testCandle(tm, 1, 1, 1, 1, 1), 3),
+			Incoming: withDelta(testCandle(tm, 2, 2, 2, 2, 2), 2),
+			Result:   withDelta(testCandle(tm, 2, 2, 2, 2, 1), 2),
+		},
+		"Max delta set on existing candle only": {
+			Policy:   volumeRule(MergeMax),
+			Existing: withDelta(testCandle(tm, 1, 1, 1, 1, 1), 3),
+			Incoming: testCandle(tm, 2, 2, 2, 2, 2),
+			Result:   withDelta(testCandle(tm, 2, 2, 2, 2, 2), 3),
+		},
+		"Min delta set on incoming candle only": {
+			Policy:   volumeRule(MergeMin),
+			Existing: testCandle(tm, 1, 1, 1, 1, 1),
+			Incoming: withDelta(testCandle(tm, 2, 2, 2, 2, 2), 2),
+			Result:   withDelta(testCandle(tm, 2, 2, 2, 2, 1), 2),
+		},
+		"Last wins with adjusted close": {
+			Policy:   LastWinsPolicy(),
+			Existing: withAdjClose(testCandle(tm, 1, 5, 1, 2, 10), 1),
+			Incoming: withAdjClose(testCandle(tm, 2, 2, 2, 2, 3), 2),
+			Result:   withAdjClose(testCandle(tm, 2, 2, 2, 2, 3), 2),
+		},
+		"Richer with adjusted close": {
+			Policy:   RicherPolicy(),
+			Existing: withAdjClose(testCandle(tm, 1, 5, 1, 2, 1), 1),
+			Incoming: testCandle(tm, 2, 3, 2, 3, 1),
+			Result:   withAdjClose(testCandle(tm, 1, 5, 1, 2, 1), 1),
+		},
+		"Per field rules": {
+			Policy: MergePolicy{
+				Open:   MergeExisting,
+				High:   MergeMax,
+				Low:    MergeMin,
+				Close:  MergeMax,
+				Volume: MergeRicher,
+			},
+			Existing: withAdjClose(testCandle(tm, 1, 5, 2, 2, 0), 4),
+			Incoming: withAdjClose(testCandle(tm, 3, 4, 1, 3, 7), 1),
+			Result:   withAdjClose(testCandle(tm, 1, 5, 1, 3, 7), 4),
+		},
+	}
+
+	for cn, c := range cc {
+		c := c
+
+		t.Run(cn, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, c.Result, c.Policy.MergeCandle(c.Existing, c.Incoming))
+		})
+	}
+}
+
+func Test_Merge(t *testing.T) {
+	tm := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	_, err := Merge(nil, nil, MergePolicy{})
+	assert.Equal(t, ErrInvalidMergeRule, err)
+
+	res, err := Merge([]Candle{
+		testCandle(tm, 1, 1, 1, 1, 1),
+		testCandle(tm.Add(2*time.Minute), 3, 3, 3, 3, 3),
+		testCandle(tm.Add(3*time.Minute), 4, 4, 4, 4, 4),
+	}, []Candle{
+		testCandle(tm.Add(time.Minute), 2, 2, 2, 2, 2),
+		testCandle(tm.Add(2*time.Minute), 5, 5, 5, 5, 0),
+	}, RicherPolicy())
+	assert.NoError(t, err)
+	assert.Equal(t, []Candle{
+		testCandle(tm, 1, 1, 1, 1, 1),
+		testCandle(tm.Add(time.Minute), 2, 2, 2, 2, 2),
+		testCandle(tm.Add(2*time.Minute), 3, 3, 3, 3, 3),
+		testCandle(tm.Add(3*time.Minute), 4, 4, 4, 4, 4),
+	}, res)
+}
@@ -0,0 +1,97 @@
+package chartype
+
+import (
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+// MidCandleBuilder aggregates order book snapshots or best bid and
+// ask quotes into interval-long candles of mid prices. Candle volumes
+// are zero.
+//
+// Snapshots older than the candle currently being built and
+// snapshots with an empty side are counted and dropped.
+//
+// MidCandleBuilder is not safe for concurrent use.
+type MidCandleBuilder struct {
+	interval Interval
+	head     *Candle
+	dropped  int
+}
+
+// NewMidCandleBuilder creates a new mid-price candle builder producing
+// interval-long candles.
+func NewMidCandleBuilder(i Interval) (*MidCandleBuilder, error) {
+	if err := i.Validate(); err != nil {
+		return nil, err
+	}
+
+	return &MidCandleBuilder{interval: i}, nil
+}
+
+// AddOrderBook applies the order book snapshot's mid price to the
+// candle of the interval it belongs to. The candle closed by the
+// snapshot, if any, is returned.
+func (mb *MidCandleBuilder) AddOrderBook(ob OrderBook) (Candle, bool) {
+	m, ok := ob.Mid()
+	if !ok {
+		mb.dropped++
+		return Candle{}, false
+	}
+
+	return mb.add(ob.Timestamp, m)
+}
+
+// AddQuote applies the best bid and ask quote's mid price to the
+// candle of the interval it belongs to. The candle closed by the
+// quote, if any, is returned.
+func (mb *MidCandleBuilder) AddQuote(t time.Time, bid, ask decimal.Decimal) (Candle, bool) {
+	return mb.add(t, bid.Add(ask).Div(two))
+}
+
+// Head returns the candle that is currently being built and whether
+// there is one.
+func (mb *MidCandleBuilder) Head() (Candle, bool) {
+	if mb.head == nil {
+		return Candle{}, false
+	}
+
+	return *mb.head, true
+}
+
+// Flush closes and returns the candle that is currently being built
+// and whether there was one.
+func (mb *MidCandleBuilder) Flush() (Candle, bool) {
+	c, ok := mb.Head()
+	mb.head = nil
+
+	return c, ok
+}
+
+// Dropped returns the number of dropped snapshots.
+func (mb *MidCandleBuilder) Dropped() int {
+	return mb.dropped
+}
+
+// add applies the mid price to the candle of the interval the
+// timestamp belongs to.
+func (mb *MidCandleBuilder) add(t time.Time, m decimal.Decimal) (Candle, bool) {
+	ts := mb.interval.Truncate(t)
+
+	switch {
+	case mb.head == nil || ts.After(mb.head.Timestamp):
+		c, ok := mb.Flush()
+		mb.head = &Candle{Timestamp: ts, Open: m, High: m, Low: m, Close: m}
+
+		return c, ok
+	case ts.Equal(mb.head.Timestamp):
+		mb.head.High = decimal.Max(mb.head.High, m)
+		mb.head.Low = decimal.Min(mb.head.Low, m)
+		mb.head.Close = m
+	default:
+		mb.dropped++
+	}
+
+	return Candle{}, false
+}
@@ -0,0 +1,73 @@
+package chartype
+
+import (
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_NewMidCandleBuilder(t *testing.T) {
+	_, err := NewMidCandleBuilder(0)
+	assert.Equal(t, ErrInvalidInterval, err)
+
+	mb, err := NewMidCandleBuilder(IntervalMinute)
+	assert.NoError(t, err)
+	assert.NotNil(t, mb)
+}
+
+func Test_MidCandleBuilder(t *testing.T) {
+	tm := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	mb, err := NewMidCandleBuilder(IntervalMinute)
+	assert.NoError(t, err)
+
+	_, ok := mb.Head()
+	assert.False(t, ok)
+
+	_, ok = mb.AddOrderBook(OrderBook{Timestamp: tm})
+	assert.False(t, ok)
+
+	_, ok = mb.AddOrderBook(OrderBook{
+		Timestamp: tm.Add(10 * time.Second),
+		Bids:      []PriceLevel{testLevel("9", "1")},
+		Asks:      []PriceLevel{testLevel("11", "1")},
+	})
+	assert.False(t, ok)
+
+	_, ok = mb.AddQuote(tm.Add(20*time.Second), decimal.NewFromInt(11), decimal.NewFromInt(13))
+	assert.False(t, ok)
+
+	_, ok = mb.AddQuote(tm.Add(30*time.Second), decimal.NewFromInt(7), decimal.NewFromInt(9))
+	assert.False(t, ok)
+
+	_, ok = mb.AddQuote(tm.Add(40*time.Second), decimal.NewFromInt(9), decimal.NewFromInt(10))
+	assert.False(t, ok)
+
+	h, ok := mb.Head()
+	assert.True(t, ok)
+	assert.Equal(t, "9.5", h.Close.String())
+
+	c, ok := mb.AddQuote(tm.Add(70*time.Second), decimal.NewFromInt(20), decimal.NewFromInt(20))
+	assert.True(t, ok)
+	assert.Equal(t, tm, c.Timestamp)
+	assert.Equal(t, "10", c.Open.String())
+	assert.Equal(t, "12", c.High.String())
+	assert.Equal(t, "8", c.Low.String())
+	assert.Equal(t, "9.5", c.Close.String())
+	assert.True(t, c.Volume.IsZero())
+
+	// older than the head
+	_, ok = mb.AddQuote(tm.Add(50*time.Second), decimal.NewFromInt(1), decimal.NewFromInt(1))
+	assert.False(t, ok)
+	assert.Equal(t, 2, mb.Dropped())
+
+	c, ok = mb.Flush()
+	assert.True(t, ok)
+	assert.Equal(t, tm.Add(time.Minute), c.Timestamp)
+	assert.Equal(t, "20", c.Close.String())
+
+	_, ok = mb.Flush()
+	assert.False(t, ok)
+}
@@ -0,0 +1,110 @@
+package chartype
+
+import (
+	"strings"
+
+	"github.com/shopspring/decimal"
+)
+
+var (
+	// ErrInvalidMoney is returned when money with missing or invalid
+	// currency code is being used.
+	ErrInvalidMoney = newError(CodeInvalidArgument, "invalid money")
+
+	// ErrCurrencyMismatch is returned when money values in different
+	// currencies are combined.
+	ErrCurrencyMismatch = newError(CodeIncompatible, "currency mismatch")
+)
+
+// Money holds a monetary amount in a specific currency.
+type Money struct {
+	Amount   decimal.Decimal `json:"amount" yaml:"amount"`
+	Currency string          `json:"currency" yaml:"currency"`
+}
+
+// NewMoney creates a new money value with the provided amount and
+// currency.
+func NewMoney(a decimal.Decimal, c string) (Money, error) {
+	m := Money{Amount: a, Currency: c}
+	if err := m.Validate(); err != nil {
+		return Money{}, err
+	}
+
+	return m, nil
+}
+
+// ParseMoney parses provided string into a new money value.
+// Expected format is "AMOUNT CURRENCY", e.g. "12.5 USD".
+func ParseMoney(s string) (Money, error) {
+	ss := strings.Fields(s)
+	if len(ss) != 2 {
+		return Money{}, ErrInvalidMoney
+	}
+
+	a, err := decimalFromString(ss[0])
+	if err != nil {
+		return Money{}, err
+	}
+
+	return NewMoney(a, ss[1])
+}
+
+// Validate checks whether money's currency code is set and valid.
+func (m Money) Validate() error {
+	if !validCurrency(m.Currency) {
+		return ErrInvalidMoney
+	}
+
+	return nil
+}
+
+// String returns money's string representation, e.g. "12.5 USD".
+func (m Money) String() string {
+	return m.Amount.String() + " " + m.Currency
+}
+
+// IsZero checks whether money's amount is zero.
+func (m Money) IsZero() bool {
+	return m.Amount.IsZero()
+}
+
+// Add returns the sum of the money values. An error is returned if
+// their currencies differ.
+func (m Money) Add(m1 Money) (Money, error) {
+	if m.Currency != m1.Currency {
+		return Money{}, ErrCurrencyMismatch
+	}
+
+	return Money{Amount: m.Amount.Add(m1.Amount), Currency: m.Currency}, nil
+}
+
+// Sub returns the difference of the money values. An error is
+// returned if their currencies differ.
+func (m Money) Sub(m1 Money) (Money, error) {
+	if m.Currency != m1.Currency {
+		return Money{}, ErrCurrencyMismatch
+	}
+
+	return Money{Amount: m.Amount.Sub(m1.Amount), Currency: m.Currency}, nil
+}
+
+// Cmp compares the money values and returns -1, 0 or 1 if m is less
+// than, equal to or greater than m1 respectively. An error is
+// returned if their currencies differ.
+func (m Money) Cmp(m1 Money) (int, error) {
+	if m.Currency != m1.Currency {
+		return 0, ErrCurrencyMismatch
+	}
+
+	return m.Amount.Cmp(m1.Amount), nil
+}
+
+// Mul returns the money value multiplied by the factor.
+func (m Money) Mul(f decimal.Decimal) Money {
+	return Money{Amount: m.Amount.Mul(f), Currency: m.Currency}
+}
+
+// Neg returns the money value with the opposite sign.
+func (m Money) Neg() Money {
+	return Money{Amount: m.Amount.Neg(), Currency: m.Currency}
+}
@@ -0,0 +1,100 @@
+package chartype
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_ParseMoney(t *testing.T) {
+	cc := map[string]struct {
+		Text   string
+		Result Money
+		Err    error
+	}{
+		"Missing currency": {
+			Text: "12.5",
+			Err:  ErrInvalidMoney,
+		},
+		"Invalid amount": {
+			Text: "x USD",
+			Err:  assert.AnError,
+		},
+		"Invalid currency": {
+			Text: "12.5 US/D",
+			Err:  ErrInvalidMoney,
+		},
+		"Successful parse": {
+			Text:   "12.5 USD",
+			Result: Money{Amount: decimal.RequireFromString("12.5"), Currency: "USD"},
+		},
+	}
+
+	for cn, c := range cc {
+		c := c
+
+		t.Run(cn, func(t *testing.T) {
+			t.Parallel()
+
+			m, err := ParseMoney(c.Text)
+			equalError(t, c.Err, err)
+			if err != nil {
+				return
+			}
+
+			assert.Equal(t, c.Result, m)
+			assert.Equal(t, c.Text, m.String())
+		})
+	}
+}
+
+func Test_Money_Arithmetic(t *testing.T) {
+	m1, err := NewMoney(decimal.NewFromInt(5), "USD")
+	assert.NoError(t, err)
+
+	m2, err := NewMoney(decimal.NewFromInt(3), "USD")
+	assert.NoError(t, err)
+
+	e, err := NewMoney(decimal.NewFromInt(3), "EUR")
+	assert.NoError(t, err)
+
+	_, err = m1.Add(e)
+	assert.Equal(t, ErrCurrencyMismatch, err)
+
+	_, err = m1.Sub(e)
+	assert.Equal(t, ErrCurrencyMismatch, err)
+
+	_, err = m1.Cmp(e)
+	assert.Equal(t, ErrCurrencyMismatch, err)
+
+	res, err := m1.Add(m2)
+	assert.NoError(t, err)
+	assert.Equal(t, "8 USD", res.String())
+
+	res, err = m1.Sub(m2)
+	assert.NoError(t, err)
+	assert.Equal(t, "2 USD", res.String())
+
+	v, err := m1.Cmp(m2)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, v)
+
+	assert.Equal(t, "10 USD", m1.Mul(decimal.NewFromInt(2)).String())
+	assert.Equal(t, "-5 USD", m1.Neg().String())
+	assert.False(t, m1.IsZero())
+	assert.True(t, Money{Currency: "USD"}.IsZero())
+}
+
+func Test_Money_JSON(t *testing.T) {
+	m := Money{Amount: decimal.RequireFromString("12.5"), Currency: "USD"}
+
+	d, err := json.Marshal(m)
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"amount":"12.5","currency":"USD"}`, string(d))
+
+	var res Money
+	assert.NoError(t, json.Unmarshal(d, &res))
+	assert.Equal(t, m, res)
+}
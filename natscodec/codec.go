@@ -0,0 +1,310 @@
+// Package natscodec provides NATS subject conventions for chartype's
+// structures and JSON encoding of published messages.
+//
+// Subjects have the following form:
+//
+//	<prefix>.candles.<pair>.<interval>, e.g. "md.candles.BTC_USDT.1m"
+//	<prefix>.ticker.<pair>, e.g. "md.ticker.BTC_USDT"
+//	<prefix>.trades.<pair>, e.g. "md.trades.BTC_USDT"
+package natscodec
+
+import (
+	"encoding/json"
+	"strings"
+
+	"github.com/jellydator/chartype"
+)
+
+const (
+	// KindCandle specifies a candle series subject.
+	KindCandle Kind = iota + 1
+
+	// KindTicker specifies a ticker subject.
+	KindTicker
+
+	// KindTrade specifies a trade subject.
+	KindTrade
+)
+
+const (
+	// DefaultPrefix is used when codec's prefix is not set.
+	DefaultPrefix = "md"
+
+	// wildcard matches any single subject token.
+	wildcard = "*"
+)
+
+var (
+	// ErrInvalidKind is returned when kind with invalid value is
+	// being used.
+	ErrInvalidKind = &chartype.Error{
+		Code:    chartype.CodeInvalidArgument,
+		Message: "invalid kind",
+	}
+
+	// ErrInvalidSubject is returned when subject does not follow
+	// the conventions.
+	ErrInvalidSubject = &chartype.Error{
+		Code:    chartype.CodeParseFailure,
+		Message: "invalid subject",
+	}
+)
+
+// Kind specifies which structure is published on a subject.
+type Kind int
+
+// Validate checks whether the kind is one of supported kind types
+// or not.
+func (k Kind) Validate() error {
+	switch k {
+	case KindCandle, KindTicker, KindTrade:
+		return nil
+	default:
+		return ErrInvalidKind
+	}
+}
+
+// MarshalText turns kind to appropriate subject token.
+func (k Kind) MarshalText() ([]byte, error) {
+	var v string
+
+	switch k {
+	case KindCandle:
+		v = "candles"
+	case KindTicker:
+		v = "ticker"
+	case KindTrade:
+		v = "trades"
+	default:
+		return nil, ErrInvalidKind
+	}
+
+	return []byte(v), nil
+}
+
+// UnmarshalText turns subject token to appropriate kind value.
+func (k *Kind) UnmarshalText(d []byte) error {
+	switch string(d) {
+	case "candles":
+		*k = KindCandle
+	case "ticker":
+		*k = KindTicker
+	case "trades":
+		*k = KindTrade
+	default:
+		return ErrInvalidKind
+	}
+
+	return nil
+}
+
+// Subject holds typed subject information.
+type Subject struct {
+	Kind Kind
+	Pair chartype.Pair
+
+	// Interval is used only by candle subjects.
+	Interval chartype.Interval
+}
+
+// Validate checks whether subject's kind and pair are valid and
+// whether interval is set only for candle subjects.
+func (s Subject) Validate() error {
+	if err := s.Kind.Validate(); err != nil {
+		return err
+	}
+
+	if err := s.Pair.Validate(); err != nil {
+		return err
+	}
+
+	if s.Kind == KindCandle {
+		return s.Interval.Validate()
+	}
+
+	if s.Interval != 0 {
+		return ErrInvalidSubject
+	}
+
+	return nil
+}
+
+// Message holds a decoded message. Only the field matching
+// subject's kind is set.
+type Message struct {
+	Subject Subject
+	Candle  chartype.Candle
+	Ticker  chartype.Ticker
+	Trade   chartype.Trade
+}
+
+// Codec derives subjects and encodes and decodes messages.
+type Codec struct {
+	// Prefix specifies the first tokens of every subject.
+	// DefaultPrefix is used when it is empty.
+	Prefix string
+}
+
+// Subject returns subject's string representation.
+func (c Codec) Subject(s Subject) (string, error) {
+	if err := s.Validate(); err != nil {
+		return "", err
+	}
+
+	return c.join(s.Kind, s.Pair.String(), s.Interval), nil
+}
+
+// Pattern returns subscription subject of the given kind. Zero pair
+// or interval is replaced with a single token wildcard, e.g.
+// "md.candles.*.1m" matches one minute candles of all pairs.
+func (c Codec) Pattern(k Kind, p chartype.Pair, i chartype.Interval) (string, error) {
+	if err := k.Validate(); err != nil {
+		return "", err
+	}
+
+	ps := wildcard
+
+	if p != (chartype.Pair{}) {
+		if err := p.Validate(); err != nil {
+			return "", err
+		}
+
+		ps = p.String()
+	}
+
+	if k != KindCandle {
+		if i != 0 {
+			return "", ErrInvalidSubject
+		}
+
+		return c.join(k, ps, 0), nil
+	}
+
+	if i == 0 {
+		return c.join(k, ps, 0) + "." + wildcard, nil
+	}
+
+	if err := i.Validate(); err != nil {
+		return "", err
+	}
+
+	return c.join(k, ps, i), nil
+}
+
+// ParseSubject parses subject of a received message.
+func (c Codec) ParseSubject(subj string) (Subject, error) {
+	rest := strings.TrimPrefix(subj, c.prefix()+".")
+	if rest == subj {
+		return Subject{}, ErrInvalidSubject
+	}
+
+	tt := strings.Split(rest, ".")
+
+	var s Subject
+
+	if err := s.Kind.UnmarshalText([]byte(tt[0])); err != nil {
+		return Subject{}, err
+	}
+
+	n := 2
+	if s.Kind == KindCandle {
+		n = 3
+	}
+
+	if len(tt) != n {
+		return Subject{}, ErrInvalidSubject
+	}
+
+	if err := s.Pair.UnmarshalText([]byte(tt[1])); err != nil {
+		return Subject{}, err
+	}
+
+	if n == 3 {
+		if err := s.Interval.UnmarshalText([]byte(tt[2])); err != nil {
+			return Subject{}, err
+		}
+	}
+
+	return s, nil
+}
+
+// EncodeCandle returns subject and JSON data of the candle from
+// the pair's interval-long series.
+func (c Codec) EncodeCandle(p chartype.Pair, i chartype.Interval, cd chartype.Candle) (string, []byte, error) {
+	return c.encode(Subject{Kind: KindCandle, Pair: p, Interval: i}, cd)
+}
+
+// EncodeTicker returns subject and JSON data of the pair's ticker.
+func (c Codec) EncodeTicker(p chartype.Pair, t chartype.Ticker) (string, []byte, error) {
+	return c.encode(Subject{Kind: KindTicker, Pair: p}, t)
+}
+
+// EncodeTrade returns subject and JSON data of the pair's trade.
+func (c Codec) EncodeTrade(p chartype.Pair, t chartype.Trade) (string, []byte, error) {
+	return c.encode(Subject{Kind: KindTrade, Pair: p}, t)
+}
+
+// Decode parses received message's subject and decodes its data into
+// the structure matching subject's kind. It can be used for messages
+// received via wildcard subscriptions.
+func (c Codec) Decode(subj string, d []byte) (Message, error) {
+	s, err := c.ParseSubject(subj)
+	if err != nil {
+		return Message{}, err
+	}
+
+	m := Message{Subject: s}
+
+	switch s.Kind {
+	case KindCandle:
+		err = json.Unmarshal(d, &m.Candle)
+	case KindTicker:
+		err = json.Unmarshal(d, &m.Ticker)
+	default:
+		err = json.Unmarshal(d, &m.Trade)
+	}
+
+	if err != nil {
+		return Message{}, err
+	}
+
+	return m, nil
+}
+
+// encode returns subject's string representation and JSON encoded
+// value.
+func (c Codec) encode(s Subject, v interface{}) (string, []byte, error) {
+	subj, err := c.Subject(s)
+	if err != nil {
+		return "", nil, err
+	}
+
+	d, err := json.Marshal(v)
+	if err != nil {
+		return "", nil, err
+	}
+
+	return subj, d, nil
+}
+
+// join returns subject made of prefix, kind, pair token and interval,
+// if it is set.
+func (c Codec) join(k Kind, p string, i chartype.Interval) string {
+	kt, _ := k.MarshalText() //nolint:errcheck // kind is validated by callers
+
+	s := c.prefix() + "." + string(kt) + "." + p
+	if i != 0 {
+		s += "." + i.String()
+	}
+
+	return s
+}
+
+// prefix returns codec's prefix or the default one.
+func (c Codec) prefix() string {
+	if c.Prefix == "" {
+		return DefaultPrefix
+	}
+
+	return c.Prefix
+}
@@ -0,0 +1,330 @@
+package natscodec
+
+import (
+	"testing"
+	"time"
+
+	"github.com/jellydator/chartype"
+	"github.com/jellydator/chartype/chartypetest"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+)
+
+var testPair = chartype.Pair{Base: "BTC", Quote: "USDT"} //nolint:gochecknoglobals // test data
+
+func Test_Kind_Validate(t *testing.T) {
+	assert.Equal(t, ErrInvalidKind, Kind(70).Validate())
+	assert.NoError(t, KindCandle.Validate())
+	assert.NoError(t, KindTicker.Validate())
+	assert.NoError(t, KindTrade.Validate())
+}
+
+func Test_Kind_MarshalText(t *testing.T) {
+	cc := map[string]struct {
+		Kind Kind
+		Text string
+		Err  error
+	}{
+		"Invalid Kind": {
+			Kind: 70,
+			Err:  ErrInvalidKind,
+		},
+		"Successful KindCandle marshal": {
+			Kind: KindCandle,
+			Text: "candles",
+		},
+		"Successful KindTicker marshal": {
+			Kind: KindTicker,
+			Text: "ticker",
+		},
+		"Successful KindTrade marshal": {
+			Kind: KindTrade,
+			Text: "trades",
+		},
+	}
+
+	for cn, c := range cc {
+		c := c
+
+		t.Run(cn, func(t *testing.T) {
+			t.Parallel()
+
+			d, err := c.Kind.MarshalText()
+			chartypetest.AssertErrorEqual(t, c.Err, err)
+			if err != nil {
+				return
+			}
+
+			assert.Equal(t, c.Text, string(d))
+		})
+	}
+}
+
+func Test_Kind_UnmarshalText(t *testing.T) {
+	cc := map[string]struct {
+		Text   string
+		Result Kind
+		Err    error
+	}{
+		"Invalid Kind": {
+			Text: "books",
+			Err:  ErrInvalidKind,
+		},
+		"Successful KindCandle unmarshal": {
+			Text:   "candles",
+			Result: KindCandle,
+		},
+		"Successful KindTicker unmarshal": {
+			Text:   "ticker",
+			Result: KindTicker,
+		},
+		"Successful KindTrade unmarshal": {
+			Text:   "trades",
+			Result: KindTrade,
+		},
+	}
+
+	for cn, c := range cc {
+		c := c
+
+		t.Run(cn, func(t *testing.T) {
+			t.Parallel()
+
+			var k Kind
+
+			err := k.UnmarshalText([]byte(c.Text))
+			chartypetest.AssertErrorEqual(t, c.Err, err)
+			if err != nil {
+				return
+			}
+
+			assert.Equal(t, c.Result, k)
+		})
+	}
+}
+
+func Test_Codec_Subject(t *testing.T) {
+	cc := map[string]struct {
+		Codec   Codec
+		Subject Subject
+		Result  string
+		Err     error
+	}{
+		"Invalid kind": {
+			Subject: Subject{Pair: testPair},
+			Err:     ErrInvalidKind,
+		},
+		"Invalid pair": {
+			Subject: Subject{Kind: KindTicker},
+			Err:     chartype.ErrInvalidPair,
+		},
+		"Missing candle interval": {
+			Subject: Subject{Kind: KindCandle, Pair: testPair},
+			Err:     chartype.ErrInvalidInterval,
+		},
+		"Unexpected ticker interval": {
+			Subject: Subject{Kind: KindTicker, Pair: testPair, Interval: chartype.IntervalHour},
+			Err:     ErrInvalidSubject,
+		},
+		"Successful candle subject": {
+			Subject: Subject{Kind: KindCandle, Pair: testPair, Interval: chartype.IntervalMinute},
+			Result:  "md.candles.BTC_USDT.1m",
+		},
+		"Successful trade subject with custom prefix": {
+			Codec:   Codec{Prefix: "prod.md"},
+			Subject: Subject{Kind: KindTrade, Pair: testPair},
+			Result:  "prod.md.trades.BTC_USDT",
+		},
+	}
+
+	for cn, c := range cc {
+		c := c
+
+		t.Run(cn, func(t *testing.T) {
+			t.Parallel()
+
+			res, err := c.Codec.Subject(c.Subject)
+			chartypetest.AssertErrorEqual(t, c.Err, err)
+			if err != nil {
+				return
+			}
+
+			assert.Equal(t, c.Result, res)
+		})
+	}
+}
+
+func Test_Codec_Pattern(t *testing.T) {
+	cc := map[string]struct {
+		Kind     Kind
+		Pair     chartype.Pair
+		Interval chartype.Interval
+		Result   string
+		Err      error
+	}{
+		"Invalid kind": {
+			Err: ErrInvalidKind,
+		},
+		"Invalid pair": {
+			Kind: KindTicker,
+			Pair: chartype.Pair{Base: "BTC"},
+			Err:  chartype.ErrInvalidPair,
+		},
+		"Unexpected ticker interval": {
+			Kind:     KindTicker,
+			Interval: chartype.IntervalHour,
+			Err:      ErrInvalidSubject,
+		},
+		"Invalid candle interval": {
+			Kind:     KindCandle,
+			Interval: -1,
+			Err:      chartype.ErrInvalidInterval,
+		},
+		"All tickers": {
+			Kind:   KindTicker,
+			Result: "md.ticker.*",
+		},
+		"All candles": {
+			Kind:   KindCandle,
+			Result: "md.candles.*.*",
+		},
+		"All pair's candles": {
+			Kind:   KindCandle,
+			Pair:   testPair,
+			Result: "md.candles.BTC_USDT.*",
+		},
+		"All one minute candles": {
+			Kind:     KindCandle,
+			Interval: chartype.IntervalMinute,
+			Result:   "md.candles.*.1m",
+		},
+	}
+
+	for cn, c := range cc {
+		c := c
+
+		t.Run(cn, func(t *testing.T) {
+			t.Parallel()
+
+			res, err := Codec{}.Pattern(c.Kind, c.Pair, c.Interval)
+			chartypetest.AssertErrorEqual(t, c.Err, err)
+			if err != nil {
+				return
+			}
+
+			assert.Equal(t, c.Result, res)
+		})
+	}
+}
+
+func Test_Codec_ParseSubject(t *testing.T) {
+	cc := map[string]struct {
+		Subject string
+		Result  Subject
+		Err     error
+	}{
+		"Invalid prefix": {
+			Subject: "xx.ticker.BTC_USDT",
+			Err:     ErrInvalidSubject,
+		},
+		"Invalid kind": {
+			Subject: "md.books.BTC_USDT",
+			Err:     ErrInvalidKind,
+		},
+		"Invalid token count": {
+			Subject: "md.candles.BTC_USDT",
+			Err:     ErrInvalidSubject,
+		},
+		"Invalid pair": {
+			Subject: "md.ticker.BTC",
+			Err:     chartype.ErrInvalidPair,
+		},
+		"Invalid interval": {
+			Subject: "md.candles.BTC_USDT.1y",
+			Err:     chartype.ErrInvalidInterval,
+		},
+		"Successful candle subject parse": {
+			Subject: "md.candles.BTC_USDT.1h",
+			Result:  Subject{Kind: KindCandle, Pair: testPair, Interval: chartype.IntervalHour},
+		},
+		"Successful trade subject parse": {
+			Subject: "md.trades.BTC_USDT",
+			Result:  Subject{Kind: KindTrade, Pair: testPair},
+		},
+	}
+
+	for cn, c := range cc {
+		c := c
+
+		t.Run(cn, func(t *testing.T) {
+			t.Parallel()
+
+			res, err := Codec{}.ParseSubject(c.Subject)
+			chartypetest.AssertErrorEqual(t, c.Err, err)
+			if err != nil {
+				return
+			}
+
+			assert.Equal(t, c.Result, res)
+		})
+	}
+}
+
+func Test_Codec_Encode(t *testing.T) {
+	var cd Codec
+
+	adj := decimal.RequireFromString("3.5")
+	delta := decimal.RequireFromString("-0.25")
+
+	c := chartype.Candle{
+		Timestamp: time.Unix(60, 0).UTC(),
+		Open:      decimal.NewFromInt(1),
+		High:      decimal.NewFromInt(2),
+		Low:       decimal.NewFromInt(3),
+		Close:     decimal.NewFromInt(4),
+		Volume:    decimal.NewFromInt(5),
+		AdjClose:  &adj,
+		Delta:     &delta,
+	}
+
+	subj, d, err := cd.EncodeCandle(testPair, chartype.IntervalMinute, c)
+	assert.NoError(t, err)
+	assert.Equal(t, "md.candles.BTC_USDT.1m", subj)
+
+	m, err := cd.Decode(subj, d)
+	assert.NoError(t, err)
+	assert.Equal(t, Message{
+		Subject: Subject{Kind: KindCandle, Pair: testPair, Interval: chartype.IntervalMinute},
+		Candle:  c,
+	}, m)
+
+	tk := chartype.Ticker{Last: decimal.NewFromInt(1)}
+
+	subj, d, err = cd.EncodeTicker(testPair, tk)
+	assert.NoError(t, err)
+
+	m, err = cd.Decode(subj, d)
+	assert.NoError(t, err)
+	assert.True(t, tk.Last.Equal(m.Ticker.Last))
+
+	tr := chartype.Trade{Timestamp: time.Unix(1, 0).UTC(), Side: chartype.SideBuy}
+
+	subj, d, err = cd.EncodeTrade(testPair, tr)
+	assert.NoError(t, err)
+
+	m, err = cd.Decode(subj, d)
+	assert.NoError(t, err)
+	assert.Equal(t, chartype.SideBuy, m.Trade.Side)
+
+	_, _, err = cd.EncodeTicker(chartype.Pair{}, tk)
+	assert.Equal(t, chartype.ErrInvalidPair, err)
+
+	_, _, err = cd.EncodeTrade(testPair, chartype.Trade{})
+	assert.Error(t, err)
+
+	_, err = cd.Decode("md.ticker", nil)
+	assert.Equal(t, ErrInvalidSubject, err)
+
+	_, err = cd.Decode("md.ticker.BTC_USDT", []byte("{"))
+	assert.Error(t, err)
+}
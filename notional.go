@@ -0,0 +1,64 @@
+package chartype
+
+import "github.com/shopspring/decimal"
+
+// three is the number of prices averaged by the typical price.
+var three = decimal.NewFromInt(3) //nolint:gochecknoglobals // decimal constants cannot be declared as consts
+
+// TypicalPrice returns the average of candle's high, low and close
+// prices.
+func (c Candle) TypicalPrice() decimal.Decimal {
+	return c.High.Add(c.Low).Add(c.Close).Div(three)
+}
+
+// Notional returns candle's volume valued at its typical price,
+// i.e. an estimate of the traded quote currency amount.
+func (c Candle) Notional() decimal.Decimal {
+	return c.TypicalPrice().Mul(c.Volume)
+}
+
+// QuoteVolume converts candle's base currency volume to quote
+// currency volume using the price specified by the candle field.
+// Volume field is not accepted as a price.
+func (c Candle) QuoteVolume(cf CandleField) (decimal.Decimal, error) {
+	if err := validPriceField(cf); err != nil {
+		return decimal.Zero, err
+	}
+
+	return cf.Extract(c).Mul(c.Volume), nil
+}
+
+// NotionalSeries returns notional values of all candles.
+func (cc Candles) NotionalSeries() []decimal.Decimal {
+	res := make([]decimal.Decimal, len(cc))
+	for i, c := range cc {
+		res[i] = c.Notional()
+	}
+
+	return res
+}
+
+// QuoteVolumeSeries returns quote currency volumes of all candles
+// using the price specified by the candle field.
+func (cc Candles) QuoteVolumeSeries(cf CandleField) ([]decimal.Decimal, error) {
+	if err := validPriceField(cf); err != nil {
+		return nil, err
+	}
+
+	res := make([]decimal.Decimal, len(cc))
+	for i, c := range cc {
+		res[i] = cf.Extract(c).Mul(c.Volume)
+	}
+
+	return res, nil
+}
+
+// validPriceField checks whether the candle field specifies one of
+// candle's prices.
+func validPriceField(cf CandleField) error {
+	if cf == CandleVolume {
+		return ErrInvalidCandleField
+	}
+
+	return cf.Validate()
+}
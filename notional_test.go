@@ -0,0 +1,81 @@
+package chartype
+
+import (
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Candle_Notional(t *testing.T) {
+	c := testCandle(time.Time{}, 1, 6, 2, 4, 10)
+
+	assert.Equal(t, "4", c.TypicalPrice().String())
+	assert.Equal(t, "40", c.Notional().String())
+}
+
+func Test_Candle_QuoteVolume(t *testing.T) {
+	cc := map[string]struct {
+		CandleField CandleField
+		Result      decimal.Decimal
+		Err         error
+	}{
+		"Invalid CandleField": {
+			CandleField: 70,
+			Err:         ErrInvalidCandleField,
+		},
+		"Volume CandleField": {
+			CandleField: CandleVolume,
+			Err:         ErrInvalidCandleField,
+		},
+		"Successful conversion": {
+			CandleField: CandleClose,
+			Result:      decimal.NewFromInt(40),
+		},
+	}
+
+	for cn, c := range cc {
+		c := c
+
+		t.Run(cn, func(t *testing.T) {
+			t.Parallel()
+
+			res, err := testCandle(time.Time{}, 1, 6, 2, 4, 10).QuoteVolume(c.CandleField)
+			equalError(t, c.Err, err)
+			if err != nil {
+				return
+			}
+
+			assert.Equal(t, c.Result.String(), res.String())
+		})
+	}
+}
+
+func Test_Candles_NotionalSeries(t *testing.T) {
+	cc := Candles{
+		testCandle(time.Time{}, 1, 6, 2, 4, 10),
+		testCandle(time.Time{}, 1, 3, 3, 3, 2),
+	}
+
+	res := cc.NotionalSeries()
+	assert.Len(t, res, 2)
+	assert.Equal(t, "40", res[0].String())
+	assert.Equal(t, "6", res[1].String())
+}
+
+func Test_Candles_QuoteVolumeSeries(t *testing.T) {
+	cc := Candles{
+		testCandle(time.Time{}, 1, 6, 2, 4, 10),
+		testCandle(time.Time{}, 1, 3, 3, 3, 2),
+	}
+
+	_, err := cc.QuoteVolumeSeries(CandleVolume)
+	assert.Equal(t, ErrInvalidCandleField, err)
+
+	res, err := cc.QuoteVolumeSeries(CandleOpen)
+	assert.NoError(t, err)
+	assert.Len(t, res, 2)
+	assert.Equal(t, "10", res[0].String())
+	assert.Equal(t, "2", res[1].String())
+}
@@ -0,0 +1,231 @@
+package chartype
+
+import (
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+var (
+	// ErrInvalidAmount is returned when zero or negative amount is
+	// used where a positive one is expected.
+	ErrInvalidAmount = newError(CodeInvalidArgument, "invalid amount")
+
+	// ErrInsufficientLiquidity is returned when order book's side
+	// does not hold enough volume to fill the requested amount.
+	ErrInsufficientLiquidity = newError(CodeInsufficientLiquidity, "insufficient liquidity")
+
+	// ErrCrossedBook is returned when order book's best bid is not
+	// lower than its best ask.
+	ErrCrossedBook = newError(CodeCrossedBook, "crossed book")
+)
+
+// PriceLevel holds the total amount offered at a single price.
+type PriceLevel struct {
+	Price  decimal.Decimal `json:"price" yaml:"price"`
+	Amount decimal.Decimal `json:"amount" yaml:"amount"`
+}
+
+// OrderBook holds a snapshot of resting orders aggregated by price.
+// Bids are ordered from the highest price, asks from the lowest one.
+type OrderBook struct {
+	Timestamp time.Time    `json:"timestamp" yaml:"timestamp"`
+	Bids      []PriceLevel `json:"bids" yaml:"bids"`
+	Asks      []PriceLevel `json:"asks" yaml:"asks"`
+}
+
+// BestBid returns the highest bid and whether there is one.
+func (ob OrderBook) BestBid() (PriceLevel, bool) {
+	if len(ob.Bids) == 0 {
+		return PriceLevel{}, false
+	}
+
+	return ob.Bids[0], true
+}
+
+// BestAsk returns the lowest ask and whether there is one.
+func (ob OrderBook) BestAsk() (PriceLevel, bool) {
+	if len(ob.Asks) == 0 {
+		return PriceLevel{}, false
+	}
+
+	return ob.Asks[0], true
+}
+
+// Validate checks whether the best bid is lower than the best ask.
+// Books with an empty side are valid.
+func (ob OrderBook) Validate() error {
+	b, bok := ob.BestBid()
+	a, aok := ob.BestAsk()
+
+	if bok && aok && b.Price.GreaterThanOrEqual(a.Price) {
+		return ErrCrossedBook
+	}
+
+	return nil
+}
+
+// Mid returns the average of the best bid and ask prices and whether
+// both sides have orders.
+func (ob OrderBook) Mid() (decimal.Decimal, bool) {
+	b, bok := ob.BestBid()
+	a, aok := ob.BestAsk()
+
+	if !bok || !aok {
+		return decimal.Zero, false
+	}
+
+	return a.Price.Add(b.Price).Div(two), true
+}
+
+// Fill holds the estimated execution of a market order.
+type Fill struct {
+	// AveragePrice specifies the volume-weighted price of the fill.
+	AveragePrice decimal.Decimal `json:"average_price" yaml:"average_price"`
+
+	// Notional specifies the quote currency amount of the fill.
+	Notional decimal.Decimal `json:"notional" yaml:"notional"`
+
+	// Slippage specifies how much worse the average price is than
+	// the best price of the consumed side.
+	Slippage Percent `json:"slippage" yaml:"slippage"`
+}
+
+// EstimateFill estimates the execution of a market order of the
+// provided side and base currency amount against the order book.
+// Buy orders consume asks, sell orders consume bids. ErrInvalidPrice
+// is returned if the best price of the consumed side is not positive.
+func (ob OrderBook) EstimateFill(s Side, amount decimal.Decimal) (Fill, error) {
+	if err := s.Validate(); err != nil {
+		return Fill{}, err
+	}
+
+	if !amount.IsPositive() {
+		return Fill{}, ErrInvalidAmount
+	}
+
+	ll := ob.Asks
+	if s == SideSell {
+		ll = ob.Bids
+	}
+
+	var (
+		left     = amount
+		notional decimal.Decimal
+	)
+
+	for _, l := range ll {
+		a := decimal.Min(left, l.Amount)
+		notional = notional.Add(a.Mul(l.Price))
+		left = left.Sub(a)
+
+		if left.IsZero() {
+			break
+		}
+	}
+
+	if left.IsPositive() {
+		return Fill{}, ErrInsufficientLiquidity
+	}
+
+	best := ll[0].Price
+	if !best.IsPositive() {
+		return Fill{}, ErrInvalidPrice
+	}
+
+	avg := notional.Div(amount)
+
+	slip := avg.Sub(best)
+	if s == SideSell {
+		slip = slip.Neg()
+	}
+
+	return Fill{
+		AveragePrice: avg,
+		Notional:     notional,
+		Slippage:     NewPercentFromRatio(slip.Div(best)),
+	}, nil
+}
+
+// Imbalance returns the difference between bid and ask volumes
+// divided by their sum within the provided number of best levels of
+// each side, or all levels if it is zero or negative. The result is
+// in [-1, 1] range, positive values indicate buying pressure. False
+// is returned if both sides are empty.
+func (ob OrderBook) Imbalance(levels int) (decimal.Decimal, bool) {
+	b := sumAmount(ob.Bids, levels)
+	a := sumAmount(ob.Asks, levels)
+
+	total := b.Add(a)
+	if total.IsZero() {
+		return decimal.Zero, false
+	}
+
+	return b.Sub(a).Div(total), true
+}
+
+// sumAmount returns the total amount of the provided number of best
+// levels, or all levels if it is zero or negative.
+func sumAmount(ll []PriceLevel, levels int) decimal.Decimal {
+	if levels > 0 && levels < len(ll) {
+		ll = ll[:levels]
+	}
+
+	res := decimal.Zero
+	for _, l := range ll {
+		res = res.Add(l.Amount)
+	}
+
+	return res
+}
+
+// Depth holds cumulative amounts of both order book's sides.
+type Depth struct {
+	Bid decimal.Decimal `json:"bid" yaml:"bid"`
+	Ask decimal.Decimal `json:"ask" yaml:"ask"`
+}
+
+// DepthWithin returns cumulative bid and ask amounts offered at
+// prices within the provided number of basis points of the mid
+// price. False is returned if either side is empty.
+func (ob OrderBook) DepthWithin(bps decimal.Decimal) (Depth, bool) {
+	m, ok := ob.Mid()
+	if !ok {
+		return Depth{}, false
+	}
+
+	d := m.Mul(bps).Shift(-4)
+	low, high := m.Sub(d), m.Add(d)
+
+	var res Depth
+
+	for _, l := range ob.Bids {
+		if l.Price.LessThan(low) {
+			break
+		}
+
+		res.Bid = res.Bid.Add(l.Amount)
+	}
+
+	for _, l := range ob.Asks {
+		if l.Price.GreaterThan(high) {
+			break
+		}
+
+		res.Ask = res.Ask.Add(l.Amount)
+	}
+
+	return res, true
+}
+
+// DWAP returns the depth-weighted average price of filling the
+// provided base currency amount on the side consumed by a market
+// order of the provided side.
+func (ob OrderBook) DWAP(s Side, amount decimal.Decimal) (decimal.Decimal, error) {
+	f, err := ob.EstimateFill(s, amount)
+	if err != nil {
+		return decimal.Zero, err
+	}
+
+	return f.AveragePrice, nil
+}
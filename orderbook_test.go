@@ -0,0 +1,227 @@
+package chartype
+
+import (
+	"testing"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+)
+
+func testLevel(p, a string) PriceLevel {
+	return PriceLevel{
+		Price:  decimal.RequireFromString(p),
+		Amount: decimal.RequireFromString(a),
+	}
+}
+
+func testOrderBook() OrderBook {
+	return OrderBook{
+		Bids: []PriceLevel{
+			testLevel("99", "1"),
+			testLevel("98", "2"),
+		},
+		Asks: []PriceLevel{
+			testLevel("101", "1"),
+			testLevel("102", "1"),
+			testLevel("104", "2"),
+		},
+	}
+}
+
+func Test_OrderBook_Best(t *testing.T) {
+	ob := testOrderBook()
+
+	b, ok := ob.BestBid()
+	assert.True(t, ok)
+	assert.Equal(t, testLevel("99", "1"), b)
+
+	a, ok := ob.BestAsk()
+	assert.True(t, ok)
+	assert.Equal(t, testLevel("101", "1"), a)
+
+	m, ok := ob.Mid()
+	assert.True(t, ok)
+	assert.Equal(t, "100", m.String())
+
+	_, ok = OrderBook{}.BestBid()
+	assert.False(t, ok)
+
+	_, ok = OrderBook{}.BestAsk()
+	assert.False(t, ok)
+
+	_, ok = OrderBook{Bids: ob.Bids}.Mid()
+	assert.False(t, ok)
+}
+
+func Test_OrderBook_Validate(t *testing.T) {
+	cc := map[string]struct {
+		Book OrderBook
+		Err  error
+	}{
+		"Empty book": {},
+		"Empty side": {
+			Book: OrderBook{Bids: []PriceLevel{testLevel("100", "1")}},
+		},
+		"Locked book": {
+			Book: OrderBook{
+				Bids: []PriceLevel{testLevel("100", "1")},
+				Asks: []PriceLevel{testLevel("100", "1")},
+			},
+			Err: ErrCrossedBook,
+		},
+		"Crossed book": {
+			Book: OrderBook{
+				Bids: []PriceLevel{testLevel("101", "1")},
+				Asks: []PriceLevel{testLevel("100", "1")},
+			},
+			Err: ErrCrossedBook,
+		},
+		"Successful validation": {
+			Book: testOrderBook(),
+		},
+	}
+
+	for cn, c := range cc {
+		c := c
+
+		t.Run(cn, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, c.Err, c.Book.Validate())
+		})
+	}
+}
+
+func Test_OrderBook_EstimateFill(t *testing.T) {
+	cc := map[string]struct {
+		Book         *OrderBook
+		Side         Side
+		Amount       string
+		AveragePrice string
+		Notional     string
+		Slippage     string
+		Err          error
+	}{
+		"Invalid side": {
+			Amount: "1",
+			Err:    ErrInvalidSide,
+		},
+		"Invalid amount": {
+			Side:   SideBuy,
+			Amount: "0",
+			Err:    ErrInvalidAmount,
+		},
+		"Insufficient liquidity": {
+			Side:   SideSell,
+			Amount: "3.5",
+			Err:    ErrInsufficientLiquidity,
+		},
+		"Zero best price": {
+			Book: &OrderBook{
+				Bids: []PriceLevel{testLevel("0", "1")},
+			},
+			Side:   SideSell,
+			Amount: "1",
+			Err:    ErrInvalidPrice,
+		},
+		"Negative best price": {
+			Book: &OrderBook{
+				Asks: []PriceLevel{testLevel("-1", "1")},
+			},
+			Side:   SideBuy,
+			Amount: "1",
+			Err:    ErrInvalidPrice,
+		},
+		"Successful buy within best level": {
+			Side:         SideBuy,
+			Amount:       "0.5",
+			AveragePrice: "101",
+			Notional:     "50.5",
+			Slippage:     "0.00%",
+		},
+		"Successful buy across levels": {
+			Side:         SideBuy,
+			Amount:       "4",
+			AveragePrice: "102.75",
+			Notional:     "411",
+			Slippage:     "1.73%",
+		},
+		"Successful sell across levels": {
+			Side:         SideSell,
+			Amount:       "2",
+			AveragePrice: "98.5",
+			Notional:     "197",
+			Slippage:     "0.51%",
+		},
+	}
+
+	for cn, c := range cc {
+		c := c
+
+		t.Run(cn, func(t *testing.T) {
+			t.Parallel()
+
+			ob := testOrderBook()
+			if c.Book != nil {
+				ob = *c.Book
+			}
+
+			f, err := ob.EstimateFill(c.Side, decimal.RequireFromString(c.Amount))
+			equalError(t, c.Err, err)
+			if err != nil {
+				return
+			}
+
+			assert.Equal(t, c.AveragePrice, f.AveragePrice.String())
+			assert.Equal(t, c.Notional, f.Notional.String())
+			assert.Equal(t, c.Slippage, f.Slippage.StringFixed(2))
+		})
+	}
+}
+
+func Test_OrderBook_Imbalance(t *testing.T) {
+	ob := testOrderBook()
+
+	_, ok := OrderBook{}.Imbalance(0)
+	assert.False(t, ok)
+
+	i, ok := ob.Imbalance(0)
+	assert.True(t, ok)
+	assert.Equal(t, "-0.1428571428571429", i.String())
+
+	i, ok = ob.Imbalance(1)
+	assert.True(t, ok)
+	assert.Equal(t, "0", i.String())
+
+	i, ok = ob.Imbalance(2)
+	assert.True(t, ok)
+	assert.Equal(t, "0.2", i.String())
+}
+
+func Test_OrderBook_DepthWithin(t *testing.T) {
+	ob := testOrderBook()
+
+	_, ok := OrderBook{}.DepthWithin(decimal.NewFromInt(100))
+	assert.False(t, ok)
+
+	d, ok := ob.DepthWithin(decimal.NewFromInt(100))
+	assert.True(t, ok)
+	assert.Equal(t, "1", d.Bid.String())
+	assert.Equal(t, "1", d.Ask.String())
+
+	d, ok = ob.DepthWithin(decimal.NewFromInt(300))
+	assert.True(t, ok)
+	assert.Equal(t, "3", d.Bid.String())
+	assert.Equal(t, "2", d.Ask.String())
+}
+
+func Test_OrderBook_DWAP(t *testing.T) {
+	ob := testOrderBook()
+
+	_, err := ob.DWAP(SideBuy, decimal.NewFromInt(10))
+	assert.Equal(t, ErrInsufficientLiquidity, err)
+
+	p, err := ob.DWAP(SideBuy, decimal.NewFromInt(2))
+	assert.NoError(t, err)
+	assert.Equal(t, "101.5", p.String())
+}
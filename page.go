@@ -0,0 +1,97 @@
+package chartype
+
+import "time"
+
+// CandlePage is a single page of a paginated candle query.
+type CandlePage struct {
+	// Candles specifies the page's candles in the order of the
+	// query's direction.
+	Candles []Candle `json:"candles" yaml:"candles"`
+
+	// Next specifies the cursor of the next page. It is nil if there
+	// are no more candles.
+	Next *Cursor `json:"next,omitempty" yaml:"next,omitempty"`
+
+	// HasMore specifies whether there are more candles after the
+	// page.
+	HasMore bool `json:"has_more" yaml:"has_more"`
+
+	// ServerTime specifies the time at which the page was produced.
+	ServerTime time.Time `json:"server_time" yaml:"server_time"`
+}
+
+// NewCandlePage creates a new candle page from candles retrieved in
+// the provided direction. To detect whether more candles exist, up to
+// limit+1 candles should be provided: if there are more than limit
+// candles, the extra ones are dropped and the page points to the next
+// one. Zero limit means no limit.
+func NewCandlePage(cc []Candle, limit int, d Direction, now time.Time) (CandlePage, error) {
+	if limit < 0 {
+		return CandlePage{}, ErrInvalidLimit
+	}
+
+	if err := d.Validate(); err != nil {
+		return CandlePage{}, err
+	}
+
+	cp := CandlePage{Candles: cc, ServerTime: now}
+
+	if limit > 0 && len(cc) > limit {
+		cp.Candles = cc[:limit]
+		cp.HasMore = true
+		cp.Next = &Cursor{Last: cc[limit-1].Timestamp, Direction: d}
+	}
+
+	if cp.Candles == nil {
+		cp.Candles = []Candle{}
+	}
+
+	return cp, nil
+}
+
+// FetchPage retrieves a page of candles described by the candle
+// request from the store. The request's limit specifies the page
+// size. If the cursor is provided, the page continues after it in
+// the cursor's direction; otherwise the first page in the provided
+// direction is retrieved.
+func FetchPage(cs CandleStore, cr CandleRequest, d Direction, cur *Cursor, now time.Time) (CandlePage, error) {
+	if err := cr.Validate(); err != nil {
+		return CandlePage{}, err
+	}
+
+	tr := cr.Range
+
+	if cur != nil {
+		if err := cur.Validate(); err != nil {
+			return CandlePage{}, err
+		}
+
+		d = cur.Direction
+		tr = cur.Next(tr)
+	}
+
+	if err := d.Validate(); err != nil {
+		return CandlePage{}, err
+	}
+
+	if !tr.From.Before(tr.To) {
+		return NewCandlePage(nil, cr.Limit, d, now)
+	}
+
+	cc, err := cs.Range(cr.Pair, cr.Interval, tr.From, tr.To)
+	if err != nil {
+		return CandlePage{}, err
+	}
+
+	if d == Backward {
+		for i, j := 0, len(cc)-1; i < j; i, j = i+1, j-1 {
+			cc[i], cc[j] = cc[j], cc[i]
+		}
+	}
+
+	if cr.Limit > 0 && len(cc) > cr.Limit+1 {
+		cc = cc[:cr.Limit+1]
+	}
+
+	return NewCandlePage(cc, cr.Limit, d, now)
+}
@@ -0,0 +1,117 @@
+package chartype
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// rangeErrStore is a candle store whose range queries fail.
+type rangeErrStore struct {
+	*MemoryStore
+}
+
+func (rangeErrStore) Range(Pair, Interval, time.Time, time.Time) ([]Candle, error) {
+	return nil, assert.AnError
+}
+
+func Test_NewCandlePage(t *testing.T) {
+	tm := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	now := tm.Add(time.Hour)
+	cc := []Candle{testCandle(tm, 1, 1, 1, 1, 1), testCandle(tm.Add(time.Minute), 2, 2, 2, 2, 2)}
+
+	_, err := NewCandlePage(cc, -1, Forward, now)
+	assert.Equal(t, ErrInvalidLimit, err)
+
+	_, err = NewCandlePage(cc, 1, 70, now)
+	assert.Equal(t, ErrInvalidDirection, err)
+
+	cp, err := NewCandlePage(cc, 1, Backward, now)
+	require.NoError(t, err)
+	assert.Equal(t, CandlePage{
+		Candles:    cc[:1],
+		Next:       &Cursor{Last: tm, Direction: Backward},
+		HasMore:    true,
+		ServerTime: now,
+	}, cp)
+
+	cp, err = NewCandlePage(cc, 0, Forward, now)
+	require.NoError(t, err)
+	assert.Equal(t, CandlePage{Candles: cc, ServerTime: now}, cp)
+
+	cp, err = NewCandlePage(nil, 2, Forward, now)
+	require.NoError(t, err)
+	assert.Equal(t, CandlePage{Candles: []Candle{}, ServerTime: now}, cp)
+}
+
+func Test_FetchPage(t *testing.T) {
+	cr := testCandleRequest()
+	cr.Limit = 2
+
+	now := cr.Range.To
+	at := func(h int) time.Time {
+		return cr.Range.From.Add(time.Duration(h) * time.Hour)
+	}
+
+	series := make([]Candle, 5)
+	for i := range series {
+		series[i] = testCandle(at(i), 1, 1, 1, 1, 1)
+	}
+
+	ms := NewMemoryStore()
+	require.NoError(t, ms.Put(cr.Pair, cr.Interval, series...))
+
+	invalid := cr
+	invalid.Limit = -1
+
+	_, err := FetchPage(ms, invalid, Forward, nil, now)
+	assert.Equal(t, ErrInvalidLimit, err)
+
+	_, err = FetchPage(ms, cr, Forward, &Cursor{Direction: Forward}, now)
+	assert.Equal(t, ErrInvalidCursor, err)
+
+	_, err = FetchPage(ms, cr, 70, nil, now)
+	assert.Equal(t, ErrInvalidDirection, err)
+
+	_, err = FetchPage(rangeErrStore{ms}, cr, Forward, nil, now)
+	assert.Equal(t, assert.AnError, err)
+
+	cp, err := FetchPage(ms, cr, Forward, &Cursor{Last: cr.Range.To, Direction: Forward}, now)
+	require.NoError(t, err)
+	assert.Equal(t, CandlePage{Candles: []Candle{}, ServerTime: now}, cp)
+
+	for _, d := range []Direction{Forward, Backward} {
+		var (
+			res []Candle
+			cur *Cursor
+		)
+
+		for pages := 1; ; pages++ {
+			cp, err := FetchPage(ms, cr, d, cur, now)
+			require.NoError(t, err)
+			require.LessOrEqual(t, len(cp.Candles), cr.Limit)
+			assert.Equal(t, now, cp.ServerTime)
+
+			res = append(res, cp.Candles...)
+
+			if !cp.HasMore {
+				assert.Nil(t, cp.Next)
+				assert.Equal(t, 3, pages)
+
+				break
+			}
+
+			assert.Equal(t, d, cp.Next.Direction)
+			cur = cp.Next
+		}
+
+		if d == Forward {
+			assert.Equal(t, series, res)
+			continue
+		}
+
+		assert.Equal(t, []Candle{series[4], series[3], series[2], series[1], series[0]}, res)
+	}
+}
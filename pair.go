@@ -0,0 +1,79 @@
+package chartype
+
+import "strings"
+
+// pairSeparator separates base and quote currencies in pair's
+// string representation.
+const pairSeparator = "_"
+
+var (
+	// ErrInvalidPair is returned when pair with missing or invalid
+	// currency codes is being used.
+	ErrInvalidPair = newError(CodeInvalidArgument, "invalid pair")
+)
+
+// Pair specifies a traded instrument as a base and quote
+// currency combination.
+type Pair struct {
+	Base  string
+	Quote string
+}
+
+// ParsePair parses provided string into a new pair instance.
+// Expected format is "BASE_QUOTE", e.g. "BTC_USDT".
+func ParsePair(s string) (Pair, error) {
+	var p Pair
+	if err := p.UnmarshalText([]byte(s)); err != nil {
+		return Pair{}, err
+	}
+
+	return p, nil
+}
+
+// Validate checks whether both pair's currencies are set and
+// whether they contain no separator characters.
+func (p Pair) Validate() error {
+	if !validCurrency(p.Base) || !validCurrency(p.Quote) {
+		return ErrInvalidPair
+	}
+
+	return nil
+}
+
+// String returns pair's string representation.
+func (p Pair) String() string {
+	return p.Base + pairSeparator + p.Quote
+}
+
+// MarshalText turns pair to appropriate string representation.
+func (p Pair) MarshalText() ([]byte, error) {
+	if err := p.Validate(); err != nil {
+		return nil, err
+	}
+
+	return []byte(p.String()), nil
+}
+
+// UnmarshalText turns string to appropriate pair value.
+func (p *Pair) UnmarshalText(d []byte) error {
+	ss := strings.Split(string(d), pairSeparator)
+	if len(ss) != 2 {
+		return ErrInvalidPair
+	}
+
+	np := Pair{Base: ss[0], Quote: ss[1]}
+	if err := np.Validate(); err != nil {
+		return err
+	}
+
+	*p = np
+
+	return nil
+}
+
+// validCurrency checks whether the provided currency code is
+// not empty and contains no characters reserved by the pair,
+// subject and key formats.
+func validCurrency(c string) bool {
+	return c != "" && !strings.ContainsAny(c, "_./-: \t\n")
+}
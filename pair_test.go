@@ -0,0 +1,162 @@
+package chartype
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_ParsePair(t *testing.T) {
+	cc := map[string]struct {
+		Text   string
+		Result Pair
+		Err    error
+	}{
+		"Missing separator": {
+			Text: "BTCUSDT",
+			Err:  ErrInvalidPair,
+		},
+		"Too many separators": {
+			Text: "BTC_USDT_X",
+			Err:  ErrInvalidPair,
+		},
+		"Missing base": {
+			Text: "_USDT",
+			Err:  ErrInvalidPair,
+		},
+		"Missing quote": {
+			Text: "BTC_",
+			Err:  ErrInvalidPair,
+		},
+		"Successful parse": {
+			Text:   "BTC_USDT",
+			Result: Pair{Base: "BTC", Quote: "USDT"},
+		},
+	}
+
+	for cn, c := range cc {
+		c := c
+
+		t.Run(cn, func(t *testing.T) {
+			t.Parallel()
+
+			res, err := ParsePair(c.Text)
+			equalError(t, c.Err, err)
+			if err != nil {
+				return
+			}
+
+			assert.Equal(t, c.Result, res)
+		})
+	}
+}
+
+func Test_Pair_Validate(t *testing.T) {
+	cc := map[string]struct {
+		Pair Pair
+		Err  error
+	}{
+		"Missing base": {
+			Pair: Pair{Quote: "USDT"},
+			Err:  ErrInvalidPair,
+		},
+		"Missing quote": {
+			Pair: Pair{Base: "BTC"},
+			Err:  ErrInvalidPair,
+		},
+		"Reserved character in base": {
+			Pair: Pair{Base: "BTC.X", Quote: "USDT"},
+			Err:  ErrInvalidPair,
+		},
+		"Reserved character in quote": {
+			Pair: Pair{Base: "BTC", Quote: "US DT"},
+			Err:  ErrInvalidPair,
+		},
+		"Successful validation": {
+			Pair: Pair{Base: "BTC", Quote: "USDT"},
+		},
+	}
+
+	for cn, c := range cc {
+		c := c
+
+		t.Run(cn, func(t *testing.T) {
+			t.Parallel()
+
+			err := c.Pair.Validate()
+			equalError(t, c.Err, err)
+		})
+	}
+}
+
+func Test_Pair_String(t *testing.T) {
+	assert.Equal(t, "ETH_BTC", Pair{Base: "ETH", Quote: "BTC"}.String())
+}
+
+func Test_Pair_MarshalText(t *testing.T) {
+	cc := map[string]struct {
+		Pair Pair
+		Text string
+		Err  error
+	}{
+		"Invalid Pair": {
+			Pair: Pair{Base: "BTC"},
+			Err:  ErrInvalidPair,
+		},
+		"Successful marshal": {
+			Pair: Pair{Base: "BTC", Quote: "USDT"},
+			Text: "BTC_USDT",
+		},
+	}
+
+	for cn, c := range cc {
+		c := c
+
+		t.Run(cn, func(t *testing.T) {
+			t.Parallel()
+
+			d, err := c.Pair.MarshalText()
+			equalError(t, c.Err, err)
+			if err != nil {
+				return
+			}
+
+			assert.Equal(t, c.Text, string(d))
+		})
+	}
+}
+
+func Test_Pair_UnmarshalText(t *testing.T) {
+	cc := map[string]struct {
+		Text   string
+		Result Pair
+		Err    error
+	}{
+		"Invalid Pair": {
+			Text: "BTC",
+			Err:  ErrInvalidPair,
+		},
+		"Successful unmarshal": {
+			Text:   "BTC_USDT",
+			Result: Pair{Base: "BTC", Quote: "USDT"},
+		},
+	}
+
+	for cn, c := range cc {
+		c := c
+
+		t.Run(cn, func(t *testing.T) {
+			t.Parallel()
+
+			var p Pair
+
+			err := p.UnmarshalText([]byte(c.Text))
+			equalError(t, c.Err, err)
+			if err != nil {
+				return
+			}
+
+			assert.Equal(t, c.Result, p)
+		})
+	}
+}
@@ -0,0 +1,245 @@
+package chartype
+
+import (
+	"strings"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+var (
+	// ErrInvalidNumber is returned when a number does not satisfy
+	// parse options, e.g. it is not in plain notation in strict mode,
+	// exceeds precision or exponent limit or has misplaced thousands
+	// separators.
+	ErrInvalidNumber = newError(CodeParseFailure, "invalid number")
+
+	// ErrInconsistentCandle is returned in strict mode when candle's
+	// high is below its low, its open or close is outside of the
+	// high-low range or its volume is negative.
+	ErrInconsistentCandle = newError(CodeInvalidArgument, "inconsistent candle")
+)
+
+// ParseOption configures how ParseCandle, ParseTicker, ParseTrade and
+// Parser parse their parameters.
+type ParseOption func(*parseConfig)
+
+// parseConfig holds parse options' settings.
+type parseConfig struct {
+	loc       *time.Location
+	strict    bool
+	places    int32
+	exponent  int32
+	thousands bool
+	comma     bool
+	intern    int
+}
+
+// newParseConfig applies the parse options to the default settings.
+func newParseConfig(opts []ParseOption) parseConfig {
+	pc := parseConfig{places: -1, exponent: -1}
+
+	for _, o := range opts {
+		o(&pc)
+	}
+
+	return pc
+}
+
+// WithLocation converts parsed timestamps to the location.
+func WithLocation(loc *time.Location) ParseOption {
+	return func(pc *parseConfig) {
+		pc.loc = loc
+	}
+}
+
+// WithStrict rejects numbers that are not in plain decimal notation,
+// such as "1e3" or "+1", and candles that are inconsistent.
+func WithStrict() ParseOption {
+	return func(pc *parseConfig) {
+		pc.strict = true
+	}
+}
+
+// WithPrecisionLimit rejects numbers with more than the provided
+// number of decimal places. Negative limit disables the check.
+func WithPrecisionLimit(places int32) ParseOption {
+	return func(pc *parseConfig) {
+		pc.places = places
+	}
+}
+
+// WithExponentLimit rejects numbers whose decimal exponent, e.g. -8
+// for "1.2e-7" or 3 for "1e3", is beyond the provided limit in either
+// direction, protecting downstream arithmetic from hostile or corrupt
+// values such as "1e100000". Negative limit disables the check.
+func WithExponentLimit(limit int32) ParseOption {
+	return func(pc *parseConfig) {
+		pc.exponent = limit
+	}
+}
+
+// WithThousandSeparators accepts numbers with commas separating
+// groups of three integer digits, e.g. "1,234.56".
+func WithThousandSeparators() ParseOption {
+	return func(pc *parseConfig) {
+		pc.thousands = true
+	}
+}
+
+// WithDecimalComma accepts numbers with a comma as the decimal point,
+// e.g. "1234,56". Combined with WithThousandSeparators, dots separate
+// groups of integer digits instead, e.g. "1.234,56".
+func WithDecimalComma() ParseOption {
+	return func(pc *parseConfig) {
+		pc.comma = true
+	}
+}
+
+// WithInterning makes Parser return the same decimal for fields
+// that repeat verbatim, e.g. zero volumes or prices of an illiquid
+// instrument, so that repeated values share memory instead of each
+// holding its own copy. Up to size distinct values are remembered per
+// parser. Other parse functions ignore this option.
+func WithInterning(size int) ParseOption {
+	return func(pc *parseConfig) {
+		pc.intern = size
+	}
+}
+
+// plain checks whether no number or candle settings differ from the
+// default ones.
+func (pc parseConfig) plain() bool {
+	return !pc.strict && pc.places < 0 && pc.exponent < 0 && !pc.thousands && !pc.comma
+}
+
+// timestamp returns the timestamp in the configured location.
+func (pc parseConfig) timestamp(t time.Time) time.Time {
+	if pc.loc == nil {
+		return t
+	}
+
+	return t.In(pc.loc)
+}
+
+// number parses the string into a decimal according to the
+// settings.
+func (pc parseConfig) number(s string) (decimal.Decimal, error) {
+	if pc.thousands || pc.comma {
+		group, point := byte(','), byte('.')
+		if pc.comma {
+			group, point = '.', ','
+		}
+
+		if !pc.thousands {
+			group = 0
+		}
+
+		var err error
+		if s, err = normalizeNumber(s, group, point); err != nil {
+			return decimal.Decimal{}, err
+		}
+	}
+
+	if pc.strict && !plainNumber(s) {
+		return decimal.Decimal{}, ErrInvalidNumber
+	}
+
+	d, err := decimalFromString(s)
+	if err != nil {
+		return decimal.Decimal{}, err
+	}
+
+	if pc.places >= 0 && -d.Exponent() > pc.places {
+		return decimal.Decimal{}, ErrInvalidNumber
+	}
+
+	if pc.exponent >= 0 && (d.Exponent() > pc.exponent || -d.Exponent() > pc.exponent) {
+		return decimal.Decimal{}, ErrInvalidNumber
+	}
+
+	return d, nil
+}
+
+// numbers parses the strings into decimals according to the
+// settings.
+func (pc parseConfig) numbers(ss ...string) ([]decimal.Decimal, error) {
+	res := make([]decimal.Decimal, len(ss))
+
+	for i, s := range ss {
+		d, err := pc.number(s)
+		if err != nil {
+			return nil, err
+		}
+
+		res[i] = d
+	}
+
+	return res, nil
+}
+
+// checkCandle checks whether the candle is consistent in strict mode.
+func (pc parseConfig) checkCandle(c Candle) error {
+	if !pc.strict {
+		return nil
+	}
+
+	if c.High.LessThan(c.Low) || outside(c.Open, c.Low, c.High) ||
+		outside(c.Close, c.Low, c.High) || c.Volume.IsNegative() {
+		return ErrInconsistentCandle
+	}
+
+	return nil
+}
+
+// normalizeNumber removes group separators from the number's integer
+// part and replaces its decimal point with a dot. Each group except
+// the first one must have exactly three digits.
+func normalizeNumber(s string, group, point byte) (string, error) {
+	ip, frac := s, ""
+
+	i := strings.IndexByte(s, point)
+	if i >= 0 {
+		ip, frac = s[:i], "."+s[i+1:]
+	}
+
+	var sign string
+	if ip != "" && (ip[0] == '-' || ip[0] == '+') {
+		sign, ip = ip[:1], ip[1:]
+	}
+
+	if group != 0 && strings.IndexByte(ip, group) >= 0 {
+		gg := strings.Split(ip, string(group))
+
+		for j, g := range gg {
+			if g == "" || len(g) > 3 || (j > 0 && len(g) != 3) {
+				return "", ErrInvalidNumber
+			}
+		}
+
+		ip = strings.Join(gg, "")
+	}
+
+	return sign + ip + frac, nil
+}
+
+// plainNumber checks whether the string is a number in plain decimal
+// notation with an optional minus sign.
+func plainNumber(s string) bool {
+	s = strings.TrimPrefix(s, "-")
+
+	var digits, points int
+
+	for j := 0; j < len(s); j++ {
+		switch {
+		case s[j] >= '0' && s[j] <= '9':
+			digits++
+		case s[j] == '.' && points == 0 && digits > 0 && j < len(s)-1:
+			points++
+		default:
+			return false
+		}
+	}
+
+	return digits > 0
+}
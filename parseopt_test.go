@@ -0,0 +1,263 @@
+package chartype
+
+import (
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_ParseCandle_Options(t *testing.T) {
+	tm := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	loc := time.FixedZone("UTC+2", 2*60*60)
+
+	cc := map[string]struct {
+		Fields  [5]string
+		Options []ParseOption
+		Result  Candle
+		Err     error
+	}{
+		"Invalid thousands separators": {
+			Fields:  [5]string{"1,23", "1", "1", "1", "1"},
+			Options: []ParseOption{WithThousandSeparators()},
+			Err:     ErrInvalidNumber,
+		},
+		"Thousands separators without option": {
+			Fields: [5]string{"1,234", "1", "1", "1", "1"},
+			Err:    assert.AnError,
+		},
+		"Exponent in strict mode": {
+			Fields:  [5]string{"1e3", "1", "1", "1", "1"},
+			Options: []ParseOption{WithStrict()},
+			Err:     ErrInvalidNumber,
+		},
+		"Inconsistent candle in strict mode": {
+			Fields:  [5]string{"1", "2", "3", "2", "1"},
+			Options: []ParseOption{WithStrict()},
+			Err:     ErrInconsistentCandle,
+		},
+		"Precision limit exceeded": {
+			Fields:  [5]string{"1", "1", "1", "1.001", "1"},
+			Options: []ParseOption{WithPrecisionLimit(2)},
+			Err:     ErrInvalidNumber,
+		},
+		"Positive exponent limit exceeded": {
+			Fields:  [5]string{"1", "1", "1", "1e100000", "1"},
+			Options: []ParseOption{WithExponentLimit(30)},
+			Err:     ErrInvalidNumber,
+		},
+		"Negative exponent limit exceeded": {
+			Fields:  [5]string{"1", "1", "1", "1", "1.5e-40"},
+			Options: []ParseOption{WithExponentLimit(30)},
+			Err:     ErrInvalidNumber,
+		},
+		"Invalid number with precision limit": {
+			Fields:  [5]string{"1", "1", "1", "x", "1"},
+			Options: []ParseOption{WithPrecisionLimit(2)},
+			Err:     assert.AnError,
+		},
+		"Successful parse without options": {
+			Fields: [5]string{"1e1", "20", "1", "2", "-1"},
+			Result: Candle{
+				Timestamp: tm,
+				Open:      decimal.New(1, 1),
+				High:      decimal.NewFromInt(20),
+				Low:       decimal.NewFromInt(1),
+				Close:     decimal.NewFromInt(2),
+				Volume:    decimal.NewFromInt(-1),
+			},
+		},
+		"Successful parse with all options": {
+			Fields: [5]string{"1,000.5", "-1,234,567.25", "-1,234,568", "999.50", "12"},
+			Options: []ParseOption{
+				WithLocation(loc),
+				WithPrecisionLimit(2),
+				WithThousandSeparators(),
+			},
+			Result: Candle{
+				Timestamp: tm.In(loc),
+				Open:      decimal.RequireFromString("1000.5"),
+				High:      decimal.RequireFromString("-1234567.25"),
+				Low:       decimal.NewFromInt(-1234568),
+				Close:     decimal.RequireFromString("999.50"),
+				Volume:    decimal.NewFromInt(12),
+			},
+		},
+		"Decimal comma without thousands separators": {
+			Fields:  [5]string{"1.234,5", "1", "1", "1", "1"},
+			Options: []ParseOption{WithDecimalComma()},
+			Err:     assert.AnError,
+		},
+		"Successful parse with exponents within limit": {
+			Fields:  [5]string{"1.2e-7", "2e3", "-1E-6", "1.5e2", "0.001"},
+			Options: []ParseOption{WithExponentLimit(8)},
+			Result: Candle{
+				Timestamp: tm,
+				Open:      decimal.New(12, -8),
+				High:      decimal.New(2, 3),
+				Low:       decimal.New(-1, -6),
+				Close:     decimal.New(15, 1),
+				Volume:    decimal.New(1, -3),
+			},
+		},
+		"Successful parse with decimal comma": {
+			Fields:  [5]string{"1,5", "2", "-0,25", "1", "1000"},
+			Options: []ParseOption{WithDecimalComma()},
+			Result: Candle{
+				Timestamp: tm,
+				Open:      decimal.RequireFromString("1.5"),
+				High:      decimal.NewFromInt(2),
+				Low:       decimal.RequireFromString("-0.25"),
+				Close:     decimal.NewFromInt(1),
+				Volume:    decimal.NewFromInt(1000),
+			},
+		},
+		"Successful parse with decimal comma and thousands separators": {
+			Fields:  [5]string{"1.234,56", "1.300", "1.200,5", "1.250", "12.000.000,125"},
+			Options: []ParseOption{WithDecimalComma(), WithThousandSeparators(), WithStrict()},
+			Result: Candle{
+				Timestamp: tm,
+				Open:      decimal.RequireFromString("1234.56"),
+				High:      decimal.NewFromInt(1300),
+				Low:       decimal.RequireFromString("1200.5"),
+				Close:     decimal.NewFromInt(1250),
+				Volume:    decimal.RequireFromString("12000000.125"),
+			},
+		},
+		"Successful parse in strict mode": {
+			Fields:  [5]string{"2.5", "3", "-1", "0", "0"},
+			Options: []ParseOption{WithStrict(), WithPrecisionLimit(-1)},
+			Result: Candle{
+				Timestamp: tm,
+				Open:      decimal.RequireFromString("2.5"),
+				High:      decimal.NewFromInt(3),
+				Low:       decimal.NewFromInt(-1),
+				Close:     decimal.NewFromInt(0),
+				Volume:    decimal.NewFromInt(0),
+			},
+		},
+	}
+
+	for cn, c := range cc {
+		c := c
+
+		t.Run(cn, func(t *testing.T) {
+			t.Parallel()
+
+			res, err := ParseCandle(tm, c.Fields[0], c.Fields[1], c.Fields[2],
+				c.Fields[3], c.Fields[4], c.Options...)
+			equalError(t, c.Err, err)
+			if err != nil {
+				return
+			}
+
+			assert.Equal(t, c.Result, res)
+		})
+	}
+}
+
+func Test_ParseTicker_Options(t *testing.T) {
+	_, err := ParseTicker("1", "1", "1", "1", "1.5%", "1e2", WithStrict())
+	assert.Equal(t, ErrInvalidNumber, err)
+
+	res, err := ParseTicker("1,000", "1,001", "999", "-2", " 1.25 % ", "2,000,000", WithThousandSeparators())
+	assert.NoError(t, err)
+	assert.Equal(t, Ticker{
+		Last:          decimal.NewFromInt(1000),
+		Ask:           decimal.NewFromInt(1001),
+		Bid:           decimal.NewFromInt(999),
+		Change:        decimal.NewFromInt(-2),
+		PercentChange: NewPercent(decimal.RequireFromString("1.25")),
+		Volume:        decimal.NewFromInt(2000000),
+	}, res)
+}
+
+func Test_ParseTicker_DecimalComma(t *testing.T) {
+	res, err := ParseTicker("1,5", "1,6", "1,4", "-0,1", "-6,25%", "100", WithDecimalComma())
+	assert.NoError(t, err)
+	assert.Equal(t, Ticker{
+		Last:          decimal.RequireFromString("1.5"),
+		Ask:           decimal.RequireFromString("1.6"),
+		Bid:           decimal.RequireFromString("1.4"),
+		Change:        decimal.RequireFromString("-0.1"),
+		PercentChange: NewPercent(decimal.RequireFromString("-6.25")),
+		Volume:        decimal.NewFromInt(100),
+	}, res)
+}
+
+func Test_ParseTrade_Options(t *testing.T) {
+	tm := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	loc := time.FixedZone("UTC-5", -5*60*60)
+
+	_, err := ParseTrade(tm, "1.123", "1", SideBuy, WithPrecisionLimit(2))
+	assert.Equal(t, ErrInvalidNumber, err)
+
+	res, err := ParseTrade(tm, "1,234.5", "2", SideSell, WithThousandSeparators(), WithLocation(loc))
+	assert.NoError(t, err)
+	assert.Equal(t, Trade{
+		Timestamp: tm.In(loc),
+		Price:     decimal.RequireFromString("1234.5"),
+		Amount:    decimal.NewFromInt(2),
+		Side:      SideSell,
+	}, res)
+}
+
+func Test_plainNumber(t *testing.T) {
+	for _, s := range []string{"0", "-1", "1.5", "-0.25", "100"} {
+		assert.True(t, plainNumber(s), s)
+	}
+
+	for _, s := range []string{"", "-", "+1", ".5", "1.", "1.2.3", "1e3", " 1", "--1"} {
+		assert.False(t, plainNumber(s), s)
+	}
+}
+
+func Test_normalizeNumber(t *testing.T) {
+	cc := map[string]struct {
+		Number string
+		Result string
+		Err    error
+	}{
+		"Empty group": {
+			Number: "1,,000",
+			Err:    ErrInvalidNumber,
+		},
+		"Leading separator": {
+			Number: ",100",
+			Err:    ErrInvalidNumber,
+		},
+		"Long first group": {
+			Number: "1000,000",
+			Err:    ErrInvalidNumber,
+		},
+		"Short group": {
+			Number: "1,00.5",
+			Err:    ErrInvalidNumber,
+		},
+		"Successful normalization without separators": {
+			Number: "1000.5",
+			Result: "1000.5",
+		},
+		"Successful normalization with sign": {
+			Number: "+12,345,678.901",
+			Result: "+12345678.901",
+		},
+	}
+
+	for cn, c := range cc {
+		c := c
+
+		t.Run(cn, func(t *testing.T) {
+			t.Parallel()
+
+			res, err := normalizeNumber(c.Number, ',', '.')
+			equalError(t, c.Err, err)
+			if err != nil {
+				return
+			}
+
+			assert.Equal(t, c.Result, res)
+		})
+	}
+}
@@ -0,0 +1,184 @@
+package chartype
+
+import (
+	"github.com/shopspring/decimal"
+)
+
+// maxFastDigits is the maximum number of digits a decimal may have
+// to be parsed without intermediate allocations.
+const maxFastDigits = 18
+
+var (
+	// ErrInvalidFieldCount is returned when the number of provided
+	// fields does not match the number of expected ones.
+	ErrInvalidFieldCount = newError(CodeInvalidField, "invalid field count")
+)
+
+// Parser parses candles from raw byte slice fields, e.g. columns of
+// CSV records or parts of network messages. Unlike ParseCandle, it
+// requires no string conversions and parses plain decimal numbers of
+// up to 18 digits without intermediate allocations. A single parser
+// should be reused across calls.
+//
+// Parse options other than WithLocation are supported; numbers are
+// then parsed with intermediate allocations.
+//
+// Parser is not safe for concurrent use.
+type Parser struct {
+	config   parseConfig
+	values   [5]decimal.Decimal
+	interned map[string]decimal.Decimal
+}
+
+// NewParser creates a new candle parser.
+func NewParser(opts ...ParseOption) *Parser {
+	return &Parser{config: newParseConfig(opts)}
+}
+
+// Reset clears parser's internal buffers, including interned values.
+func (p *Parser) Reset() {
+	p.values = [5]decimal.Decimal{}
+	p.interned = nil
+}
+
+// ParseInto parses open, high, low, close and volume fields, in this
+// order, into the destination candle. They can be followed by
+// adjusted close and volume delta fields, which are left unset when
+// they are missing or empty. Candle's timestamp is not modified. The
+// destination candle is not modified if any of the fields is invalid.
+func (p *Parser) ParseInto(dst *Candle, fields ...[]byte) error {
+	if len(fields) < len(p.values) || len(fields) > len(p.values)+2 {
+		return ErrInvalidFieldCount
+	}
+
+	for i := range p.values {
+		v, err := p.parse(fields[i])
+		if err != nil {
+			return err
+		}
+
+		p.values[i] = v
+	}
+
+	var opt [2]*decimal.Decimal
+
+	for i, f := range fields[len(p.values):] {
+		if len(f) == 0 {
+			continue
+		}
+
+		v, err := p.parse(f)
+		if err != nil {
+			return err
+		}
+
+		opt[i] = &v
+	}
+
+	err := p.config.checkCandle(Candle{
+		Open:   p.values[0],
+		High:   p.values[1],
+		Low:    p.values[2],
+		Close:  p.values[3],
+		Volume: p.values[4],
+	})
+	if err != nil {
+		return err
+	}
+
+	dst.Open = p.values[0]
+	dst.High = p.values[1]
+	dst.Low = p.values[2]
+	dst.Close = p.values[3]
+	dst.Volume = p.values[4]
+	dst.AdjClose = opt[0]
+	dst.Delta = opt[1]
+
+	return nil
+}
+
+// parse parses the field into a decimal, reusing its interned value
+// if there is one.
+func (p *Parser) parse(f []byte) (decimal.Decimal, error) {
+	if v, ok := p.interned[string(f)]; ok {
+		return v, nil
+	}
+
+	var (
+		v   decimal.Decimal
+		err error
+	)
+
+	if p.config.plain() {
+		v, err = parseDecimal(f)
+	} else {
+		v, err = p.config.number(string(f))
+	}
+
+	if err != nil {
+		return decimal.Decimal{}, err
+	}
+
+	p.intern(f, v)
+
+	return v, nil
+}
+
+// intern remembers the value parsed from the field if interning is
+// enabled and its limit is not reached.
+func (p *Parser) intern(f []byte, v decimal.Decimal) {
+	if len(p.interned) >= p.config.intern {
+		return
+	}
+
+	if p.interned == nil {
+		p.interned = make(map[string]decimal.Decimal)
+	}
+
+	p.interned[string(f)] = v
+}
+
+// parseDecimal parses the byte slice into a decimal. Plain numbers
+// with an optional sign and decimal point are parsed directly; other
+// forms fall back to decimal.NewFromString.
+func parseDecimal(b []byte) (decimal.Decimal, error) {
+	var (
+		m      int64
+		exp    int32
+		digits int
+		point  bool
+		neg    bool
+	)
+
+	d := b
+	if len(d) > 0 && (d[0] == '-' || d[0] == '+') {
+		neg = d[0] == '-'
+		d = d[1:]
+	}
+
+	for _, ch := range d {
+		switch {
+		case ch >= '0' && ch <= '9':
+			m = m*10 + int64(ch-'0')
+			digits++
+
+			if point {
+				exp--
+			}
+		case ch == '.' && !point:
+			point = true
+		default:
+			return decimalFromString(string(b))
+		}
+	}
+
+	if digits == 0 || digits > maxFastDigits {
+		return decimalFromString(string(b))
+	}
+
+	if neg {
+		m = -m
+	}
+
+	return decimal.New(m, exp), nil
+}
@@ -0,0 +1,246 @@
+package chartype
+
+import (
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Parser_ParseInto(t *testing.T) {
+	tm := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	adj := decimal.RequireFromString("3.5")
+	delta := decimal.RequireFromString("-1.5")
+
+	cc := map[string]struct {
+		Fields []string
+		Result Candle
+		Err    error
+	}{
+		"Invalid field count": {
+			Fields: []string{"1", "2", "3", "4"},
+			Err:    ErrInvalidFieldCount,
+		},
+		"Invalid field": {
+			Fields: []string{"1", "2", "3", "4", "-"},
+			Err:    assert.AnError,
+		},
+		"Invalid field with decimal point": {
+			Fields: []string{"1", "2", "3", "4", "1.2.3"},
+			Err:    assert.AnError,
+		},
+		"Too many fields": {
+			Fields: []string{"1", "2", "3", "4", "5", "6", "7", "8"},
+			Err:    ErrInvalidFieldCount,
+		},
+		"Invalid adjusted close": {
+			Fields: []string{"1", "2", "3", "4", "5", "-"},
+			Err:    assert.AnError,
+		},
+		"Invalid volume delta": {
+			Fields: []string{"1", "2", "3", "4", "5", "", "-"},
+			Err:    assert.AnError,
+		},
+		"Successful parse with empty adjusted close": {
+			Fields: []string{"1", "2", "3", "4", "5", ""},
+			Result: testCandle(tm, 1, 2, 3, 4, 5),
+		},
+		"Successful parse with adjusted close": {
+			Fields: []string{"1", "2", "3", "4", "5", "3.5"},
+			Result: Candle{
+				Timestamp: tm,
+				Open:      decimal.NewFromInt(1),
+				High:      decimal.NewFromInt(2),
+				Low:       decimal.NewFromInt(3),
+				Close:     decimal.NewFromInt(4),
+				Volume:    decimal.NewFromInt(5),
+				AdjClose:  &adj,
+			},
+		},
+		"Successful parse with volume delta": {
+			Fields: []string{"1", "2", "3", "4", "5", "", "-1.5"},
+			Result: Candle{
+				Timestamp: tm,
+				Open:      decimal.NewFromInt(1),
+				High:      decimal.NewFromInt(2),
+				Low:       decimal.NewFromInt(3),
+				Close:     decimal.NewFromInt(4),
+				Volume:    decimal.NewFromInt(5),
+				Delta:     &delta,
+			},
+		},
+		"Successful parse with adjusted close and volume delta": {
+			Fields: []string{"1", "2", "3", "4", "5", "3.5", "-1.5"},
+			Result: Candle{
+				Timestamp: tm,
+				Open:      decimal.NewFromInt(1),
+				High:      decimal.NewFromInt(2),
+				Low:       decimal.NewFromInt(3),
+				Close:     decimal.NewFromInt(4),
+				Volume:    decimal.NewFromInt(5),
+				AdjClose:  &adj,
+				Delta:     &delta,
+			},
+		},
+		"Successful parse": {
+			Fields: []string{"1.5", "-2", "+3.25", "1e3", "12345678901234567890.5"},
+			Result: Candle{
+				Timestamp: tm,
+				Open:      decimal.RequireFromString("1.5"),
+				High:      decimal.RequireFromString("-2"),
+				Low:       decimal.RequireFromString("3.25"),
+				Close:     decimal.RequireFromString("1000"),
+				Volume:    decimal.RequireFromString("12345678901234567890.5"),
+			},
+		},
+	}
+
+	for cn, c := range cc {
+		c := c
+
+		t.Run(cn, func(t *testing.T) {
+			t.Parallel()
+
+			ff := make([][]byte, len(c.Fields))
+			for i, f := range c.Fields {
+				ff[i] = []byte(f)
+			}
+
+			p := NewParser()
+			res := Candle{Timestamp: tm}
+
+			err := p.ParseInto(&res, ff...)
+			equalError(t, c.Err, err)
+			if err != nil {
+				assert.Equal(t, Candle{Timestamp: tm}, res)
+				return
+			}
+
+			assertEqualCandles(t, []Candle{c.Result}, []Candle{res})
+		})
+	}
+}
+
+func Test_Parser_Reset(t *testing.T) {
+	p := NewParser()
+
+	var c Candle
+
+	assert.NoError(t, p.ParseInto(&c, []byte("1"), []byte("2"), []byte("3"), []byte("4"), []byte("5")))
+
+	p.Reset()
+	assert.Equal(t, Parser{config: newParseConfig(nil)}, *p)
+}
+
+func Test_Parser_ParseInto_Options(t *testing.T) {
+	var ec Candle
+
+	err := NewParser(WithExponentLimit(10)).ParseInto(&ec, []byte("1"), []byte("1e11"), []byte("1"), []byte("1"), []byte("1"))
+	assert.Equal(t, ErrInvalidNumber, err)
+
+	p := NewParser(WithDecimalComma(), WithThousandSeparators(), WithStrict())
+
+	var c Candle
+
+	err = p.ParseInto(&c, []byte("1.000,5"), []byte("1.001"), []byte("999,75"), []byte("1.000"), []byte("-1"))
+	assert.Equal(t, ErrInconsistentCandle, err)
+	assert.Equal(t, Candle{}, c)
+
+	err = p.ParseInto(&c, []byte("1.000,5"), []byte("1.001"), []byte("999,75"), []byte("1.000"), []byte("x"))
+	assert.Equal(t, ErrInvalidNumber, err)
+
+	err = p.ParseInto(&c, []byte("1.000,5"), []byte("1.001"), []byte("999,75"), []byte("1.000"), []byte("12.345,6"))
+	assert.NoError(t, err)
+	assert.Equal(t, Candle{
+		Open:   decimal.RequireFromString("1000.5"),
+		High:   decimal.NewFromInt(1001),
+		Low:    decimal.RequireFromString("999.75"),
+		Close:  decimal.NewFromInt(1000),
+		Volume: decimal.RequireFromString("12345.6"),
+	}, c)
+}
+
+func Test_Parser_ParseInto_Interning(t *testing.T) {
+	p := NewParser(WithInterning(2), WithStrict())
+
+	var c1, c2 Candle
+
+	assert.NoError(t, p.ParseInto(&c1, []byte("1.5"), []byte("2"), []byte("1.5"), []byte("2"), []byte("0")))
+	assert.Len(t, p.interned, 2)
+
+	assert.NoError(t, p.ParseInto(&c2, []byte("1.5"), []byte("2"), []byte("1.5"), []byte("2"), []byte("0")))
+	assert.Len(t, p.interned, 2)
+	assert.Equal(t, c1, c2)
+	assert.Equal(t, "1.5", c2.Low.String())
+	assert.Equal(t, "0", c2.Volume.String())
+
+	err := p.ParseInto(&c2, []byte("1.5"), []byte("2"), []byte("1.5"), []byte("3"), []byte("0"))
+	assert.Equal(t, ErrInconsistentCandle, err)
+
+	p.Reset()
+	assert.Nil(t, p.interned)
+
+	p = NewParser()
+	assert.NoError(t, p.ParseInto(&c1, []byte("1.5"), []byte("2"), []byte("1.5"), []byte("2"), []byte("0")))
+	assert.Nil(t, p.interned)
+}
+
+func Test_parseDecimal(t *testing.T) {
+	for _, s := range []string{"0", "-0", "1", "-1.50", "0.000001", ".5", "5.", "999999999999999999", "1234567890123456789"} {
+		exp, err := decimal.NewFromString(s)
+		assert.NoError(t, err)
+
+		res, err := parseDecimal([]byte(s))
+		assert.NoError(t, err)
+		assert.True(t, exp.Equal(res), s)
+		assert.Equal(t, exp.Exponent(), res.Exponent(), s)
+	}
+
+	_, err := parseDecimal(nil)
+	assert.Error(t, err)
+
+	_, err = parseDecimal([]byte("-"))
+	assert.Error(t, err)
+}
+
+func Benchmark_ParseCandle(b *testing.B) {
+	b.ReportAllocs()
+
+	for i := 0; i < b.N; i++ {
+		_, _ = ParseCandle(time.Time{}, "7123.45", "7150.5", "7100.25", "7140", "12.345678")
+	}
+}
+
+func Benchmark_Parser_ParseInto(b *testing.B) {
+	ff := [][]byte{[]byte("7123.45"), []byte("7150.5"), []byte("7100.25"), []byte("7140"), []byte("12.345678")}
+	p := NewParser()
+
+	var c Candle
+
+	b.ReportAllocs()
+
+	for i := 0; i < b.N; i++ {
+		_ = p.ParseInto(&c, ff...)
+	}
+}
+
+func Benchmark_Parser_ParseInto_Sparse(b *testing.B) {
+	// A quiet market: flat prices and zero volume most of the time.
+	ff := [][]byte{[]byte("7140.25"), []byte("7140.25"), []byte("7140.25"), []byte("7140.25"), []byte("0")}
+
+	for _, n := range []int{0, 1024} {
+		p := NewParser(WithInterning(n))
+
+		b.Run("interning="+strconv.Itoa(n), func(b *testing.B) {
+			var c Candle
+
+			b.ReportAllocs()
+
+			for i := 0; i < b.N; i++ {
+				_ = p.ParseInto(&c, ff...)
+			}
+		})
+	}
+}
@@ -0,0 +1,119 @@
+package chartype
+
+const (
+	// PatchUpdate specifies that the patch's candle replaces the last
+	// candle of a series, e.g. when a forming candle changes.
+	PatchUpdate PatchOp = iota + 1
+
+	// PatchAppend specifies that the patch's candle is added to the
+	// end of a series as a new candle.
+	PatchAppend
+)
+
+var (
+	// ErrInvalidPatchOp is returned when patch operation with invalid
+	// value is being used.
+	ErrInvalidPatchOp = newError(CodeInvalidArgument, "invalid patch operation")
+)
+
+// PatchOp specifies how a patch changes a candle series.
+// Can be included in configuration structures.
+type PatchOp int
+
+// Validate checks whether the patch operation is one of supported
+// operation types or not.
+func (po PatchOp) Validate() error {
+	switch po {
+	case PatchUpdate, PatchAppend:
+		return nil
+	default:
+		return ErrInvalidPatchOp
+	}
+}
+
+// MarshalText turns patch operation to appropriate string
+// representation.
+func (po PatchOp) MarshalText() ([]byte, error) {
+	var v string
+
+	switch po {
+	case PatchUpdate:
+		v = "update"
+	case PatchAppend:
+		v = "append"
+	default:
+		return nil, ErrInvalidPatchOp
+	}
+
+	return []byte(v), nil
+}
+
+// UnmarshalText turns string to appropriate patch operation value.
+func (po *PatchOp) UnmarshalText(d []byte) error {
+	switch string(d) {
+	case "update":
+		*po = PatchUpdate
+	case "append":
+		*po = PatchAppend
+	default:
+		return ErrInvalidPatchOp
+	}
+
+	return nil
+}
+
+// CandlePatch holds a live update of a candle series, telling its
+// receiver whether the last candle changed in place or a new candle
+// was added.
+type CandlePatch struct {
+	Op     PatchOp `json:"op" yaml:"op"`
+	Candle Candle  `json:"candle" yaml:"candle"`
+}
+
+// Patch returns a patch that updates the last candle of the series if
+// it has the same timestamp as the candle or appends the candle if it
+// is newer. ErrUnorderedCandle is returned if the candle is older than
+// the last one.
+func (cc Candles) Patch(c Candle) (CandlePatch, error) {
+	if len(cc) == 0 {
+		return CandlePatch{Op: PatchAppend, Candle: c}, nil
+	}
+
+	last := cc[len(cc)-1].Timestamp
+
+	switch {
+	case c.Timestamp.Equal(last):
+		return CandlePatch{Op: PatchUpdate, Candle: c}, nil
+	case c.Timestamp.After(last):
+		return CandlePatch{Op: PatchAppend, Candle: c}, nil
+	default:
+		return CandlePatch{}, ErrUnorderedCandle
+	}
+}
+
+// Apply applies the patch to the series and returns the result. Like
+// append, it may modify the series' underlying array.
+//
+// ErrCandleNotFound is returned if an updated candle's timestamp does
+// not match the last candle's one and ErrUnorderedCandle if an
+// appended candle is not newer than the last one.
+func (cc Candles) Apply(p CandlePatch) (Candles, error) {
+	switch p.Op {
+	case PatchUpdate:
+		if len(cc) == 0 || !cc[len(cc)-1].Timestamp.Equal(p.Candle.Timestamp) {
+			return nil, ErrCandleNotFound
+		}
+
+		cc[len(cc)-1] = p.Candle
+
+		return cc, nil
+	case PatchAppend:
+		if len(cc) > 0 && !p.Candle.Timestamp.After(cc[len(cc)-1].Timestamp) {
+			return nil, ErrUnorderedCandle
+		}
+
+		return append(cc, p.Candle), nil
+	default:
+		return nil, ErrInvalidPatchOp
+	}
+}
@@ -0,0 +1,209 @@
+package chartype
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_PatchOp_Validate(t *testing.T) {
+	cc := map[string]struct {
+		Op  PatchOp
+		Err error
+	}{
+		"Invalid PatchOp": {
+			Op:  70,
+			Err: ErrInvalidPatchOp,
+		},
+		"Successful PatchUpdate validation": {
+			Op: PatchUpdate,
+		},
+		"Successful PatchAppend validation": {
+			Op: PatchAppend,
+		},
+	}
+
+	for cn, c := range cc {
+		c := c
+
+		t.Run(cn, func(t *testing.T) {
+			t.Parallel()
+
+			equalError(t, c.Err, c.Op.Validate())
+		})
+	}
+}
+
+func Test_PatchOp_MarshalText(t *testing.T) {
+	cc := map[string]struct {
+		Op   PatchOp
+		Text string
+		Err  error
+	}{
+		"Invalid PatchOp": {
+			Op:  70,
+			Err: ErrInvalidPatchOp,
+		},
+		"Successful PatchUpdate marshal": {
+			Op:   PatchUpdate,
+			Text: "update",
+		},
+		"Successful PatchAppend marshal": {
+			Op:   PatchAppend,
+			Text: "append",
+		},
+	}
+
+	for cn, c := range cc {
+		c := c
+
+		t.Run(cn, func(t *testing.T) {
+			t.Parallel()
+
+			d, err := c.Op.MarshalText()
+			equalError(t, c.Err, err)
+			if err != nil {
+				return
+			}
+
+			assert.Equal(t, c.Text, string(d))
+		})
+	}
+}
+
+func Test_PatchOp_UnmarshalText(t *testing.T) {
+	cc := map[string]struct {
+		Text   string
+		Result PatchOp
+		Err    error
+	}{
+		"Invalid PatchOp": {
+			Text: "replace",
+			Err:  ErrInvalidPatchOp,
+		},
+		"Successful PatchUpdate unmarshal": {
+			Text:   "update",
+			Result: PatchUpdate,
+		},
+		"Successful PatchAppend unmarshal": {
+			Text:   "append",
+			Result: PatchAppend,
+		},
+	}
+
+	for cn, c := range cc {
+		c := c
+
+		t.Run(cn, func(t *testing.T) {
+			t.Parallel()
+
+			var po PatchOp
+
+			err := po.UnmarshalText([]byte(c.Text))
+			equalError(t, c.Err, err)
+			if err != nil {
+				return
+			}
+
+			assert.Equal(t, c.Result, po)
+		})
+	}
+}
+
+func Test_Candles_Patch(t *testing.T) {
+	tm := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	cc := Candles{
+		testCandle(tm, 1, 1, 1, 1, 1),
+		testCandle(tm.Add(time.Minute), 2, 2, 2, 2, 2),
+	}
+
+	p, err := Candles(nil).Patch(cc[0])
+	assert.NoError(t, err)
+	assert.Equal(t, PatchAppend, p.Op)
+
+	p, err = cc.Patch(testCandle(tm.Add(time.Minute), 2, 3, 2, 3, 3))
+	assert.NoError(t, err)
+	assert.Equal(t, CandlePatch{Op: PatchUpdate, Candle: testCandle(tm.Add(time.Minute), 2, 3, 2, 3, 3)}, p)
+
+	p, err = cc.Patch(testCandle(tm.Add(2*time.Minute), 3, 3, 3, 3, 3))
+	assert.NoError(t, err)
+	assert.Equal(t, PatchAppend, p.Op)
+
+	_, err = cc.Patch(testCandle(tm, 3, 3, 3, 3, 3))
+	assert.Equal(t, ErrUnorderedCandle, err)
+}
+
+func Test_Candles_Apply(t *testing.T) {
+	tm := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	series := func() Candles {
+		return Candles{
+			testCandle(tm, 1, 1, 1, 1, 1),
+			testCandle(tm.Add(time.Minute), 2, 2, 2, 2, 2),
+		}
+	}
+
+	cc := map[string]struct {
+		Candles Candles
+		Patch   CandlePatch
+		Result  Candles
+		Err     error
+	}{
+		"Invalid PatchOp": {
+			Candles: series(),
+			Patch:   CandlePatch{Op: 70},
+			Err:     ErrInvalidPatchOp,
+		},
+		"Update of empty series": {
+			Patch: CandlePatch{Op: PatchUpdate, Candle: testCandle(tm, 1, 1, 1, 1, 1)},
+			Err:   ErrCandleNotFound,
+		},
+		"Update of non-last candle": {
+			Candles: series(),
+			Patch:   CandlePatch{Op: PatchUpdate, Candle: testCandle(tm, 1, 1, 1, 1, 1)},
+			Err:     ErrCandleNotFound,
+		},
+		"Append of older candle": {
+			Candles: series(),
+			Patch:   CandlePatch{Op: PatchAppend, Candle: testCandle(tm.Add(time.Minute), 1, 1, 1, 1, 1)},
+			Err:     ErrUnorderedCandle,
+		},
+		"Successful update": {
+			Candles: series(),
+			Patch:   CandlePatch{Op: PatchUpdate, Candle: testCandle(tm.Add(time.Minute), 2, 3, 2, 3, 3)},
+			Result: Candles{
+				testCandle(tm, 1, 1, 1, 1, 1),
+				testCandle(tm.Add(time.Minute), 2, 3, 2, 3, 3),
+			},
+		},
+		"Successful append": {
+			Candles: series(),
+			Patch:   CandlePatch{Op: PatchAppend, Candle: testCandle(tm.Add(2*time.Minute), 3, 3, 3, 3, 3)},
+			Result: Candles{
+				testCandle(tm, 1, 1, 1, 1, 1),
+				testCandle(tm.Add(time.Minute), 2, 2, 2, 2, 2),
+				testCandle(tm.Add(2*time.Minute), 3, 3, 3, 3, 3),
+			},
+		},
+		"Successful append to empty series": {
+			Patch:  CandlePatch{Op: PatchAppend, Candle: testCandle(tm, 1, 1, 1, 1, 1)},
+			Result: Candles{testCandle(tm, 1, 1, 1, 1, 1)},
+		},
+	}
+
+	for cn, c := range cc {
+		c := c
+
+		t.Run(cn, func(t *testing.T) {
+			t.Parallel()
+
+			res, err := c.Candles.Apply(c.Patch)
+			equalError(t, c.Err, err)
+			if err != nil {
+				return
+			}
+
+			assert.Equal(t, c.Result, res)
+		})
+	}
+}
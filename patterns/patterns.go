@@ -0,0 +1,368 @@
+// Package patterns provides recognition of candlestick patterns in
+// chartype's candle series.
+package patterns
+
+import (
+	"time"
+
+	"github.com/jellydator/chartype"
+	"github.com/shopspring/decimal"
+)
+
+const (
+	// Doji specifies a candle whose open and close prices are nearly
+	// equal.
+	Doji Kind = iota + 1
+
+	// Hammer specifies a candle with a small body at the top of its
+	// range and a long lower shadow.
+	Hammer
+
+	// BullishEngulfing specifies a bullish candle whose body engulfs
+	// the previous bearish candle's body.
+	BullishEngulfing
+
+	// BearishEngulfing specifies a bearish candle whose body engulfs
+	// the previous bullish candle's body.
+	BearishEngulfing
+
+	// MorningStar specifies a long bearish candle followed by a small
+	// bodied candle below its close and a bullish candle closing above
+	// the middle of the first candle's body.
+	MorningStar
+
+	// ThreeWhiteSoldiers specifies three consecutive bullish candles,
+	// each opening within the previous candle's body and closing
+	// higher.
+	ThreeWhiteSoldiers
+)
+
+var (
+	// ErrInvalidKind is returned when pattern kind with invalid value
+	// is being used.
+	ErrInvalidKind = &chartype.Error{
+		Code:    chartype.CodeInvalidArgument,
+		Message: "invalid pattern kind",
+	}
+)
+
+//nolint:gochecknoglobals // decimal constants cannot be declared as consts
+var (
+	// one is the maximum strength of a match.
+	one = decimal.NewFromInt(1)
+
+	// two is used to calculate body midpoints.
+	two = decimal.NewFromInt(2)
+
+	// three is the number of candles of three candle patterns.
+	three = decimal.NewFromInt(3)
+
+	// dojiBodyRatio is the maximum ratio of doji's body to its range.
+	dojiBodyRatio = decimal.New(1, -1)
+
+	// starBodyRatio is the maximum ratio of morning star's middle
+	// candle's body to the first candle's body.
+	starBodyRatio = decimal.New(3, -1)
+
+	// longBodyRatio is the minimum ratio of a long candle's body to
+	// its range.
+	longBodyRatio = decimal.New(5, -1)
+)
+
+// Kind specifies a candlestick pattern.
+// Can be included in configuration structures.
+type Kind int
+
+// Validate checks whether the pattern kind is one of supported kind
+// types or not.
+func (k Kind) Validate() error {
+	if _, ok := detectors[k]; !ok {
+		return ErrInvalidKind
+	}
+
+	return nil
+}
+
+// MarshalText turns pattern kind to appropriate string
+// representation.
+func (k Kind) MarshalText() ([]byte, error) {
+	var v string
+
+	switch k {
+	case Doji:
+		v = "doji"
+	case Hammer:
+		v = "hammer"
+	case BullishEngulfing:
+		v = "bullish_engulfing"
+	case BearishEngulfing:
+		v = "bearish_engulfing"
+	case MorningStar:
+		v = "morning_star"
+	case ThreeWhiteSoldiers:
+		v = "three_white_soldiers"
+	default:
+		return nil, ErrInvalidKind
+	}
+
+	return []byte(v), nil
+}
+
+// UnmarshalText turns string to appropriate pattern kind value.
+func (k *Kind) UnmarshalText(d []byte) error {
+	switch string(d) {
+	case "doji":
+		*k = Doji
+	case "hammer":
+		*k = Hammer
+	case "bullish_engulfing":
+		*k = BullishEngulfing
+	case "bearish_engulfing":
+		*k = BearishEngulfing
+	case "morning_star":
+		*k = MorningStar
+	case "three_white_soldiers":
+		*k = ThreeWhiteSoldiers
+	default:
+		return ErrInvalidKind
+	}
+
+	return nil
+}
+
+// Match describes a pattern found in a candle series.
+type Match struct {
+	// Kind specifies the found pattern.
+	Kind Kind `json:"kind" yaml:"kind"`
+
+	// Start specifies the index of the pattern's first candle.
+	Start int `json:"start" yaml:"start"`
+
+	// End specifies the index of the pattern's last candle.
+	End int `json:"end" yaml:"end"`
+
+	// Timestamp specifies the timestamp of the pattern's last
+	// candle.
+	Timestamp time.Time `json:"timestamp" yaml:"timestamp"`
+
+	// Strength specifies how pronounced the pattern is, from 0 to 1.
+	Strength decimal.Decimal `json:"strength" yaml:"strength"`
+}
+
+// detector checks whether candles form a pattern and returns its
+// strength.
+type detector struct {
+	size   int
+	detect func(cc []chartype.Candle) (decimal.Decimal, bool)
+}
+
+//nolint:gochecknoglobals // map literals cannot be declared as consts
+var detectors = map[Kind]detector{
+	Doji:               {size: 1, detect: doji},
+	Hammer:             {size: 1, detect: hammer},
+	BullishEngulfing:   {size: 2, detect: bullishEngulfing},
+	BearishEngulfing:   {size: 2, detect: bearishEngulfing},
+	MorningStar:        {size: 3, detect: morningStar},
+	ThreeWhiteSoldiers: {size: 3, detect: threeWhiteSoldiers},
+}
+
+// Find searches the candles for the provided patterns, or for all
+// supported patterns if none are provided. Matches are ordered by
+// their last candle's index and then by pattern kind. Patterns are
+// recognized by candle shapes alone; the preceding trend is not
+// checked. Candles must be sorted by timestamp in ascending order.
+func Find(cc []chartype.Candle, kk ...Kind) ([]Match, error) {
+	if len(kk) == 0 {
+		kk = []Kind{Doji, Hammer, BullishEngulfing, BearishEngulfing, MorningStar, ThreeWhiteSoldiers}
+	}
+
+	var enabled [ThreeWhiteSoldiers + 1]bool
+
+	for _, k := range kk {
+		if err := k.Validate(); err != nil {
+			return nil, err
+		}
+
+		enabled[k] = true
+	}
+
+	var res []Match
+
+	for i := range cc {
+		for k := Doji; k <= ThreeWhiteSoldiers; k++ {
+			d := detectors[k]
+			if !enabled[k] || i+1 < d.size {
+				continue
+			}
+
+			start := i + 1 - d.size
+
+			if s, ok := d.detect(cc[start : i+1]); ok {
+				res = append(res, Match{
+					Kind:      k,
+					Start:     start,
+					End:       i,
+					Timestamp: cc[i].Timestamp,
+					Strength:  clamp(s),
+				})
+			}
+		}
+	}
+
+	return res, nil
+}
+
+// doji checks whether the candle's body is at most a tenth of its
+// range. Strength decreases as the body grows.
+func doji(cc []chartype.Candle) (decimal.Decimal, bool) {
+	c := cc[0]
+
+	if !span(c).IsPositive() {
+		return decimal.Zero, false
+	}
+
+	limit := span(c).Mul(dojiBodyRatio)
+	if body(c).GreaterThan(limit) {
+		return decimal.Zero, false
+	}
+
+	return one.Sub(body(c).Div(limit)), true
+}
+
+// hammer checks whether the candle's lower shadow is at least twice
+// its body and its upper shadow is at most its body. Strength is the
+// lower shadow's share of the range.
+func hammer(cc []chartype.Candle) (decimal.Decimal, bool) {
+	c := cc[0]
+	b := body(c)
+
+	if !b.IsPositive() || !span(c).IsPositive() {
+		return decimal.Zero, false
+	}
+
+	lower := decimal.Min(c.Open, c.Close).Sub(c.Low)
+	upper := c.High.Sub(decimal.Max(c.Open, c.Close))
+
+	if lower.LessThan(b.Mul(two)) || upper.GreaterThan(b) {
+		return decimal.Zero, false
+	}
+
+	return lower.Div(span(c)), true
+}
+
+// bullishEngulfing checks whether a bearish candle is followed by a
+// bullish one whose body covers the first one's body. Strength
+// grows with the ratio of the bodies.
+func bullishEngulfing(cc []chartype.Candle) (decimal.Decimal, bool) {
+	c1, c2 := cc[0], cc[1]
+
+	if !bearish(c1) || !bullish(c2) ||
+		c2.Open.GreaterThan(c1.Close) || c2.Close.LessThan(c1.Open) {
+		return decimal.Zero, false
+	}
+
+	return engulfingStrength(c1, c2)
+}
+
+// bearishEngulfing checks whether a bullish candle is followed by a
+// bearish one whose body covers the first one's body. Strength
+// grows with the ratio of the bodies.
+func bearishEngulfing(cc []chartype.Candle) (decimal.Decimal, bool) {
+	c1, c2 := cc[0], cc[1]
+
+	if !bullish(c1) || !bearish(c2) ||
+		c2.Open.LessThan(c1.Close) || c2.Close.GreaterThan(c1.Open) {
+		return decimal.Zero, false
+	}
+
+	return engulfingStrength(c1, c2)
+}
+
+// engulfingStrength returns the strength of an engulfing pattern.
+// Equal bodies do not form a pattern.
+func engulfingStrength(c1, c2 chartype.Candle) (decimal.Decimal, bool) {
+	b1, b2 := body(c1), body(c2)
+	if !b2.GreaterThan(b1) {
+		return decimal.Zero, false
+	}
+
+	return one.Sub(b1.Div(b2)), true
+}
+
+// morningStar checks whether a long bearish candle is followed by a
+// small bodied candle below its close and a bullish candle closing
+// above the middle of the first candle's body. Strength is the share
+// of the first candle's upper half of the body recovered by the
+// third candle.
+func morningStar(cc []chartype.Candle) (decimal.Decimal, bool) {
+	c1, c2, c3 := cc[0], cc[1], cc[2]
+
+	if !bearish(c1) || !long(c1) || !bullish(c3) ||
+		body(c2).GreaterThan(body(c1).Mul(starBodyRatio)) ||
+		decimal.Max(c2.Open, c2.Close).GreaterThan(c1.Close) {
+		return decimal.Zero, false
+	}
+
+	mid := c1.Open.Add(c1.Close).Div(two)
+	if !c3.Close.GreaterThan(mid) {
+		return decimal.Zero, false
+	}
+
+	return c3.Close.Sub(mid).Div(c1.Open.Sub(mid)), true
+}
+
+// threeWhiteSoldiers checks whether three bullish candles each open
+// within the previous candle's body and close higher. Strength is
+// the average share of the candles' bodies in their ranges.
+func threeWhiteSoldiers(cc []chartype.Candle) (decimal.Decimal, bool) {
+	s := decimal.Zero
+
+	for i, c := range cc {
+		if !bullish(c) || !span(c).IsPositive() {
+			return decimal.Zero, false
+		}
+
+		if i > 0 {
+			prev := cc[i-1]
+			if c.Open.LessThan(prev.Open) || c.Open.GreaterThan(prev.Close) ||
+				!c.Close.GreaterThan(prev.Close) {
+				return decimal.Zero, false
+			}
+		}
+
+		s = s.Add(body(c).Div(span(c)))
+	}
+
+	return s.Div(three), true
+}
+
+// body returns the size of the candle's body.
+func body(c chartype.Candle) decimal.Decimal {
+	return c.Close.Sub(c.Open).Abs()
+}
+
+// bullish checks whether the candle closed above its open.
+func bullish(c chartype.Candle) bool {
+	return c.Close.GreaterThan(c.Open)
+}
+
+// bearish checks whether the candle closed below its open.
+func bearish(c chartype.Candle) bool {
+	return c.Close.LessThan(c.Open)
+}
+
+// long checks whether the candle's body makes up at least half of
+// its range.
+func long(c chartype.Candle) bool {
+	return body(c).GreaterThanOrEqual(span(c).Mul(longBodyRatio))
+}
+
+// span returns the size of the candle's range.
+func span(c chartype.Candle) decimal.Decimal {
+	return c.High.Sub(c.Low)
+}
+
+// clamp limits the strength to [0, 1] range.
+func clamp(s decimal.Decimal) decimal.Decimal {
+	return decimal.Max(decimal.Zero, decimal.Min(one, s))
+}
@@ -0,0 +1,283 @@
+package patterns
+
+import (
+	"testing"
+
+	"github.com/jellydator/chartype"
+	"github.com/jellydator/chartype/chartypetest"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Kind_Validate(t *testing.T) {
+	cc := map[string]struct {
+		Kind Kind
+		Err  error
+	}{
+		"Invalid Kind": {
+			Kind: 70,
+			Err:  ErrInvalidKind,
+		},
+		"Successful Doji validation": {
+			Kind: Doji,
+		},
+		"Successful ThreeWhiteSoldiers validation": {
+			Kind: ThreeWhiteSoldiers,
+		},
+	}
+
+	for cn, c := range cc {
+		c := c
+
+		t.Run(cn, func(t *testing.T) {
+			t.Parallel()
+
+			err := c.Kind.Validate()
+			chartypetest.AssertErrorEqual(t, c.Err, err)
+
+			if c.Err != nil {
+				assert.Equal(t, chartype.CodeInvalidArgument, chartype.Code(err))
+			}
+		})
+	}
+}
+
+func Test_Kind_MarshalText(t *testing.T) {
+	cc := map[string]struct {
+		Kind Kind
+		Text string
+		Err  error
+	}{
+		"Invalid Kind": {
+			Kind: 70,
+			Err:  ErrInvalidKind,
+		},
+		"Successful Doji marshal": {
+			Kind: Doji,
+			Text: "doji",
+		},
+		"Successful Hammer marshal": {
+			Kind: Hammer,
+			Text: "hammer",
+		},
+		"Successful BullishEngulfing marshal": {
+			Kind: BullishEngulfing,
+			Text: "bullish_engulfing",
+		},
+		"Successful BearishEngulfing marshal": {
+			Kind: BearishEngulfing,
+			Text: "bearish_engulfing",
+		},
+		"Successful MorningStar marshal": {
+			Kind: MorningStar,
+			Text: "morning_star",
+		},
+		"Successful ThreeWhiteSoldiers marshal": {
+			Kind: ThreeWhiteSoldiers,
+			Text: "three_white_soldiers",
+		},
+	}
+
+	for cn, c := range cc {
+		c := c
+
+		t.Run(cn, func(t *testing.T) {
+			t.Parallel()
+
+			d, err := c.Kind.MarshalText()
+			chartypetest.AssertErrorEqual(t, c.Err, err)
+			if err != nil {
+				return
+			}
+
+			assert.Equal(t, c.Text, string(d))
+		})
+	}
+}
+
+func Test_Kind_UnmarshalText(t *testing.T) {
+	cc := map[string]struct {
+		Text   string
+		Result Kind
+		Err    error
+	}{
+		"Invalid Kind": {
+			Text: "shooting_star",
+			Err:  ErrInvalidKind,
+		},
+		"Successful Doji unmarshal": {
+			Text:   "doji",
+			Result: Doji,
+		},
+		"Successful Hammer unmarshal": {
+			Text:   "hammer",
+			Result: Hammer,
+		},
+		"Successful BullishEngulfing unmarshal": {
+			Text:   "bullish_engulfing",
+			Result: BullishEngulfing,
+		},
+		"Successful BearishEngulfing unmarshal": {
+			Text:   "bearish_engulfing",
+			Result: BearishEngulfing,
+		},
+		"Successful MorningStar unmarshal": {
+			Text:   "morning_star",
+			Result: MorningStar,
+		},
+		"Successful ThreeWhiteSoldiers unmarshal": {
+			Text:   "three_white_soldiers",
+			Result: ThreeWhiteSoldiers,
+		},
+	}
+
+	for cn, c := range cc {
+		c := c
+
+		t.Run(cn, func(t *testing.T) {
+			t.Parallel()
+
+			var k Kind
+
+			err := k.UnmarshalText([]byte(c.Text))
+			chartypetest.AssertErrorEqual(t, c.Err, err)
+			if err != nil {
+				return
+			}
+
+			assert.Equal(t, c.Result, k)
+		})
+	}
+}
+
+func Test_Find(t *testing.T) {
+	// result is a match with its strength turned to string, as
+	// decimals with equal values may differ in representation.
+	type result struct {
+		Kind     Kind
+		Start    int
+		End      int
+		Strength string
+	}
+
+	cc := map[string]struct {
+		Candles []chartype.Candle
+		Kinds   []Kind
+		Result  []result
+		Err     error
+	}{
+		"Invalid kind": {
+			Candles: []chartype.Candle{testCandle(0, 10, 12, 8, 10)},
+			Kinds:   []Kind{Doji, 70},
+			Err:     ErrInvalidKind,
+		},
+		"No candles": {},
+		"Doji": {
+			Candles: []chartype.Candle{
+				testCandle(0, 10, 12, 8, 10),
+				testCandle(1, 10, 10, 10, 10),
+				testCandle(2, 10, 20, 10, 11),
+				testCandle(3, 10, 20, 10, 10),
+			},
+			Kinds: []Kind{Doji},
+			Result: []result{
+				{Kind: Doji, Start: 0, End: 0, Strength: "1"},
+				{Kind: Doji, Start: 2, End: 2, Strength: "0"},
+				{Kind: Doji, Start: 3, End: 3, Strength: "1"},
+			},
+		},
+		"Hammer": {
+			Candles: []chartype.Candle{
+				testCandle(0, 10, 11, 5, 11),
+				testCandle(1, 10, 10, 10, 10),
+				testCandle(2, 10, 13, 5, 11),
+				testCandle(3, 10, 11, 9, 11),
+				testCandle(4, 10, 5, 5, 11),
+			},
+			Kinds: []Kind{Hammer},
+			Result: []result{
+				{Kind: Hammer, Start: 0, End: 0, Strength: "0.8333333333333333"},
+			},
+		},
+		"Engulfing": {
+			Candles: []chartype.Candle{
+				testCandle(0, 12, 13, 9, 10),
+				testCandle(1, 9, 14, 8, 13),
+				testCandle(2, 14, 14, 8, 8),
+				testCandle(3, 9, 10, 8, 10),
+				testCandle(4, 10, 13, 9, 12),
+				testCandle(5, 12, 13, 9, 10),
+			},
+			Kinds: []Kind{BullishEngulfing, BearishEngulfing},
+			Result: []result{
+				{Kind: BullishEngulfing, Start: 0, End: 1, Strength: "0.5"},
+				{Kind: BearishEngulfing, Start: 1, End: 2, Strength: "0.3333333333333333"},
+			},
+		},
+		"Morning star": {
+			Candles: []chartype.Candle{
+				testCandle(0, 20, 21, 9, 10),
+				testCandle(1, 9, 10, 7, 8),
+				testCandle(2, 9, 18, 8, 17),
+				testCandle(3, 20, 21, 9, 10),
+				testCandle(4, 9, 10, 7, 8),
+				testCandle(5, 9, 18, 8, 15),
+				testCandle(6, 20, 20, 10, 10),
+				testCandle(7, 9, 10, 8, 9),
+				testCandle(8, 9, 30, 9, 30),
+			},
+			Kinds: []Kind{MorningStar},
+			Result: []result{
+				{Kind: MorningStar, Start: 0, End: 2, Strength: "0.4"},
+				{Kind: MorningStar, Start: 6, End: 8, Strength: "1"},
+			},
+		},
+		"Three white soldiers": {
+			Candles: []chartype.Candle{
+				testCandle(0, 10, 13, 9, 12),
+				testCandle(1, 11, 14, 11, 14),
+				testCandle(2, 13, 17, 12, 16),
+				testCandle(3, 17, 18, 16, 18),
+				testCandle(4, 18, 18, 18, 18),
+			},
+			Kinds: []Kind{ThreeWhiteSoldiers},
+			Result: []result{
+				{Kind: ThreeWhiteSoldiers, Start: 0, End: 2, Strength: "0.7"},
+			},
+		},
+		"All patterns": {
+			Candles: []chartype.Candle{
+				testCandle(0, 10, 12, 8, 10),
+				testCandle(1, 20, 21, 9, 10),
+				testCandle(2, 9, 10, 7, 8),
+				testCandle(3, 9, 18, 8, 17),
+			},
+			Result: []result{
+				{Kind: Doji, Start: 0, End: 0, Strength: "1"},
+				{Kind: MorningStar, Start: 1, End: 3, Strength: "0.4"},
+			},
+		},
+	}
+
+	for cn, c := range cc {
+		c := c
+
+		t.Run(cn, func(t *testing.T) {
+			t.Parallel()
+
+			mm, err := Find(c.Candles, c.Kinds...)
+			chartypetest.AssertErrorEqual(t, c.Err, err)
+			if err != nil {
+				return
+			}
+
+			var res []result
+
+			for _, m := range mm {
+				assert.Equal(t, c.Candles[m.End].Timestamp, m.Timestamp)
+				res = append(res, result{Kind: m.Kind, Start: m.Start, End: m.End, Strength: m.Strength.String()})
+			}
+
+			assert.Equal(t, c.Result, res)
+		})
+	}
+}
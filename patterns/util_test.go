@@ -0,0 +1,18 @@
+package patterns
+
+import (
+	"time"
+
+	"github.com/jellydator/chartype"
+	"github.com/shopspring/decimal"
+)
+
+func testCandle(i int, o, h, l, c int64) chartype.Candle {
+	return chartype.Candle{
+		Timestamp: time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC).AddDate(0, 0, i),
+		Open:      decimal.NewFromInt(o),
+		High:      decimal.NewFromInt(h),
+		Low:       decimal.NewFromInt(l),
+		Close:     decimal.NewFromInt(c),
+	}
+}
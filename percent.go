@@ -0,0 +1,97 @@
+package chartype
+
+import (
+	"strings"
+
+	"github.com/shopspring/decimal"
+)
+
+// percentSign is the optional suffix of percent's string
+// representation.
+const percentSign = "%"
+
+// Percent is a relative value stored in percentage points, i.e.
+// 3.5% is stored as 3.5, not as 0.035. Its zero value is 0%.
+type Percent struct {
+	points decimal.Decimal
+}
+
+// NewPercent creates a new percent from percentage points, e.g. 3.5
+// for 3.5%.
+func NewPercent(points decimal.Decimal) Percent {
+	return Percent{points: points}
+}
+
+// NewPercentFromRatio creates a new percent from a ratio, e.g. 0.035
+// for 3.5%.
+func NewPercentFromRatio(r decimal.Decimal) Percent {
+	return Percent{points: r.Shift(2)}
+}
+
+// ParsePercent parses provided string into a new percent. Both
+// "-3.5%" and "-3.5" are parsed as -3.5%.
+func ParsePercent(s string) (Percent, error) {
+	var p Percent
+	if err := p.UnmarshalText([]byte(s)); err != nil {
+		return Percent{}, err
+	}
+
+	return p, nil
+}
+
+// Points returns percent's value in percentage points, e.g. 3.5
+// for 3.5%.
+func (p Percent) Points() decimal.Decimal {
+	return p.points
+}
+
+// Ratio returns percent's value as a ratio, e.g. 0.035 for 3.5%.
+func (p Percent) Ratio() decimal.Decimal {
+	return p.points.Shift(-2)
+}
+
+// String returns percent's string representation with a percent
+// sign, e.g. "-3.5%".
+func (p Percent) String() string {
+	return p.points.String() + percentSign
+}
+
+// StringFixed returns percent's string representation with a
+// percent sign and the provided number of decimal places, e.g.
+// "-3.50%".
+func (p Percent) StringFixed(places int32) string {
+	return p.points.StringFixed(places) + percentSign
+}
+
+// MarshalText turns percent to its percentage points string
+// representation without a percent sign, so that it remains
+// compatible with plain decimal values.
+func (p Percent) MarshalText() ([]byte, error) {
+	return []byte(p.points.String()), nil
+}
+
+// UnmarshalJSON turns JSON string or number to appropriate percent
+// value. JSON null leaves the percent unchanged, the same way as it
+// does for plain decimal values.
+func (p *Percent) UnmarshalJSON(d []byte) error {
+	if string(d) == "null" {
+		return nil
+	}
+
+	return p.UnmarshalText(unquote(d))
+}
+
+// UnmarshalText turns string, with or without a percent sign, to
+// appropriate percent value.
+func (p *Percent) UnmarshalText(d []byte) error {
+	s := strings.TrimSpace(strings.TrimSuffix(strings.TrimSpace(string(d)), percentSign))
+
+	v, err := decimalFromString(s)
+	if err != nil {
+		return err
+	}
+
+	p.points = v
+
+	return nil
+}
@@ -0,0 +1,83 @@
+package chartype
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_NewPercent(t *testing.T) {
+	p := NewPercent(decimal.RequireFromString("-3.5"))
+	assert.Equal(t, "-3.5", p.Points().String())
+	assert.Equal(t, "-0.035", p.Ratio().String())
+	assert.Equal(t, "-3.5%", p.String())
+	assert.Equal(t, "-3.50%", p.StringFixed(2))
+
+	p = NewPercentFromRatio(decimal.RequireFromString("0.035"))
+	assert.Equal(t, "3.5", p.Points().String())
+	assert.Equal(t, "0.035", p.Ratio().String())
+}
+
+func Test_ParsePercent(t *testing.T) {
+	cc := map[string]struct {
+		Text   string
+		Result string
+		Err    error
+	}{
+		"Invalid decimal": {
+			Text: "x%",
+			Err:  assert.AnError,
+		},
+		"Successful parse with percent sign": {
+			Text:   "-3.5%",
+			Result: "-3.5%",
+		},
+		"Successful parse with spaces": {
+			Text:   " 3.5 % ",
+			Result: "3.5%",
+		},
+		"Successful parse without percent sign": {
+			Text:   "-3.5",
+			Result: "-3.5%",
+		},
+	}
+
+	for cn, c := range cc {
+		c := c
+
+		t.Run(cn, func(t *testing.T) {
+			t.Parallel()
+
+			p, err := ParsePercent(c.Text)
+			equalError(t, c.Err, err)
+			if err != nil {
+				return
+			}
+
+			assert.Equal(t, c.Result, p.String())
+		})
+	}
+}
+
+func Test_Percent_JSON(t *testing.T) {
+	var v struct {
+		Percent Percent `json:"percent"`
+	}
+
+	assert.NoError(t, json.Unmarshal([]byte(`{"percent":1.25}`), &v))
+	assert.Equal(t, "1.25%", v.Percent.String())
+
+	assert.NoError(t, json.Unmarshal([]byte(`{"percent":"-2.5%"}`), &v))
+	assert.Equal(t, "-2.5%", v.Percent.String())
+
+	assert.Error(t, json.Unmarshal([]byte(`{"percent":"x"}`), &v))
+
+	assert.NoError(t, json.Unmarshal([]byte(`{"percent":null}`), &v))
+	assert.Equal(t, "-2.5%", v.Percent.String())
+
+	d, err := json.Marshal(v)
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"percent":"-2.5"}`, string(d))
+}
@@ -0,0 +1,116 @@
+package chartype
+
+import "github.com/shopspring/decimal"
+
+//nolint:gochecknoglobals // decimal constants cannot be declared as consts
+var (
+	// fibonacciRatios are the range ratios of fibonacci pivot levels.
+	fibonacciRatios = [3]decimal.Decimal{
+		decimal.New(382, -3),
+		decimal.New(618, -3),
+		decimal.New(1, 0),
+	}
+
+	// camarillaDivisors are the range divisors of camarilla pivot
+	// levels.
+	camarillaDivisors = [4]decimal.Decimal{
+		decimal.NewFromInt(12),
+		decimal.NewFromInt(6),
+		decimal.NewFromInt(4),
+		decimal.NewFromInt(2),
+	}
+
+	// camarillaMultiplier is the range multiplier of camarilla pivot
+	// levels.
+	camarillaMultiplier = decimal.New(11, -1)
+)
+
+// PivotLevels holds a pivot point together with its resistance and
+// support levels.
+type PivotLevels struct {
+	// Pivot specifies the pivot point.
+	Pivot decimal.Decimal `json:"pivot" yaml:"pivot"`
+
+	// R1 specifies the first resistance level.
+	R1 decimal.Decimal `json:"r1" yaml:"r1"`
+
+	// R2 specifies the second resistance level.
+	R2 decimal.Decimal `json:"r2" yaml:"r2"`
+
+	// R3 specifies the third resistance level.
+	R3 decimal.Decimal `json:"r3" yaml:"r3"`
+
+	// R4 specifies the fourth resistance level. It is set only by
+	// camarilla pivots.
+	R4 decimal.Decimal `json:"r4" yaml:"r4"`
+
+	// S1 specifies the first support level.
+	S1 decimal.Decimal `json:"s1" yaml:"s1"`
+
+	// S2 specifies the second support level.
+	S2 decimal.Decimal `json:"s2" yaml:"s2"`
+
+	// S3 specifies the third support level.
+	S3 decimal.Decimal `json:"s3" yaml:"s3"`
+
+	// S4 specifies the fourth support level. It is set only by
+	// camarilla pivots.
+	S4 decimal.Decimal `json:"s4" yaml:"s4"`
+}
+
+// Pivots holds pivot levels calculated using different methods.
+type Pivots struct {
+	// Classic specifies floor trader pivot levels.
+	Classic PivotLevels `json:"classic" yaml:"classic"`
+
+	// Fibonacci specifies pivot levels spaced by fibonacci ratios of
+	// the candle's range.
+	Fibonacci PivotLevels `json:"fibonacci" yaml:"fibonacci"`
+
+	// Camarilla specifies levels spaced around the close price by
+	// fractions of the candle's range.
+	Camarilla PivotLevels `json:"camarilla" yaml:"camarilla"`
+}
+
+// PivotPoints calculates pivot levels for the next period from the
+// period's candle. All methods use the candle's typical price as the
+// pivot point.
+func PivotPoints(c Candle) Pivots {
+	p := c.TypicalPrice()
+	r := c.High.Sub(c.Low)
+
+	classic := PivotLevels{
+		Pivot: p,
+		R1:    p.Mul(two).Sub(c.Low),
+		R2:    p.Add(r),
+		R3:    c.High.Add(p.Sub(c.Low).Mul(two)),
+		S1:    p.Mul(two).Sub(c.High),
+		S2:    p.Sub(r),
+		S3:    c.Low.Sub(c.High.Sub(p).Mul(two)),
+	}
+
+	fib := PivotLevels{Pivot: p}
+	fibR := [3]*decimal.Decimal{&fib.R1, &fib.R2, &fib.R3}
+	fibS := [3]*decimal.Decimal{&fib.S1, &fib.S2, &fib.S3}
+
+	for i, f := range fibonacciRatios {
+		*fibR[i] = p.Add(r.Mul(f))
+		*fibS[i] = p.Sub(r.Mul(f))
+	}
+
+	cam := PivotLevels{Pivot: p}
+	camR := [4]*decimal.Decimal{&cam.R1, &cam.R2, &cam.R3, &cam.R4}
+	camS := [4]*decimal.Decimal{&cam.S1, &cam.S2, &cam.S3, &cam.S4}
+
+	for i, d := range camarillaDivisors {
+		off := r.Mul(camarillaMultiplier).Div(d)
+		*camR[i] = c.Close.Add(off)
+		*camS[i] = c.Close.Sub(off)
+	}
+
+	return Pivots{
+		Classic:   classic,
+		Fibonacci: fib,
+		Camarilla: cam,
+	}
+}
@@ -0,0 +1,57 @@
+package chartype
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_PivotPoints(t *testing.T) {
+	levels := func(pl PivotLevels) []string {
+		res := make([]string, 0, 9)
+		for _, v := range []interface{ String() string }{
+			pl.Pivot, pl.R1, pl.R2, pl.R3, pl.R4, pl.S1, pl.S2, pl.S3, pl.S4,
+		} {
+			res = append(res, v.String())
+		}
+
+		return res
+	}
+
+	cc := map[string]struct {
+		Candle    Candle
+		Classic   []string
+		Fibonacci []string
+		Camarilla []string
+	}{
+		"Flat candle": {
+			Candle:    testCandle(time.Time{}, 100, 100, 100, 100, 10),
+			Classic:   []string{"100", "100", "100", "100", "0", "100", "100", "100", "0"},
+			Fibonacci: []string{"100", "100", "100", "100", "0", "100", "100", "100", "0"},
+			Camarilla: []string{"100", "100", "100", "100", "100", "100", "100", "100", "100"},
+		},
+		"Ranged candle": {
+			Candle:    testCandle(time.Time{}, 100, 130, 90, 110, 10),
+			Classic:   []string{"110", "130", "150", "170", "0", "90", "70", "50", "0"},
+			Fibonacci: []string{"110", "125.28", "134.72", "150", "0", "94.72", "85.28", "70", "0"},
+			Camarilla: []string{
+				"110", "113.6666666666666667", "117.3333333333333333", "121", "132",
+				"106.3333333333333333", "102.6666666666666667", "99", "88",
+			},
+		},
+	}
+
+	for cn, c := range cc {
+		c := c
+
+		t.Run(cn, func(t *testing.T) {
+			t.Parallel()
+
+			pp := PivotPoints(c.Candle)
+			assert.Equal(t, c.Classic, levels(pp.Classic))
+			assert.Equal(t, c.Fibonacci, levels(pp.Fibonacci))
+			assert.Equal(t, c.Camarilla, levels(pp.Camarilla))
+		})
+	}
+}
@@ -0,0 +1,216 @@
+package chartype
+
+import (
+	"github.com/shopspring/decimal"
+)
+
+// MaxPrecision specifies the maximum number of decimal places of
+// prices and quantities.
+const MaxPrecision = 18
+
+var (
+	// ErrInvalidPrice is returned when negative price, zero price
+	// where a positive one is expected or price with too many
+	// decimal places is being used.
+	ErrInvalidPrice = newError(CodeInvalidArgument, "invalid price")
+
+	// ErrInvalidQuantity is returned when negative quantity or
+	// quantity with too many decimal places is being used.
+	ErrInvalidQuantity = newError(CodeInvalidArgument, "invalid quantity")
+)
+
+// validValue checks whether the decimal is non-negative and has no
+// more than MaxPrecision decimal places.
+func validValue(d decimal.Decimal) bool {
+	return !d.IsNegative() && d.Equal(d.Truncate(MaxPrecision))
+}
+
+// unquote removes surrounding double quotes from the JSON value, so
+// that both JSON strings and numbers can be decoded as decimals.
+func unquote(d []byte) []byte {
+	if len(d) >= 2 && d[0] == '"' && d[len(d)-1] == '"' {
+		return d[1 : len(d)-1]
+	}
+
+	return d
+}
+
+// Price is a non-negative decimal price. Its zero value is a valid
+// zero price.
+type Price struct {
+	d decimal.Decimal
+}
+
+// NewPrice creates a new price from the decimal.
+func NewPrice(d decimal.Decimal) (Price, error) {
+	if !validValue(d) {
+		return Price{}, ErrInvalidPrice
+	}
+
+	return Price{d: d}, nil
+}
+
+// ParsePrice parses provided string into a new price.
+func ParsePrice(s string) (Price, error) {
+	var p Price
+	if err := p.UnmarshalText([]byte(s)); err != nil {
+		return Price{}, err
+	}
+
+	return p, nil
+}
+
+// Decimal returns price's decimal value.
+func (p Price) Decimal() decimal.Decimal {
+	return p.d
+}
+
+// String returns price's string representation.
+func (p Price) String() string {
+	return p.d.String()
+}
+
+// IsZero checks whether the price is zero.
+func (p Price) IsZero() bool {
+	return p.d.IsZero()
+}
+
+// Cmp compares the prices and returns -1, 0 or 1 if p is less than,
+// equal to or greater than p1 respectively.
+func (p Price) Cmp(p1 Price) int {
+	return p.d.Cmp(p1.d)
+}
+
+// Add returns the sum of the prices.
+func (p Price) Add(p1 Price) Price {
+	return Price{d: p.d.Add(p1.d)}
+}
+
+// Sub returns the difference of the prices. An error is returned if
+// the result is negative.
+func (p Price) Sub(p1 Price) (Price, error) {
+	return NewPrice(p.d.Sub(p1.d))
+}
+
+// Mul returns the notional value of the quantity at the price.
+func (p Price) Mul(q Quantity) decimal.Decimal {
+	return p.d.Mul(q.d)
+}
+
+// MarshalText turns price to appropriate string representation.
+func (p Price) MarshalText() ([]byte, error) {
+	return []byte(p.d.String()), nil
+}
+
+// UnmarshalJSON turns JSON string or number to appropriate price
+// value.
+func (p *Price) UnmarshalJSON(d []byte) error {
+	return p.UnmarshalText(unquote(d))
+}
+
+// UnmarshalText turns string to appropriate price value.
+func (p *Price) UnmarshalText(d []byte) error {
+	v, err := decimalFromString(string(d))
+	if err != nil {
+		return err
+	}
+
+	np, err := NewPrice(v)
+	if err != nil {
+		return err
+	}
+
+	*p = np
+
+	return nil
+}
+
+// Quantity is a non-negative decimal amount of an asset. Its zero
+// value is a valid zero quantity.
+type Quantity struct {
+	d decimal.Decimal
+}
+
+// NewQuantity creates a new quantity from the decimal.
+func NewQuantity(d decimal.Decimal) (Quantity, error) {
+	if !validValue(d) {
+		return Quantity{}, ErrInvalidQuantity
+	}
+
+	return Quantity{d: d}, nil
+}
+
+// ParseQuantity parses provided string into a new quantity.
+func ParseQuantity(s string) (Quantity, error) {
+	var q Quantity
+	if err := q.UnmarshalText([]byte(s)); err != nil {
+		return Quantity{}, err
+	}
+
+	return q, nil
+}
+
+// Decimal returns quantity's decimal value.
+func (q Quantity) Decimal() decimal.Decimal {
+	return q.d
+}
+
+// String returns quantity's string representation.
+func (q Quantity) String() string {
+	return q.d.String()
+}
+
+// IsZero checks whether the quantity is zero.
+func (q Quantity) IsZero() bool {
+	return q.d.IsZero()
+}
+
+// Cmp compares the quantities and returns -1, 0 or 1 if q is less
+// than, equal to or greater than q1 respectively.
+func (q Quantity) Cmp(q1 Quantity) int {
+	return q.d.Cmp(q1.d)
+}
+
+// Add returns the sum of the quantities.
+func (q Quantity) Add(q1 Quantity) Quantity {
+	return Quantity{d: q.d.Add(q1.d)}
+}
+
+// Sub returns the difference of the quantities. An error is returned
+// if the result is negative.
+func (q Quantity) Sub(q1 Quantity) (Quantity, error) {
+	return NewQuantity(q.d.Sub(q1.d))
+}
+
+// Mul returns the notional value of the quantity at the price.
+func (q Quantity) Mul(p Price) decimal.Decimal {
+	return q.d.Mul(p.d)
+}
+
+// MarshalText turns quantity to appropriate string representation.
+func (q Quantity) MarshalText() ([]byte, error) {
+	return []byte(q.d.String()), nil
+}
+
+// UnmarshalJSON turns JSON string or number to appropriate quantity
+// value.
+func (q *Quantity) UnmarshalJSON(d []byte) error {
+	return q.UnmarshalText(unquote(d))
+}
+
+// UnmarshalText turns string to appropriate quantity value.
+func (q *Quantity) UnmarshalText(d []byte) error {
+	v, err := decimalFromString(string(d))
+	if err != nil {
+		return err
+	}
+
+	nq, err := NewQuantity(v)
+	if err != nil {
+		return err
+	}
+
+	*q = nq
+
+	return nil
+}
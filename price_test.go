@@ -0,0 +1,241 @@
+package chartype
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_NewPrice(t *testing.T) {
+	cc := map[string]struct {
+		Value string
+		Err   error
+	}{
+		"Negative value": {
+			Value: "-1",
+			Err:   ErrInvalidPrice,
+		},
+		"Too many decimal places": {
+			Value: "0.0000000000000000001",
+			Err:   ErrInvalidPrice,
+		},
+		"Successful creation": {
+			Value: "1.000000000000000001",
+		},
+	}
+
+	for cn, c := range cc {
+		c := c
+
+		t.Run(cn, func(t *testing.T) {
+			t.Parallel()
+
+			p, err := NewPrice(decimal.RequireFromString(c.Value))
+			equalError(t, c.Err, err)
+			if err != nil {
+				return
+			}
+
+			assert.Equal(t, c.Value, p.String())
+			assert.Equal(t, c.Value, p.Decimal().String())
+		})
+	}
+}
+
+func Test_ParsePrice(t *testing.T) {
+	cc := map[string]struct {
+		Text   string
+		Result string
+		Err    error
+	}{
+		"Invalid decimal": {
+			Text: "x",
+			Err:  assert.AnError,
+		},
+		"Negative value": {
+			Text: "-1",
+			Err:  ErrInvalidPrice,
+		},
+		"Successful parse": {
+			Text:   "1.5",
+			Result: "1.5",
+		},
+	}
+
+	for cn, c := range cc {
+		c := c
+
+		t.Run(cn, func(t *testing.T) {
+			t.Parallel()
+
+			p, err := ParsePrice(c.Text)
+			equalError(t, c.Err, err)
+			if err != nil {
+				return
+			}
+
+			assert.Equal(t, c.Result, p.String())
+		})
+	}
+}
+
+func Test_Price_Arithmetic(t *testing.T) {
+	p1, err := ParsePrice("2.5")
+	assert.NoError(t, err)
+
+	p2, err := ParsePrice("1")
+	assert.NoError(t, err)
+
+	q, err := ParseQuantity("4")
+	assert.NoError(t, err)
+
+	assert.False(t, p1.IsZero())
+	assert.True(t, Price{}.IsZero())
+	assert.Equal(t, 1, p1.Cmp(p2))
+	assert.Equal(t, "3.5", p1.Add(p2).String())
+
+	res, err := p1.Sub(p2)
+	assert.NoError(t, err)
+	assert.Equal(t, "1.5", res.String())
+
+	_, err = p2.Sub(p1)
+	assert.Equal(t, ErrInvalidPrice, err)
+
+	assert.Equal(t, "10", p1.Mul(q).String())
+}
+
+func Test_Price_JSON(t *testing.T) {
+	var v struct {
+		Price Price `json:"price"`
+	}
+
+	assert.NoError(t, json.Unmarshal([]byte(`{"price":1.25}`), &v))
+	assert.Equal(t, "1.25", v.Price.String())
+
+	assert.NoError(t, json.Unmarshal([]byte(`{"price":"2.5"}`), &v))
+	assert.Equal(t, "2.5", v.Price.String())
+
+	assert.Error(t, json.Unmarshal([]byte(`{"price":-1}`), &v))
+
+	d, err := json.Marshal(v)
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"price":"2.5"}`, string(d))
+}
+
+func Test_NewQuantity(t *testing.T) {
+	cc := map[string]struct {
+		Value string
+		Err   error
+	}{
+		"Negative value": {
+			Value: "-1",
+			Err:   ErrInvalidQuantity,
+		},
+		"Too many decimal places": {
+			Value: "0.0000000000000000001",
+			Err:   ErrInvalidQuantity,
+		},
+		"Successful creation": {
+			Value: "1.000000000000000001",
+		},
+	}
+
+	for cn, c := range cc {
+		c := c
+
+		t.Run(cn, func(t *testing.T) {
+			t.Parallel()
+
+			q, err := NewQuantity(decimal.RequireFromString(c.Value))
+			equalError(t, c.Err, err)
+			if err != nil {
+				return
+			}
+
+			assert.Equal(t, c.Value, q.String())
+			assert.Equal(t, c.Value, q.Decimal().String())
+		})
+	}
+}
+
+func Test_ParseQuantity(t *testing.T) {
+	cc := map[string]struct {
+		Text   string
+		Result string
+		Err    error
+	}{
+		"Invalid decimal": {
+			Text: "x",
+			Err:  assert.AnError,
+		},
+		"Negative value": {
+			Text: "-1",
+			Err:  ErrInvalidQuantity,
+		},
+		"Successful parse": {
+			Text:   "1.5",
+			Result: "1.5",
+		},
+	}
+
+	for cn, c := range cc {
+		c := c
+
+		t.Run(cn, func(t *testing.T) {
+			t.Parallel()
+
+			q, err := ParseQuantity(c.Text)
+			equalError(t, c.Err, err)
+			if err != nil {
+				return
+			}
+
+			assert.Equal(t, c.Result, q.String())
+		})
+	}
+}
+
+func Test_Quantity_Arithmetic(t *testing.T) {
+	q1, err := ParseQuantity("2.5")
+	assert.NoError(t, err)
+
+	q2, err := ParseQuantity("1")
+	assert.NoError(t, err)
+
+	p, err := ParsePrice("4")
+	assert.NoError(t, err)
+
+	assert.False(t, q1.IsZero())
+	assert.True(t, Quantity{}.IsZero())
+	assert.Equal(t, -1, q2.Cmp(q1))
+	assert.Equal(t, "3.5", q1.Add(q2).String())
+
+	res, err := q1.Sub(q2)
+	assert.NoError(t, err)
+	assert.Equal(t, "1.5", res.String())
+
+	_, err = q2.Sub(q1)
+	assert.Equal(t, ErrInvalidQuantity, err)
+
+	assert.Equal(t, "10", q1.Mul(p).String())
+}
+
+func Test_Quantity_JSON(t *testing.T) {
+	var v struct {
+		Quantity Quantity `json:"quantity"`
+	}
+
+	assert.NoError(t, json.Unmarshal([]byte(`{"quantity":1.25}`), &v))
+	assert.Equal(t, "1.25", v.Quantity.String())
+
+	assert.NoError(t, json.Unmarshal([]byte(`{"quantity":"2.5"}`), &v))
+	assert.Equal(t, "2.5", v.Quantity.String())
+
+	assert.Error(t, json.Unmarshal([]byte(`{"quantity":-1}`), &v))
+
+	d, err := json.Marshal(v)
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"quantity":"2.5"}`, string(d))
+}
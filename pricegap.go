@@ -0,0 +1,147 @@
+package chartype
+
+import (
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+const (
+	// GapUp specifies that the candle opened above the previous
+	// candle's close.
+	GapUp GapDirection = iota + 1
+
+	// GapDown specifies that the candle opened below the previous
+	// candle's close.
+	GapDown
+)
+
+var (
+	// ErrInvalidGapDirection is returned when gap direction with
+	// invalid value is being used.
+	ErrInvalidGapDirection = newError(CodeInvalidArgument, "invalid gap direction")
+)
+
+// GapDirection specifies in which direction the price gapped.
+// Can be included in configuration structures.
+type GapDirection int
+
+// Validate checks whether the gap direction is one of supported
+// direction types or not.
+func (gd GapDirection) Validate() error {
+	switch gd {
+	case GapUp, GapDown:
+		return nil
+	default:
+		return ErrInvalidGapDirection
+	}
+}
+
+// MarshalText turns gap direction to appropriate string
+// representation.
+func (gd GapDirection) MarshalText() ([]byte, error) {
+	var v string
+
+	switch gd {
+	case GapUp:
+		v = "up"
+	case GapDown:
+		v = "down"
+	default:
+		return nil, ErrInvalidGapDirection
+	}
+
+	return []byte(v), nil
+}
+
+// UnmarshalText turns string to appropriate gap direction value.
+func (gd *GapDirection) UnmarshalText(d []byte) error {
+	switch string(d) {
+	case "up":
+		*gd = GapUp
+	case "down":
+		*gd = GapDown
+	default:
+		return ErrInvalidGapDirection
+	}
+
+	return nil
+}
+
+// PriceGap describes a gap between a candle's open price and the
+// previous candle's close price.
+type PriceGap struct {
+	// Index specifies the index of the candle that opened with
+	// the gap.
+	Index int `json:"index" yaml:"index"`
+
+	// Timestamp specifies the timestamp of the candle that opened
+	// with the gap.
+	Timestamp time.Time `json:"timestamp" yaml:"timestamp"`
+
+	// Direction specifies whether the price gapped up or down.
+	Direction GapDirection `json:"direction" yaml:"direction"`
+
+	// From specifies the previous candle's close price.
+	From decimal.Decimal `json:"from" yaml:"from"`
+
+	// To specifies the candle's open price.
+	To decimal.Decimal `json:"to" yaml:"to"`
+
+	// Size specifies the absolute difference between the prices.
+	Size decimal.Decimal `json:"size" yaml:"size"`
+
+	// Filled specifies whether the price returned to the previous
+	// candle's close price later on.
+	Filled bool `json:"filled" yaml:"filled"`
+
+	// FilledIndex specifies the index of the candle that filled the
+	// gap. It is only meaningful when the gap is filled.
+	FilledIndex int `json:"filled_index,omitempty" yaml:"filled_index,omitempty"`
+}
+
+// PriceGaps detects gaps between candles' open prices and previous
+// candles' close prices that are not smaller than the minimum size.
+// A gap is filled by the first candle, starting with the gapping one,
+// whose low (for gaps up) or high (for gaps down) price reaches the
+// previous candle's close price. Candles must be sorted by timestamp
+// in ascending order.
+func PriceGaps(cc []Candle, minSize decimal.Decimal) []PriceGap {
+	var res []PriceGap
+
+	for i := 1; i < len(cc); i++ {
+		from, to := cc[i-1].Close, cc[i].Open
+
+		size := to.Sub(from).Abs()
+		if size.IsZero() || size.LessThan(minSize) {
+			continue
+		}
+
+		g := PriceGap{
+			Index:     i,
+			Timestamp: cc[i].Timestamp,
+			Direction: GapUp,
+			From:      from,
+			To:        to,
+			Size:      size,
+		}
+
+		if to.LessThan(from) {
+			g.Direction = GapDown
+		}
+
+		for j := i; j < len(cc); j++ {
+			if g.Direction == GapUp && cc[j].Low.LessThanOrEqual(from) ||
+				g.Direction == GapDown && cc[j].High.GreaterThanOrEqual(from) {
+				g.Filled = true
+				g.FilledIndex = j
+
+				break
+			}
+		}
+
+		res = append(res, g)
+	}
+
+	return res
+}
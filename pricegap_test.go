@@ -0,0 +1,212 @@
+package chartype
+
+import (
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_GapDirection_Validate(t *testing.T) {
+	cc := map[string]struct {
+		Direction GapDirection
+		Err       error
+	}{
+		"Invalid GapDirection": {
+			Direction: 70,
+			Err:       ErrInvalidGapDirection,
+		},
+		"Successful GapUp validation": {
+			Direction: GapUp,
+		},
+		"Successful GapDown validation": {
+			Direction: GapDown,
+		},
+	}
+
+	for cn, c := range cc {
+		c := c
+
+		t.Run(cn, func(t *testing.T) {
+			t.Parallel()
+
+			equalError(t, c.Err, c.Direction.Validate())
+		})
+	}
+}
+
+func Test_GapDirection_MarshalText(t *testing.T) {
+	cc := map[string]struct {
+		Direction GapDirection
+		Text      string
+		Err       error
+	}{
+		"Invalid GapDirection": {
+			Direction: 70,
+			Err:       ErrInvalidGapDirection,
+		},
+		"Successful GapUp marshal": {
+			Direction: GapUp,
+			Text:      "up",
+		},
+		"Successful GapDown marshal": {
+			Direction: GapDown,
+			Text:      "down",
+		},
+	}
+
+	for cn, c := range cc {
+		c := c
+
+		t.Run(cn, func(t *testing.T) {
+			t.Parallel()
+
+			d, err := c.Direction.MarshalText()
+			equalError(t, c.Err, err)
+			if err != nil {
+				return
+			}
+
+			assert.Equal(t, c.Text, string(d))
+		})
+	}
+}
+
+func Test_GapDirection_UnmarshalText(t *testing.T) {
+	cc := map[string]struct {
+		Text   string
+		Result GapDirection
+		Err    error
+	}{
+		"Invalid GapDirection": {
+			Text: "sideways",
+			Err:  ErrInvalidGapDirection,
+		},
+		"Successful GapUp unmarshal": {
+			Text:   "up",
+			Result: GapUp,
+		},
+		"Successful GapDown unmarshal": {
+			Text:   "down",
+			Result: GapDown,
+		},
+	}
+
+	for cn, c := range cc {
+		c := c
+
+		t.Run(cn, func(t *testing.T) {
+			t.Parallel()
+
+			var gd GapDirection
+
+			err := gd.UnmarshalText([]byte(c.Text))
+			equalError(t, c.Err, err)
+			if err != nil {
+				return
+			}
+
+			assert.Equal(t, c.Result, gd)
+		})
+	}
+}
+
+func Test_PriceGaps(t *testing.T) {
+	tm := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	at := func(d int) time.Time {
+		return tm.AddDate(0, 0, d)
+	}
+
+	cc := map[string]struct {
+		Candles []Candle
+		MinSize int64
+		Result  []PriceGap
+	}{
+		"No candles": {},
+		"No gaps": {
+			Candles: []Candle{
+				testCandle(at(0), 10, 12, 9, 11, 1),
+				testCandle(at(1), 11, 13, 10, 12, 1),
+			},
+		},
+		"Gaps below minimum size": {
+			Candles: []Candle{
+				testCandle(at(0), 10, 12, 9, 11, 1),
+				testCandle(at(1), 12, 13, 12, 12, 1),
+			},
+			MinSize: 2,
+		},
+		"Filled and unfilled gaps": {
+			Candles: []Candle{
+				testCandle(at(0), 10, 12, 9, 11, 1),
+				testCandle(at(1), 14, 15, 13, 14, 1),
+				testCandle(at(2), 14, 14, 10, 12, 1),
+				testCandle(at(3), 9, 10, 8, 9, 1),
+				testCandle(at(4), 9, 11, 8, 10, 1),
+				testCandle(at(5), 12, 12, 11, 11, 1),
+			},
+			MinSize: 2,
+			Result: []PriceGap{
+				{
+					Index:       1,
+					Timestamp:   at(1),
+					Direction:   GapUp,
+					From:        decimal.NewFromInt(11),
+					To:          decimal.NewFromInt(14),
+					Size:        decimal.NewFromInt(3),
+					Filled:      true,
+					FilledIndex: 2,
+				},
+				{
+					Index:       3,
+					Timestamp:   at(3),
+					Direction:   GapDown,
+					From:        decimal.NewFromInt(12),
+					To:          decimal.NewFromInt(9),
+					Size:        decimal.NewFromInt(3),
+					Filled:      true,
+					FilledIndex: 5,
+				},
+				{
+					Index:       5,
+					Timestamp:   at(5),
+					Direction:   GapUp,
+					From:        decimal.NewFromInt(10),
+					To:          decimal.NewFromInt(12),
+					Size:        decimal.NewFromInt(2),
+					Filled:      false,
+					FilledIndex: 0,
+				},
+			},
+		},
+		"Gap filled by the gapping candle": {
+			Candles: []Candle{
+				testCandle(at(0), 10, 12, 9, 11, 1),
+				testCandle(at(1), 9, 11, 8, 10, 1),
+			},
+			Result: []PriceGap{
+				{
+					Index:       1,
+					Timestamp:   at(1),
+					Direction:   GapDown,
+					From:        decimal.NewFromInt(11),
+					To:          decimal.NewFromInt(9),
+					Size:        decimal.NewFromInt(2),
+					Filled:      true,
+					FilledIndex: 1,
+				},
+			},
+		},
+	}
+
+	for cn, c := range cc {
+		c := c
+
+		t.Run(cn, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, c.Result, PriceGaps(c.Candles, decimal.NewFromInt(c.MinSize)))
+		})
+	}
+}
@@ -0,0 +1,278 @@
+package chartype
+
+import (
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+const (
+	// AnomalyMisaligned specifies a candle whose timestamp is not
+	// aligned to the interval boundary.
+	AnomalyMisaligned AnomalyKind = iota + 1
+
+	// AnomalyDuplicate specifies a candle with the same timestamp as
+	// the previous one.
+	AnomalyDuplicate
+
+	// AnomalyUnordered specifies a candle with an older timestamp
+	// than the previous one.
+	AnomalyUnordered
+
+	// AnomalyInvalidRange specifies a candle whose high is below its
+	// low or whose open or close is outside of the high-low range.
+	AnomalyInvalidRange
+
+	// AnomalyNegativeVolume specifies a candle with negative volume.
+	AnomalyNegativeVolume
+)
+
+var (
+	// ErrInvalidAnomalyKind is returned when anomaly kind with
+	// invalid value is being used.
+	ErrInvalidAnomalyKind = newError(CodeInvalidArgument, "invalid anomaly kind")
+)
+
+// AnomalyKind specifies the type of the problem found in a candle
+// series.
+type AnomalyKind int
+
+// Validate checks whether the anomaly kind is one of supported
+// kind types or not.
+func (ak AnomalyKind) Validate() error {
+	switch ak {
+	case AnomalyMisaligned, AnomalyDuplicate, AnomalyUnordered,
+		AnomalyInvalidRange, AnomalyNegativeVolume:
+		return nil
+	default:
+		return ErrInvalidAnomalyKind
+	}
+}
+
+// MarshalText turns anomaly kind to appropriate string
+// representation.
+func (ak AnomalyKind) MarshalText() ([]byte, error) {
+	var v string
+
+	switch ak {
+	case AnomalyMisaligned:
+		v = "misaligned"
+	case AnomalyDuplicate:
+		v = "duplicate"
+	case AnomalyUnordered:
+		v = "unordered"
+	case AnomalyInvalidRange:
+		v = "invalid_range"
+	case AnomalyNegativeVolume:
+		v = "negative_volume"
+	default:
+		return nil, ErrInvalidAnomalyKind
+	}
+
+	return []byte(v), nil
+}
+
+// UnmarshalText turns string to appropriate anomaly kind value.
+func (ak *AnomalyKind) UnmarshalText(d []byte) error {
+	switch string(d) {
+	case "misaligned":
+		*ak = AnomalyMisaligned
+	case "duplicate":
+		*ak = AnomalyDuplicate
+	case "unordered":
+		*ak = AnomalyUnordered
+	case "invalid_range":
+		*ak = AnomalyInvalidRange
+	case "negative_volume":
+		*ak = AnomalyNegativeVolume
+	default:
+		return ErrInvalidAnomalyKind
+	}
+
+	return nil
+}
+
+// Anomaly describes a problem found in a single candle.
+type Anomaly struct {
+	Timestamp time.Time   `json:"timestamp" yaml:"timestamp"`
+	Kind      AnomalyKind `json:"kind" yaml:"kind"`
+}
+
+// Gaps returns time ranges within the provided time range that
+// contain no candles. Consecutive missing interval-long buckets are
+// merged into a single time range.
+func (cc Candles) Gaps(i Interval, tr TimeRange) ([]TimeRange, error) {
+	if err := i.Validate(); err != nil {
+		return nil, err
+	}
+
+	if err := tr.Validate(); err != nil {
+		return nil, err
+	}
+
+	return cc.gaps(i, tr), nil
+}
+
+// gaps returns time ranges that contain no candles. Interval and
+// time range must be valid.
+func (cc Candles) gaps(i Interval, tr TimeRange) []TimeRange {
+	present := make(map[int64]struct{}, len(cc))
+	for _, c := range cc {
+		present[i.Truncate(c.Timestamp).UnixNano()] = struct{}{}
+	}
+
+	bi := newBucketIterator(tr, i.Duration())
+
+	var res []TimeRange
+
+	for bi.Next() {
+		b := bi.Bucket()
+		if _, ok := present[b.From.UnixNano()]; ok {
+			continue
+		}
+
+		if n := len(res); n > 0 && res[n-1].To.Equal(b.From) {
+			res[n-1].To = b.To
+			continue
+		}
+
+		res = append(res, b)
+	}
+
+	return res
+}
+
+// Anomalies checks every candle for inconsistent values and for
+// timestamps that are misaligned, duplicated or out of order.
+// A single candle may have multiple anomalies.
+func (cc Candles) Anomalies(i Interval) ([]Anomaly, error) {
+	if err := i.Validate(); err != nil {
+		return nil, err
+	}
+
+	return cc.anomalies(i), nil
+}
+
+// anomalies checks the candles for anomalies. Interval must be valid.
+func (cc Candles) anomalies(i Interval) []Anomaly {
+	var res []Anomaly
+
+	add := func(c Candle, ak AnomalyKind) {
+		res = append(res, Anomaly{Timestamp: c.Timestamp, Kind: ak})
+	}
+
+	for j, c := range cc {
+		if !i.Truncate(c.Timestamp).Equal(c.Timestamp) {
+			add(c, AnomalyMisaligned)
+		}
+
+		if j > 0 {
+			switch prev := cc[j-1].Timestamp; {
+			case c.Timestamp.Equal(prev):
+				add(c, AnomalyDuplicate)
+			case c.Timestamp.Before(prev):
+				add(c, AnomalyUnordered)
+			}
+		}
+
+		if c.High.LessThan(c.Low) || outside(c.Open, c.Low, c.High) ||
+			outside(c.Close, c.Low, c.High) {
+			add(c, AnomalyInvalidRange)
+		}
+
+		if c.Volume.IsNegative() {
+			add(c, AnomalyNegativeVolume)
+		}
+	}
+
+	return res
+}
+
+// outside checks whether the value is outside of [low, high] range.
+func outside(v, low, high decimal.Decimal) bool {
+	return v.LessThan(low) || v.GreaterThan(high)
+}
+
+// QualityReport summarizes gaps and anomalies of a candle series
+// within a time range.
+type QualityReport struct {
+	// Range specifies the checked time range.
+	Range TimeRange `json:"range" yaml:"range"`
+
+	// Interval specifies the expected interval of candles.
+	Interval Interval `json:"interval" yaml:"interval"`
+
+	// Expected specifies the number of interval-long buckets within
+	// the time range.
+	Expected int `json:"expected" yaml:"expected"`
+
+	// Missing specifies the number of buckets with no candles.
+	Missing int `json:"missing" yaml:"missing"`
+
+	// Coverage specifies the percent of buckets that have candles.
+	Coverage Percent `json:"coverage" yaml:"coverage"`
+
+	// Gaps holds time ranges with no candles.
+	Gaps []TimeRange `json:"gaps" yaml:"gaps"`
+
+	// LargestGap holds the longest gap. It is zero if there are
+	// no gaps.
+	LargestGap TimeRange `json:"largest_gap" yaml:"largest_gap"`
+
+	// Anomalies holds all found anomalies.
+	Anomalies []Anomaly `json:"anomalies" yaml:"anomalies"`
+
+	// AnomalyCounts holds the number of anomalies of each kind.
+	AnomalyCounts map[AnomalyKind]int `json:"anomaly_counts" yaml:"anomaly_counts"`
+}
+
+// NewQualityReport checks the candles for gaps and anomalies within
+// the time range and returns a summary of the results. Only candles
+// within the time range are checked.
+func NewQualityReport(cc Candles, i Interval, tr TimeRange) (QualityReport, error) {
+	gg, err := cc.Gaps(i, tr)
+	if err != nil {
+		return QualityReport{}, err
+	}
+
+	var in Candles
+
+	for _, c := range cc {
+		if tr.Contains(c.Timestamp) {
+			in = append(in, c)
+		}
+	}
+
+	aa := in.anomalies(i)
+
+	res := QualityReport{
+		Range:         tr,
+		Interval:      i,
+		Gaps:          gg,
+		Anomalies:     aa,
+		AnomalyCounts: make(map[AnomalyKind]int),
+	}
+
+	bi := newBucketIterator(tr, i.Duration())
+
+	for bi.Next() {
+		res.Expected++
+	}
+
+	for _, g := range gg {
+		res.Missing += int(g.Duration() / i.Duration())
+
+		if g.Duration() > res.LargestGap.Duration() {
+			res.LargestGap = g
+		}
+	}
+
+	res.Coverage = NewPercent(decimal.NewFromInt(int64(res.Expected - res.Missing)).
+		Shift(2).Div(decimal.NewFromInt(int64(res.Expected))).Round(2))
+
+	for _, a := range aa {
+		res.AnomalyCounts[a.Kind]++
+	}
+
+	return res, nil
+}
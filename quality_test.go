@@ -0,0 +1,272 @@
+package chartype
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_AnomalyKind_Validate(t *testing.T) {
+	cc := map[string]struct {
+		Kind AnomalyKind
+		Err  error
+	}{
+		"Invalid AnomalyKind": {
+			Kind: 70,
+			Err:  ErrInvalidAnomalyKind,
+		},
+		"Successful AnomalyMisaligned validation": {
+			Kind: AnomalyMisaligned,
+		},
+		"Successful AnomalyDuplicate validation": {
+			Kind: AnomalyDuplicate,
+		},
+		"Successful AnomalyUnordered validation": {
+			Kind: AnomalyUnordered,
+		},
+		"Successful AnomalyInvalidRange validation": {
+			Kind: AnomalyInvalidRange,
+		},
+		"Successful AnomalyNegativeVolume validation": {
+			Kind: AnomalyNegativeVolume,
+		},
+	}
+
+	for cn, c := range cc {
+		c := c
+
+		t.Run(cn, func(t *testing.T) {
+			t.Parallel()
+
+			equalError(t, c.Err, c.Kind.Validate())
+		})
+	}
+}
+
+func Test_AnomalyKind_MarshalText(t *testing.T) {
+	cc := map[string]struct {
+		Kind AnomalyKind
+		Text string
+		Err  error
+	}{
+		"Invalid AnomalyKind": {
+			Kind: 70,
+			Err:  ErrInvalidAnomalyKind,
+		},
+		"Successful AnomalyMisaligned marshal": {
+			Kind: AnomalyMisaligned,
+			Text: "misaligned",
+		},
+		"Successful AnomalyDuplicate marshal": {
+			Kind: AnomalyDuplicate,
+			Text: "duplicate",
+		},
+		"Successful AnomalyUnordered marshal": {
+			Kind: AnomalyUnordered,
+			Text: "unordered",
+		},
+		"Successful AnomalyInvalidRange marshal": {
+			Kind: AnomalyInvalidRange,
+			Text: "invalid_range",
+		},
+		"Successful AnomalyNegativeVolume marshal": {
+			Kind: AnomalyNegativeVolume,
+			Text: "negative_volume",
+		},
+	}
+
+	for cn, c := range cc {
+		c := c
+
+		t.Run(cn, func(t *testing.T) {
+			t.Parallel()
+
+			d, err := c.Kind.MarshalText()
+			equalError(t, c.Err, err)
+			if err != nil {
+				return
+			}
+
+			assert.Equal(t, c.Text, string(d))
+		})
+	}
+}
+
+func Test_AnomalyKind_UnmarshalText(t *testing.T) {
+	cc := map[string]struct {
+		Text   string
+		Result AnomalyKind
+		Err    error
+	}{
+		"Invalid AnomalyKind": {
+			Text: "x",
+			Err:  ErrInvalidAnomalyKind,
+		},
+		"Successful AnomalyMisaligned unmarshal": {
+			Text:   "misaligned",
+			Result: AnomalyMisaligned,
+		},
+		"Successful AnomalyDuplicate unmarshal": {
+			Text:   "duplicate",
+			Result: AnomalyDuplicate,
+		},
+		"Successful AnomalyUnordered unmarshal": {
+			Text:   "unordered",
+			Result: AnomalyUnordered,
+		},
+		"Successful AnomalyInvalidRange unmarshal": {
+			Text:   "invalid_range",
+			Result: AnomalyInvalidRange,
+		},
+		"Successful AnomalyNegativeVolume unmarshal": {
+			Text:   "negative_volume",
+			Result: AnomalyNegativeVolume,
+		},
+	}
+
+	for cn, c := range cc {
+		c := c
+
+		t.Run(cn, func(t *testing.T) {
+			t.Parallel()
+
+			var ak AnomalyKind
+
+			err := ak.UnmarshalText([]byte(c.Text))
+			equalError(t, c.Err, err)
+			if err != nil {
+				return
+			}
+
+			assert.Equal(t, c.Result, ak)
+		})
+	}
+}
+
+func Test_Candles_Gaps(t *testing.T) {
+	tm := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	tr := TimeRange{From: tm, To: tm.Add(6 * time.Minute)}
+
+	cc := map[string]struct {
+		Candles  Candles
+		Interval Interval
+		Range    TimeRange
+		Result   []TimeRange
+		Err      error
+	}{
+		"Invalid interval": {
+			Range: tr,
+			Err:   ErrInvalidInterval,
+		},
+		"Invalid time range": {
+			Interval: IntervalMinute,
+			Err:      ErrInvalidTimeRange,
+		},
+		"No gaps": {
+			Candles: Candles{
+				testCandle(tm, 1, 1, 1, 1, 1),
+				testCandle(tm.Add(time.Minute), 1, 1, 1, 1, 1),
+			},
+			Interval: IntervalMinute,
+			Range:    TimeRange{From: tm, To: tm.Add(2 * time.Minute)},
+		},
+		"Multiple gaps": {
+			Candles: Candles{
+				testCandle(tm.Add(time.Minute), 1, 1, 1, 1, 1),
+				testCandle(tm.Add(4*time.Minute+time.Second), 1, 1, 1, 1, 1),
+			},
+			Interval: IntervalMinute,
+			Range:    tr,
+			Result: []TimeRange{
+				{From: tm, To: tm.Add(time.Minute)},
+				{From: tm.Add(2 * time.Minute), To: tm.Add(4 * time.Minute)},
+				{From: tm.Add(5 * time.Minute), To: tm.Add(6 * time.Minute)},
+			},
+		},
+	}
+
+	for cn, c := range cc {
+		c := c
+
+		t.Run(cn, func(t *testing.T) {
+			t.Parallel()
+
+			res, err := c.Candles.Gaps(c.Interval, c.Range)
+			equalError(t, c.Err, err)
+			if err != nil {
+				return
+			}
+
+			assert.Equal(t, c.Result, res)
+		})
+	}
+}
+
+func Test_Candles_Anomalies(t *testing.T) {
+	tm := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	_, err := Candles{}.Anomalies(0)
+	assert.Equal(t, ErrInvalidInterval, err)
+
+	res, err := Candles{
+		testCandle(tm, 1, 2, 1, 2, 1),
+		testCandle(tm.Add(time.Minute), 3, 2, 1, 2, 1),
+		testCandle(tm.Add(time.Minute), 1, 2, 1, 0, 1),
+		testCandle(tm, 1, 1, 2, 1, -1),
+		testCandle(tm.Add(time.Second), 1, 1, 1, 1, 1),
+	}.Anomalies(IntervalMinute)
+	assert.NoError(t, err)
+	assert.Equal(t, []Anomaly{
+		{Timestamp: tm.Add(time.Minute), Kind: AnomalyInvalidRange},
+		{Timestamp: tm.Add(time.Minute), Kind: AnomalyDuplicate},
+		{Timestamp: tm.Add(time.Minute), Kind: AnomalyInvalidRange},
+		{Timestamp: tm, Kind: AnomalyUnordered},
+		{Timestamp: tm, Kind: AnomalyInvalidRange},
+		{Timestamp: tm, Kind: AnomalyNegativeVolume},
+		{Timestamp: tm.Add(time.Second), Kind: AnomalyMisaligned},
+	}, res)
+}
+
+func Test_NewQualityReport(t *testing.T) {
+	tm := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	tr := TimeRange{From: tm, To: tm.Add(6 * time.Minute)}
+
+	_, err := NewQualityReport(nil, IntervalMinute, TimeRange{})
+	assert.Equal(t, ErrInvalidTimeRange, err)
+
+	qr, err := NewQualityReport(Candles{
+		testCandle(tm.Add(-time.Minute), 1, 1, 1, 1, -1),
+		testCandle(tm.Add(time.Minute), 1, 1, 1, 1, 1),
+		testCandle(tm.Add(4*time.Minute), 1, 1, 1, 1, -1),
+	}, IntervalMinute, tr)
+	assert.NoError(t, err)
+	assert.Equal(t, QualityReport{
+		Range:    tr,
+		Interval: IntervalMinute,
+		Expected: 6,
+		Missing:  4,
+		Coverage: NewPercent(decimal.RequireFromString("33.33")),
+		Gaps: []TimeRange{
+			{From: tm, To: tm.Add(time.Minute)},
+			{From: tm.Add(2 * time.Minute), To: tm.Add(4 * time.Minute)},
+			{From: tm.Add(5 * time.Minute), To: tm.Add(6 * time.Minute)},
+		},
+		LargestGap: TimeRange{From: tm.Add(2 * time.Minute), To: tm.Add(4 * time.Minute)},
+		Anomalies: []Anomaly{
+			{Timestamp: tm.Add(4 * time.Minute), Kind: AnomalyNegativeVolume},
+		},
+		AnomalyCounts: map[AnomalyKind]int{AnomalyNegativeVolume: 1},
+	}, qr)
+
+	d, err := json.Marshal(qr)
+	assert.NoError(t, err)
+	assert.Contains(t, string(d), `"coverage":"33.33"`)
+	assert.Contains(t, string(d), `"anomaly_counts":{"negative_volume":1}`)
+
+	var res QualityReport
+	assert.NoError(t, json.Unmarshal(d, &res))
+	assert.Equal(t, qr, res)
+}
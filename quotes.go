@@ -0,0 +1,171 @@
+package chartype
+
+import (
+	"sort"
+	"sync"
+)
+
+// Quote holds the most recent market data of a pair. Fields are nil
+// until the corresponding value is set.
+type Quote struct {
+	Ticker *Ticker `json:"ticker,omitempty" yaml:"ticker,omitempty"`
+	Candle *Candle `json:"candle,omitempty" yaml:"candle,omitempty"`
+}
+
+// copy returns a deep copy of the quote.
+func (q Quote) copy() Quote {
+	if q.Ticker != nil {
+		t := *q.Ticker
+		q.Ticker = &t
+	}
+
+	if q.Candle != nil {
+		c := copyCandle(*q.Candle)
+		q.Candle = &c
+	}
+
+	return q
+}
+
+// LatestQuotes holds the most recent ticker and candle of each pair,
+// e.g. to serve "current state" lookups of a market data service.
+// Values are swapped in atomically and copied when they are read, so
+// callers never share them with the cache or with each other.
+//
+// LatestQuotes is safe for concurrent use.
+type LatestQuotes struct {
+	mu     sync.RWMutex
+	quotes map[Pair]Quote
+}
+
+// NewLatestQuotes creates a new empty latest quotes cache.
+func NewLatestQuotes() *LatestQuotes {
+	return &LatestQuotes{quotes: make(map[Pair]Quote)}
+}
+
+// SwapTicker sets the pair's ticker and returns the previous one and
+// whether there was one.
+func (lq *LatestQuotes) SwapTicker(p Pair, t Ticker) (Ticker, bool) {
+	lq.mu.Lock()
+	defer lq.mu.Unlock()
+
+	q := lq.quotes[p]
+	prev := q.Ticker
+	q.Ticker = &t
+	lq.quotes[p] = q
+
+	if prev == nil {
+		return Ticker{}, false
+	}
+
+	return *prev, true
+}
+
+// SwapCandle sets the pair's candle and returns the previous one and
+// whether there was one.
+func (lq *LatestQuotes) SwapCandle(p Pair, c Candle) (Candle, bool) {
+	c = copyCandle(c)
+
+	lq.mu.Lock()
+	defer lq.mu.Unlock()
+
+	q := lq.quotes[p]
+	prev := q.Candle
+	q.Candle = &c
+	lq.quotes[p] = q
+
+	if prev == nil {
+		return Candle{}, false
+	}
+
+	return *prev, true
+}
+
+// Get returns a copy of the pair's quote and whether the pair has
+// one.
+func (lq *LatestQuotes) Get(p Pair) (Quote, bool) {
+	lq.mu.RLock()
+	q, ok := lq.quotes[p]
+	lq.mu.RUnlock()
+
+	return q.copy(), ok
+}
+
+// Ticker returns the pair's ticker and whether it was set.
+func (lq *LatestQuotes) Ticker(p Pair) (Ticker, bool) {
+	q, _ := lq.Get(p)
+	if q.Ticker == nil {
+		return Ticker{}, false
+	}
+
+	return *q.Ticker, true
+}
+
+// Candle returns the pair's candle and whether it was set.
+func (lq *LatestQuotes) Candle(p Pair) (Candle, bool) {
+	q, _ := lq.Get(p)
+	if q.Candle == nil {
+		return Candle{}, false
+	}
+
+	return *q.Candle, true
+}
+
+// Delete removes the pair's quote and reports whether there was one.
+func (lq *LatestQuotes) Delete(p Pair) bool {
+	lq.mu.Lock()
+	defer lq.mu.Unlock()
+
+	_, ok := lq.quotes[p]
+	delete(lq.quotes, p)
+
+	return ok
+}
+
+// Pairs returns the pairs with a quote, sorted by their string
+// representation.
+func (lq *LatestQuotes) Pairs() []Pair {
+	lq.mu.RLock()
+
+	res := make([]Pair, 0, len(lq.quotes))
+	for p := range lq.quotes {
+		res = append(res, p)
+	}
+
+	lq.mu.RUnlock()
+
+	sort.Slice(res, func(i, j int) bool {
+		return res[i].String() < res[j].String()
+	})
+
+	return res
+}
+
+// Snapshot returns copies of all quotes.
+func (lq *LatestQuotes) Snapshot() map[Pair]Quote {
+	lq.mu.RLock()
+	defer lq.mu.RUnlock()
+
+	res := make(map[Pair]Quote, len(lq.quotes))
+	for p, q := range lq.quotes {
+		res[p] = q.copy()
+	}
+
+	return res
+}
+
+// copyCandle returns a copy of the candle that does not share its
+// adjusted close and volume delta with the original.
+func copyCandle(c Candle) Candle {
+	if c.AdjClose != nil {
+		adj := *c.AdjClose
+		c.AdjClose = &adj
+	}
+
+	if c.Delta != nil {
+		d := *c.Delta
+		c.Delta = &d
+	}
+
+	return c
+}
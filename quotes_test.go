@@ -0,0 +1,106 @@
+package chartype
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_LatestQuotes(t *testing.T) {
+	tm := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	btc := Pair{Base: "BTC", Quote: "USD"}
+	eth := Pair{Base: "ETH", Quote: "USD"}
+
+	lq := NewLatestQuotes()
+
+	_, ok := lq.Get(btc)
+	assert.False(t, ok)
+
+	_, ok = lq.Ticker(btc)
+	assert.False(t, ok)
+
+	_, ok = lq.Candle(btc)
+	assert.False(t, ok)
+
+	_, ok = lq.SwapTicker(btc, Ticker{Last: decimal.NewFromInt(1)})
+	assert.False(t, ok)
+
+	prev, ok := lq.SwapTicker(btc, Ticker{Last: decimal.NewFromInt(2)})
+	assert.True(t, ok)
+	assert.Equal(t, "1", prev.Last.String())
+
+	c := testBuiltCandle(tm, 1, 1, 1, 1, 1, 1)
+	c.AdjClose = decimalPtr(1)
+
+	_, ok = lq.SwapCandle(eth, c)
+	assert.False(t, ok)
+
+	*c.AdjClose = decimal.NewFromInt(5)
+	*c.Delta = decimal.NewFromInt(-5)
+
+	pc, ok := lq.SwapCandle(eth, testCandle(tm.Add(time.Minute), 2, 2, 2, 2, 2))
+	assert.True(t, ok)
+	assert.Equal(t, "1", pc.AdjClose.String())
+	assert.Equal(t, "1", pc.Delta.String())
+
+	q, ok := lq.Get(btc)
+	assert.True(t, ok)
+	assert.Nil(t, q.Candle)
+	assert.Equal(t, "2", q.Ticker.Last.String())
+
+	q.Ticker.Last = decimal.NewFromInt(9)
+
+	tk, ok := lq.Ticker(btc)
+	assert.True(t, ok)
+	assert.Equal(t, "2", tk.Last.String())
+
+	lc, ok := lq.Candle(eth)
+	assert.True(t, ok)
+	assert.Equal(t, testCandle(tm.Add(time.Minute), 2, 2, 2, 2, 2), lc)
+
+	_, ok = lq.SwapCandle(btc, c)
+	assert.False(t, ok)
+
+	snap := lq.Snapshot()
+	assert.Len(t, snap, 2)
+
+	*snap[btc].Candle.AdjClose = decimal.NewFromInt(7)
+
+	lc, _ = lq.Candle(btc)
+	assert.Equal(t, "5", lc.AdjClose.String())
+
+	assert.Equal(t, []Pair{btc, eth}, lq.Pairs())
+
+	assert.True(t, lq.Delete(btc))
+	assert.False(t, lq.Delete(btc))
+	assert.Equal(t, []Pair{eth}, lq.Pairs())
+}
+
+func Test_LatestQuotes_Concurrency(t *testing.T) {
+	p := Pair{Base: "BTC", Quote: "USD"}
+	lq := NewLatestQuotes()
+
+	var wg sync.WaitGroup
+
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+
+		go func(i int) {
+			defer wg.Done()
+
+			for j := 0; j < 100; j++ {
+				lq.SwapTicker(p, Ticker{Last: decimal.NewFromInt(int64(i))})
+				lq.Get(p)
+				lq.Snapshot()
+			}
+		}(i)
+	}
+
+	wg.Wait()
+
+	_, ok := lq.Ticker(p)
+	assert.True(t, ok)
+}
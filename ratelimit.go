@@ -0,0 +1,202 @@
+package chartype
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"time"
+)
+
+var (
+	// ErrInvalidBurst is returned when zero or negative rate limiter
+	// burst is being used.
+	ErrInvalidBurst = newError(CodeInvalidArgument, "invalid burst")
+)
+
+// RateLimiter limits how often requests are made to a remote service.
+type RateLimiter interface {
+	// Wait blocks until a request may be made. The context's error
+	// is returned if it is done first.
+	Wait(ctx context.Context) error
+}
+
+// TokenBucket is a rate limiter that allows a request every interval
+// on average and bursts of up to burst requests after idle periods.
+//
+// TokenBucket is safe for concurrent use.
+type TokenBucket struct {
+	interval time.Duration
+	burst    int
+	now      func() time.Time
+
+	mu sync.Mutex
+
+	// tat holds the time at which the bucket becomes full again
+	// once all reserved requests are made.
+	tat time.Time
+}
+
+// NewTokenBucket creates a new full token bucket that allows a
+// request every interval and up to burst requests at once, e.g. an
+// interval of 100ms and a burst of 5 allow 10 requests per second.
+func NewTokenBucket(interval time.Duration, burst int) (*TokenBucket, error) {
+	if interval <= 0 {
+		return nil, ErrInvalidDuration
+	}
+
+	if burst <= 0 {
+		return nil, ErrInvalidBurst
+	}
+
+	return &TokenBucket{interval: interval, burst: burst, now: time.Now}, nil
+}
+
+// Wait takes a token from the bucket, waiting until one is available.
+// Requests are served in the order they arrive. A token reserved by
+// a request whose context is done first is returned to the bucket.
+func (tb *TokenBucket) Wait(ctx context.Context) error {
+	d := tb.reserve()
+	if d <= 0 {
+		return nil
+	}
+
+	t := time.NewTimer(d)
+	defer t.Stop()
+
+	select {
+	case <-ctx.Done():
+		tb.mu.Lock()
+		tb.tat = tb.tat.Add(-tb.interval)
+		tb.mu.Unlock()
+
+		return ctx.Err()
+	case <-t.C:
+		return nil
+	}
+}
+
+// reserve takes a token from the bucket and returns how long the
+// caller has to wait before using it.
+func (tb *TokenBucket) reserve() time.Duration {
+	tb.mu.Lock()
+	defer tb.mu.Unlock()
+
+	now := tb.now()
+	if tb.tat.Before(now) {
+		tb.tat = now
+	}
+
+	tb.tat = tb.tat.Add(tb.interval)
+
+	return tb.tat.Sub(now) - time.Duration(tb.burst)*tb.interval
+}
+
+// HostLimiter holds a separate rate limiter per remote host, so that
+// requests to one exchange do not use up the budget of another one.
+//
+// HostLimiter is safe for concurrent use.
+type HostLimiter struct {
+	newLimiter func(host string) RateLimiter
+
+	mu       sync.Mutex
+	limiters map[string]RateLimiter
+}
+
+// NewHostLimiter creates a new host limiter that creates rate limiters
+// of hosts without a configured one with the function. Requests to
+// such hosts are not limited if the function is nil or returns nil.
+func NewHostLimiter(newLimiter func(host string) RateLimiter) *HostLimiter {
+	return &HostLimiter{
+		newLimiter: newLimiter,
+		limiters:   make(map[string]RateLimiter),
+	}
+}
+
+// SetLimiter sets the rate limiter of the host. Requests to the host
+// are not limited if the rate limiter is nil.
+func (hl *HostLimiter) SetLimiter(host string, rl RateLimiter) {
+	hl.mu.Lock()
+	defer hl.mu.Unlock()
+
+	hl.limiters[host] = rl
+}
+
+// Wait blocks until a request to the host may be made. The context's
+// error is returned if it is done first.
+func (hl *HostLimiter) Wait(ctx context.Context, host string) error {
+	hl.mu.Lock()
+
+	rl, ok := hl.limiters[host]
+	if !ok && hl.newLimiter != nil {
+		rl = hl.newLimiter(host)
+		hl.limiters[host] = rl
+	}
+
+	hl.mu.Unlock()
+
+	if rl == nil {
+		return nil
+	}
+
+	return rl.Wait(ctx)
+}
+
+// RateLimitedTransport is an HTTP transport that waits for the host
+// limiter's permission before sending each request, e.g. to keep a
+// candle fetcher's HTTP client within exchanges' API limits.
+type RateLimitedTransport struct {
+	// Base specifies the transport that sends requests.
+	// http.DefaultTransport is used if it is nil.
+	Base http.RoundTripper
+
+	// Limiter specifies the rate limiters of requests' hosts.
+	// Requests are not limited if it is nil.
+	Limiter *HostLimiter
+}
+
+// RoundTrip waits until a request to the request's host may be made
+// and sends it with the base transport.
+func (rt *RateLimitedTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if rt.Limiter != nil {
+		if err := rt.Limiter.Wait(req.Context(), req.URL.Host); err != nil {
+			return nil, err
+		}
+	}
+
+	base := rt.Base
+	if base == nil {
+		base = http.DefaultTransport
+	}
+
+	return base.RoundTrip(req)
+}
+
+// RateLimitSource returns a candle source that waits for the rate
+// limiter's permission before each request to the source. If the
+// source is a Source, the returned one is a Source too, whose streams
+// wait for the permission the same way.
+func RateLimitSource(cs CandleSource, rl RateLimiter) CandleSource {
+	candles := CandleSourceFunc(func(ctx context.Context, cr CandleRequest) ([]Candle, error) {
+		if err := rl.Wait(ctx); err != nil {
+			return nil, err
+		}
+
+		return cs.Candles(ctx, cr)
+	})
+
+	src, ok := cs.(Source)
+	if !ok {
+		return candles
+	}
+
+	return streamingSource{
+		CandleSourceFunc: candles,
+		stream: func(ctx context.Context, cr CandleRequest) (*Subscription, error) {
+			if err := rl.Wait(ctx); err != nil {
+				return nil, err
+			}
+
+			return src.Stream(ctx, cr)
+		},
+	}
+}
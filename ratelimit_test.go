@@ -0,0 +1,336 @@
+package chartype
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// countingLimiter is a rate limiter that counts waits.
+type countingLimiter struct {
+	mu    sync.Mutex
+	waits int
+	err   error
+}
+
+func (cl *countingLimiter) Wait(context.Context) error {
+	cl.mu.Lock()
+	defer cl.mu.Unlock()
+
+	cl.waits++
+
+	return cl.err
+}
+
+func (cl *countingLimiter) Waits() int {
+	cl.mu.Lock()
+	defer cl.mu.Unlock()
+
+	return cl.waits
+}
+
+func Test_NewTokenBucket(t *testing.T) {
+	cc := map[string]struct {
+		Interval time.Duration
+		Burst    int
+		Err      error
+	}{
+		"Invalid interval": {
+			Burst: 1,
+			Err:   ErrInvalidDuration,
+		},
+		"Invalid burst": {
+			Interval: time.Second,
+			Err:      ErrInvalidBurst,
+		},
+		"Successful creation": {
+			Interval: time.Second,
+			Burst:    1,
+		},
+	}
+
+	for cn, c := range cc {
+		c := c
+
+		t.Run(cn, func(t *testing.T) {
+			t.Parallel()
+
+			tb, err := NewTokenBucket(c.Interval, c.Burst)
+			assert.Equal(t, c.Err, err)
+			if err != nil {
+				return
+			}
+
+			assert.NotNil(t, tb)
+		})
+	}
+}
+
+func Test_TokenBucket_reserve(t *testing.T) {
+	tm := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	now := tm
+
+	tb, err := NewTokenBucket(time.Second, 2)
+	require.NoError(t, err)
+
+	tb.now = func() time.Time { return now }
+
+	// burst
+	assert.True(t, tb.reserve() <= 0)
+	assert.True(t, tb.reserve() <= 0)
+
+	// queued requests
+	assert.Equal(t, time.Second, tb.reserve())
+	assert.Equal(t, 2*time.Second, tb.reserve())
+
+	now = now.Add(2500 * time.Millisecond)
+	assert.Equal(t, 500*time.Millisecond, tb.reserve())
+
+	// refilled after idle period
+	now = now.Add(time.Minute)
+	assert.True(t, tb.reserve() <= 0)
+	assert.True(t, tb.reserve() <= 0)
+	assert.Equal(t, time.Second, tb.reserve())
+}
+
+func Test_TokenBucket_Wait(t *testing.T) {
+	tb, err := NewTokenBucket(20*time.Millisecond, 1)
+	require.NoError(t, err)
+
+	start := time.Now()
+
+	assert.NoError(t, tb.Wait(context.Background()))
+	assert.NoError(t, tb.Wait(context.Background()))
+	assert.True(t, time.Since(start) >= 20*time.Millisecond)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	tb, err = NewTokenBucket(time.Hour, 1)
+	require.NoError(t, err)
+
+	assert.NoError(t, tb.Wait(ctx))
+	assert.Equal(t, context.Canceled, tb.Wait(ctx))
+
+	// token of the canceled request is returned
+	tb.mu.Lock()
+	tat := tb.tat
+	tb.mu.Unlock()
+	assert.True(t, tat.Sub(time.Now()) <= time.Hour)
+}
+
+func Test_HostLimiter_Wait(t *testing.T) {
+	cc := map[string]struct {
+		NoFactory bool
+		Host      string
+		Calls     int
+		Err       error
+		Waits     int
+		Created   map[string]int
+	}{
+		"Successful wait without a factory": {
+			NoFactory: true,
+			Host:      "a",
+			Calls:     1,
+			Created:   map[string]int{},
+		},
+		"Successful wait with a created limiter": {
+			Host:    "a",
+			Calls:   2,
+			Waits:   2,
+			Created: map[string]int{"a": 1},
+		},
+		"Successful wait without a created limiter": {
+			Host:    "c",
+			Calls:   2,
+			Created: map[string]int{"c": 1},
+		},
+		"Limiter error": {
+			Host:    "b",
+			Calls:   1,
+			Err:     assert.AnError,
+			Created: map[string]int{},
+		},
+	}
+
+	for cn, c := range cc {
+		c := c
+
+		t.Run(cn, func(t *testing.T) {
+			t.Parallel()
+
+			a, b := &countingLimiter{}, &countingLimiter{err: assert.AnError}
+			created := map[string]int{}
+
+			factory := func(host string) RateLimiter {
+				created[host]++
+
+				if host == "c" {
+					return nil
+				}
+
+				return a
+			}
+
+			if c.NoFactory {
+				factory = nil
+			}
+
+			hl := NewHostLimiter(factory)
+			hl.SetLimiter("b", b)
+
+			for i := 0; i < c.Calls; i++ {
+				assert.Equal(t, c.Err, hl.Wait(context.Background(), c.Host))
+			}
+
+			assert.Equal(t, c.Waits, a.Waits())
+			assert.Equal(t, c.Created, created)
+		})
+	}
+}
+
+func Test_RateLimitedTransport_RoundTrip(t *testing.T) {
+	cc := map[string]struct {
+		NoLimiter bool
+		Base      http.RoundTripper
+		Err       error
+		Waits     int
+	}{
+		"Limiter error": {
+			Err:   assert.AnError,
+			Waits: 1,
+		},
+		"Successful request without a limiter": {
+			NoLimiter: true,
+		},
+		"Successful request with the default transport": {
+			Waits: 1,
+		},
+		"Successful request with a custom transport": {
+			Base:  http.DefaultTransport,
+			Waits: 1,
+		},
+	}
+
+	for cn, c := range cc {
+		c := c
+
+		t.Run(cn, func(t *testing.T) {
+			t.Parallel()
+
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+				w.WriteHeader(http.StatusNoContent)
+			}))
+			defer srv.Close()
+
+			u, err := url.Parse(srv.URL)
+			require.NoError(t, err)
+
+			cl := &countingLimiter{err: c.Err}
+			hl := NewHostLimiter(nil)
+			hl.SetLimiter(u.Host, cl)
+
+			rt := &RateLimitedTransport{Base: c.Base, Limiter: hl}
+			if c.NoLimiter {
+				rt.Limiter = nil
+			}
+
+			hc := &http.Client{Transport: rt}
+
+			resp, err := hc.Get(srv.URL) //nolint:bodyclose // closed below
+			assert.Equal(t, c.Waits, cl.Waits())
+
+			if c.Err != nil {
+				assert.True(t, errors.Is(err, c.Err))
+				return
+			}
+
+			require.NoError(t, err)
+			assert.NoError(t, resp.Body.Close())
+			assert.Equal(t, http.StatusNoContent, resp.StatusCode)
+		})
+	}
+}
+
+func Test_RateLimitSource(t *testing.T) {
+	tm := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	cr := CandleRequest{
+		Pair:     Pair{Base: "BTC", Quote: "USD"},
+		Interval: IntervalMinute,
+		Range:    TimeRange{From: tm, To: tm.Add(time.Hour)},
+	}
+
+	series := []Candle{testCandle(tm, 1, 1, 1, 1, 1)}
+
+	cc := map[string]struct {
+		Stream bool
+		Err    error
+		Result []Candle
+		Calls  int
+	}{
+		"Limiter error": {
+			Err: assert.AnError,
+		},
+		"Successful retrieval": {
+			Result: series,
+			Calls:  1,
+		},
+		"Limiter error of a stream": {
+			Stream: true,
+			Err:    assert.AnError,
+		},
+		"Successful stream": {
+			Stream: true,
+			Result: series,
+			Calls:  1,
+		},
+	}
+
+	for cn, c := range cc {
+		c := c
+
+		t.Run(cn, func(t *testing.T) {
+			t.Parallel()
+
+			src := &testSource{candles: series}
+			cl := &countingLimiter{err: c.Err}
+
+			if !c.Stream {
+				rs := RateLimitSource(src, cl)
+
+				_, ok := rs.(Source)
+				assert.False(t, ok)
+
+				res, err := rs.Candles(context.Background(), cr)
+				assert.Equal(t, c.Err, err)
+				assert.Equal(t, c.Result, res)
+				assert.Equal(t, 1, cl.Waits())
+				assert.Equal(t, c.Calls, src.Calls())
+
+				return
+			}
+
+			rs, ok := RateLimitSource(ReplaySource(src), cl).(Source)
+			require.True(t, ok)
+
+			sub, err := rs.Stream(context.Background(), cr)
+			assert.Equal(t, c.Err, err)
+			assert.Equal(t, 1, cl.Waits())
+			assert.Equal(t, c.Calls, src.Calls())
+
+			if err != nil {
+				return
+			}
+
+			assert.Equal(t, c.Result, drainCandles(t, sub))
+		})
+	}
+}
@@ -0,0 +1,100 @@
+package chartype
+
+import (
+	"context"
+	"sort"
+	"time"
+)
+
+// ReadThroughSource is a candle source that serves requests from a
+// store and retrieves missing candles from a remote source, so callers
+// get complete series without managing the store themselves. Candles
+// retrieved from the source are written to the store once their
+// interval has ended; candles of the current interval are returned
+// but not stored, so they are retrieved again until they are final.
+//
+// Gaps that the source has no candles for, e.g. periods without
+// trading, are requested again by every request that covers them.
+type ReadThroughSource struct {
+	store  CandleStore
+	source CandleSource
+	now    func() time.Time
+}
+
+// NewReadThroughSource creates a new read-through source on top of
+// the store and the remote source.
+func NewReadThroughSource(cs CandleStore, src CandleSource) *ReadThroughSource {
+	return &ReadThroughSource{store: cs, source: src, now: time.Now}
+}
+
+// Candles returns candles matching the request. Stored candles are
+// combined with candles of missing sub-ranges retrieved from the
+// remote source, which are written to the store. Request's limit is
+// applied to the combined candles, oldest first.
+func (rs *ReadThroughSource) Candles(ctx context.Context, cr CandleRequest) ([]Candle, error) {
+	if err := cr.Validate(); err != nil {
+		return nil, err
+	}
+
+	res, err := rs.store.Range(cr.Pair, cr.Interval, cr.Range.From, cr.Range.To)
+	if err != nil {
+		return nil, err
+	}
+
+	gg := Candles(res).gaps(cr.Interval, cr.Range)
+	if len(gg) == 0 {
+		return limitCandles(res, cr.Limit), nil
+	}
+
+	closed := cr.Interval.Truncate(rs.now())
+
+	for _, g := range gg {
+		cc, err := rs.source.Candles(ctx, CandleRequest{Pair: cr.Pair, Interval: cr.Interval, Range: g})
+		if err != nil {
+			return nil, err
+		}
+
+		var final []Candle
+
+		for _, c := range cc {
+			if !g.Contains(c.Timestamp) {
+				continue
+			}
+
+			res = append(res, c)
+
+			if c.Timestamp.Before(closed) {
+				final = append(final, c)
+			}
+		}
+
+		if len(final) == 0 {
+			continue
+		}
+
+		if err = rs.store.Put(cr.Pair, cr.Interval, final...); err != nil {
+			return nil, err
+		}
+	}
+
+	sort.SliceStable(res, func(i, j int) bool {
+		return res[i].Timestamp.Before(res[j].Timestamp)
+	})
+
+	return limitCandles(res, cr.Limit), nil
+}
+
+// Stream retrieves candles matching the request and replays them.
+func (rs *ReadThroughSource) Stream(ctx context.Context, cr CandleRequest) (*Subscription, error) {
+	return streamCandles(ctx, rs, cr)
+}
+
+// limitCandles returns up to limit first candles. Zero limit means no
+// limit.
+func limitCandles(cc []Candle, limit int) []Candle {
+	if limit > 0 && len(cc) > limit {
+		return cc[:limit]
+	}
+
+	return cc
+}
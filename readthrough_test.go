@@ -0,0 +1,116 @@
+package chartype
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_ReadThroughSource_Candles(t *testing.T) {
+	tm := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	p := Pair{Base: "BTC", Quote: "USD"}
+	at := func(m int) time.Time {
+		return tm.Add(time.Duration(m) * time.Minute)
+	}
+
+	var series []Candle
+	for i := 0; i < 6; i++ {
+		series = append(series, testCandle(at(i), 1, 1, 1, 1, 1))
+	}
+
+	// the source does not have the last minute yet
+	src := &testSource{candles: series[:5]}
+
+	ms := NewMemoryStore()
+	require.NoError(t, ms.Put(p, IntervalMinute, series[1]))
+
+	rs := NewReadThroughSource(ms, src)
+	rs.now = func() time.Time { return at(4).Add(30 * time.Second) }
+
+	cr := CandleRequest{
+		Pair:     p,
+		Interval: IntervalMinute,
+		Range:    TimeRange{From: at(0), To: at(6)},
+	}
+
+	_, err := rs.Candles(context.Background(), CandleRequest{})
+	assert.Equal(t, ErrInvalidPair, err)
+
+	res, err := rs.Candles(context.Background(), cr)
+	require.NoError(t, err)
+	assert.Equal(t, series[:5], res)
+	assert.Equal(t, 2, src.Calls())
+
+	// candles of the current interval are not stored
+	stored, err := ms.Range(p, IntervalMinute, at(0), at(6))
+	require.NoError(t, err)
+	assert.Equal(t, series[:4], stored)
+
+	// complete ranges are served from the store
+	res, err = rs.Candles(context.Background(), CandleRequest{
+		Pair:     p,
+		Interval: IntervalMinute,
+		Range:    TimeRange{From: at(0), To: at(4)},
+		Limit:    2,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, series[:2], res)
+	assert.Equal(t, 2, src.Calls())
+
+	cr.Limit = 3
+
+	res, err = rs.Candles(context.Background(), cr)
+	require.NoError(t, err)
+	assert.Equal(t, series[:3], res)
+	assert.Equal(t, 3, src.Calls())
+
+	src.setErr(assert.AnError)
+
+	_, err = rs.Candles(context.Background(), cr)
+	assert.Equal(t, assert.AnError, err)
+
+	src.setErr(nil)
+
+	rs.store = &faultyStore{MemoryStore: NewMemoryStore(), putErr: assert.AnError}
+
+	_, err = rs.Candles(context.Background(), cr)
+	assert.Equal(t, assert.AnError, err)
+
+	rs.store = &faultyStore{MemoryStore: ms, rangeErr: assert.AnError}
+
+	_, err = rs.Candles(context.Background(), cr)
+	assert.Equal(t, assert.AnError, err)
+
+	// candles outside of the missing ranges are skipped
+	rs = NewReadThroughSource(NewMemoryStore(), CandleSourceFunc(func(context.Context, CandleRequest) ([]Candle, error) {
+		return series, nil
+	}))
+	rs.now = func() time.Time { return at(10) }
+
+	res, err = rs.Candles(context.Background(), CandleRequest{
+		Pair:     p,
+		Interval: IntervalMinute,
+		Range:    TimeRange{From: at(1), To: at(3)},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, series[1:3], res)
+}
+
+func Test_ReadThroughSource_Stream(t *testing.T) {
+	tm := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	src := &testSource{candles: []Candle{testCandle(tm, 1, 1, 1, 1, 1)}}
+
+	rs := NewReadThroughSource(NewMemoryStore(), src)
+
+	sub, err := rs.Stream(context.Background(), CandleRequest{
+		Pair:     Pair{Base: "BTC", Quote: "USD"},
+		Interval: IntervalMinute,
+		Range:    TimeRange{From: tm, To: tm.Add(time.Hour)},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, src.candles, drainCandles(t, sub))
+}
@@ -0,0 +1,90 @@
+package chartype
+
+import "github.com/shopspring/decimal"
+
+var (
+	// ErrInvalidBase is returned when a series is rebased to a
+	// non-positive base value or when the series starts with a zero
+	// value.
+	ErrInvalidBase = newError(CodeInvalidArgument, "invalid base")
+)
+
+// Rebase returns a new slice with candles whose prices are scaled so
+// that the first candle's value of the candle field equals the base,
+// e.g. 100 for relative performance charts. Volumes are not changed.
+// Volume field is not accepted.
+func Rebase(cc []Candle, cf CandleField, base decimal.Decimal) ([]Candle, error) {
+	if err := validPriceField(cf); err != nil {
+		return nil, err
+	}
+
+	if !base.IsPositive() {
+		return nil, ErrInvalidBase
+	}
+
+	if len(cc) == 0 {
+		return nil, nil
+	}
+
+	first := cf.Extract(cc[0])
+	if first.IsZero() {
+		return nil, ErrInvalidBase
+	}
+
+	scale := func(v decimal.Decimal) decimal.Decimal {
+		return v.Mul(base).Div(first)
+	}
+
+	res := make([]Candle, len(cc))
+
+	for i, c := range cc {
+		c.Open = scale(c.Open)
+		c.High = scale(c.High)
+		c.Low = scale(c.Low)
+		c.Close = scale(c.Close)
+
+		if c.AdjClose != nil {
+			adj := scale(*c.AdjClose)
+			c.AdjClose = &adj
+		}
+
+		res[i] = c
+	}
+
+	return res, nil
+}
+
+// RebaseAll aligns the series by keeping only candles whose
+// timestamps are present in all of them and then rebases each of
+// them using Rebase, so that all series start at the base value at
+// the same time. Series must contain no duplicate timestamps.
+func RebaseAll(series map[string][]Candle, cf CandleField, base decimal.Decimal) (map[string][]Candle, error) {
+	counts := make(map[int64]int)
+
+	for _, cc := range series {
+		for _, c := range cc {
+			counts[c.Timestamp.UnixNano()]++
+		}
+	}
+
+	res := make(map[string][]Candle, len(series))
+
+	for name, cc := range series {
+		var aligned []Candle
+
+		for _, c := range cc {
+			if counts[c.Timestamp.UnixNano()] == len(series) {
+				aligned = append(aligned, c)
+			}
+		}
+
+		rc, err := Rebase(aligned, cf, base)
+		if err != nil {
+			return nil, err
+		}
+
+		res[name] = rc
+	}
+
+	return res, nil
+}
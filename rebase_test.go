@@ -0,0 +1,141 @@
+package chartype
+
+import (
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Rebase(t *testing.T) {
+	tm := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	withAdj := testCandle(tm.Add(time.Hour), 30, 60, 20, 50, 7)
+	withAdj.AdjClose = decimalPtr(40)
+
+	cc := map[string]struct {
+		Candles []Candle
+		Field   CandleField
+		Base    int64
+		Result  []Candle
+		Err     error
+	}{
+		"Invalid candle field": {
+			Field: CandleVolume,
+			Base:  100,
+			Err:   ErrInvalidCandleField,
+		},
+		"Invalid base": {
+			Field: CandleClose,
+			Base:  0,
+			Err:   ErrInvalidBase,
+		},
+		"Zero first value": {
+			Candles: []Candle{testCandle(tm, 0, 10, 0, 10, 1)},
+			Field:   CandleOpen,
+			Base:    100,
+			Err:     ErrInvalidBase,
+		},
+		"No candles": {
+			Field: CandleClose,
+			Base:  100,
+		},
+		"Successful rebase": {
+			Candles: []Candle{testCandle(tm, 10, 30, 5, 20, 3), withAdj},
+			Field:   CandleClose,
+			Base:    100,
+			Result: []Candle{
+				testCandle(tm, 50, 150, 25, 100, 3),
+				func() Candle {
+					c := testCandle(tm.Add(time.Hour), 150, 300, 100, 250, 7)
+					c.AdjClose = decimalPtr(200)
+
+					return c
+				}(),
+			},
+		},
+	}
+
+	for cn, c := range cc {
+		c := c
+
+		t.Run(cn, func(t *testing.T) {
+			t.Parallel()
+
+			res, err := Rebase(c.Candles, c.Field, decimal.NewFromInt(c.Base))
+			equalError(t, c.Err, err)
+			if err != nil {
+				return
+			}
+
+			assertEqualCandles(t, c.Result, res)
+		})
+	}
+}
+
+func Test_RebaseAll(t *testing.T) {
+	tm := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	at := func(d int) time.Time {
+		return tm.AddDate(0, 0, d)
+	}
+
+	cc := map[string]struct {
+		Series map[string][]Candle
+		Result map[string][]Candle
+		Err    error
+	}{
+		"Invalid series": {
+			Series: map[string][]Candle{
+				"a": {testCandle(at(0), 1, 1, 1, 0, 1)},
+			},
+			Err: ErrInvalidBase,
+		},
+		"No series": {
+			Result: map[string][]Candle{},
+		},
+		"Successful rebase": {
+			Series: map[string][]Candle{
+				"a": {
+					testCandle(at(0), 1, 1, 1, 1, 1),
+					testCandle(at(1), 2, 2, 2, 2, 1),
+					testCandle(at(2), 3, 3, 3, 3, 1),
+				},
+				"b": {
+					testCandle(at(1), 40, 40, 40, 40, 1),
+					testCandle(at(2), 20, 20, 20, 20, 1),
+					testCandle(at(3), 10, 10, 10, 10, 1),
+				},
+			},
+			Result: map[string][]Candle{
+				"a": {
+					testCandle(at(1), 100, 100, 100, 100, 1),
+					testCandle(at(2), 150, 150, 150, 150, 1),
+				},
+				"b": {
+					testCandle(at(1), 100, 100, 100, 100, 1),
+					testCandle(at(2), 50, 50, 50, 50, 1),
+				},
+			},
+		},
+	}
+
+	for cn, c := range cc {
+		c := c
+
+		t.Run(cn, func(t *testing.T) {
+			t.Parallel()
+
+			res, err := RebaseAll(c.Series, CandleClose, decimal.NewFromInt(100))
+			equalError(t, c.Err, err)
+			if err != nil {
+				return
+			}
+
+			assert.Len(t, res, len(c.Result))
+
+			for name, exp := range c.Result {
+				assertEqualCandles(t, exp, res[name])
+			}
+		})
+	}
+}
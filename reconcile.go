@@ -0,0 +1,122 @@
+package chartype
+
+import (
+	"sort"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+// FieldDeviation describes a candle field whose values in two series
+// differ by more than the allowed tolerance.
+type FieldDeviation struct {
+	// Timestamp specifies the timestamp of the compared candles.
+	Timestamp time.Time `json:"timestamp" yaml:"timestamp"`
+
+	// Field specifies the compared candle field.
+	Field CandleField `json:"field" yaml:"field"`
+
+	// A specifies the value of the field in the first series.
+	A decimal.Decimal `json:"a" yaml:"a"`
+
+	// B specifies the value of the field in the second series.
+	B decimal.Decimal `json:"b" yaml:"b"`
+
+	// Diff specifies the absolute difference between the values.
+	Diff decimal.Decimal `json:"diff" yaml:"diff"`
+}
+
+// ReconcileReport describes differences between two candle series,
+// e.g. ones retrieved from different vendors.
+type ReconcileReport struct {
+	// Matched specifies the number of timestamps present in both
+	// series.
+	Matched int `json:"matched" yaml:"matched"`
+
+	// MissingInA specifies timestamps present only in the second
+	// series, ordered by time.
+	MissingInA []time.Time `json:"missing_in_a,omitempty" yaml:"missing_in_a,omitempty"`
+
+	// MissingInB specifies timestamps present only in the first
+	// series, ordered by time.
+	MissingInB []time.Time `json:"missing_in_b,omitempty" yaml:"missing_in_b,omitempty"`
+
+	// Deviations specifies fields of matched candles that differ
+	// beyond the tolerance, ordered by time and field.
+	Deviations []FieldDeviation `json:"deviations,omitempty" yaml:"deviations,omitempty"`
+}
+
+// Consistent checks whether the series have the same timestamps and
+// no deviations.
+func (rr ReconcileReport) Consistent() bool {
+	return len(rr.MissingInA) == 0 && len(rr.MissingInB) == 0 &&
+		len(rr.Deviations) == 0
+}
+
+// Reconcile compares two candle series and reports timestamps missing
+// from either of them as well as open, high, low, close and volume
+// values of candles with equal timestamps whose absolute difference
+// is greater than the tolerance. Adjusted close values are compared
+// only when at least one of the candles has them. Series do not have
+// to be sorted, but should contain no duplicate timestamps.
+func Reconcile(a, b []Candle, tolerance decimal.Decimal) ReconcileReport {
+	candles := make(map[int64]Candle, len(b))
+	for _, c := range b {
+		candles[c.Timestamp.UnixNano()] = c
+	}
+
+	var res ReconcileReport
+
+	for _, ca := range a {
+		k := ca.Timestamp.UnixNano()
+
+		cb, ok := candles[k]
+		if !ok {
+			res.MissingInB = append(res.MissingInB, ca.Timestamp)
+			continue
+		}
+
+		delete(candles, k)
+		res.Matched++
+
+		for _, cf := range []CandleField{CandleOpen, CandleHigh, CandleLow, CandleClose, CandleVolume, CandleAdjClose, CandleDelta} {
+			switch {
+			case cf == CandleAdjClose && ca.AdjClose == nil && cb.AdjClose == nil,
+				cf == CandleDelta && ca.Delta == nil && cb.Delta == nil:
+				continue
+			}
+
+			va, vb := cf.Extract(ca), cf.Extract(cb)
+
+			if diff := va.Sub(vb).Abs(); diff.GreaterThan(tolerance) {
+				res.Deviations = append(res.Deviations, FieldDeviation{
+					Timestamp: ca.Timestamp,
+					Field:     cf,
+					A:         va,
+					B:         vb,
+					Diff:      diff,
+				})
+			}
+		}
+	}
+
+	for _, c := range candles {
+		res.MissingInA = append(res.MissingInA, c.Timestamp)
+	}
+
+	sortTimes(res.MissingInA)
+	sortTimes(res.MissingInB)
+
+	sort.SliceStable(res.Deviations, func(i, j int) bool {
+		return res.Deviations[i].Timestamp.Before(res.Deviations[j].Timestamp)
+	})
+
+	return res
+}
+
+// sortTimes sorts timestamps in ascending order.
+func sortTimes(tt []time.Time) {
+	sort.Slice(tt, func(i, j int) bool {
+		return tt[i].Before(tt[j])
+	})
+}
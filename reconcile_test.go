@@ -0,0 +1,129 @@
+package chartype
+
+import (
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_ReconcileReport_Consistent(t *testing.T) {
+	tm := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	assert.True(t, ReconcileReport{Matched: 2}.Consistent())
+	assert.False(t, ReconcileReport{MissingInA: []time.Time{tm}}.Consistent())
+	assert.False(t, ReconcileReport{MissingInB: []time.Time{tm}}.Consistent())
+	assert.False(t, ReconcileReport{Deviations: []FieldDeviation{{Timestamp: tm}}}.Consistent())
+}
+
+func Test_Reconcile(t *testing.T) {
+	tm := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	at := func(m int) time.Time {
+		return tm.Add(time.Duration(m) * time.Minute)
+	}
+
+	withAdj := func(c Candle, v int64) Candle {
+		c.AdjClose = decimalPtr(v)
+		return c
+	}
+
+	cc := map[string]struct {
+		A      []Candle
+		B      []Candle
+		Report ReconcileReport
+	}{
+		"Empty series": {},
+		"Equal series": {
+			A: []Candle{testCandle(at(0), 10, 20, 5, 15, 100), withAdj(testCandle(at(1), 15, 20, 5, 10, 100), 9)},
+			B: []Candle{testCandle(at(0), 10, 20, 5, 15, 100), withAdj(testCandle(at(1), 15, 20, 5, 10, 100), 9)},
+			Report: ReconcileReport{
+				Matched: 2,
+			},
+		},
+		"Deviations within tolerance": {
+			A: []Candle{testCandle(at(0), 10, 20, 5, 15, 100)},
+			B: []Candle{testCandle(at(0), 11, 19, 5, 15, 101)},
+			Report: ReconcileReport{
+				Matched: 1,
+			},
+		},
+		"Missing timestamps and deviations": {
+			A: []Candle{
+				testCandle(at(3), 10, 20, 5, 15, 100),
+				testCandle(at(0), 10, 20, 5, 15, 100),
+				withAdj(testCandle(at(2), 10, 20, 5, 15, 100), 15),
+				testCandle(at(1), 10, 20, 5, 15, 100),
+			},
+			B: []Candle{
+				testCandle(at(2), 10, 20, 5, 15, 100),
+				testCandle(at(5), 10, 20, 5, 15, 100),
+				testCandle(at(0), 13, 20, 5, 15, 90),
+				testCandle(at(4), 10, 20, 5, 15, 100),
+			},
+			Report: ReconcileReport{
+				Matched:    2,
+				MissingInA: []time.Time{at(4), at(5)},
+				MissingInB: []time.Time{at(1), at(3)},
+				Deviations: []FieldDeviation{
+					{
+						Timestamp: at(0),
+						Field:     CandleOpen,
+						A:         decimal.NewFromInt(10),
+						B:         decimal.NewFromInt(13),
+						Diff:      decimal.NewFromInt(3),
+					},
+					{
+						Timestamp: at(0),
+						Field:     CandleVolume,
+						A:         decimal.NewFromInt(100),
+						B:         decimal.NewFromInt(90),
+						Diff:      decimal.NewFromInt(10),
+					},
+				},
+			},
+		},
+		"Volume delta deviation": {
+			A: []Candle{testBuiltCandle(at(0), 10, 20, 5, 15, 100, 4)},
+			B: []Candle{testCandle(at(0), 10, 20, 5, 15, 100)},
+			Report: ReconcileReport{
+				Matched: 1,
+				Deviations: []FieldDeviation{
+					{
+						Timestamp: at(0),
+						Field:     CandleDelta,
+						A:         decimal.NewFromInt(4),
+						B:         decimal.Zero,
+						Diff:      decimal.NewFromInt(4),
+					},
+				},
+			},
+		},
+		"Adjusted close deviation": {
+			A: []Candle{testCandle(at(0), 10, 20, 5, 15, 100)},
+			B: []Candle{withAdj(testCandle(at(0), 10, 20, 5, 15, 100), 12)},
+			Report: ReconcileReport{
+				Matched: 1,
+				Deviations: []FieldDeviation{
+					{
+						Timestamp: at(0),
+						Field:     CandleAdjClose,
+						A:         decimal.NewFromInt(15),
+						B:         decimal.NewFromInt(12),
+						Diff:      decimal.NewFromInt(3),
+					},
+				},
+			},
+		},
+	}
+
+	for cn, c := range cc {
+		c := c
+
+		t.Run(cn, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, c.Report, Reconcile(c.A, c.B, decimal.NewFromInt(1)))
+		})
+	}
+}
@@ -0,0 +1,244 @@
+// Package redisstream provides encoding and decoding of chartype's
+// structures to and from Redis stream entry field maps, as used by
+// XADD and returned by XREAD and XRANGE.
+//
+// Field names match the JSON field names of the structures.
+// All values are encoded as strings; timestamps are encoded as Unix
+// time in nanoseconds.
+package redisstream
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/jellydator/chartype"
+	"github.com/shopspring/decimal"
+)
+
+var (
+	// ErrMissingField is returned when entry does not contain
+	// a required field.
+	ErrMissingField = &chartype.Error{
+		Code:    chartype.CodeInvalidField,
+		Message: "missing field",
+	}
+
+	// ErrInvalidValue is returned when entry's field value is
+	// neither a string nor a byte slice.
+	ErrInvalidValue = &chartype.Error{
+		Code:    chartype.CodeParseFailure,
+		Message: "invalid field value",
+	}
+)
+
+// CandleValues returns candle's stream entry field map. The
+// "adj_close" and "delta" fields are set only if the candle has an
+// adjusted close and a volume delta value respectively.
+func CandleValues(c chartype.Candle) map[string]interface{} {
+	vv := map[string]interface{}{
+		"timestamp": formatTime(c.Timestamp),
+		"open":      c.Open.String(),
+		"high":      c.High.String(),
+		"low":       c.Low.String(),
+		"close":     c.Close.String(),
+		"volume":    c.Volume.String(),
+	}
+
+	if c.AdjClose != nil {
+		vv["adj_close"] = c.AdjClose.String()
+	}
+
+	if c.Delta != nil {
+		vv["delta"] = c.Delta.String()
+	}
+
+	return vv
+}
+
+// ParseCandle parses stream entry field map into a new candle.
+// The "adj_close" and "delta" fields are optional.
+func ParseCandle(vv map[string]interface{}) (chartype.Candle, error) {
+	e := entry{values: vv}
+
+	c := chartype.Candle{
+		Timestamp: e.time("timestamp"),
+		Open:      e.decimal("open"),
+		High:      e.decimal("high"),
+		Low:       e.decimal("low"),
+		Close:     e.decimal("close"),
+		Volume:    e.decimal("volume"),
+		AdjClose:  e.optionalDecimal("adj_close"),
+		Delta:     e.optionalDecimal("delta"),
+	}
+
+	if e.err != nil {
+		return chartype.Candle{}, e.err
+	}
+
+	return c, nil
+}
+
+// TickerValues returns ticker's stream entry field map.
+func TickerValues(t chartype.Ticker) map[string]interface{} {
+	return map[string]interface{}{
+		"last":           t.Last.String(),
+		"ask":            t.Ask.String(),
+		"bid":            t.Bid.String(),
+		"change":         t.Change.String(),
+		"percent_change": t.PercentChange.Points().String(),
+		"volume":         t.Volume.String(),
+	}
+}
+
+// ParseTicker parses stream entry field map into a new ticker.
+func ParseTicker(vv map[string]interface{}) (chartype.Ticker, error) {
+	e := entry{values: vv}
+
+	t := chartype.Ticker{
+		Last:          e.decimal("last"),
+		Ask:           e.decimal("ask"),
+		Bid:           e.decimal("bid"),
+		Change:        e.decimal("change"),
+		PercentChange: chartype.NewPercent(e.decimal("percent_change")),
+		Volume:        e.decimal("volume"),
+	}
+
+	if e.err != nil {
+		return chartype.Ticker{}, e.err
+	}
+
+	return t, nil
+}
+
+// TradeValues returns trade's stream entry field map. Trade's side
+// must be valid.
+func TradeValues(t chartype.Trade) (map[string]interface{}, error) {
+	s, err := t.Side.MarshalText()
+	if err != nil {
+		return nil, err
+	}
+
+	vv := map[string]interface{}{
+		"timestamp": formatTime(t.Timestamp),
+		"price":     t.Price.String(),
+		"amount":    t.Amount.String(),
+		"side":      string(s),
+	}
+
+	if t.ID != "" {
+		vv["id"] = t.ID
+	}
+
+	return vv, nil
+}
+
+// ParseTrade parses stream entry field map into a new trade.
+// The "id" field is optional.
+func ParseTrade(vv map[string]interface{}) (chartype.Trade, error) {
+	e := entry{values: vv}
+
+	t := chartype.Trade{
+		Timestamp: e.time("timestamp"),
+		Price:     e.decimal("price"),
+		Amount:    e.decimal("amount"),
+	}
+
+	if s := e.string("side"); e.err == nil {
+		e.err = t.Side.UnmarshalText([]byte(s))
+	}
+
+	if e.err != nil {
+		return chartype.Trade{}, e.err
+	}
+
+	if _, ok := vv["id"]; ok {
+		if t.ID = e.string("id"); e.err != nil {
+			return chartype.Trade{}, e.err
+		}
+	}
+
+	return t, nil
+}
+
+// formatTime returns time as Unix time in nanoseconds string.
+func formatTime(t time.Time) string {
+	return strconv.FormatInt(t.UnixNano(), 10)
+}
+
+// entry reads stream entry's field values. The first error is
+// remembered and all subsequent reads return zero values.
+type entry struct {
+	values map[string]interface{}
+	err    error
+}
+
+// string returns field's value as a string.
+func (e *entry) string(k string) string {
+	if e.err != nil {
+		return ""
+	}
+
+	v, ok := e.values[k]
+	if !ok {
+		e.err = fmt.Errorf("%w: %s", ErrMissingField, k)
+		return ""
+	}
+
+	switch v := v.(type) {
+	case string:
+		return v
+	case []byte:
+		return string(v)
+	default:
+		e.err = fmt.Errorf("%w: %s", ErrInvalidValue, k)
+		return ""
+	}
+}
+
+// decimal returns field's value parsed as a decimal.
+func (e *entry) decimal(k string) decimal.Decimal {
+	s := e.string(k)
+	if e.err != nil {
+		return decimal.Zero
+	}
+
+	d, err := decimal.NewFromString(s)
+	if err != nil {
+		e.err = err
+		return decimal.Zero
+	}
+
+	return d
+}
+
+// optionalDecimal returns field's value parsed as a decimal or nil
+// if the field is missing.
+func (e *entry) optionalDecimal(k string) *decimal.Decimal {
+	if _, ok := e.values[k]; !ok {
+		return nil
+	}
+
+	d := e.decimal(k)
+	if e.err != nil {
+		return nil
+	}
+
+	return &d
+}
+
+// time returns field's value parsed as Unix time in nanoseconds.
+func (e *entry) time(k string) time.Time {
+	s := e.string(k)
+	if e.err != nil {
+		return time.Time{}
+	}
+
+	n, err := strconv.ParseInt(s, 10, 64)
+	if err != nil {
+		e.err = err
+		return time.Time{}
+	}
+
+	return time.Unix(0, n).UTC()
+}
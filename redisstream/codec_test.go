@@ -0,0 +1,254 @@
+package redisstream
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/jellydator/chartype"
+	"github.com/jellydator/chartype/chartypetest"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_CandleValues(t *testing.T) {
+	c := chartype.Candle{
+		Timestamp: time.Unix(1, 5).UTC(),
+		Open:      decimal.NewFromInt(1),
+		High:      decimal.NewFromInt(2),
+		Low:       decimal.RequireFromString("0.5"),
+		Close:     decimal.NewFromInt(1),
+		Volume:    decimal.NewFromInt(10),
+	}
+
+	vv := CandleValues(c)
+	assert.Equal(t, map[string]interface{}{
+		"timestamp": "1000000005",
+		"open":      "1",
+		"high":      "2",
+		"low":       "0.5",
+		"close":     "1",
+		"volume":    "10",
+	}, vv)
+
+	res, err := ParseCandle(vv)
+	assert.NoError(t, err)
+	assert.Equal(t, c, res)
+
+	adj := decimal.RequireFromString("0.75")
+	c.AdjClose = &adj
+
+	vv = CandleValues(c)
+	assert.Equal(t, "0.75", vv["adj_close"])
+	assert.NotContains(t, vv, "delta")
+
+	res, err = ParseCandle(vv)
+	assert.NoError(t, err)
+	assert.Equal(t, c, res)
+
+	delta := decimal.RequireFromString("-2")
+	c.Delta = &delta
+
+	vv = CandleValues(c)
+	assert.Equal(t, "-2", vv["delta"])
+
+	res, err = ParseCandle(vv)
+	assert.NoError(t, err)
+	assert.Equal(t, c, res)
+}
+
+func Test_ParseCandle(t *testing.T) {
+	valid := func() map[string]interface{} {
+		return map[string]interface{}{
+			"timestamp": "1",
+			"open":      []byte("1"),
+			"high":      "2",
+			"low":       "3",
+			"close":     "4",
+			"volume":    "5",
+		}
+	}
+
+	cc := map[string]struct {
+		Modify func(vv map[string]interface{})
+		Is     error
+		Err    error
+	}{
+		"Missing field": {
+			Modify: func(vv map[string]interface{}) { delete(vv, "close") },
+			Is:     ErrMissingField,
+			Err:    assert.AnError,
+		},
+		"Invalid value type": {
+			Modify: func(vv map[string]interface{}) { vv["open"] = 1 },
+			Is:     ErrInvalidValue,
+			Err:    assert.AnError,
+		},
+		"Missing timestamp": {
+			Modify: func(vv map[string]interface{}) { delete(vv, "timestamp") },
+			Is:     ErrMissingField,
+			Err:    assert.AnError,
+		},
+		"Invalid timestamp": {
+			Modify: func(vv map[string]interface{}) { vv["timestamp"] = "x" },
+			Err:    assert.AnError,
+		},
+		"Invalid decimal": {
+			Modify: func(vv map[string]interface{}) { vv["volume"] = "x" },
+			Err:    assert.AnError,
+		},
+		"Invalid adjusted close": {
+			Modify: func(vv map[string]interface{}) { vv["adj_close"] = "x" },
+			Err:    assert.AnError,
+		},
+		"Invalid volume delta": {
+			Modify: func(vv map[string]interface{}) { vv["delta"] = "x" },
+			Err:    assert.AnError,
+		},
+		"Successful parse": {
+			Modify: func(vv map[string]interface{}) {},
+		},
+		"Successful parse with volume delta": {
+			Modify: func(vv map[string]interface{}) { vv["delta"] = "-3.5" },
+		},
+		"Successful parse with adjusted close": {
+			Modify: func(vv map[string]interface{}) { vv["adj_close"] = "3.5" },
+		},
+	}
+
+	for cn, c := range cc {
+		c := c
+
+		t.Run(cn, func(t *testing.T) {
+			t.Parallel()
+
+			vv := valid()
+			c.Modify(vv)
+
+			_, err := ParseCandle(vv)
+			chartypetest.AssertErrorEqual(t, c.Err, err)
+
+			if c.Is != nil {
+				assert.True(t, errors.Is(err, c.Is))
+				assert.Equal(t, chartype.Code(c.Is), chartype.Code(err))
+			}
+		})
+	}
+}
+
+func Test_TickerValues(t *testing.T) {
+	tk := chartype.Ticker{
+		Last:          decimal.NewFromInt(1),
+		Ask:           decimal.NewFromInt(2),
+		Bid:           decimal.NewFromInt(3),
+		Change:        decimal.NewFromInt(4),
+		PercentChange: chartype.NewPercent(decimal.NewFromInt(5)),
+		Volume:        decimal.NewFromInt(6),
+	}
+
+	vv := TickerValues(tk)
+	assert.Equal(t, "5", vv["percent_change"])
+
+	res, err := ParseTicker(vv)
+	assert.NoError(t, err)
+	assert.Equal(t, tk, res)
+
+	delete(vv, "bid")
+
+	_, err = ParseTicker(vv)
+	assert.True(t, errors.Is(err, ErrMissingField))
+}
+
+func Test_TradeValues(t *testing.T) {
+	cc := map[string]struct {
+		Trade chartype.Trade
+		Err   error
+	}{
+		"Invalid Side": {
+			Trade: chartype.Trade{},
+			Err:   chartype.ErrInvalidSide,
+		},
+		"Successful trade without ID": {
+			Trade: chartype.Trade{
+				Timestamp: time.Unix(1, 0).UTC(),
+				Price:     decimal.NewFromInt(1),
+				Amount:    decimal.NewFromInt(2),
+				Side:      chartype.SideSell,
+			},
+		},
+		"Successful trade with ID": {
+			Trade: chartype.Trade{
+				ID:        "123",
+				Timestamp: time.Unix(1, 0).UTC(),
+				Price:     decimal.NewFromInt(1),
+				Amount:    decimal.NewFromInt(2),
+				Side:      chartype.SideBuy,
+			},
+		},
+	}
+
+	for cn, c := range cc {
+		c := c
+
+		t.Run(cn, func(t *testing.T) {
+			t.Parallel()
+
+			vv, err := TradeValues(c.Trade)
+			chartypetest.AssertErrorEqual(t, c.Err, err)
+			if err != nil {
+				return
+			}
+
+			res, err := ParseTrade(vv)
+			assert.NoError(t, err)
+			assert.Equal(t, c.Trade, res)
+		})
+	}
+}
+
+func Test_ParseTrade(t *testing.T) {
+	valid := func() map[string]interface{} {
+		return map[string]interface{}{
+			"id":        "1",
+			"timestamp": "1",
+			"price":     "1",
+			"amount":    "2",
+			"side":      "buy",
+		}
+	}
+
+	cc := map[string]struct {
+		Modify func(vv map[string]interface{})
+		Err    error
+	}{
+		"Missing side": {
+			Modify: func(vv map[string]interface{}) { delete(vv, "side") },
+			Err:    assert.AnError,
+		},
+		"Invalid side": {
+			Modify: func(vv map[string]interface{}) { vv["side"] = "x" },
+			Err:    chartype.ErrInvalidSide,
+		},
+		"Invalid ID": {
+			Modify: func(vv map[string]interface{}) { vv["id"] = 1 },
+			Err:    assert.AnError,
+		},
+		"Successful parse": {
+			Modify: func(vv map[string]interface{}) {},
+		},
+	}
+
+	for cn, c := range cc {
+		c := c
+
+		t.Run(cn, func(t *testing.T) {
+			t.Parallel()
+
+			vv := valid()
+			c.Modify(vv)
+
+			_, err := ParseTrade(vv)
+			chartypetest.AssertErrorEqual(t, c.Err, err)
+		})
+	}
+}
@@ -0,0 +1,167 @@
+package chartype
+
+import (
+	"encoding/json"
+	"net/url"
+	"strconv"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+var (
+	// ErrInvalidLimit is returned when negative limit is being used.
+	ErrInvalidLimit = newError(CodeInvalidArgument, "invalid limit")
+)
+
+// CandleRequest describes which candles should be retrieved:
+// instrument, timeframe, period of time and maximum number of
+// candles.
+type CandleRequest struct {
+	Pair     Pair      `json:"pair" yaml:"pair"`
+	Interval Interval  `json:"interval" yaml:"interval"`
+	Range    TimeRange `json:"range" yaml:"range"`
+
+	// Limit specifies the maximum number of candles to retrieve.
+	// Zero means no limit.
+	Limit int `json:"limit,omitempty" yaml:"limit,omitempty"`
+}
+
+// Validate checks whether all candle request's fields are valid.
+func (cr CandleRequest) Validate() error {
+	if err := cr.Pair.Validate(); err != nil {
+		return err
+	}
+
+	if err := cr.Interval.Validate(); err != nil {
+		return err
+	}
+
+	if err := cr.Range.Validate(); err != nil {
+		return err
+	}
+
+	if cr.Limit < 0 {
+		return ErrInvalidLimit
+	}
+
+	return nil
+}
+
+// MarshalText turns candle request to URL query string
+// representation, e.g.
+// "from=2020-01-01T00:00:00Z&interval=1h&pair=BTC_USDT&to=2020-01-02T00:00:00Z".
+func (cr CandleRequest) MarshalText() ([]byte, error) {
+	if err := cr.Validate(); err != nil {
+		return nil, err
+	}
+
+	v := url.Values{}
+	v.Set("pair", cr.Pair.String())
+	v.Set("interval", cr.Interval.String())
+	v.Set("from", cr.Range.From.Format(time.RFC3339Nano))
+	v.Set("to", cr.Range.To.Format(time.RFC3339Nano))
+
+	if cr.Limit > 0 {
+		v.Set("limit", strconv.Itoa(cr.Limit))
+	}
+
+	return []byte(v.Encode()), nil
+}
+
+// UnmarshalText turns URL query string to appropriate candle
+// request value. The result is validated.
+func (cr *CandleRequest) UnmarshalText(d []byte) error {
+	v, err := url.ParseQuery(string(d))
+	if err != nil {
+		return parseFailure(err)
+	}
+
+	var ncr CandleRequest
+
+	if err = ncr.Pair.UnmarshalText([]byte(v.Get("pair"))); err != nil {
+		return err
+	}
+
+	if err = ncr.Interval.UnmarshalText([]byte(v.Get("interval"))); err != nil {
+		return err
+	}
+
+	if ncr.Range.From, err = time.Parse(time.RFC3339Nano, v.Get("from")); err != nil {
+		return parseFailure(err)
+	}
+
+	if ncr.Range.To, err = time.Parse(time.RFC3339Nano, v.Get("to")); err != nil {
+		return parseFailure(err)
+	}
+
+	if l := v.Get("limit"); l != "" {
+		if ncr.Limit, err = strconv.Atoi(l); err != nil {
+			return ErrInvalidLimit
+		}
+	}
+
+	if err = ncr.Validate(); err != nil {
+		return err
+	}
+
+	*cr = ncr
+
+	return nil
+}
+
+// plainCandleRequest is used to prevent MarshalText and UnmarshalText
+// from being used during JSON and YAML encoding and decoding.
+type plainCandleRequest CandleRequest
+
+// MarshalJSON turns candle request to JSON object representation.
+func (cr CandleRequest) MarshalJSON() ([]byte, error) {
+	if err := cr.Validate(); err != nil {
+		return nil, err
+	}
+
+	return json.Marshal(plainCandleRequest(cr))
+}
+
+// UnmarshalJSON turns JSON object to appropriate candle request
+// value. The result is validated.
+func (cr *CandleRequest) UnmarshalJSON(d []byte) error {
+	var ncr plainCandleRequest
+	if err := json.Unmarshal(d, &ncr); err != nil {
+		return err
+	}
+
+	if err := CandleRequest(ncr).Validate(); err != nil {
+		return err
+	}
+
+	*cr = CandleRequest(ncr)
+
+	return nil
+}
+
+// MarshalYAML turns candle request to YAML mapping representation.
+func (cr CandleRequest) MarshalYAML() (interface{}, error) {
+	if err := cr.Validate(); err != nil {
+		return nil, err
+	}
+
+	return plainCandleRequest(cr), nil
+}
+
+// UnmarshalYAML turns YAML mapping to appropriate candle request
+// value. The result is validated.
+func (cr *CandleRequest) UnmarshalYAML(n *yaml.Node) error {
+	var ncr plainCandleRequest
+	if err := n.Decode(&ncr); err != nil {
+		return err
+	}
+
+	if err := CandleRequest(ncr).Validate(); err != nil {
+		return err
+	}
+
+	*cr = CandleRequest(ncr)
+
+	return nil
+}
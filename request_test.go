@@ -0,0 +1,237 @@
+package chartype
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func testCandleRequest() CandleRequest {
+	tm := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	return CandleRequest{
+		Pair:     Pair{Base: "BTC", Quote: "USDT"},
+		Interval: IntervalHour,
+		Range:    TimeRange{From: tm, To: tm.Add(24 * time.Hour)},
+		Limit:    10,
+	}
+}
+
+func Test_CandleRequest_Validate(t *testing.T) {
+	cc := map[string]struct {
+		Modify func(cr *CandleRequest)
+		Err    error
+	}{
+		"Invalid Pair": {
+			Modify: func(cr *CandleRequest) { cr.Pair = Pair{} },
+			Err:    ErrInvalidPair,
+		},
+		"Invalid Interval": {
+			Modify: func(cr *CandleRequest) { cr.Interval = 0 },
+			Err:    ErrInvalidInterval,
+		},
+		"Invalid Range": {
+			Modify: func(cr *CandleRequest) { cr.Range = TimeRange{} },
+			Err:    ErrInvalidTimeRange,
+		},
+		"Invalid Limit": {
+			Modify: func(cr *CandleRequest) { cr.Limit = -1 },
+			Err:    ErrInvalidLimit,
+		},
+		"Successful validation": {
+			Modify: func(cr *CandleRequest) {},
+		},
+	}
+
+	for cn, c := range cc {
+		c := c
+
+		t.Run(cn, func(t *testing.T) {
+			t.Parallel()
+
+			cr := testCandleRequest()
+			c.Modify(&cr)
+
+			err := cr.Validate()
+			equalError(t, c.Err, err)
+		})
+	}
+}
+
+func Test_CandleRequest_MarshalText(t *testing.T) {
+	cc := map[string]struct {
+		Modify func(cr *CandleRequest)
+		Text   string
+		Err    error
+	}{
+		"Invalid CandleRequest": {
+			Modify: func(cr *CandleRequest) { cr.Limit = -1 },
+			Err:    ErrInvalidLimit,
+		},
+		"Successful marshal without limit": {
+			Modify: func(cr *CandleRequest) { cr.Limit = 0 },
+			Text:   "from=2020-01-01T00%3A00%3A00Z&interval=1h&pair=BTC_USDT&to=2020-01-02T00%3A00%3A00Z",
+		},
+		"Successful marshal with limit": {
+			Modify: func(cr *CandleRequest) {},
+			Text:   "from=2020-01-01T00%3A00%3A00Z&interval=1h&limit=10&pair=BTC_USDT&to=2020-01-02T00%3A00%3A00Z",
+		},
+	}
+
+	for cn, c := range cc {
+		c := c
+
+		t.Run(cn, func(t *testing.T) {
+			t.Parallel()
+
+			cr := testCandleRequest()
+			c.Modify(&cr)
+
+			d, err := cr.MarshalText()
+			equalError(t, c.Err, err)
+			if err != nil {
+				return
+			}
+
+			assert.Equal(t, c.Text, string(d))
+		})
+	}
+}
+
+func Test_CandleRequest_UnmarshalText(t *testing.T) {
+	cc := map[string]struct {
+		Text   string
+		Result CandleRequest
+		Err    error
+	}{
+		"Invalid query": {
+			Text: "%",
+			Err:  assert.AnError,
+		},
+		"Invalid pair": {
+			Text: "pair=BTC&interval=1h&from=2020-01-01T00:00:00Z&to=2020-01-02T00:00:00Z",
+			Err:  ErrInvalidPair,
+		},
+		"Invalid interval": {
+			Text: "pair=BTC_USDT&interval=1y&from=2020-01-01T00:00:00Z&to=2020-01-02T00:00:00Z",
+			Err:  ErrInvalidInterval,
+		},
+		"Invalid from": {
+			Text: "pair=BTC_USDT&interval=1h&from=x&to=2020-01-02T00:00:00Z",
+			Err:  assert.AnError,
+		},
+		"Invalid to": {
+			Text: "pair=BTC_USDT&interval=1h&from=2020-01-01T00:00:00Z&to=x",
+			Err:  assert.AnError,
+		},
+		"Invalid limit": {
+			Text: "pair=BTC_USDT&interval=1h&from=2020-01-01T00:00:00Z&to=2020-01-02T00:00:00Z&limit=x",
+			Err:  ErrInvalidLimit,
+		},
+		"Invalid range": {
+			Text: "pair=BTC_USDT&interval=1h&from=2020-01-02T00:00:00Z&to=2020-01-01T00:00:00Z",
+			Err:  ErrInvalidTimeRange,
+		},
+		"Successful unmarshal": {
+			Text:   "pair=BTC_USDT&interval=1h&from=2020-01-01T00:00:00Z&to=2020-01-02T00:00:00Z&limit=10",
+			Result: testCandleRequest(),
+		},
+	}
+
+	for cn, c := range cc {
+		c := c
+
+		t.Run(cn, func(t *testing.T) {
+			t.Parallel()
+
+			var cr CandleRequest
+
+			err := cr.UnmarshalText([]byte(c.Text))
+			equalError(t, c.Err, err)
+			if err != nil {
+				return
+			}
+
+			assert.Equal(t, c.Result, cr)
+		})
+	}
+}
+
+func Test_CandleRequest_MarshalJSON(t *testing.T) {
+	cc := map[string]struct {
+		Modify func(cr *CandleRequest)
+		JSON   string
+		Err    error
+	}{
+		"Invalid CandleRequest": {
+			Modify: func(cr *CandleRequest) { cr.Limit = -1 },
+			Err:    assert.AnError,
+		},
+		"Successful marshal": {
+			Modify: func(cr *CandleRequest) {},
+			JSON: `{"pair":"BTC_USDT","interval":"1h",` +
+				`"range":{"from":"2020-01-01T00:00:00Z","to":"2020-01-02T00:00:00Z"},"limit":10}`,
+		},
+	}
+
+	for cn, c := range cc {
+		c := c
+
+		t.Run(cn, func(t *testing.T) {
+			t.Parallel()
+
+			cr := testCandleRequest()
+			c.Modify(&cr)
+
+			d, err := json.Marshal(cr)
+			equalError(t, c.Err, err)
+			if err != nil {
+				return
+			}
+
+			assert.JSONEq(t, c.JSON, string(d))
+		})
+	}
+}
+
+func Test_CandleRequest_UnmarshalJSON(t *testing.T) {
+	cc := map[string]struct {
+		JSON   string
+		Result CandleRequest
+		Err    error
+	}{
+		"Invalid JSON": {
+			JSON: `{"pair":1}`,
+			Err:  assert.AnError,
+		},
+		"Invalid CandleRequest": {
+			JSON: `{"pair":"BTC_USDT","interval":"1h"}`,
+			Err:  ErrInvalidTimeRange,
+		},
+		"Successful unmarshal": {
+			JSON: `{"pair":"BTC_USDT","interval":"1h",` +
+				`"range":{"from":"2020-01-01T00:00:00Z","to":"2020-01-02T00:00:00Z"},"limit":10}`,
+			Result: testCandleRequest(),
+		},
+	}
+
+	for cn, c := range cc {
+		c := c
+
+		t.Run(cn, func(t *testing.T) {
+			t.Parallel()
+
+			var cr CandleRequest
+
+			err := json.Unmarshal([]byte(c.JSON), &cr)
+			equalError(t, c.Err, err)
+			if err != nil {
+				return
+			}
+
+			assert.Equal(t, c.Result, cr)
+		})
+	}
+}
@@ -0,0 +1,110 @@
+package chartype
+
+import "time"
+
+// Resampler aggregates candles into longer interval candles.
+type Resampler struct {
+	// Interval specifies the interval of produced candles.
+	Interval Interval
+
+	// Calendar specifies the days on which the market is closed.
+	// Candles on these days are skipped. It is optional.
+	Calendar HolidayCalendar
+
+	// Aggregators specifies custom aggregators of candle fields.
+	// Fields without an aggregator use the default ones. It is
+	// optional.
+	Aggregators map[CandleField]Aggregator
+
+	// Offset specifies how far produced candles' buckets are shifted
+	// from interval's usual boundaries, e.g. 22 hours for daily FX
+	// candles starting at 17:00 New York standard time. It should be
+	// a multiple of source candles' interval. It is optional.
+	Offset time.Duration
+
+	// Location specifies the time zone whose calendar daily and weekly
+	// buckets follow, as done by Interval.TruncateLocal. Offset is then
+	// applied in location's wall clock time, e.g. -7 hours for daily
+	// FX candles starting at 17:00 New York time regardless of
+	// daylight saving time. UTC is used if it is nil.
+	Location *time.Location
+}
+
+// Validate checks whether resampler's interval and aggregators' candle
+// fields are valid.
+func (r Resampler) Validate() error {
+	if err := r.Interval.Validate(); err != nil {
+		return err
+	}
+
+	for cf := range r.Aggregators {
+		if err := cf.Validate(); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Resample aggregates the candles into resampler's interval candles.
+// By default, the first open, the highest high, the lowest low, the
+// last close, the last adjusted close, the total volume and the total
+// volume delta of each interval are used. Candles must be sorted by
+// timestamp in ascending order. Intervals without candles are skipped.
+func (r Resampler) Resample(cc []Candle) ([]Candle, error) {
+	if err := r.Validate(); err != nil {
+		return nil, err
+	}
+
+	var res []Candle
+
+	for _, c := range cc {
+		if r.Calendar != nil && r.Calendar.IsHoliday(c.Timestamp) {
+			continue
+		}
+
+		ts := r.truncate(c.Timestamp)
+
+		n := len(res)
+		if n == 0 || !res[n-1].Timestamp.Equal(ts) {
+			c.Timestamp = ts
+			res = append(res, c)
+
+			continue
+		}
+
+		res[n-1] = r.merge(res[n-1], c)
+	}
+
+	return res, nil
+}
+
+// truncate returns the start of the resampler's bucket the time
+// belongs to.
+func (r Resampler) truncate(t time.Time) time.Time {
+	return r.Interval.TruncateLocal(t, r.Offset, r.Location)
+}
+
+// merge merges the candle into the aggregated candle field by field.
+// Adjusted close and volume delta are aggregated only if either of the
+// candles has them.
+func (r Resampler) merge(agg, c Candle) Candle {
+	res := agg
+
+	for cf := CandleOpen; cf <= CandleDelta; cf++ {
+		switch {
+		case cf == CandleAdjClose && agg.AdjClose == nil && c.AdjClose == nil,
+			cf == CandleDelta && agg.Delta == nil && c.Delta == nil:
+			continue
+		}
+
+		a := r.Aggregators[cf]
+		if a == nil {
+			a = defaultAggregator(cf)
+		}
+
+		setField(&res, cf, a.Aggregate(agg, c))
+	}
+
+	return res
+}
@@ -0,0 +1,147 @@
+package chartype
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Resampler_Resample(t *testing.T) {
+	tm := time.Date(2020, 12, 24, 0, 0, 0, 0, time.UTC)
+
+	sc, err := NewStaticCalendar(nil, "2020-12-25")
+	assert.NoError(t, err)
+
+	series := []Candle{
+		testCandle(tm, 1, 4, 1, 3, 1),
+		testCandle(tm.Add(12*time.Hour), 3, 5, 2, 4, 2),
+		testCandle(tm.Add(24*time.Hour), 4, 9, 4, 8, 3),
+		testCandle(tm.Add(48*time.Hour), 8, 8, 6, 7, 4),
+	}
+
+	cc := map[string]struct {
+		Resampler Resampler
+		Result    []Candle
+		Err       error
+	}{
+		"Invalid interval": {
+			Err: ErrInvalidInterval,
+		},
+		"Invalid aggregator candle field": {
+			Resampler: Resampler{
+				Interval:    IntervalDay,
+				Aggregators: map[CandleField]Aggregator{70: AggregateSum(CandleClose)},
+			},
+			Err: ErrInvalidCandleField,
+		},
+		"Successful resample": {
+			Resampler: Resampler{Interval: IntervalDay},
+			Result: []Candle{
+				testCandle(tm, 1, 5, 1, 4, 3),
+				testCandle(tm.Add(24*time.Hour), 4, 9, 4, 8, 3),
+				testCandle(tm.Add(48*time.Hour), 8, 8, 6, 7, 4),
+			},
+		},
+		"Successful resample with holidays": {
+			Resampler: Resampler{Interval: IntervalDay, Calendar: sc},
+			Result: []Candle{
+				testCandle(tm, 1, 5, 1, 4, 3),
+				testCandle(tm.Add(48*time.Hour), 8, 8, 6, 7, 4),
+			},
+		},
+		"Successful resample with custom aggregators": {
+			Resampler: Resampler{
+				Interval: IntervalDay,
+				Aggregators: map[CandleField]Aggregator{
+					CandleOpen:  AggregateLast(CandleOpen),
+					CandleHigh:  nil,
+					CandleClose: AggregateSum(CandleClose),
+				},
+			},
+			Result: []Candle{
+				testCandle(tm, 3, 5, 1, 7, 3),
+				testCandle(tm.Add(24*time.Hour), 4, 9, 4, 8, 3),
+				testCandle(tm.Add(48*time.Hour), 8, 8, 6, 7, 4),
+			},
+		},
+		"Successful resample with offset": {
+			Resampler: Resampler{Interval: IntervalDay, Offset: 12 * time.Hour},
+			Result: []Candle{
+				testCandle(tm.Add(-12*time.Hour), 1, 4, 1, 3, 1),
+				testCandle(tm.Add(12*time.Hour), 3, 9, 2, 8, 5),
+				testCandle(tm.Add(36*time.Hour), 8, 8, 6, 7, 4),
+			},
+		},
+	}
+
+	for cn, c := range cc {
+		c := c
+
+		t.Run(cn, func(t *testing.T) {
+			t.Parallel()
+
+			res, err := c.Resampler.Resample(series)
+			equalError(t, c.Err, err)
+			if err != nil {
+				return
+			}
+
+			assert.Equal(t, c.Result, res)
+		})
+	}
+}
+
+func Test_Resampler_Resample_AdjClose(t *testing.T) {
+	tm := time.Date(2020, 12, 24, 0, 0, 0, 0, time.UTC)
+
+	c1 := testCandle(tm, 1, 4, 1, 3, 1)
+	c2 := testCandle(tm.Add(12*time.Hour), 3, 5, 2, 4, 2)
+	c2.AdjClose = decimalPtr(2)
+	c3 := testCandle(tm.Add(24*time.Hour), 4, 9, 4, 8, 3)
+	c4 := testCandle(tm.Add(36*time.Hour), 8, 8, 6, 7, 4)
+
+	res, err := Resampler{Interval: IntervalDay}.Resample([]Candle{c1, c2, c3, c4})
+	assert.NoError(t, err)
+	assert.Len(t, res, 2)
+	assert.Equal(t, decimalPtr(2), res[0].AdjClose)
+	assert.Nil(t, res[1].AdjClose)
+}
+
+func Test_Resampler_Resample_Delta(t *testing.T) {
+	tm := time.Date(2020, 12, 24, 0, 0, 0, 0, time.UTC)
+
+	c1 := testCandle(tm, 1, 4, 1, 3, 1)
+	c2 := testBuiltCandle(tm.Add(12*time.Hour), 3, 5, 2, 4, 2, -2)
+	c3 := testBuiltCandle(tm.Add(24*time.Hour), 4, 9, 4, 8, 3, 3)
+	c4 := testBuiltCandle(tm.Add(36*time.Hour), 8, 8, 6, 7, 4, -1)
+	c5 := testCandle(tm.Add(48*time.Hour), 7, 7, 7, 7, 1)
+
+	res, err := Resampler{Interval: IntervalDay}.Resample([]Candle{c1, c2, c3, c4, c5})
+	assert.NoError(t, err)
+	assertEqualCandles(t, []Candle{
+		testBuiltCandle(tm, 1, 5, 1, 4, 3, -2),
+		testBuiltCandle(tm.Add(24*time.Hour), 4, 9, 4, 7, 7, 2),
+		testCandle(tm.Add(48*time.Hour), 7, 7, 7, 7, 1),
+	}, res)
+}
+
+func Test_Resampler_Resample_DST(t *testing.T) {
+	ny := testLocation(t, "America/New_York")
+	tm := time.Date(2020, 3, 7, 5, 0, 0, 0, time.UTC)
+
+	var series []Candle
+
+	for i := 0; i < 72; i++ {
+		series = append(series, testCandle(tm.Add(time.Duration(i)*time.Hour), 1, 1, 1, 1, 1))
+	}
+
+	res, err := Resampler{Interval: IntervalDay, Location: ny}.Resample(series)
+	assert.NoError(t, err)
+	assertEqualCandles(t, []Candle{
+		testCandle(tm, 1, 1, 1, 1, 24),
+		testCandle(tm.Add(24*time.Hour), 1, 1, 1, 1, 23),
+		testCandle(tm.Add(47*time.Hour), 1, 1, 1, 1, 24),
+		testCandle(tm.Add(71*time.Hour), 1, 1, 1, 1, 1),
+	}, res)
+}
@@ -0,0 +1,197 @@
+package chartype
+
+import (
+	"math"
+	"sort"
+	"time"
+)
+
+var (
+	// ErrInvalidRetentionPolicy is returned when retention policy
+	// without rules or with multiple rules of the same interval is
+	// being used.
+	ErrInvalidRetentionPolicy = newError(CodeInvalidArgument, "invalid retention policy")
+)
+
+// minTime is the earliest time whose nanosecond timestamp can be
+// represented, used as the start of all stored data.
+var minTime = time.Unix(0, math.MinInt64).UTC() //nolint:gochecknoglobals // time values cannot be declared as consts
+
+// RetentionRule specifies how long candles of a single interval are
+// kept in a store.
+type RetentionRule struct {
+	// Interval specifies the interval of the series.
+	Interval Interval `json:"interval" yaml:"interval"`
+
+	// MaxAge specifies how long candles are kept. Zero means that
+	// they are kept forever.
+	MaxAge time.Duration `json:"max_age" yaml:"max_age"`
+}
+
+// RetentionPolicy specifies the lifecycle of candle series in a store,
+// e.g. to keep 1m candles for 30 days and 1h candles forever:
+//
+//	RetentionPolicy{Rules: []RetentionRule{
+//		{Interval: IntervalMinute, MaxAge: 30 * 24 * time.Hour},
+//		{Interval: IntervalHour},
+//	}}
+//
+// Can be included in configuration structures.
+type RetentionPolicy struct {
+	Rules []RetentionRule `json:"rules" yaml:"rules"`
+}
+
+// Validate checks whether retention policy's rules are valid and
+// whether each interval has a single rule.
+func (rp RetentionPolicy) Validate() error {
+	if len(rp.Rules) == 0 {
+		return ErrInvalidRetentionPolicy
+	}
+
+	seen := make(map[Interval]struct{}, len(rp.Rules))
+
+	for _, r := range rp.Rules {
+		if err := r.Interval.Validate(); err != nil {
+			return err
+		}
+
+		if r.MaxAge < 0 {
+			return ErrInvalidDuration
+		}
+
+		if _, ok := seen[r.Interval]; ok {
+			return ErrInvalidRetentionPolicy
+		}
+
+		seen[r.Interval] = struct{}{}
+	}
+
+	return nil
+}
+
+// Compact applies the policy to the pairs' series in the store. All
+// pairs of the store are compacted if none are provided and the store
+// implements SeriesLister. Candles older than their rule's maximum age
+// are resampled into the closest coarser interval of the policy that
+// they can be aggregated into, if any, and deleted. Rules are applied
+// from the finest interval to the coarsest one, so expired candles
+// roll up through every retained interval.
+//
+// Only whole coarser interval candles are rolled up, so expired
+// candles of a coarser interval that has not ended yet are kept until
+// it does. Rolled-up candles do not replace existing candles of the
+// coarser series.
+func (rp RetentionPolicy) Compact(cs CandleStore, now time.Time, pp ...Pair) error {
+	if err := rp.Validate(); err != nil {
+		return err
+	}
+
+	if len(pp) == 0 {
+		sl, ok := cs.(SeriesLister)
+		if !ok {
+			return ErrNoSeries
+		}
+
+		ss, err := sl.Series()
+		if err != nil {
+			return err
+		}
+
+		pp = seriesPairs(ss)
+	}
+
+	rr := make([]RetentionRule, len(rp.Rules))
+	copy(rr, rp.Rules)
+
+	sort.Slice(rr, func(i, j int) bool {
+		return rr[i].Interval < rr[j].Interval
+	})
+
+	for _, p := range pp {
+		for j, r := range rr {
+			if r.MaxAge == 0 {
+				continue
+			}
+
+			if err := compactSeries(cs, p, r, rr[j+1:], now); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// compactSeries rolls up the pair's expired candles of the rule's
+// interval into the first of the coarser rules' intervals they can be
+// aggregated into and deletes them.
+func compactSeries(cs CandleStore, p Pair, r RetentionRule, coarser []RetentionRule, now time.Time) error {
+	cutoff := now.Add(-r.MaxAge)
+
+	for _, cr := range coarser {
+		if !cr.Interval.DivisibleBy(r.Interval) {
+			continue
+		}
+
+		cutoff = cr.Interval.Truncate(cutoff)
+
+		if err := rollUp(cs, p, r.Interval, cr.Interval, cutoff); err != nil {
+			return err
+		}
+
+		break
+	}
+
+	return cs.Delete(p, r.Interval, minTime, cutoff)
+}
+
+// rollUp resamples the pair's src interval candles before the cutoff
+// into dst interval candles and writes the ones that do not exist yet.
+func rollUp(cs CandleStore, p Pair, src, dst Interval, cutoff time.Time) error {
+	cc, err := RangeResampled(cs, p, src, dst, minTime, cutoff)
+	if err != nil || len(cc) == 0 {
+		return err
+	}
+
+	existing, err := cs.Range(p, dst, cc[0].Timestamp, cutoff)
+	if err != nil {
+		return err
+	}
+
+	present := make(map[int64]struct{}, len(existing))
+	for _, c := range existing {
+		present[c.Timestamp.UnixNano()] = struct{}{}
+	}
+
+	res := cc[:0]
+
+	for _, c := range cc {
+		if _, ok := present[c.Timestamp.UnixNano()]; !ok {
+			res = append(res, c)
+		}
+	}
+
+	if len(res) == 0 {
+		return nil
+	}
+
+	return cs.Put(p, dst, res...)
+}
+
+// seriesPairs returns unique pairs of the series in their order.
+func seriesPairs(ss []Series) []Pair {
+	seen := make(map[Pair]struct{}, len(ss))
+
+	var res []Pair
+
+	for _, s := range ss {
+		if _, ok := seen[s.Pair]; ok {
+			continue
+		}
+
+		seen[s.Pair] = struct{}{}
+		res = append(res, s.Pair)
+	}
+
+	return res
+}
@@ -0,0 +1,135 @@
+package chartype
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_RetentionPolicy_Validate(t *testing.T) {
+	cc := map[string]struct {
+		Policy RetentionPolicy
+		Err    error
+	}{
+		"No rules": {
+			Err: ErrInvalidRetentionPolicy,
+		},
+		"Invalid interval": {
+			Policy: RetentionPolicy{Rules: []RetentionRule{{}}},
+			Err:    ErrInvalidInterval,
+		},
+		"Negative max age": {
+			Policy: RetentionPolicy{Rules: []RetentionRule{{Interval: IntervalMinute, MaxAge: -1}}},
+			Err:    ErrInvalidDuration,
+		},
+		"Duplicate interval": {
+			Policy: RetentionPolicy{Rules: []RetentionRule{
+				{Interval: IntervalMinute, MaxAge: time.Hour},
+				{Interval: IntervalMinute},
+			}},
+			Err: ErrInvalidRetentionPolicy,
+		},
+		"Successful validation": {
+			Policy: RetentionPolicy{Rules: []RetentionRule{
+				{Interval: IntervalMinute, MaxAge: time.Hour},
+				{Interval: IntervalHour},
+			}},
+		},
+	}
+
+	for cn, c := range cc {
+		c := c
+
+		t.Run(cn, func(t *testing.T) {
+			t.Parallel()
+
+			equalError(t, c.Err, c.Policy.Validate())
+		})
+	}
+}
+
+func Test_RetentionPolicy_Compact(t *testing.T) {
+	tm := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	btc, eth := Pair{Base: "BTC", Quote: "USD"}, Pair{Base: "ETH", Quote: "USD"}
+
+	rp := RetentionPolicy{Rules: []RetentionRule{
+		{Interval: IntervalHour},
+		{Interval: IntervalMinute, MaxAge: 90 * time.Minute},
+		{Interval: Interval(90 * time.Second)},
+		{Interval: Interval(2 * time.Hour), MaxAge: time.Minute},
+	}}
+
+	ms := NewMemoryStore()
+
+	// 1m candles of three hours
+	for i := 0; i < 180; i++ {
+		require.NoError(t, ms.Put(btc, IntervalMinute, testCandle(tm.Add(time.Duration(i)*time.Minute), 1, 2, 1, 1, 1)))
+	}
+
+	require.NoError(t, ms.Put(btc, Interval(2*time.Hour), testCandle(tm, 1, 1, 1, 1, 1)))
+	require.NoError(t, ms.Put(eth, IntervalMinute, testCandle(tm, 1, 1, 1, 1, 1)))
+
+	// existing hour candles are kept
+	require.NoError(t, ms.Put(eth, IntervalHour, testCandle(tm, 5, 5, 5, 5, 5)))
+
+	now := tm.Add(3 * time.Hour)
+
+	assert.Equal(t, ErrInvalidRetentionPolicy, RetentionPolicy{}.Compact(ms, now))
+	assert.Equal(t, ErrNoSeries, rp.Compact(struct{ CandleStore }{ms}, now))
+	assert.Equal(t, assert.AnError, rp.Compact(listStore{CandleStore: ms, err: assert.AnError}, now))
+
+	require.NoError(t, rp.Compact(ms, now))
+
+	// minutes before the last whole hour of expired candles are rolled up
+	res, err := ms.Range(btc, IntervalMinute, minTime, now)
+	require.NoError(t, err)
+	require.Len(t, res, 120)
+	assert.Equal(t, tm.Add(time.Hour), res[0].Timestamp)
+
+	res, err = ms.Range(btc, IntervalHour, minTime, now)
+	require.NoError(t, err)
+	assertEqualCandles(t, []Candle{testCandle(tm, 1, 2, 1, 1, 60)}, res)
+
+	// intervals without coarser rules are deleted
+	res, err = ms.Range(btc, Interval(2*time.Hour), minTime, now)
+	require.NoError(t, err)
+	assert.Empty(t, res)
+
+	res, err = ms.Range(eth, IntervalHour, minTime, now)
+	require.NoError(t, err)
+	assert.Equal(t, []Candle{testCandle(tm, 5, 5, 5, 5, 5)}, res)
+
+	res, err = ms.Range(eth, IntervalMinute, minTime, now)
+	require.NoError(t, err)
+	assert.Empty(t, res)
+
+	// compacting again is a no-op
+	require.NoError(t, rp.Compact(ms, now, btc))
+
+	res, err = ms.Range(btc, IntervalMinute, minTime, now)
+	require.NoError(t, err)
+	assert.Len(t, res, 120)
+}
+
+func Test_RetentionPolicy_Compact_Errors(t *testing.T) {
+	tm := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	p := Pair{Base: "BTC", Quote: "USD"}
+
+	rp := RetentionPolicy{Rules: []RetentionRule{
+		{Interval: IntervalMinute, MaxAge: time.Hour},
+		{Interval: IntervalHour},
+	}}
+
+	ms := NewMemoryStore()
+	require.NoError(t, ms.Put(p, IntervalMinute, testCandle(tm, 1, 1, 1, 1, 1)))
+
+	now := tm.Add(3 * time.Hour)
+
+	assert.Equal(t, assert.AnError, rp.Compact(&faultyStore{MemoryStore: ms, putErr: assert.AnError}, now, p))
+	assert.Equal(t, ErrInvalidTimeRange, rp.Compact(ms, time.Time{}, p))
+
+	// existing coarser candles fail to load
+	assert.Equal(t, assert.AnError, rp.Compact(&faultyStore{MemoryStore: ms, rangeErr: assert.AnError}, now, p))
+}
@@ -0,0 +1,245 @@
+package chartype
+
+import (
+	"context"
+	"errors"
+	"math"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+var (
+	// ErrInvalidRetryPolicy is returned when retry policy with
+	// negative attempts or durations, a multiplier below one or
+	// a jitter outside of the [0, 1] range is being used.
+	ErrInvalidRetryPolicy = newError(CodeInvalidArgument, "invalid retry policy")
+)
+
+// RetryPolicy specifies how failed requests, such as candle fetches
+// from flaky exchange APIs, are retried with exponential backoff.
+// Its zero value does not retry.
+// Can be included in configuration structures.
+type RetryPolicy struct {
+	// MaxAttempts specifies the maximum number of attempts, including
+	// the first one. Zero is the same as one.
+	MaxAttempts int `json:"max_attempts" yaml:"max_attempts"`
+
+	// InitialBackoff specifies the delay before the first retry.
+	InitialBackoff time.Duration `json:"initial_backoff" yaml:"initial_backoff"`
+
+	// MaxBackoff specifies the maximum delay between attempts, unless
+	// the error asks for a longer one. Zero means no limit.
+	MaxBackoff time.Duration `json:"max_backoff" yaml:"max_backoff"`
+
+	// Multiplier specifies how much the delay grows after each retry.
+	// Zero is the same as two.
+	Multiplier float64 `json:"multiplier" yaml:"multiplier"`
+
+	// Jitter specifies the fraction of each delay that is randomized,
+	// so that many clients do not retry in lockstep, e.g. 0.2 waits
+	// between 80% and 100% of the delay.
+	Jitter float64 `json:"jitter" yaml:"jitter"`
+}
+
+// Validate checks whether retry policy's values are within their
+// ranges.
+func (rp RetryPolicy) Validate() error {
+	switch {
+	case rp.MaxAttempts < 0, rp.InitialBackoff < 0, rp.MaxBackoff < 0,
+		rp.Multiplier != 0 && rp.Multiplier < 1,
+		rp.Jitter < 0, rp.Jitter > 1:
+		return ErrInvalidRetryPolicy
+	default:
+		return nil
+	}
+}
+
+// Backoff returns the delay before the retry with the number,
+// starting at 1, without jitter.
+func (rp RetryPolicy) Backoff(retry int) time.Duration {
+	m := rp.Multiplier
+	if m == 0 {
+		m = 2
+	}
+
+	d := float64(rp.InitialBackoff) * math.Pow(m, float64(retry-1))
+
+	if rp.MaxBackoff > 0 && d > float64(rp.MaxBackoff) {
+		return rp.MaxBackoff
+	}
+
+	if d > math.MaxInt64 {
+		return math.MaxInt64
+	}
+
+	return time.Duration(d)
+}
+
+// Do calls the function until it succeeds, returns an error that is
+// not retryable as reported by IsRetryable, or the attempts run out,
+// waiting between attempts as specified by the policy or by the
+// error's RetryAfterError. The last error is returned. The context's
+// error is returned if it is done while waiting.
+func (rp RetryPolicy) Do(ctx context.Context, fn func(context.Context) error) error {
+	if err := rp.Validate(); err != nil {
+		return err
+	}
+
+	for retry := 0; ; retry++ {
+		err := fn(ctx)
+		if err == nil || retry+1 >= rp.MaxAttempts || !IsRetryable(err) {
+			return err
+		}
+
+		t := time.NewTimer(rp.delay(retry+1, err, rand.Float64())) //nolint:gosec // jitter needs no secure randomness
+
+		select {
+		case <-ctx.Done():
+			t.Stop()
+			return ctx.Err()
+		case <-t.C:
+		}
+	}
+}
+
+// delay returns the delay before the retry with the number caused by
+// the error, with the jitter fraction scaled by the random number
+// from the [0, 1) range.
+func (rp RetryPolicy) delay(retry int, err error, r float64) time.Duration {
+	d := rp.Backoff(retry)
+	d -= time.Duration(float64(d) * rp.Jitter * r)
+
+	var rae *RetryAfterError
+	if errors.As(err, &rae) && rae.Delay > d {
+		return rae.Delay
+	}
+
+	return d
+}
+
+// RetryAfterError is a retryable error that should not be retried
+// before the delay, e.g. one created from an HTTP response's
+// Retry-After header.
+type RetryAfterError struct {
+	// Delay specifies the minimum time to wait before the retry.
+	Delay time.Duration
+
+	// Err specifies the underlying error.
+	Err error
+}
+
+// Error returns the underlying error's description.
+func (e *RetryAfterError) Error() string {
+	return e.Err.Error()
+}
+
+// Unwrap returns the underlying error.
+func (e *RetryAfterError) Unwrap() error {
+	return e.Err
+}
+
+// IsRetryable checks whether the request that failed with the error
+// may succeed if it is repeated. Context errors and errors of this
+// package, such as parse failures of malformed responses, are fatal,
+// except for RetryAfterError and errors with CodeUnavailable. Errors
+// that implement Temporary() report it themselves. Other errors, such
+// as network errors, are retryable.
+func IsRetryable(err error) bool {
+	var (
+		rae *RetryAfterError
+		te  interface{ Temporary() bool }
+	)
+
+	switch {
+	case err == nil, errors.Is(err, context.Canceled), errors.Is(err, context.DeadlineExceeded):
+		return false
+	case errors.As(err, &rae):
+		return true
+	case errors.As(err, &te):
+		return te.Temporary()
+	}
+
+	c := Code(err)
+
+	return c == CodeUnknown || c == CodeUnavailable
+}
+
+// ParseRetryAfter parses the value of an HTTP Retry-After header,
+// either a number of seconds or an HTTP date, into the delay from the
+// provided time. Delays too long to be represented are clamped to the
+// longest duration. False is returned if the value is invalid.
+func ParseRetryAfter(v string, now time.Time) (time.Duration, bool) {
+	if s, err := strconv.ParseInt(v, 10, 64); err == nil {
+		switch {
+		case s < 0:
+			return 0, false
+		case s > math.MaxInt64/int64(time.Second):
+			return math.MaxInt64, true
+		}
+
+		return time.Duration(s) * time.Second, true
+	}
+
+	t, err := http.ParseTime(v)
+	if err != nil {
+		return 0, false
+	}
+
+	if d := t.Sub(now); d > 0 {
+		return d, true
+	}
+
+	return 0, true
+}
+
+// RetrySource returns a candle source that retries failed requests to
+// the source according to the retry policy. If the source is a Source,
+// the returned one is a Source too, whose streams are retried the
+// same way.
+func RetrySource(cs CandleSource, rp RetryPolicy) (CandleSource, error) {
+	if err := rp.Validate(); err != nil {
+		return nil, err
+	}
+
+	candles := CandleSourceFunc(func(ctx context.Context, cr CandleRequest) ([]Candle, error) {
+		var res []Candle
+
+		err := rp.Do(ctx, func(ctx context.Context) error {
+			cc, err := cs.Candles(ctx, cr)
+			res = cc
+
+			return err
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		return res, nil
+	})
+
+	src, ok := cs.(Source)
+	if !ok {
+		return candles, nil
+	}
+
+	return streamingSource{
+		CandleSourceFunc: candles,
+		stream: func(ctx context.Context, cr CandleRequest) (*Subscription, error) {
+			var res *Subscription
+
+			err := rp.Do(ctx, func(ctx context.Context) error {
+				sub, err := src.Stream(ctx, cr)
+				res = sub
+
+				return err
+			})
+			if err != nil {
+				return nil, err
+			}
+
+			return res, nil
+		},
+	}, nil
+}
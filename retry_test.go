@@ -0,0 +1,351 @@
+package chartype
+
+import (
+	"context"
+	"errors"
+	"math"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_RetryPolicy_Validate(t *testing.T) {
+	cc := map[string]struct {
+		Policy RetryPolicy
+		Err    error
+	}{
+		"Negative attempts": {
+			Policy: RetryPolicy{MaxAttempts: -1},
+			Err:    ErrInvalidRetryPolicy,
+		},
+		"Negative initial backoff": {
+			Policy: RetryPolicy{InitialBackoff: -1},
+			Err:    ErrInvalidRetryPolicy,
+		},
+		"Negative max backoff": {
+			Policy: RetryPolicy{MaxBackoff: -1},
+			Err:    ErrInvalidRetryPolicy,
+		},
+		"Multiplier below one": {
+			Policy: RetryPolicy{Multiplier: 0.5},
+			Err:    ErrInvalidRetryPolicy,
+		},
+		"Negative jitter": {
+			Policy: RetryPolicy{Jitter: -0.1},
+			Err:    ErrInvalidRetryPolicy,
+		},
+		"Jitter above one": {
+			Policy: RetryPolicy{Jitter: 1.1},
+			Err:    ErrInvalidRetryPolicy,
+		},
+		"Zero value": {
+			Policy: RetryPolicy{},
+		},
+		"Successful validation": {
+			Policy: RetryPolicy{
+				MaxAttempts:    5,
+				InitialBackoff: time.Second,
+				MaxBackoff:     time.Minute,
+				Multiplier:     1.5,
+				Jitter:         1,
+			},
+		},
+	}
+
+	for cn, c := range cc {
+		c := c
+
+		t.Run(cn, func(t *testing.T) {
+			t.Parallel()
+
+			equalError(t, c.Err, c.Policy.Validate())
+		})
+	}
+}
+
+func Test_RetryPolicy_Backoff(t *testing.T) {
+	rp := RetryPolicy{InitialBackoff: time.Second}
+	assert.Equal(t, time.Second, rp.Backoff(1))
+	assert.Equal(t, 2*time.Second, rp.Backoff(2))
+	assert.Equal(t, 8*time.Second, rp.Backoff(4))
+	assert.Equal(t, time.Duration(math.MaxInt64), rp.Backoff(100))
+
+	rp.Multiplier = 3
+	rp.MaxBackoff = 10 * time.Second
+	assert.Equal(t, 9*time.Second, rp.Backoff(3))
+	assert.Equal(t, 10*time.Second, rp.Backoff(4))
+}
+
+func Test_RetryPolicy_delay(t *testing.T) {
+	rp := RetryPolicy{InitialBackoff: time.Second, Jitter: 0.5}
+	assert.Equal(t, 2*time.Second, rp.delay(2, assert.AnError, 0))
+	assert.Equal(t, 1500*time.Millisecond, rp.delay(2, assert.AnError, 0.5))
+
+	// Retry-After longer than the backoff wins
+	err := &RetryAfterError{Delay: 5 * time.Second, Err: assert.AnError}
+	assert.Equal(t, 5*time.Second, rp.delay(2, err, 0))
+	assert.Equal(t, 2*time.Second, rp.delay(2, &RetryAfterError{Delay: time.Second, Err: assert.AnError}, 0))
+}
+
+func Test_RetryPolicy_Do(t *testing.T) {
+	rp := RetryPolicy{MaxAttempts: 3, InitialBackoff: time.Millisecond}
+
+	var calls int
+
+	fn := func(errs ...error) func(context.Context) error {
+		calls = 0
+
+		return func(context.Context) error {
+			calls++
+
+			if calls > len(errs) {
+				return nil
+			}
+
+			return errs[calls-1]
+		}
+	}
+
+	assert.Equal(t, ErrInvalidRetryPolicy, RetryPolicy{MaxAttempts: -1}.Do(context.Background(), fn()))
+	assert.Equal(t, 0, calls)
+
+	assert.NoError(t, rp.Do(context.Background(), fn(assert.AnError, assert.AnError)))
+	assert.Equal(t, 3, calls)
+
+	assert.Equal(t, assert.AnError, rp.Do(context.Background(), fn(assert.AnError, assert.AnError, assert.AnError)))
+	assert.Equal(t, 3, calls)
+
+	// fatal errors are not retried
+	assert.Equal(t, ErrInvalidPair, rp.Do(context.Background(), fn(ErrInvalidPair)))
+	assert.Equal(t, 1, calls)
+
+	// zero value does not retry
+	assert.Equal(t, assert.AnError, RetryPolicy{}.Do(context.Background(), fn(assert.AnError)))
+	assert.Equal(t, 1, calls)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	rp.InitialBackoff = time.Hour
+	assert.Equal(t, context.Canceled, rp.Do(ctx, fn(assert.AnError)))
+	assert.Equal(t, 1, calls)
+}
+
+func Test_RetryAfterError(t *testing.T) {
+	err := &RetryAfterError{Delay: time.Second, Err: ErrSourceUnavailable}
+	assert.Equal(t, ErrSourceUnavailable.Error(), err.Error())
+	assert.True(t, errors.Is(err, ErrSourceUnavailable))
+}
+
+func Test_IsRetryable(t *testing.T) {
+	cc := map[string]struct {
+		Err       error
+		Retryable bool
+	}{
+		"Nil": {},
+		"Context canceled": {
+			Err: context.Canceled,
+		},
+		"Deadline exceeded": {
+			Err: context.DeadlineExceeded,
+		},
+		"Retry-After": {
+			Err:       &RetryAfterError{Err: ErrInvalidPair},
+			Retryable: true,
+		},
+		"Temporary network error": {
+			Err:       &net.DNSError{IsTemporary: true},
+			Retryable: true,
+		},
+		"Permanent network error": {
+			Err: &net.DNSError{},
+		},
+		"Unavailable": {
+			Err:       ErrSourceUnavailable,
+			Retryable: true,
+		},
+		"Decode error": {
+			Err: ErrInvalidFrame,
+		},
+		"Invalid argument": {
+			Err: ErrInvalidPair,
+		},
+		"Unknown": {
+			Err:       assert.AnError,
+			Retryable: true,
+		},
+	}
+
+	for cn, c := range cc {
+		c := c
+
+		t.Run(cn, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, c.Retryable, IsRetryable(c.Err))
+		})
+	}
+}
+
+func Test_ParseRetryAfter(t *testing.T) {
+	now := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	cc := map[string]struct {
+		Value string
+		Delay time.Duration
+		OK    bool
+	}{
+		"Invalid value": {
+			Value: "soon",
+		},
+		"Negative seconds": {
+			Value: "-1",
+		},
+		"Overflowing seconds": {
+			Value: "9223372036854775807",
+			Delay: math.MaxInt64,
+			OK:    true,
+		},
+		"Largest seconds without overflow": {
+			Value: "9223372036",
+			Delay: 9223372036 * time.Second,
+			OK:    true,
+		},
+		"Seconds": {
+			Value: "120",
+			Delay: 2 * time.Minute,
+			OK:    true,
+		},
+		"Future date": {
+			Value: "Wed, 01 Jan 2020 00:00:30 GMT",
+			Delay: 30 * time.Second,
+			OK:    true,
+		},
+		"Past date": {
+			Value: "Tue, 31 Dec 2019 23:59:30 GMT",
+			OK:    true,
+		},
+	}
+
+	for cn, c := range cc {
+		c := c
+
+		t.Run(cn, func(t *testing.T) {
+			t.Parallel()
+
+			d, ok := ParseRetryAfter(c.Value, now)
+			assert.Equal(t, c.OK, ok)
+			assert.Equal(t, c.Delay, d)
+		})
+	}
+}
+
+func Test_RetrySource(t *testing.T) {
+	tm := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	cr := CandleRequest{
+		Pair:     Pair{Base: "BTC", Quote: "USD"},
+		Interval: IntervalMinute,
+		Range:    TimeRange{From: tm, To: tm.Add(time.Hour)},
+	}
+
+	_, err := RetrySource(&testSource{}, RetryPolicy{Jitter: 2})
+	assert.Equal(t, ErrInvalidRetryPolicy, err)
+
+	var calls int
+
+	src := CandleSourceFunc(func(ctx context.Context, cr CandleRequest) ([]Candle, error) {
+		calls++
+
+		if calls == 1 {
+			return nil, &RetryAfterError{Delay: time.Millisecond, Err: assert.AnError}
+		}
+
+		return []Candle{testCandle(tm, 1, 1, 1, 1, 1)}, nil
+	})
+
+	rs, err := RetrySource(src, RetryPolicy{MaxAttempts: 2})
+	require.NoError(t, err)
+
+	res, err := rs.Candles(context.Background(), cr)
+	assert.NoError(t, err)
+	assert.Equal(t, []Candle{testCandle(tm, 1, 1, 1, 1, 1)}, res)
+	assert.Equal(t, 2, calls)
+
+	rs, err = RetrySource(&testSource{err: ErrInvalidFrame}, RetryPolicy{MaxAttempts: 2})
+	require.NoError(t, err)
+
+	res, err = rs.Candles(context.Background(), cr)
+	assert.Equal(t, ErrInvalidFrame, err)
+	assert.Nil(t, res)
+
+	_, ok := rs.(Source)
+	assert.False(t, ok)
+}
+
+func Test_RetrySource_Stream(t *testing.T) {
+	tm := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	cr := CandleRequest{
+		Pair:     Pair{Base: "BTC", Quote: "USD"},
+		Interval: IntervalMinute,
+		Range:    TimeRange{From: tm, To: tm.Add(time.Hour)},
+	}
+
+	series := []Candle{testCandle(tm, 1, 1, 1, 1, 1)}
+
+	cc := map[string]struct {
+		Failures int
+		Err      error
+		Result   []Candle
+		Calls    int
+	}{
+		"Retries exhausted": {
+			Failures: 2,
+			Err:      assert.AnError,
+			Calls:    2,
+		},
+		"Successful stream after a retry": {
+			Failures: 1,
+			Result:   series,
+			Calls:    2,
+		},
+	}
+
+	for cn, c := range cc {
+		c := c
+
+		t.Run(cn, func(t *testing.T) {
+			t.Parallel()
+
+			var calls int
+
+			src := CandleSourceFunc(func(context.Context, CandleRequest) ([]Candle, error) {
+				calls++
+
+				if calls <= c.Failures {
+					return nil, &RetryAfterError{Delay: time.Millisecond, Err: assert.AnError}
+				}
+
+				return series, nil
+			})
+
+			rs, err := RetrySource(ReplaySource(src), RetryPolicy{MaxAttempts: 2})
+			require.NoError(t, err)
+
+			ss, ok := rs.(Source)
+			require.True(t, ok)
+
+			sub, err := ss.Stream(context.Background(), cr)
+			equalError(t, c.Err, err)
+			assert.Equal(t, c.Calls, calls)
+
+			if err != nil {
+				return
+			}
+
+			assert.Equal(t, c.Result, drainCandles(t, sub))
+		})
+	}
+}
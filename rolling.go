@@ -0,0 +1,160 @@
+package chartype
+
+import "github.com/shopspring/decimal"
+
+// RollingWindow maintains the high, low, sum and mean of a candle
+// field's values over the last n candles. Each update takes amortized
+// constant time.
+//
+// RollingWindow is not safe for concurrent use.
+type RollingWindow struct {
+	field  CandleField
+	values []decimal.Decimal
+	seq    int
+	sum    decimal.Decimal
+	highs  monotonicDeque
+	lows   monotonicDeque
+}
+
+// rollingEntry is a value of a monotonic deque, together with the
+// sequence number it was pushed with.
+type rollingEntry struct {
+	seq   int
+	value decimal.Decimal
+}
+
+// NewRollingWindow creates a new rolling window over the last n values
+// of the candle field.
+func NewRollingWindow(n int, cf CandleField) (*RollingWindow, error) {
+	if n <= 0 {
+		return nil, ErrInvalidLength
+	}
+
+	if err := cf.Validate(); err != nil {
+		return nil, err
+	}
+
+	return &RollingWindow{
+		field:  cf,
+		values: make([]decimal.Decimal, 0, n),
+	}, nil
+}
+
+// Push adds the candle's field value to the window, evicting the
+// oldest value if the window is full.
+func (rw *RollingWindow) Push(c Candle) {
+	rw.PushValue(rw.field.Extract(c))
+}
+
+// PushValue adds the value to the window, evicting the oldest value if
+// the window is full.
+func (rw *RollingWindow) PushValue(v decimal.Decimal) {
+	n := cap(rw.values)
+	pos := rw.seq % n
+
+	if len(rw.values) < n {
+		rw.values = append(rw.values, v)
+	} else {
+		rw.sum = rw.sum.Sub(rw.values[pos])
+		rw.values[pos] = v
+	}
+
+	rw.sum = rw.sum.Add(v)
+
+	oldest := rw.seq - n + 1
+	e := rollingEntry{seq: rw.seq, value: v}
+	rw.highs.push(e, oldest, decimal.Decimal.LessThanOrEqual)
+	rw.lows.push(e, oldest, decimal.Decimal.GreaterThanOrEqual)
+	rw.seq++
+}
+
+// Len returns the number of values in the window.
+func (rw *RollingWindow) Len() int {
+	return len(rw.values)
+}
+
+// Full checks whether the window holds n values.
+func (rw *RollingWindow) Full() bool {
+	return len(rw.values) == cap(rw.values)
+}
+
+// High returns the highest value in the window and whether the window
+// is not empty.
+func (rw *RollingWindow) High() (decimal.Decimal, bool) {
+	return rw.highs.front()
+}
+
+// Low returns the lowest value in the window and whether the window
+// is not empty.
+func (rw *RollingWindow) Low() (decimal.Decimal, bool) {
+	return rw.lows.front()
+}
+
+// Sum returns the sum of values in the window.
+func (rw *RollingWindow) Sum() decimal.Decimal {
+	return rw.sum
+}
+
+// Mean returns the mean of values in the window and whether the window
+// is not empty.
+func (rw *RollingWindow) Mean() (decimal.Decimal, bool) {
+	if len(rw.values) == 0 {
+		return decimal.Zero, false
+	}
+
+	return rw.sum.Div(decimal.NewFromInt(int64(len(rw.values)))), true
+}
+
+// Reset removes all values from the window.
+func (rw *RollingWindow) Reset() {
+	rw.values = rw.values[:0]
+	rw.seq = 0
+	rw.sum = decimal.Zero
+	rw.highs.reset()
+	rw.lows.reset()
+}
+
+// monotonicDeque holds window values in push order whose values are
+// monotonic, so that its front is the window's extreme value.
+type monotonicDeque struct {
+	entries []rollingEntry
+	head    int
+}
+
+// push appends the entry after removing entries older than the
+// oldest sequence number from the front and entries dominated by the
+// new value from the back.
+func (md *monotonicDeque) push(e rollingEntry, oldest int, dominated func(d, v decimal.Decimal) bool) {
+	for len(md.entries) > md.head && dominated(md.entries[len(md.entries)-1].value, e.value) {
+		md.entries = md.entries[:len(md.entries)-1]
+	}
+
+	for md.head < len(md.entries) && md.entries[md.head].seq < oldest {
+		md.head++
+	}
+
+	// removed front entries are compacted once they make up half of
+	// the slice, which keeps pushes amortized constant time.
+	if md.head > 0 && md.head*2 >= len(md.entries) {
+		md.entries = md.entries[:copy(md.entries, md.entries[md.head:])]
+		md.head = 0
+	}
+
+	md.entries = append(md.entries, e)
+}
+
+// front returns the first entry's value and whether the deque is not
+// empty.
+func (md *monotonicDeque) front() (decimal.Decimal, bool) {
+	if md.head == len(md.entries) {
+		return decimal.Zero, false
+	}
+
+	return md.entries[md.head].value, true
+}
+
+// reset removes all entries.
+func (md *monotonicDeque) reset() {
+	md.entries = md.entries[:0]
+	md.head = 0
+}
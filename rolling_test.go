@@ -0,0 +1,136 @@
+package chartype
+
+import (
+	"math/rand"
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_NewRollingWindow(t *testing.T) {
+	cc := map[string]struct {
+		Length int
+		Field  CandleField
+		Err    error
+	}{
+		"Invalid length": {
+			Length: 0,
+			Field:  CandleClose,
+			Err:    ErrInvalidLength,
+		},
+		"Invalid candle field": {
+			Length: 3,
+			Field:  70,
+			Err:    ErrInvalidCandleField,
+		},
+		"Successful creation": {
+			Length: 3,
+			Field:  CandleClose,
+		},
+	}
+
+	for cn, c := range cc {
+		c := c
+
+		t.Run(cn, func(t *testing.T) {
+			t.Parallel()
+
+			rw, err := NewRollingWindow(c.Length, c.Field)
+			equalError(t, c.Err, err)
+
+			if err != nil {
+				assert.Nil(t, rw)
+				return
+			}
+
+			assert.Equal(t, c.Field, rw.field)
+			assert.Equal(t, c.Length, cap(rw.values))
+		})
+	}
+}
+
+func Test_RollingWindow(t *testing.T) {
+	rw, err := NewRollingWindow(3, CandleHigh)
+	require.NoError(t, err)
+
+	assertEmpty := func() {
+		_, ok := rw.High()
+		assert.False(t, ok)
+
+		_, ok = rw.Low()
+		assert.False(t, ok)
+
+		_, ok = rw.Mean()
+		assert.False(t, ok)
+
+		assert.Equal(t, 0, rw.Len())
+		assert.False(t, rw.Full())
+		assert.True(t, rw.Sum().IsZero())
+	}
+
+	assertEmpty()
+
+	tm := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	for i, h := range []int64{4, 2, 6, 3} {
+		rw.Push(testCandle(tm.Add(time.Duration(i)*time.Minute), 1, h, 1, 1, 1))
+	}
+
+	high, ok := rw.High()
+	assert.True(t, ok)
+	assert.Equal(t, "6", high.String())
+
+	low, ok := rw.Low()
+	assert.True(t, ok)
+	assert.Equal(t, "2", low.String())
+
+	mean, ok := rw.Mean()
+	assert.True(t, ok)
+	assert.Equal(t, "3.6666666666666667", mean.String())
+
+	assert.Equal(t, "11", rw.Sum().String())
+	assert.Equal(t, 3, rw.Len())
+	assert.True(t, rw.Full())
+
+	rw.Reset()
+	assertEmpty()
+}
+
+func Test_RollingWindow_Random(t *testing.T) {
+	r := rand.New(rand.NewSource(1)) //nolint:gosec // deterministic test data
+
+	for _, n := range []int{1, 2, 5, 17} {
+		rw, err := NewRollingWindow(n, CandleClose)
+		require.NoError(t, err)
+
+		var vv []decimal.Decimal
+
+		for i := 0; i < 500; i++ {
+			v := decimal.New(r.Int63n(100), -1)
+			rw.PushValue(v)
+
+			vv = append(vv, v)
+			if len(vv) > n {
+				vv = vv[1:]
+			}
+
+			high, low, sum := vv[0], vv[0], decimal.Zero
+			for _, w := range vv {
+				high = decimal.Max(high, w)
+				low = decimal.Min(low, w)
+				sum = sum.Add(w)
+			}
+
+			h, _ := rw.High()
+			l, _ := rw.Low()
+
+			require.True(t, h.Equal(high), "n=%d i=%d", n, i)
+			require.True(t, l.Equal(low), "n=%d i=%d", n, i)
+			require.True(t, rw.Sum().Equal(sum), "n=%d i=%d", n, i)
+			require.Equal(t, len(vv), rw.Len())
+		}
+	}
+}
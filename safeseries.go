@@ -0,0 +1,208 @@
+package chartype
+
+import (
+	"sync"
+	"sync/atomic"
+)
+
+var (
+	// ErrUnorderedCandle is returned when a candle that is not newer
+	// than the last candle of a series is appended to it.
+	ErrUnorderedCandle = newError(CodeOutOfOrder, "unordered candle")
+)
+
+// SafeSeries is a candle series that is safe for concurrent use.
+//
+// The series is split into the closed history, which is immutable
+// once published, and the last, forming candle, which is the only one
+// that can be modified. History is only ever extended, so it can be
+// shared with readers without copying and read without locking while
+// the head candle is being updated.
+type SafeSeries struct {
+	// history holds the published Candles of closed candles. Its
+	// capacity is limited to its length, so later appends never
+	// change what readers see.
+	history atomic.Value
+
+	mu sync.Mutex
+
+	// closed holds closed candles. Its elements beyond the published
+	// history's length are written only by the writer.
+	closed Candles
+	head   *Candle
+}
+
+// NewSafeSeries creates a new series holding copies of the candles.
+// Candles must be sorted by timestamp in ascending order. The last
+// candle becomes the series' forming candle.
+func NewSafeSeries(cc ...Candle) *SafeSeries {
+	ss := &SafeSeries{}
+
+	if len(cc) > 0 {
+		ss.closed = make(Candles, len(cc)-1)
+		copy(ss.closed, cc)
+
+		head := cc[len(cc)-1]
+		ss.head = &head
+	}
+
+	ss.publish()
+
+	return ss
+}
+
+// Append adds the candles to the end of the series, closing the
+// previously forming candle. Each candle must be newer than the last
+// one of the series, otherwise no candle is added and
+// ErrUnorderedCandle is returned.
+func (ss *SafeSeries) Append(cc ...Candle) error {
+	ss.mu.Lock()
+	defer ss.mu.Unlock()
+
+	prev := ss.head
+
+	for i := range cc {
+		if prev != nil && !cc[i].Timestamp.After(prev.Timestamp) {
+			return ErrUnorderedCandle
+		}
+
+		prev = &cc[i]
+	}
+
+	if len(cc) == 0 {
+		return nil
+	}
+
+	if ss.head != nil {
+		ss.closed = append(ss.closed, *ss.head)
+	}
+
+	ss.closed = append(ss.closed, cc[:len(cc)-1]...)
+
+	head := cc[len(cc)-1]
+	ss.head = &head
+
+	ss.publish()
+
+	return nil
+}
+
+// ReplaceLast replaces the forming candle of the series, e.g. to
+// update an in-progress candle. The candle must have the same
+// timestamp as the last one, otherwise ErrCandleNotFound is returned.
+// The history is not affected.
+func (ss *SafeSeries) ReplaceLast(c Candle) error {
+	ss.mu.Lock()
+	defer ss.mu.Unlock()
+
+	if ss.head == nil || !ss.head.Timestamp.Equal(c.Timestamp) {
+		return ErrCandleNotFound
+	}
+
+	ss.head = &c
+
+	return nil
+}
+
+// publish makes the closed candles visible to history readers.
+// Mutex must be held.
+func (ss *SafeSeries) publish() {
+	n := len(ss.closed)
+	ss.history.Store(ss.closed[:n:n])
+}
+
+// Len returns the number of candles in the series.
+func (ss *SafeSeries) Len() int {
+	ss.mu.Lock()
+	defer ss.mu.Unlock()
+
+	if ss.head == nil {
+		return 0
+	}
+
+	return len(ss.closed) + 1
+}
+
+// Last returns the last candle of the series and whether the series
+// is not empty.
+func (ss *SafeSeries) Last() (Candle, bool) {
+	ss.mu.Lock()
+	defer ss.mu.Unlock()
+
+	if ss.head == nil {
+		return Candle{}, false
+	}
+
+	return *ss.head, true
+}
+
+// History returns an immutable view of the series' closed candles,
+// i.e. all candles but the forming one. It does not lock the series,
+// so it never waits for writers.
+func (ss *SafeSeries) History() SeriesSnapshot {
+	return SeriesSnapshot{history: ss.history.Load().(Candles)}
+}
+
+// Snapshot returns an immutable view of the series' current candles,
+// including the forming one. Taking a snapshot does not copy the
+// history.
+func (ss *SafeSeries) Snapshot() SeriesSnapshot {
+	ss.mu.Lock()
+	defer ss.mu.Unlock()
+
+	return SeriesSnapshot{
+		history: ss.history.Load().(Candles),
+		head:    ss.head,
+	}
+}
+
+// SeriesSnapshot is an immutable view of a series at a point in
+// time. It is safe for concurrent use.
+type SeriesSnapshot struct {
+	history Candles
+	head    *Candle
+}
+
+// Len returns the number of candles in the snapshot.
+func (sn SeriesSnapshot) Len() int {
+	if sn.head == nil {
+		return len(sn.history)
+	}
+
+	return len(sn.history) + 1
+}
+
+// At returns the i-th candle of the snapshot. It panics if i is out
+// of range.
+func (sn SeriesSnapshot) At(i int) Candle {
+	if i == len(sn.history) && sn.head != nil {
+		return *sn.head
+	}
+
+	return sn.history[i]
+}
+
+// Last returns the last candle of the snapshot and whether the
+// snapshot is not empty.
+func (sn SeriesSnapshot) Last() (Candle, bool) {
+	switch {
+	case sn.head != nil:
+		return *sn.head, true
+	case len(sn.history) > 0:
+		return sn.history[len(sn.history)-1], true
+	default:
+		return Candle{}, false
+	}
+}
+
+// Candles returns a copy of all snapshot's candles.
+func (sn SeriesSnapshot) Candles() Candles {
+	res := make(Candles, len(sn.history), sn.Len())
+	copy(res, sn.history)
+
+	if sn.head != nil {
+		res = append(res, *sn.head)
+	}
+
+	return res
+}
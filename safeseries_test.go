@@ -0,0 +1,247 @@
+package chartype
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_SafeSeries(t *testing.T) {
+	tm := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	at := func(m int) time.Time {
+		return tm.Add(time.Duration(m) * time.Minute)
+	}
+
+	ss := NewSafeSeries()
+	assert.Equal(t, 0, ss.Len())
+
+	_, ok := ss.Last()
+	assert.False(t, ok)
+
+	cc := []Candle{testCandle(at(0), 1, 1, 1, 1, 1)}
+	ss = NewSafeSeries(cc...)
+	cc[0] = Candle{}
+
+	assert.NoError(t, ss.Append(
+		testCandle(at(1), 2, 2, 2, 2, 2),
+		testCandle(at(2), 4, 4, 4, 4, 4),
+	))
+
+	snap := ss.Snapshot()
+	assert.NoError(t, ss.ReplaceLast(testCandle(at(2), 5, 5, 5, 5, 5)))
+	assert.NoError(t, ss.ReplaceLast(testCandle(at(2), 6, 6, 6, 6, 6)))
+	assert.NoError(t, ss.Append(testCandle(at(3), 7, 7, 7, 7, 7)))
+	assert.NoError(t, ss.ReplaceLast(testCandle(at(3), 5, 5, 5, 5, 5)))
+
+	assert.Equal(t, Candles{
+		testCandle(at(0), 1, 1, 1, 1, 1),
+		testCandle(at(1), 2, 2, 2, 2, 2),
+		testCandle(at(2), 4, 4, 4, 4, 4),
+	}, snap.Candles())
+	assert.Equal(t, 4, ss.Len())
+
+	c, ok := ss.Last()
+	assert.True(t, ok)
+	assert.Equal(t, testCandle(at(3), 5, 5, 5, 5, 5), c)
+}
+
+func Test_SafeSeries_Append(t *testing.T) {
+	tm := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	at := func(m int) time.Time {
+		return tm.Add(time.Duration(m) * time.Minute)
+	}
+
+	cc := map[string]struct {
+		Initial []Candle
+		Candles []Candle
+		Result  Candles
+		Err     error
+	}{
+		"Candle not newer than the last one": {
+			Initial: []Candle{testCandle(at(0), 1, 1, 1, 1, 1)},
+			Candles: []Candle{testCandle(at(0), 2, 2, 2, 2, 2)},
+			Result:  Candles{testCandle(at(0), 1, 1, 1, 1, 1)},
+			Err:     ErrUnorderedCandle,
+		},
+		"Unordered candles": {
+			Initial: []Candle{testCandle(at(0), 1, 1, 1, 1, 1)},
+			Candles: []Candle{
+				testCandle(at(2), 2, 2, 2, 2, 2),
+				testCandle(at(1), 2, 2, 2, 2, 2),
+			},
+			Result: Candles{testCandle(at(0), 1, 1, 1, 1, 1)},
+			Err:    ErrUnorderedCandle,
+		},
+		"Successful append without candles": {
+			Initial: []Candle{testCandle(at(0), 1, 1, 1, 1, 1)},
+			Result:  Candles{testCandle(at(0), 1, 1, 1, 1, 1)},
+		},
+		"Successful append to empty series": {
+			Candles: []Candle{testCandle(at(0), 1, 1, 1, 1, 1)},
+			Result:  Candles{testCandle(at(0), 1, 1, 1, 1, 1)},
+		},
+		"Successful append": {
+			Initial: []Candle{testCandle(at(0), 1, 1, 1, 1, 1)},
+			Candles: []Candle{
+				testCandle(at(1), 2, 2, 2, 2, 2),
+				testCandle(at(2), 3, 3, 3, 3, 3),
+			},
+			Result: Candles{
+				testCandle(at(0), 1, 1, 1, 1, 1),
+				testCandle(at(1), 2, 2, 2, 2, 2),
+				testCandle(at(2), 3, 3, 3, 3, 3),
+			},
+		},
+	}
+
+	for cn, c := range cc {
+		c := c
+
+		t.Run(cn, func(t *testing.T) {
+			t.Parallel()
+
+			ss := NewSafeSeries(c.Initial...)
+			assert.Equal(t, c.Err, ss.Append(c.Candles...))
+			assert.Equal(t, c.Result, ss.Snapshot().Candles())
+		})
+	}
+}
+
+func Test_SafeSeries_ReplaceLast(t *testing.T) {
+	tm := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	cc := map[string]struct {
+		Initial []Candle
+		Candle  Candle
+		Result  Candles
+		Err     error
+	}{
+		"Empty series": {
+			Candle: testCandle(tm, 1, 1, 1, 1, 1),
+			Result: Candles{},
+			Err:    ErrCandleNotFound,
+		},
+		"Different timestamp": {
+			Initial: []Candle{testCandle(tm, 1, 1, 1, 1, 1)},
+			Candle:  testCandle(tm.Add(time.Minute), 2, 2, 2, 2, 2),
+			Result:  Candles{testCandle(tm, 1, 1, 1, 1, 1)},
+			Err:     ErrCandleNotFound,
+		},
+		"Successful replace": {
+			Initial: []Candle{
+				testCandle(tm, 1, 1, 1, 1, 1),
+				testCandle(tm.Add(time.Minute), 2, 2, 2, 2, 2),
+			},
+			Candle: testCandle(tm.Add(time.Minute), 3, 3, 3, 3, 3),
+			Result: Candles{
+				testCandle(tm, 1, 1, 1, 1, 1),
+				testCandle(tm.Add(time.Minute), 3, 3, 3, 3, 3),
+			},
+		},
+	}
+
+	for cn, c := range cc {
+		c := c
+
+		t.Run(cn, func(t *testing.T) {
+			t.Parallel()
+
+			ss := NewSafeSeries(c.Initial...)
+			assert.Equal(t, c.Err, ss.ReplaceLast(c.Candle))
+			assert.Equal(t, c.Result, ss.Snapshot().Candles())
+		})
+	}
+}
+
+func Test_SafeSeries_History(t *testing.T) {
+	tm := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	ss := NewSafeSeries()
+	assert.Equal(t, 0, ss.History().Len())
+
+	ss = NewSafeSeries(testCandle(tm, 1, 1, 1, 1, 1), testCandle(tm.Add(time.Minute), 2, 2, 2, 2, 2))
+
+	h := ss.History()
+	assert.Equal(t, Candles{testCandle(tm, 1, 1, 1, 1, 1)}, h.Candles())
+
+	assert.NoError(t, ss.ReplaceLast(testCandle(tm.Add(time.Minute), 3, 3, 3, 3, 3)))
+	assert.Equal(t, 1, ss.History().Len())
+
+	assert.NoError(t, ss.Append(testCandle(tm.Add(2*time.Minute), 4, 4, 4, 4, 4)))
+	assert.Equal(t, 1, h.Len())
+
+	c, ok := ss.History().Last()
+	assert.True(t, ok)
+	assert.Equal(t, testCandle(tm.Add(time.Minute), 3, 3, 3, 3, 3), c)
+
+	sn := ss.Snapshot()
+	assert.Equal(t, 3, sn.Len())
+	assert.Equal(t, testCandle(tm.Add(time.Minute), 3, 3, 3, 3, 3), sn.At(1))
+	assert.Equal(t, testCandle(tm.Add(2*time.Minute), 4, 4, 4, 4, 4), sn.At(2))
+}
+
+func Test_SafeSeries_Concurrency(t *testing.T) {
+	tm := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	ss := NewSafeSeries()
+
+	var wg sync.WaitGroup
+
+	wg.Add(2)
+
+	go func() {
+		defer wg.Done()
+
+		for i := 0; i < 100; i++ {
+			assert.NoError(t, ss.Append(testCandle(tm.Add(time.Duration(i)*time.Minute), 1, 1, 1, 1, 1)))
+			assert.NoError(t, ss.ReplaceLast(testCandle(tm.Add(time.Duration(i)*time.Minute), 2, 2, 2, 2, 2)))
+		}
+	}()
+
+	go func() {
+		defer wg.Done()
+
+		for i := 0; i < 100; i++ {
+			sn := ss.Snapshot()
+			for j := 0; j < sn.Len(); j++ {
+				assert.False(t, sn.At(j).Timestamp.IsZero())
+			}
+
+			h := ss.History()
+			for j := 0; j < h.Len(); j++ {
+				assert.Equal(t, "2", h.At(j).Close.String())
+			}
+		}
+	}()
+
+	wg.Wait()
+
+	assert.Equal(t, 100, ss.Len())
+}
+
+func Test_SeriesSnapshot(t *testing.T) {
+	tm := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	sn := NewSafeSeries().Snapshot()
+	assert.Equal(t, 0, sn.Len())
+	assert.Empty(t, sn.Candles())
+
+	_, ok := sn.Last()
+	assert.False(t, ok)
+
+	ss := NewSafeSeries(testCandle(tm, 1, 1, 1, 1, 1), testCandle(tm.Add(time.Minute), 2, 2, 2, 2, 2))
+	sn = ss.Snapshot()
+	assert.NoError(t, ss.Append(testCandle(tm.Add(2*time.Minute), 3, 3, 3, 3, 3)))
+
+	assert.Equal(t, 2, sn.Len())
+	assert.Equal(t, testCandle(tm, 1, 1, 1, 1, 1), sn.At(0))
+
+	c, ok := sn.Last()
+	assert.True(t, ok)
+	assert.Equal(t, testCandle(tm.Add(time.Minute), 2, 2, 2, 2, 2), c)
+
+	cc := sn.Candles()
+	cc[0] = Candle{}
+	assert.Equal(t, testCandle(tm, 1, 1, 1, 1, 1), sn.At(0))
+}
@@ -0,0 +1,92 @@
+package chartype
+
+import "github.com/shopspring/decimal"
+
+// ScaleOption configures how Candle.Scale treats candle's volume.
+type ScaleOption func(*scaleConfig)
+
+// scaleConfig holds scale options' settings.
+type scaleConfig struct {
+	volume  bool
+	inverse bool
+}
+
+// WithVolumeScaled multiplies candle's volume and volume delta by the
+// same factor as its prices, e.g. when converting quote currency
+// volumes.
+func WithVolumeScaled() ScaleOption {
+	return func(sc *scaleConfig) {
+		sc.volume = true
+		sc.inverse = false
+	}
+}
+
+// WithVolumeInverse divides candle's volume and volume delta by the
+// factor its prices are multiplied by, e.g. when adjusting for stock
+// splits. The factor must not be zero.
+func WithVolumeInverse() ScaleOption {
+	return func(sc *scaleConfig) {
+		sc.volume = true
+		sc.inverse = true
+	}
+}
+
+// Scale returns a copy of the candle with open, high, low, close and
+// adjusted close prices multiplied by the factor. Volume and volume
+// delta are not changed unless a scale option specifies otherwise.
+func (c Candle) Scale(f decimal.Decimal, opts ...ScaleOption) Candle {
+	var sc scaleConfig
+
+	for _, o := range opts {
+		o(&sc)
+	}
+
+	c.Open = c.Open.Mul(f)
+	c.High = c.High.Mul(f)
+	c.Low = c.Low.Mul(f)
+	c.Close = c.Close.Mul(f)
+
+	if c.AdjClose != nil {
+		adj := c.AdjClose.Mul(f)
+		c.AdjClose = &adj
+	}
+
+	c.Volume = sc.scaleVolume(c.Volume, f)
+
+	if c.Delta != nil {
+		d := sc.scaleVolume(*c.Delta, f)
+		c.Delta = &d
+	}
+
+	return c
+}
+
+// ShiftPrices returns a copy of the candle with the offset added to
+// its open, high, low, close and adjusted close prices. Volume is not
+// changed.
+func (c Candle) ShiftPrices(d decimal.Decimal) Candle {
+	c.Open = c.Open.Add(d)
+	c.High = c.High.Add(d)
+	c.Low = c.Low.Add(d)
+	c.Close = c.Close.Add(d)
+
+	if c.AdjClose != nil {
+		adj := c.AdjClose.Add(d)
+		c.AdjClose = &adj
+	}
+
+	return c
+}
+
+// scaleVolume returns the volume value scaled by the price factor as
+// specified by the options.
+func (sc scaleConfig) scaleVolume(v, f decimal.Decimal) decimal.Decimal {
+	switch {
+	case sc.inverse:
+		return v.Div(f)
+	case sc.volume:
+		return v.Mul(f)
+	default:
+		return v
+	}
+}
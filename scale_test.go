@@ -0,0 +1,87 @@
+package chartype
+
+import (
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Candle_Scale(t *testing.T) {
+	tm := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	withAdj := func(c Candle, v int64) Candle {
+		c.AdjClose = decimalPtr(v)
+		return c
+	}
+
+	cc := map[string]struct {
+		Candle  Candle
+		Factor  int64
+		Options []ScaleOption
+		Result  Candle
+	}{
+		"Volume unchanged": {
+			Candle: testCandle(tm, 1, 4, 1, 2, 10),
+			Factor: 2,
+			Result: testCandle(tm, 2, 8, 2, 4, 10),
+		},
+		"Volume scaled": {
+			Candle:  withAdj(testCandle(tm, 1, 4, 1, 2, 10), 3),
+			Factor:  2,
+			Options: []ScaleOption{WithVolumeScaled()},
+			Result:  withAdj(testCandle(tm, 2, 8, 2, 4, 20), 6),
+		},
+		"Volume inverse": {
+			Candle:  testCandle(tm, 1, 4, 1, 2, 10),
+			Factor:  2,
+			Options: []ScaleOption{WithVolumeScaled(), WithVolumeInverse()},
+			Result:  testCandle(tm, 2, 8, 2, 4, 5),
+		},
+		"Volume delta unchanged": {
+			Candle: testBuiltCandle(tm, 1, 4, 1, 2, 10, -4),
+			Factor: 2,
+			Result: testBuiltCandle(tm, 2, 8, 2, 4, 10, -4),
+		},
+		"Volume delta scaled": {
+			Candle:  testBuiltCandle(tm, 1, 4, 1, 2, 10, -4),
+			Factor:  2,
+			Options: []ScaleOption{WithVolumeScaled()},
+			Result:  testBuiltCandle(tm, 2, 8, 2, 4, 20, -8),
+		},
+		"Volume delta inverse": {
+			Candle:  testBuiltCandle(tm, 1, 4, 1, 2, 10, -4),
+			Factor:  2,
+			Options: []ScaleOption{WithVolumeInverse()},
+			Result:  testBuiltCandle(tm, 2, 8, 2, 4, 5, -2),
+		},
+	}
+
+	for cn, c := range cc {
+		c := c
+
+		t.Run(cn, func(t *testing.T) {
+			t.Parallel()
+
+			orig := c.Candle
+			res := c.Candle.Scale(decimal.NewFromInt(c.Factor), c.Options...)
+
+			assertEqualCandles(t, []Candle{c.Result}, []Candle{res})
+			assertEqualCandles(t, []Candle{orig}, []Candle{c.Candle})
+		})
+	}
+}
+
+func Test_Candle_ShiftPrices(t *testing.T) {
+	tm := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	c := testCandle(tm, 1, 4, 1, 2, 10)
+	assertEqualCandles(t, []Candle{testCandle(tm, 3, 6, 3, 4, 10)}, []Candle{c.ShiftPrices(decimal.NewFromInt(2))})
+
+	c.AdjClose = decimalPtr(3)
+	exp := testCandle(tm, 0, 3, 0, 1, 10)
+	exp.AdjClose = decimalPtr(2)
+
+	assertEqualCandles(t, []Candle{exp}, []Candle{c.ShiftPrices(decimal.NewFromInt(-1))})
+	assert.Equal(t, "3", c.AdjClose.String())
+}
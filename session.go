@@ -0,0 +1,103 @@
+package chartype
+
+import "time"
+
+// day is the length of a calendar day without DST transitions.
+const day = 24 * time.Hour
+
+var (
+	// ErrInvalidSession is returned when session with invalid opening
+	// hours is being used.
+	ErrInvalidSession = newError(CodeInvalidArgument, "invalid session")
+)
+
+// Session specifies a market's daily trading hours.
+type Session struct {
+	// Location specifies the time zone of trading hours. UTC is used
+	// if it is nil.
+	Location *time.Location
+
+	// Open specifies the opening time as an offset from the local
+	// midnight in wall clock time, so that it stays the same on days
+	// of daylight saving time transitions.
+	Open time.Duration
+
+	// Close specifies the closing time as an offset from the local
+	// midnight. It must be after the opening time and not later than
+	// the next midnight.
+	Close time.Duration
+
+	// Weekdays specifies trading days of the week. All days are
+	// trading days if it is empty.
+	Weekdays []time.Weekday
+
+	// Calendar specifies the holidays on which the market is closed.
+	// It is optional.
+	Calendar HolidayCalendar
+}
+
+// Validate checks whether session's opening hours are within a single
+// day and whether the opening time is before the closing time.
+func (s Session) Validate() error {
+	if s.Open < 0 || s.Close > day || s.Open >= s.Close {
+		return ErrInvalidSession
+	}
+
+	return nil
+}
+
+// TradingDay checks whether the market is open on the day the
+// provided time belongs to.
+func (s Session) TradingDay(t time.Time) bool {
+	t = t.In(s.location())
+
+	if s.Calendar != nil && s.Calendar.IsHoliday(t) {
+		return false
+	}
+
+	if len(s.Weekdays) == 0 {
+		return true
+	}
+
+	for _, wd := range s.Weekdays {
+		if t.Weekday() == wd {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Contains checks whether the market is open at the provided time.
+func (s Session) Contains(t time.Time) bool {
+	if !s.TradingDay(t) {
+		return false
+	}
+
+	off := wallClock(t.In(s.location()))
+
+	return off >= s.Open && off < s.Close
+}
+
+// Filter returns a new slice with candles whose timestamps are
+// within the session.
+func (s Session) Filter(cc []Candle) []Candle {
+	var res []Candle
+
+	for _, c := range cc {
+		if s.Contains(c.Timestamp) {
+			res = append(res, c)
+		}
+	}
+
+	return res
+}
+
+// location returns session's location or UTC if it is not set.
+func (s Session) location() *time.Location {
+	if s.Location == nil {
+		return time.UTC
+	}
+
+	return s.Location
+}
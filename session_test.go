@@ -0,0 +1,134 @@
+package chartype
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Session_Validate(t *testing.T) {
+	cc := map[string]struct {
+		Session Session
+		Err     error
+	}{
+		"Negative open": {
+			Session: Session{Open: -1, Close: time.Hour},
+			Err:     ErrInvalidSession,
+		},
+		"Close after midnight": {
+			Session: Session{Close: 25 * time.Hour},
+			Err:     ErrInvalidSession,
+		},
+		"Close before open": {
+			Session: Session{Open: 2 * time.Hour, Close: time.Hour},
+			Err:     ErrInvalidSession,
+		},
+		"Successful validation": {
+			Session: Session{Open: 9 * time.Hour, Close: 16 * time.Hour},
+		},
+	}
+
+	for cn, c := range cc {
+		c := c
+
+		t.Run(cn, func(t *testing.T) {
+			t.Parallel()
+
+			equalError(t, c.Err, c.Session.Validate())
+		})
+	}
+}
+
+func testSession(t *testing.T) Session {
+	t.Helper()
+
+	ny := time.FixedZone("NY", -5*3600)
+
+	sc, err := NewStaticCalendar(ny, "2020-12-25")
+	assert.NoError(t, err)
+
+	return Session{
+		Location: ny,
+		Open:     9*time.Hour + 30*time.Minute,
+		Close:    16 * time.Hour,
+		Weekdays: []time.Weekday{
+			time.Monday, time.Tuesday, time.Wednesday,
+			time.Thursday, time.Friday,
+		},
+		Calendar: sc,
+	}
+}
+
+func Test_Session_Contains(t *testing.T) {
+	s := testSession(t)
+
+	cc := map[string]struct {
+		Session Session
+		Time    time.Time
+		Result  bool
+	}{
+		"Before open": {
+			Session: s,
+			Time:    time.Date(2020, 12, 24, 14, 29, 0, 0, time.UTC),
+		},
+		"At open": {
+			Session: s,
+			Time:    time.Date(2020, 12, 24, 14, 30, 0, 0, time.UTC),
+			Result:  true,
+		},
+		"At close": {
+			Session: s,
+			Time:    time.Date(2020, 12, 24, 21, 0, 0, 0, time.UTC),
+		},
+		"Holiday": {
+			Session: s,
+			Time:    time.Date(2020, 12, 25, 15, 0, 0, 0, time.UTC),
+		},
+		"Weekend": {
+			Session: s,
+			Time:    time.Date(2020, 12, 26, 15, 0, 0, 0, time.UTC),
+		},
+		"Every day in UTC": {
+			Session: Session{Close: day},
+			Time:    time.Date(2020, 12, 26, 15, 0, 0, 0, time.UTC),
+			Result:  true,
+		},
+	}
+
+	for cn, c := range cc {
+		c := c
+
+		t.Run(cn, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, c.Result, c.Session.Contains(c.Time))
+		})
+	}
+}
+
+func Test_Session_Filter(t *testing.T) {
+	s := testSession(t)
+	c := testCandle(time.Date(2020, 12, 24, 15, 0, 0, 0, time.UTC), 1, 1, 1, 1, 1)
+
+	assert.Equal(t, []Candle{c}, s.Filter([]Candle{
+		testCandle(time.Date(2020, 12, 24, 14, 0, 0, 0, time.UTC), 1, 1, 1, 1, 1),
+		c,
+		testCandle(time.Date(2020, 12, 25, 15, 0, 0, 0, time.UTC), 1, 1, 1, 1, 1),
+	}))
+}
+
+func Test_Session_Contains_DST(t *testing.T) {
+	ny := testLocation(t, "America/New_York")
+	s := Session{Location: ny, Open: 9*time.Hour + 30*time.Minute, Close: 16 * time.Hour}
+
+	// 09:30 EST and 09:30 EDT
+	assert.True(t, s.Contains(time.Date(2020, 3, 6, 14, 30, 0, 0, time.UTC)))
+	assert.True(t, s.Contains(time.Date(2020, 3, 9, 13, 30, 0, 0, time.UTC)))
+
+	// 09:30 local on the days of DST transitions
+	assert.True(t, s.Contains(time.Date(2020, 3, 8, 13, 30, 0, 0, time.UTC)))
+	assert.False(t, s.Contains(time.Date(2020, 3, 8, 13, 29, 0, 0, time.UTC)))
+	assert.True(t, s.Contains(time.Date(2020, 11, 1, 14, 30, 0, 0, time.UTC)))
+	assert.False(t, s.Contains(time.Date(2020, 11, 1, 14, 29, 0, 0, time.UTC)))
+}
@@ -0,0 +1,103 @@
+package chartype
+
+import (
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+// SessionMetrics holds running metrics of a trading session.
+type SessionMetrics struct {
+	// Date specifies the local midnight of the session's day.
+	Date time.Time `json:"date" yaml:"date"`
+
+	// Open specifies the open price of session's first candle.
+	Open decimal.Decimal `json:"open" yaml:"open"`
+
+	// High specifies the highest price of the session so far.
+	High decimal.Decimal `json:"high" yaml:"high"`
+
+	// Low specifies the lowest price of the session so far.
+	Low decimal.Decimal `json:"low" yaml:"low"`
+
+	// PrevClose specifies the close price of the previous session's
+	// last candle. It is nil if no previous session was tracked.
+	PrevClose *decimal.Decimal `json:"prev_close,omitempty" yaml:"prev_close,omitempty"`
+}
+
+// SessionCandle holds a candle together with the metrics of its
+// session that include the candle.
+type SessionCandle struct {
+	Candle  Candle         `json:"candle" yaml:"candle"`
+	Metrics SessionMetrics `json:"metrics" yaml:"metrics"`
+}
+
+// SessionTracker tracks session metrics of a candle stream.
+//
+// SessionTracker is not safe for concurrent use.
+type SessionTracker struct {
+	session   Session
+	metrics   *SessionMetrics
+	lastClose decimal.Decimal
+}
+
+// NewSessionTracker creates a new session tracker for the session.
+func NewSessionTracker(s Session) (*SessionTracker, error) {
+	if err := s.Validate(); err != nil {
+		return nil, err
+	}
+
+	return &SessionTracker{session: s}, nil
+}
+
+// Add applies the candle to the metrics of its session and returns it
+// together with them. A candle starting a new session closes the
+// current one, whose last close becomes the new session's previous
+// close. Candles outside the session and candles of sessions older
+// than the current one are skipped.
+func (st *SessionTracker) Add(c Candle) (SessionCandle, bool) {
+	if !st.session.Contains(c.Timestamp) {
+		return SessionCandle{}, false
+	}
+
+	t := c.Timestamp.In(st.session.location())
+	y, m, d := t.Date()
+	date := time.Date(y, m, d, 0, 0, 0, 0, t.Location())
+
+	switch {
+	case st.metrics == nil || date.After(st.metrics.Date):
+		var prev *decimal.Decimal
+
+		if st.metrics != nil {
+			cl := st.lastClose
+			prev = &cl
+		}
+
+		st.metrics = &SessionMetrics{
+			Date:      date,
+			Open:      c.Open,
+			High:      c.High,
+			Low:       c.Low,
+			PrevClose: prev,
+		}
+	case date.Equal(st.metrics.Date):
+		st.metrics.High = decimal.Max(st.metrics.High, c.High)
+		st.metrics.Low = decimal.Min(st.metrics.Low, c.Low)
+	default:
+		return SessionCandle{}, false
+	}
+
+	st.lastClose = c.Close
+
+	return SessionCandle{Candle: c, Metrics: *st.metrics}, true
+}
+
+// Metrics returns the metrics of the current session and whether
+// there is one.
+func (st *SessionTracker) Metrics() (SessionMetrics, bool) {
+	if st.metrics == nil {
+		return SessionMetrics{}, false
+	}
+
+	return *st.metrics, true
+}
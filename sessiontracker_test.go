@@ -0,0 +1,87 @@
+package chartype
+
+import (
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_NewSessionTracker(t *testing.T) {
+	st, err := NewSessionTracker(Session{Open: time.Hour})
+	equalError(t, ErrInvalidSession, err)
+	assert.Nil(t, st)
+
+	st, err = NewSessionTracker(testSession(t))
+	require.NoError(t, err)
+	assert.Equal(t, testSession(t).Open, st.session.Open)
+}
+
+func Test_SessionTracker(t *testing.T) {
+	st, err := NewSessionTracker(testSession(t))
+	require.NoError(t, err)
+
+	_, ok := st.Metrics()
+	assert.False(t, ok)
+
+	ny := testSession(t).Location
+	day1 := time.Date(2020, 12, 23, 0, 0, 0, 0, ny)
+	day2 := time.Date(2020, 12, 24, 0, 0, 0, 0, ny)
+
+	cc := []struct {
+		Candle  Candle
+		Metrics SessionMetrics
+		OK      bool
+	}{
+		{
+			Candle:  testCandle(time.Date(2020, 12, 23, 14, 30, 0, 0, time.UTC), 10, 12, 9, 11, 1),
+			Metrics: SessionMetrics{Date: day1, Open: decimal.NewFromInt(10), High: decimal.NewFromInt(12), Low: decimal.NewFromInt(9)},
+			OK:      true,
+		},
+		{
+			Candle:  testCandle(time.Date(2020, 12, 23, 15, 0, 0, 0, time.UTC), 11, 15, 10, 14, 1),
+			Metrics: SessionMetrics{Date: day1, Open: decimal.NewFromInt(10), High: decimal.NewFromInt(15), Low: decimal.NewFromInt(9)},
+			OK:      true,
+		},
+		{
+			Candle: testCandle(time.Date(2020, 12, 23, 22, 0, 0, 0, time.UTC), 14, 20, 1, 14, 1),
+		},
+		{
+			Candle: testCandle(time.Date(2020, 12, 24, 14, 30, 0, 0, time.UTC), 14, 16, 13, 15, 1),
+			Metrics: SessionMetrics{
+				Date: day2, Open: decimal.NewFromInt(14), High: decimal.NewFromInt(16), Low: decimal.NewFromInt(13),
+				PrevClose: decimalPtr(14),
+			},
+			OK: true,
+		},
+		{
+			Candle: testCandle(time.Date(2020, 12, 23, 16, 0, 0, 0, time.UTC), 14, 20, 1, 14, 1),
+		},
+		{
+			Candle: testCandle(time.Date(2020, 12, 24, 16, 0, 0, 0, time.UTC), 15, 17, 12, 16, 1),
+			Metrics: SessionMetrics{
+				Date: day2, Open: decimal.NewFromInt(14), High: decimal.NewFromInt(17), Low: decimal.NewFromInt(12),
+				PrevClose: decimalPtr(14),
+			},
+			OK: true,
+		},
+	}
+
+	for i, c := range cc {
+		sc, ok := st.Add(c.Candle)
+		require.Equal(t, c.OK, ok, i)
+
+		if !ok {
+			assert.Equal(t, SessionCandle{}, sc, i)
+			continue
+		}
+
+		assert.Equal(t, SessionCandle{Candle: c.Candle, Metrics: c.Metrics}, sc, i)
+	}
+
+	m, ok := st.Metrics()
+	assert.True(t, ok)
+	assert.Equal(t, cc[len(cc)-1].Metrics, m)
+}
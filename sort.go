@@ -0,0 +1,53 @@
+package chartype
+
+import "sort"
+
+// CompareByTimestamp compares candles by their timestamps. It returns
+// -1 if a is older than b, 1 if a is newer than b and 0 otherwise.
+// Can be used with slices.SortFunc and slices.BinarySearchFunc.
+func CompareByTimestamp(a, b Candle) int {
+	switch {
+	case a.Timestamp.Before(b.Timestamp):
+		return -1
+	case a.Timestamp.After(b.Timestamp):
+		return 1
+	default:
+		return 0
+	}
+}
+
+// CompareByField returns a function that compares candles by the
+// candle field's values. The function returns -1 if a's value is
+// smaller than b's, 1 if it is greater and 0 otherwise.
+// Can be used with slices.SortFunc and slices.BinarySearchFunc.
+func CompareByField(cf CandleField) func(a, b Candle) int {
+	return func(a, b Candle) int {
+		return cf.Extract(a).Cmp(cf.Extract(b))
+	}
+}
+
+// SortByField sorts the candles in place by the candle field's
+// values, e.g. volume for highest-volume leaderboards. Candles with
+// equal values are ordered by timestamp in ascending order.
+func SortByField(cc []Candle, cf CandleField, desc bool) error {
+	if err := cf.Validate(); err != nil {
+		return err
+	}
+
+	cmp := CompareByField(cf)
+
+	sort.SliceStable(cc, func(i, j int) bool {
+		res := cmp(cc[i], cc[j])
+		if desc {
+			res = -res
+		}
+
+		if res == 0 {
+			return CompareByTimestamp(cc[i], cc[j]) < 0
+		}
+
+		return res < 0
+	})
+
+	return nil
+}
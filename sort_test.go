@@ -0,0 +1,81 @@
+package chartype
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_CompareByTimestamp(t *testing.T) {
+	tm := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	c1, c2 := Candle{Timestamp: tm}, Candle{Timestamp: tm.Add(time.Minute)}
+
+	assert.Equal(t, -1, CompareByTimestamp(c1, c2))
+	assert.Equal(t, 1, CompareByTimestamp(c2, c1))
+	assert.Equal(t, 0, CompareByTimestamp(c1, c1))
+}
+
+func Test_CompareByField(t *testing.T) {
+	tm := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	c1, c2 := testCandle(tm, 1, 5, 1, 3, 10), testCandle(tm, 2, 4, 1, 3, 20)
+
+	assert.Equal(t, -1, CompareByField(CandleOpen)(c1, c2))
+	assert.Equal(t, 1, CompareByField(CandleHigh)(c1, c2))
+	assert.Equal(t, 0, CompareByField(CandleClose)(c1, c2))
+	assert.Equal(t, -1, CompareByField(CandleVolume)(c1, c2))
+}
+
+func Test_SortByField(t *testing.T) {
+	tm := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	at := func(m int) time.Time {
+		return tm.Add(time.Duration(m) * time.Minute)
+	}
+
+	series := []Candle{
+		testCandle(at(0), 1, 1, 1, 1, 5),
+		testCandle(at(1), 1, 1, 1, 1, 9),
+		testCandle(at(2), 1, 1, 1, 1, 5),
+		testCandle(at(3), 1, 1, 1, 1, 2),
+	}
+
+	cc := map[string]struct {
+		Field  CandleField
+		Desc   bool
+		Result []Candle
+		Err    error
+	}{
+		"Invalid candle field": {
+			Field: 70,
+			Err:   ErrInvalidCandleField,
+		},
+		"Successful ascending sort": {
+			Field:  CandleVolume,
+			Result: []Candle{series[3], series[0], series[2], series[1]},
+		},
+		"Successful descending sort": {
+			Field:  CandleVolume,
+			Desc:   true,
+			Result: []Candle{series[1], series[0], series[2], series[3]},
+		},
+	}
+
+	for cn, c := range cc {
+		c := c
+
+		t.Run(cn, func(t *testing.T) {
+			t.Parallel()
+
+			res := make([]Candle, len(series))
+			copy(res, series)
+
+			err := SortByField(res, c.Field, c.Desc)
+			equalError(t, c.Err, err)
+			if err != nil {
+				return
+			}
+
+			assert.Equal(t, c.Result, res)
+		})
+	}
+}
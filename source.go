@@ -0,0 +1,149 @@
+package chartype
+
+import "context"
+
+// replayBufferSize is the number of candles queued for the subscriber
+// of a replayed stream.
+const replayBufferSize = 64
+
+// CandleSource retrieves candles from a market data provider, such as
+// an exchange API client.
+type CandleSource interface {
+	// Candles returns candles matching the request, sorted by
+	// timestamp in ascending order.
+	Candles(ctx context.Context, cr CandleRequest) ([]Candle, error)
+}
+
+// HealthChecker is implemented by candle sources that can report
+// whether they are able to serve requests without retrieving candles.
+type HealthChecker interface {
+	// CheckHealth returns an error if the source cannot serve
+	// requests.
+	CheckHealth(ctx context.Context) error
+}
+
+// CandleSourceFunc is an adapter that allows ordinary functions to be
+// used as candle sources.
+type CandleSourceFunc func(ctx context.Context, cr CandleRequest) ([]Candle, error)
+
+// Candles calls the underlying function.
+func (sf CandleSourceFunc) Candles(ctx context.Context, cr CandleRequest) ([]Candle, error) {
+	return sf(ctx, cr)
+}
+
+// streamingSource is a source whose candles are retrieved and
+// streamed by functions, used by decorators of sources that may or
+// may not stream candles themselves.
+type streamingSource struct {
+	CandleSourceFunc
+	stream func(ctx context.Context, cr CandleRequest) (*Subscription, error)
+}
+
+// Stream calls the underlying stream function.
+func (ss streamingSource) Stream(ctx context.Context, cr CandleRequest) (*Subscription, error) {
+	return ss.stream(ctx, cr)
+}
+
+// Source is the common interface of candle providers, such as stores,
+// remote sources and generators, so that higher-level code, e.g.
+// backtesters and chart servers, can be composed against a single
+// abstraction.
+type Source interface {
+	CandleSource
+
+	// Stream returns a subscription that receives candles matching
+	// the request, sorted by timestamp in ascending order, followed
+	// by ErrStreamClosed once all of them are delivered or the
+	// context is done. Callers that stop receiving early must close
+	// the subscription or cancel the context, otherwise the stream
+	// may keep waiting for room in the subscription's queue.
+	//
+	// The returned subscription is of the same type as the ones of a
+	// CandleStream, which is a concrete publisher rather than an
+	// interface, so that replayed and live candles are consumed the
+	// same way. Implementations are swapped by implementing Source.
+	Stream(ctx context.Context, cr CandleRequest) (*Subscription, error)
+}
+
+// ReplaySource returns a source that serves requests from the candle
+// source and streams them by replaying retrieved candles.
+func ReplaySource(cs CandleSource) Source {
+	return replaySource{CandleSource: cs}
+}
+
+// StoreSource returns a source that serves requests from the pair's
+// interval series of the candle store.
+func StoreSource(cs CandleStore) Source {
+	return replaySource{CandleSource: CandleSourceFunc(func(_ context.Context, cr CandleRequest) ([]Candle, error) {
+		if err := cr.Validate(); err != nil {
+			return nil, err
+		}
+
+		cc, err := cs.Range(cr.Pair, cr.Interval, cr.Range.From, cr.Range.To)
+		if err != nil {
+			return nil, err
+		}
+
+		return limitCandles(cc, cr.Limit), nil
+	})}
+}
+
+// replaySource is a source that streams candles of its candle source.
+type replaySource struct {
+	CandleSource
+}
+
+// Stream retrieves candles matching the request and replays them.
+func (rs replaySource) Stream(ctx context.Context, cr CandleRequest) (*Subscription, error) {
+	return streamCandles(ctx, rs.CandleSource, cr)
+}
+
+// streamCandles retrieves candles matching the request from the
+// candle source and replays them.
+func streamCandles(ctx context.Context, cs CandleSource, cr CandleRequest) (*Subscription, error) {
+	cc, err := cs.Candles(ctx, cr)
+	if err != nil {
+		return nil, err
+	}
+
+	return replay(ctx, cc), nil
+}
+
+// replay returns a subscription of a new stream that the candles are
+// published to in order. Publishing stops once all candles are
+// published, the context is done or the subscription is closed, and
+// the stream is then closed.
+func replay(ctx context.Context, cc []Candle) *Subscription {
+	cs, _ := NewCandleStream(replayBufferSize, BackpressureBlock) //nolint:errcheck // options are valid
+	sub, _ := cs.Subscribe()                                      //nolint:errcheck // stream is open
+
+	done := make(chan struct{})
+
+	// publishing blocked by a full queue is released by closing the
+	// subscription
+	go func() {
+		select {
+		case <-ctx.Done():
+			sub.Close() //nolint:errcheck // subscription may already be closed
+			<-done
+		case <-done:
+		}
+
+		cs.Close() //nolint:errcheck // stream is closed only here
+	}()
+
+	go func() {
+		defer close(done)
+
+		for _, c := range cc {
+			// the subscription is closed once the context is done
+			if cs.Subscribers() == 0 {
+				return
+			}
+
+			cs.Publish(c) //nolint:errcheck // stream is closed only here
+		}
+	}()
+
+	return sub
+}
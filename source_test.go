@@ -0,0 +1,227 @@
+package chartype
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// drainCandles receives candles of the subscription until its stream
+// is closed.
+func drainCandles(t *testing.T, sub *Subscription) []Candle {
+	t.Helper()
+
+	var res []Candle
+
+	for {
+		u, err := sub.Next(context.Background())
+		if err != nil {
+			require.Equal(t, ErrStreamClosed, err)
+			return res
+		}
+
+		res = append(res, *u.Candle)
+	}
+}
+
+func Test_ReplaySource(t *testing.T) {
+	tm := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	cr := CandleRequest{
+		Pair:     Pair{Base: "BTC", Quote: "USD"},
+		Interval: IntervalMinute,
+		Range:    TimeRange{From: tm, To: tm.Add(time.Hour)},
+	}
+
+	series := []Candle{
+		testCandle(tm, 1, 1, 1, 1, 1),
+		testCandle(tm.Add(time.Minute), 2, 2, 2, 2, 2),
+	}
+
+	cc := map[string]struct {
+		Err    error
+		Result []Candle
+	}{
+		"Source error": {
+			Err: assert.AnError,
+		},
+		"Successful replay": {
+			Result: series,
+		},
+	}
+
+	for cn, c := range cc {
+		c := c
+
+		t.Run(cn, func(t *testing.T) {
+			t.Parallel()
+
+			rs := ReplaySource(&testSource{candles: series, err: c.Err})
+
+			res, err := rs.Candles(context.Background(), cr)
+			assert.Equal(t, c.Err, err)
+			assert.Equal(t, c.Result, res)
+
+			sub, err := rs.Stream(context.Background(), cr)
+			assert.Equal(t, c.Err, err)
+			if err != nil {
+				return
+			}
+
+			assert.Equal(t, c.Result, drainCandles(t, sub))
+		})
+	}
+}
+
+func Test_StoreSource(t *testing.T) {
+	tm := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	p := Pair{Base: "BTC", Quote: "USD"}
+	cr := CandleRequest{
+		Pair:     p,
+		Interval: IntervalMinute,
+		Range:    TimeRange{From: tm, To: tm.Add(time.Hour)},
+	}
+
+	limited := cr
+	limited.Limit = 1
+
+	ms := NewMemoryStore()
+	require.NoError(t, ms.Put(p, IntervalMinute,
+		testCandle(tm, 1, 1, 1, 1, 1),
+		testCandle(tm.Add(time.Minute), 2, 2, 2, 2, 2),
+		testCandle(tm.Add(time.Hour), 3, 3, 3, 3, 3),
+	))
+
+	cc := map[string]struct {
+		Store   CandleStore
+		Request CandleRequest
+		Result  []Candle
+		Err     error
+	}{
+		"Invalid request": {
+			Store: ms,
+			Err:   ErrInvalidPair,
+		},
+		"Store error": {
+			Store:   &faultyStore{MemoryStore: ms, rangeErr: assert.AnError},
+			Request: cr,
+			Err:     assert.AnError,
+		},
+		"Successful retrieval": {
+			Store:   ms,
+			Request: cr,
+			Result: []Candle{
+				testCandle(tm, 1, 1, 1, 1, 1),
+				testCandle(tm.Add(time.Minute), 2, 2, 2, 2, 2),
+			},
+		},
+		"Successful retrieval with a limit": {
+			Store:   ms,
+			Request: limited,
+			Result:  []Candle{testCandle(tm, 1, 1, 1, 1, 1)},
+		},
+	}
+
+	for cn, c := range cc {
+		c := c
+
+		t.Run(cn, func(t *testing.T) {
+			t.Parallel()
+
+			ss := StoreSource(c.Store)
+
+			res, err := ss.Candles(context.Background(), c.Request)
+			assert.Equal(t, c.Err, err)
+			assert.Equal(t, c.Result, res)
+
+			sub, err := ss.Stream(context.Background(), c.Request)
+			assert.Equal(t, c.Err, err)
+			if err != nil {
+				return
+			}
+
+			assert.Equal(t, c.Result, drainCandles(t, sub))
+		})
+	}
+}
+
+func Test_replay(t *testing.T) {
+	tm := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	var series []Candle
+	for i := 0; i < replayBufferSize*2; i++ {
+		series = append(series, testCandle(tm.Add(time.Duration(i)*time.Minute), 1, 1, 1, 1, 1))
+	}
+
+	closed := func(sub *Subscription) func() bool {
+		return func() bool {
+			sub.stream.mu.Lock()
+			defer sub.stream.mu.Unlock()
+
+			return sub.stream.closed
+		}
+	}
+
+	cc := map[string]struct {
+		Stop   func(t *testing.T, sub *Subscription, cancel context.CancelFunc)
+		Result []Candle
+	}{
+		"Successful replay": {
+			Result: series,
+		},
+		"Context done": {
+			Stop: func(t *testing.T, sub *Subscription, cancel context.CancelFunc) {
+				cancel()
+
+				// the queue is not drained until the subscription is
+				// closed by the context
+				assert.Eventually(t, func() bool {
+					sub.mu.Lock()
+					defer sub.mu.Unlock()
+
+					return sub.closed
+				}, time.Second, time.Millisecond)
+			},
+		},
+		"Subscription closed": {
+			Stop: func(t *testing.T, sub *Subscription, _ context.CancelFunc) {
+				require.NoError(t, sub.Close())
+			},
+		},
+	}
+
+	for cn, c := range cc {
+		c := c
+
+		t.Run(cn, func(t *testing.T) {
+			t.Parallel()
+
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			sub := replay(ctx, series)
+
+			u, err := sub.Next(context.Background())
+			require.NoError(t, err)
+			assert.Equal(t, series[0], *u.Candle)
+
+			if c.Stop == nil {
+				assert.Equal(t, c.Result[1:], drainCandles(t, sub))
+				assert.True(t, closed(sub)())
+
+				return
+			}
+
+			// the publisher is blocked by the full queue until it is
+			// stopped
+			c.Stop(t, sub, cancel)
+
+			res := drainCandles(t, sub)
+			assert.True(t, len(res) < len(series)-1)
+			assert.Equal(t, series[1:len(res)+1], res)
+			assert.Eventually(t, closed(sub), time.Second, time.Millisecond)
+		})
+	}
+}
@@ -0,0 +1,54 @@
+package chartype
+
+import (
+	"sort"
+
+	"github.com/shopspring/decimal"
+)
+
+var (
+	// ErrInvalidPercentile is returned when percentile outside of
+	// [0, 100] range is being used.
+	ErrInvalidPercentile = newError(CodeInvalidArgument, "invalid percentile")
+)
+
+// AverageSpread returns the mean spread of the tickers. Zero is
+// returned for an empty series.
+func (tt Tickers) AverageSpread() decimal.Decimal {
+	if len(tt) == 0 {
+		return decimal.Zero
+	}
+
+	return decimal.Sum(decimal.Zero, tt.Spreads()...).Div(decimal.NewFromInt(int64(len(tt))))
+}
+
+// SpreadPercentile returns the spread below or at which the provided
+// percent of tickers' spreads are, using the nearest-rank method.
+// Zero is returned for an empty series.
+func (tt Tickers) SpreadPercentile(p float64) (decimal.Decimal, error) {
+	if p < 0 || p > 100 {
+		return decimal.Zero, ErrInvalidPercentile
+	}
+
+	if len(tt) == 0 {
+		return decimal.Zero, nil
+	}
+
+	ss := tt.Spreads()
+
+	sort.Slice(ss, func(i, j int) bool {
+		return ss[i].LessThan(ss[j])
+	})
+
+	// nearest rank is ceil(p / 100 * n), at least 1
+	r := int(p / 100 * float64(len(ss)))
+	if float64(r) < p/100*float64(len(ss)) {
+		r++
+	}
+
+	if r < 1 {
+		r = 1
+	}
+
+	return ss[r-1], nil
+}
@@ -0,0 +1,84 @@
+package chartype
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Tickers_AverageSpread(t *testing.T) {
+	assert.True(t, Tickers{}.AverageSpread().IsZero())
+
+	tt := Tickers{
+		testQuote(time.Time{}, 5, 3),
+		testQuote(time.Time{}, 4, 3),
+	}
+
+	assert.Equal(t, "1.5", tt.AverageSpread().String())
+}
+
+func Test_Tickers_SpreadPercentile(t *testing.T) {
+	tt := Tickers{
+		testQuote(time.Time{}, 5, 1),
+		testQuote(time.Time{}, 5, 4),
+		testQuote(time.Time{}, 5, 3),
+		testQuote(time.Time{}, 5, 2),
+	}
+
+	cc := map[string]struct {
+		Tickers    Tickers
+		Percentile float64
+		Result     string
+		Err        error
+	}{
+		"Negative percentile": {
+			Percentile: -1,
+			Err:        ErrInvalidPercentile,
+		},
+		"Too large percentile": {
+			Percentile: 101,
+			Err:        ErrInvalidPercentile,
+		},
+		"Empty series": {
+			Percentile: 50,
+			Result:     "0",
+		},
+		"Zero percentile": {
+			Tickers:    tt,
+			Percentile: 0,
+			Result:     "1",
+		},
+		"Median percentile": {
+			Tickers:    tt,
+			Percentile: 50,
+			Result:     "2",
+		},
+		"Between ranks percentile": {
+			Tickers:    tt,
+			Percentile: 60,
+			Result:     "3",
+		},
+		"Maximum percentile": {
+			Tickers:    tt,
+			Percentile: 100,
+			Result:     "4",
+		},
+	}
+
+	for cn, c := range cc {
+		c := c
+
+		t.Run(cn, func(t *testing.T) {
+			t.Parallel()
+
+			res, err := c.Tickers.SpreadPercentile(c.Percentile)
+			equalError(t, c.Err, err)
+			if err != nil {
+				return
+			}
+
+			assert.Equal(t, c.Result, res.String())
+		})
+	}
+}
@@ -0,0 +1,99 @@
+package chartype
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+)
+
+// SSEWriter writes candle stream updates as server-sent events, e.g.:
+//
+//	event: candle
+//	data: {"timestamp":"2020-01-01T00:00:00Z","open":"1",...}
+//
+// Event names are frame type names and data is the update encoded to
+// JSON. Writers implementing Flush(), such as http.ResponseWriter,
+// are flushed after every event.
+type SSEWriter struct {
+	w io.Writer
+}
+
+// NewSSEWriter creates a new server-sent events writer on top of the
+// writer.
+func NewSSEWriter(w io.Writer) *SSEWriter {
+	return &SSEWriter{w: w}
+}
+
+// WriteCandle writes the candle as a "candle" event.
+func (sw *SSEWriter) WriteCandle(c Candle) error {
+	return sw.write(FrameCandle, c)
+}
+
+// WriteTicker writes the ticker as a "ticker" event.
+func (sw *SSEWriter) WriteTicker(t Ticker) error {
+	return sw.write(FrameTicker, t)
+}
+
+// WriteUpdate writes the stream update as a "candle", "ticker" or
+// "snapshot" event.
+func (sw *SSEWriter) WriteUpdate(u StreamUpdate) error {
+	switch {
+	case u.Candle != nil:
+		return sw.WriteCandle(*u.Candle)
+	case u.Ticker != nil:
+		return sw.WriteTicker(*u.Ticker)
+	case u.Snapshot != nil:
+		return sw.write(FrameSnapshot, u.Snapshot)
+	default:
+		return ErrInvalidFrameType
+	}
+}
+
+// Stream writes updates received by the subscription until it is
+// closed, the context is done or writing fails. Nil is returned when
+// the subscription is closed.
+func (sw *SSEWriter) Stream(ctx context.Context, s *Subscription) error {
+	for {
+		u, err := s.Next(ctx)
+		if err != nil {
+			if errors.Is(err, ErrStreamClosed) {
+				return nil
+			}
+
+			return err
+		}
+
+		if err = sw.WriteUpdate(u); err != nil {
+			return err
+		}
+	}
+}
+
+// write encodes the value and writes it as an event named after the
+// frame type.
+func (sw *SSEWriter) write(ft FrameType, v interface{}) error {
+	d, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+
+	e, _ := ft.MarshalText() //nolint:errcheck // frame types are set by callers
+
+	buf := make([]byte, 0, len(e)+len(d)+16)
+	buf = append(buf, "event: "...)
+	buf = append(buf, e...)
+	buf = append(buf, "\ndata: "...)
+	buf = append(buf, d...)
+	buf = append(buf, "\n\n"...)
+
+	if _, err = sw.w.Write(buf); err != nil {
+		return err
+	}
+
+	if f, ok := sw.w.(interface{ Flush() }); ok {
+		f.Flush()
+	}
+
+	return nil
+}
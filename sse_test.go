@@ -0,0 +1,90 @@
+package chartype
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// failingResponseWriter is a response writer whose writes fail.
+type failingResponseWriter struct {
+	*httptest.ResponseRecorder
+}
+
+func (failingResponseWriter) Write([]byte) (int, error) {
+	return 0, assert.AnError
+}
+
+func Test_SSEWriter_WriteUpdate(t *testing.T) {
+	tm := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	c := testCandle(tm, 1, 2, 1, 2, 3)
+	tk := Ticker{Last: decimal.NewFromInt(2)}
+
+	rec := httptest.NewRecorder()
+	sw := NewSSEWriter(rec)
+
+	require.NoError(t, sw.WriteUpdate(StreamUpdate{Candle: &c}))
+	require.NoError(t, sw.WriteUpdate(StreamUpdate{Ticker: &tk}))
+	require.NoError(t, sw.WriteUpdate(StreamUpdate{Snapshot: &Packet{Candles: []Candle{}}}))
+	assert.Equal(t, ErrInvalidFrameType, sw.WriteUpdate(StreamUpdate{}))
+
+	assert.True(t, rec.Flushed)
+	assert.Equal(t, "event: candle\n"+
+		`data: {"timestamp":"2020-01-01T00:00:00Z","open":"1","high":"2","low":"1","close":"2","volume":"3"}`+"\n\n"+
+		"event: ticker\n"+
+		`data: {"last":"2","ask":"0","bid":"0","change":"0","percent_change":"0","volume":"0"}`+"\n\n"+
+		"event: snapshot\n"+
+		`data: {"ticker":{"last":"0","ask":"0","bid":"0","change":"0","percent_change":"0","volume":"0"},"candles":[]}`+"\n\n",
+		rec.Body.String())
+
+	fw := failingResponseWriter{ResponseRecorder: httptest.NewRecorder()}
+	assert.Equal(t, assert.AnError, NewSSEWriter(fw).WriteCandle(c))
+	assert.False(t, fw.Flushed)
+
+	rec = httptest.NewRecorder()
+
+	err := NewSSEWriter(rec).WriteUpdate(StreamUpdate{Snapshot: &Packet{Pair: &Pair{}}})
+	assert.True(t, errors.Is(err, ErrInvalidPair))
+	assert.Empty(t, rec.Body.String())
+	assert.False(t, rec.Flushed)
+}
+
+func Test_SSEWriter_Stream(t *testing.T) {
+	tm := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	cs, err := NewCandleStream(2, BackpressureDropOldest)
+	require.NoError(t, err)
+
+	s, err := cs.Subscribe()
+	require.NoError(t, err)
+
+	require.NoError(t, cs.Publish(testCandle(tm, 1, 1, 1, 1, 1)))
+	require.NoError(t, cs.Close())
+
+	var buf bytes.Buffer
+
+	assert.NoError(t, NewSSEWriter(&buf).Stream(context.Background(), s))
+	assert.Contains(t, buf.String(), "event: candle\n")
+
+	cs, err = NewCandleStream(2, BackpressureDropOldest)
+	require.NoError(t, err)
+
+	s, err = cs.Subscribe()
+	require.NoError(t, err)
+
+	require.NoError(t, cs.Publish(testCandle(tm, 1, 1, 1, 1, 1)))
+	fw := failingResponseWriter{ResponseRecorder: httptest.NewRecorder()}
+	assert.Equal(t, assert.AnError, NewSSEWriter(fw).Stream(context.Background(), s))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	assert.Equal(t, context.Canceled, NewSSEWriter(&buf).Stream(ctx, s))
+}
@@ -0,0 +1,268 @@
+package chartype
+
+import (
+	"sort"
+	"sync"
+	"time"
+)
+
+var (
+	// ErrCandleNotFound is returned when the requested candle does not
+	// exist in the store.
+	ErrCandleNotFound = newError(CodeNotFound, "candle not found")
+)
+
+// CandleStore persists candle series identified by pair and interval.
+type CandleStore interface {
+	// Put inserts the candles into the pair's interval series.
+	// Candles with timestamps that already exist replace the stored
+	// ones.
+	Put(p Pair, i Interval, cc ...Candle) error
+
+	// Range returns the pair's interval series candles within the
+	// [from, to) period, sorted by timestamp in ascending order.
+	Range(p Pair, i Interval, from, to time.Time) ([]Candle, error)
+
+	// Latest returns the newest candle of the pair's interval series.
+	// ErrCandleNotFound is returned if the series is empty.
+	Latest(p Pair, i Interval) (Candle, error)
+
+	// Delete removes the pair's interval series candles within the
+	// [from, to) period.
+	Delete(p Pair, i Interval, from, to time.Time) error
+}
+
+// ResampledRanger is implemented by candle stores that can aggregate
+// candles while scanning them.
+type ResampledRanger interface {
+	// RangeResampled returns the pair's src interval series candles
+	// within the [from, to) period resampled into dst interval
+	// candles, sorted by timestamp in ascending order.
+	RangeResampled(p Pair, src, dst Interval, from, to time.Time) ([]Candle, error)
+}
+
+// RangeResampled returns the pair's src interval series candles
+// within the [from, to) period resampled into dst interval candles.
+// Store's own aggregation is used if it implements ResampledRanger,
+// otherwise the candles are loaded and resampled in memory.
+func RangeResampled(cs CandleStore, p Pair, src, dst Interval, from, to time.Time) ([]Candle, error) {
+	if rr, ok := cs.(ResampledRanger); ok {
+		return rr.RangeResampled(p, src, dst, from, to)
+	}
+
+	if err := src.ValidateResample(dst); err != nil {
+		return nil, err
+	}
+
+	cc, err := cs.Range(p, src, from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	return Resampler{Interval: dst}.Resample(cc)
+}
+
+// seriesKey identifies a single candle series.
+type seriesKey struct {
+	pair     Pair
+	interval Interval
+}
+
+// newSeriesKey validates the pair and the interval and creates a new
+// series key from them.
+func newSeriesKey(p Pair, i Interval) (seriesKey, error) {
+	if err := p.Validate(); err != nil {
+		return seriesKey{}, err
+	}
+
+	if err := i.Validate(); err != nil {
+		return seriesKey{}, err
+	}
+
+	return seriesKey{pair: p, interval: i}, nil
+}
+
+// MemoryStore is an in-memory candle store.
+// It is safe for concurrent use.
+type MemoryStore struct {
+	mu     sync.RWMutex
+	series map[seriesKey][]Candle
+}
+
+// NewMemoryStore creates a new empty in-memory candle store.
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{series: make(map[seriesKey][]Candle)}
+}
+
+// Put inserts the candles into the pair's interval series.
+// Candles with timestamps that already exist replace the stored ones.
+func (ms *MemoryStore) Put(p Pair, i Interval, cc ...Candle) error {
+	k, err := newSeriesKey(p, i)
+	if err != nil {
+		return err
+	}
+
+	ms.mu.Lock()
+	defer ms.mu.Unlock()
+
+	s := ms.series[k]
+
+	for _, c := range cc {
+		j := searchCandles(s, c.Timestamp)
+
+		if j < len(s) && s[j].Timestamp.Equal(c.Timestamp) {
+			s[j] = c
+			continue
+		}
+
+		s = append(s, Candle{})
+		copy(s[j+1:], s[j:])
+		s[j] = c
+	}
+
+	ms.series[k] = s
+
+	return nil
+}
+
+// Range returns the pair's interval series candles within the
+// [from, to) period, sorted by timestamp in ascending order.
+func (ms *MemoryStore) Range(p Pair, i Interval, from, to time.Time) ([]Candle, error) {
+	k, err := newSeriesKey(p, i)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = (TimeRange{From: from, To: to}).Validate(); err != nil {
+		return nil, err
+	}
+
+	ms.mu.RLock()
+	defer ms.mu.RUnlock()
+
+	s := ms.series[k]
+	s = s[searchCandles(s, from):searchCandles(s, to)]
+
+	res := make([]Candle, len(s))
+	copy(res, s)
+
+	return res, nil
+}
+
+// RangeResampled returns the pair's src interval series candles
+// within the [from, to) period resampled into dst interval candles,
+// sorted by timestamp in ascending order. Candles are aggregated
+// while being scanned, without being copied.
+func (ms *MemoryStore) RangeResampled(p Pair, src, dst Interval, from, to time.Time) ([]Candle, error) {
+	if err := src.ValidateResample(dst); err != nil {
+		return nil, err
+	}
+
+	k, err := newSeriesKey(p, src)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = (TimeRange{From: from, To: to}).Validate(); err != nil {
+		return nil, err
+	}
+
+	sr := &StreamResampler{resampler: Resampler{Interval: dst}}
+
+	var res []Candle
+
+	ms.mu.RLock()
+	defer ms.mu.RUnlock()
+
+	s := ms.series[k]
+	for _, c := range s[searchCandles(s, from):searchCandles(s, to)] {
+		if rc, ok := sr.Add(c); ok {
+			res = append(res, rc)
+		}
+	}
+
+	if rc, ok := sr.Flush(); ok {
+		res = append(res, rc)
+	}
+
+	return res, nil
+}
+
+// Latest returns the newest candle of the pair's interval series.
+// ErrCandleNotFound is returned if the series is empty.
+func (ms *MemoryStore) Latest(p Pair, i Interval) (Candle, error) {
+	k, err := newSeriesKey(p, i)
+	if err != nil {
+		return Candle{}, err
+	}
+
+	ms.mu.RLock()
+	defer ms.mu.RUnlock()
+
+	s := ms.series[k]
+	if len(s) == 0 {
+		return Candle{}, ErrCandleNotFound
+	}
+
+	return s[len(s)-1], nil
+}
+
+// Series returns all non-empty series of the store, sorted by pair
+// and interval.
+func (ms *MemoryStore) Series() ([]Series, error) {
+	ms.mu.RLock()
+
+	res := make([]Series, 0, len(ms.series))
+	for k := range ms.series {
+		res = append(res, Series{Pair: k.pair, Interval: k.interval})
+	}
+
+	ms.mu.RUnlock()
+
+	sort.Slice(res, func(i, j int) bool {
+		if res[i].Pair != res[j].Pair {
+			return res[i].Pair.String() < res[j].Pair.String()
+		}
+
+		return res[i].Interval < res[j].Interval
+	})
+
+	return res, nil
+}
+
+// Delete removes the pair's interval series candles within the
+// [from, to) period.
+func (ms *MemoryStore) Delete(p Pair, i Interval, from, to time.Time) error {
+	k, err := newSeriesKey(p, i)
+	if err != nil {
+		return err
+	}
+
+	if err = (TimeRange{From: from, To: to}).Validate(); err != nil {
+		return err
+	}
+
+	ms.mu.Lock()
+	defer ms.mu.Unlock()
+
+	s := ms.series[k]
+	start, end := searchCandles(s, from), searchCandles(s, to)
+
+	s = append(s[:start], s[end:]...)
+	if len(s) == 0 {
+		delete(ms.series, k)
+		return nil
+	}
+
+	ms.series[k] = s
+
+	return nil
+}
+
+// searchCandles returns the position of the first candle whose
+// timestamp is not before the provided time.
+func searchCandles(cc []Candle, t time.Time) int {
+	return sort.Search(len(cc), func(i int) bool {
+		return !cc[i].Timestamp.Before(t)
+	})
+}
@@ -0,0 +1,205 @@
+package chartype
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_MemoryStore(t *testing.T) {
+	tm := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	p := Pair{Base: "BTC", Quote: "USDT"}
+	at := func(m int) time.Time {
+		return tm.Add(time.Duration(m) * time.Minute)
+	}
+
+	ms := NewMemoryStore()
+
+	assert.Equal(t, ErrInvalidPair, ms.Put(Pair{}, IntervalMinute))
+	assert.Equal(t, ErrInvalidInterval, ms.Put(p, 0))
+
+	_, err := ms.Latest(p, IntervalMinute)
+	assert.Equal(t, ErrCandleNotFound, err)
+
+	assert.NoError(t, ms.Put(p, IntervalMinute,
+		testCandle(at(2), 3, 3, 3, 3, 3),
+		testCandle(at(0), 1, 1, 1, 1, 1),
+		testCandle(at(1), 2, 2, 2, 2, 2),
+	))
+	assert.NoError(t, ms.Put(p, IntervalMinute,
+		testCandle(at(1), 5, 5, 5, 5, 5),
+		testCandle(at(3), 4, 4, 4, 4, 4),
+	))
+
+	assert.NoError(t, ms.Put(p, IntervalHour, testCandle(at(0), 1, 1, 1, 1, 1)))
+	assert.NoError(t, ms.Put(Pair{Base: "ETH", Quote: "USDT"}, IntervalMinute, testCandle(at(0), 1, 1, 1, 1, 1)))
+
+	ss, err := ms.Series()
+	assert.NoError(t, err)
+	assert.Equal(t, []Series{
+		{Pair: p, Interval: IntervalMinute},
+		{Pair: p, Interval: IntervalHour},
+		{Pair: Pair{Base: "ETH", Quote: "USDT"}, Interval: IntervalMinute},
+	}, ss)
+
+	assert.NoError(t, ms.Delete(p, IntervalHour, at(0), at(1)))
+	assert.NoError(t, ms.Delete(Pair{Base: "ETH", Quote: "USDT"}, IntervalMinute, at(0), at(1)))
+
+	_, err = ms.Range(Pair{}, IntervalMinute, at(0), at(3))
+	assert.Equal(t, ErrInvalidPair, err)
+
+	_, err = ms.Range(p, IntervalMinute, at(3), at(0))
+	assert.Equal(t, ErrInvalidTimeRange, err)
+
+	res, err := ms.Range(p, IntervalMinute, at(1), at(3))
+	assert.NoError(t, err)
+	assert.Equal(t, []Candle{
+		testCandle(at(1), 5, 5, 5, 5, 5),
+		testCandle(at(2), 3, 3, 3, 3, 3),
+	}, res)
+
+	res, err = ms.Range(p, IntervalHour, at(0), at(3))
+	assert.NoError(t, err)
+	assert.Empty(t, res)
+
+	_, err = ms.Latest(Pair{}, IntervalMinute)
+	assert.Equal(t, ErrInvalidPair, err)
+
+	c, err := ms.Latest(p, IntervalMinute)
+	assert.NoError(t, err)
+	assert.Equal(t, testCandle(at(3), 4, 4, 4, 4, 4), c)
+
+	assert.Equal(t, ErrInvalidPair, ms.Delete(Pair{}, IntervalMinute, at(0), at(1)))
+	assert.Equal(t, ErrInvalidTimeRange, ms.Delete(p, IntervalMinute, at(1), at(0)))
+
+	assert.NoError(t, ms.Delete(p, IntervalMinute, at(1), at(3)))
+
+	res, err = ms.Range(p, IntervalMinute, at(0), at(4))
+	assert.NoError(t, err)
+	assert.Equal(t, []Candle{
+		testCandle(at(0), 1, 1, 1, 1, 1),
+		testCandle(at(3), 4, 4, 4, 4, 4),
+	}, res)
+
+	assert.NoError(t, ms.Delete(p, IntervalMinute, at(0), at(4)))
+
+	_, err = ms.Latest(p, IntervalMinute)
+	assert.Equal(t, ErrCandleNotFound, err)
+}
+
+func Test_MemoryStore_Concurrency(t *testing.T) {
+	tm := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	p := Pair{Base: "BTC", Quote: "USDT"}
+
+	var _ CandleStore = NewMemoryStore()
+
+	ms := NewMemoryStore()
+	done := make(chan struct{})
+
+	for i := 0; i < 10; i++ {
+		go func(i int) {
+			assert.NoError(t, ms.Put(p, IntervalMinute, testCandle(tm.Add(time.Duration(i)*time.Minute), 1, 1, 1, 1, 1)))
+
+			_, err := ms.Range(p, IntervalMinute, tm, tm.Add(time.Hour))
+			assert.NoError(t, err)
+
+			done <- struct{}{}
+		}(i)
+	}
+
+	for i := 0; i < 10; i++ {
+		<-done
+	}
+
+	res, err := ms.Range(p, IntervalMinute, tm, tm.Add(time.Hour))
+	assert.NoError(t, err)
+	assert.Len(t, res, 10)
+}
+
+func Test_RangeResampled(t *testing.T) {
+	tm := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	p := Pair{Base: "BTC", Quote: "USDT"}
+
+	ms := NewMemoryStore()
+	assert.NoError(t, ms.Put(p, IntervalHour,
+		testCandle(tm.Add(-time.Hour), 9, 9, 9, 9, 9),
+		testCandle(tm, 1, 4, 1, 3, 1),
+		testCandle(tm.Add(12*time.Hour), 3, 5, 2, 4, 2),
+		testCandle(tm.Add(24*time.Hour), 4, 9, 4, 8, 3),
+		testCandle(tm.Add(48*time.Hour), 8, 8, 6, 7, 4),
+	))
+
+	stores := map[string]CandleStore{
+		"Aggregating store": ms,
+		"Plain store":       struct{ CandleStore }{ms},
+	}
+
+	cc := map[string]struct {
+		Pair   Pair
+		Src    Interval
+		Dst    Interval
+		From   time.Time
+		To     time.Time
+		Result []Candle
+		Err    error
+	}{
+		"Incompatible intervals": {
+			Pair: p,
+			Src:  IntervalDay,
+			Dst:  IntervalHour,
+			From: tm,
+			To:   tm.Add(48 * time.Hour),
+			Err:  ErrIncompatibleIntervals,
+		},
+		"Invalid pair": {
+			Src:  IntervalHour,
+			Dst:  IntervalDay,
+			From: tm,
+			To:   tm.Add(48 * time.Hour),
+			Err:  ErrInvalidPair,
+		},
+		"Invalid time range": {
+			Pair: p,
+			Src:  IntervalHour,
+			Dst:  IntervalDay,
+			Err:  ErrInvalidTimeRange,
+		},
+		"Successful empty range": {
+			Pair: p,
+			Src:  IntervalHour,
+			Dst:  IntervalDay,
+			From: tm.Add(72 * time.Hour),
+			To:   tm.Add(96 * time.Hour),
+		},
+		"Successful range": {
+			Pair: p,
+			Src:  IntervalHour,
+			Dst:  IntervalDay,
+			From: tm,
+			To:   tm.Add(48 * time.Hour),
+			Result: []Candle{
+				testCandle(tm, 1, 5, 1, 4, 3),
+				testCandle(tm.Add(24*time.Hour), 4, 9, 4, 8, 3),
+			},
+		},
+	}
+
+	for sn, cs := range stores {
+		for cn, c := range cc {
+			cs, c := cs, c
+
+			t.Run(sn+"/"+cn, func(t *testing.T) {
+				t.Parallel()
+
+				res, err := RangeResampled(cs, c.Pair, c.Src, c.Dst, c.From, c.To)
+				equalError(t, c.Err, err)
+				if err != nil {
+					return
+				}
+
+				assert.Equal(t, c.Result, res)
+			})
+		}
+	}
+}
@@ -0,0 +1,100 @@
+package chartype
+
+import "time"
+
+// StreamResampler incrementally aggregates candles arriving one at a
+// time into longer interval candles, the same way as the resampler
+// it is based on does.
+//
+// Candles older than the candle currently being built are counted and
+// dropped. A candle with the same timestamp as a previously added one
+// replaces it, e.g. when the source sends updates of its own candle
+// that is still being built.
+//
+// StreamResampler is not safe for concurrent use.
+type StreamResampler struct {
+	resampler Resampler
+	head      *Candle
+	parts     []Candle
+	dropped   int
+}
+
+// NewStreamResampler creates a new stream resampler that aggregates
+// candles using the provided resampler's settings.
+func NewStreamResampler(r Resampler) (*StreamResampler, error) {
+	if err := r.Validate(); err != nil {
+		return nil, err
+	}
+
+	return &StreamResampler{resampler: r}, nil
+}
+
+// Add merges the candle into the longer interval candle it belongs
+// to. The candle closed by it, if any, is returned.
+func (sr *StreamResampler) Add(c Candle) (Candle, bool) {
+	r := sr.resampler
+	if r.Calendar != nil && r.Calendar.IsHoliday(c.Timestamp) {
+		return Candle{}, false
+	}
+
+	ts := r.truncate(c.Timestamp)
+
+	switch {
+	case sr.head == nil || ts.After(sr.head.Timestamp):
+		res, ok := sr.Flush()
+		sr.parts = []Candle{c}
+		sr.build(ts)
+
+		return res, ok
+	case ts.Equal(sr.head.Timestamp):
+		i := searchCandles(sr.parts, c.Timestamp)
+		if i == len(sr.parts) || !sr.parts[i].Timestamp.Equal(c.Timestamp) {
+			sr.parts = append(sr.parts, Candle{})
+			copy(sr.parts[i+1:], sr.parts[i:])
+		}
+
+		sr.parts[i] = c
+		sr.build(ts)
+	default:
+		sr.dropped++
+	}
+
+	return Candle{}, false
+}
+
+// build rebuilds the candle that is currently being built from the
+// candles it consists of.
+func (sr *StreamResampler) build(ts time.Time) {
+	h := sr.parts[0]
+	for _, c := range sr.parts[1:] {
+		h = sr.resampler.merge(h, c)
+	}
+
+	h.Timestamp = ts
+	sr.head = &h
+}
+
+// Head returns the candle that is currently being built and whether
+// there is one.
+func (sr *StreamResampler) Head() (Candle, bool) {
+	if sr.head == nil {
+		return Candle{}, false
+	}
+
+	return *sr.head, true
+}
+
+// Flush closes and returns the candle that is currently being built
+// and whether there was one.
+func (sr *StreamResampler) Flush() (Candle, bool) {
+	c, ok := sr.Head()
+	sr.head = nil
+	sr.parts = nil
+
+	return c, ok
+}
+
+// Dropped returns the number of dropped candles.
+func (sr *StreamResampler) Dropped() int {
+	return sr.dropped
+}
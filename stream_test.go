@@ -0,0 +1,156 @@
+package chartype
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_NewStreamResampler(t *testing.T) {
+	cc := map[string]struct {
+		Resampler Resampler
+		Err       error
+	}{
+		"Invalid interval": {
+			Err: ErrInvalidInterval,
+		},
+		"Successful creation": {
+			Resampler: Resampler{Interval: IntervalHour},
+		},
+	}
+
+	for cn, c := range cc {
+		c := c
+
+		t.Run(cn, func(t *testing.T) {
+			t.Parallel()
+
+			sr, err := NewStreamResampler(c.Resampler)
+			equalError(t, c.Err, err)
+			if err != nil {
+				return
+			}
+
+			assert.NotNil(t, sr)
+		})
+	}
+}
+
+func Test_StreamResampler(t *testing.T) {
+	tm := time.Date(2020, 12, 24, 23, 0, 0, 0, time.UTC)
+	day := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	sc, err := NewStaticCalendar(nil, "2020-12-25")
+	require.NoError(t, err)
+
+	single := testCandle(tm, 3, 5, 2, 4, 2)
+	holiday := testCandle(tm.Add(25*time.Hour), 8, 8, 6, 7, 4)
+	offset := testCandle(day.Add(2*time.Hour), 3, 9, 2, 8, 5)
+	merged := testCandle(tm, 1, 5, 1, 4, 3)
+
+	first := testCandle(tm, 1, 4, 1, 3, 10)
+	first.Delta = decimalPtr(10)
+
+	update := testCandle(tm, 1, 6, 1, 3, 12)
+	update.Delta = decimalPtr(2)
+
+	next := testCandle(tm.Add(30*time.Minute), 3, 5, 2, 4, 2)
+	next.Delta = decimalPtr(2)
+
+	updated := testCandle(tm, 1, 6, 1, 4, 14)
+	updated.Delta = decimalPtr(4)
+
+	cc := map[string]struct {
+		Resampler Resampler
+		Candles   []Candle
+		Result    []Candle
+		Head      *Candle
+		Dropped   int
+	}{
+		"Empty stream": {
+			Resampler: Resampler{Interval: IntervalHour},
+		},
+		"Single candle": {
+			Resampler: Resampler{Interval: IntervalHour},
+			Candles:   []Candle{testCandle(tm.Add(30*time.Minute), 3, 5, 2, 4, 2)},
+			Head:      &single,
+		},
+		"Updated candles": {
+			Resampler: Resampler{Interval: IntervalHour},
+			// the first candle is updated after the next one
+			Candles: []Candle{first, next, update},
+			Head:    &updated,
+		},
+		"Out of order candles": {
+			Resampler: Resampler{Interval: IntervalHour},
+			Candles: []Candle{
+				testCandle(tm.Add(30*time.Minute), 3, 5, 2, 4, 2),
+				testCandle(tm, 1, 4, 1, 3, 1),
+			},
+			Head: &merged,
+		},
+		"Holiday and stale candles": {
+			Resampler: Resampler{Interval: IntervalHour, Calendar: sc},
+			Candles: []Candle{
+				testCandle(tm, 1, 4, 1, 3, 1),
+				testCandle(tm.Add(30*time.Minute), 3, 5, 2, 4, 2),
+				// holiday
+				testCandle(tm.Add(time.Hour), 4, 9, 4, 8, 3),
+				testCandle(tm.Add(25*time.Hour+15*time.Minute), 8, 8, 6, 7, 4),
+				// older than the head
+				testCandle(tm.Add(30*time.Minute), 1, 1, 1, 1, 1),
+			},
+			Result:  []Candle{testCandle(tm, 1, 5, 1, 4, 3)},
+			Head:    &holiday,
+			Dropped: 1,
+		},
+		"Offset buckets": {
+			Resampler: Resampler{Interval: 4 * IntervalHour, Offset: 2 * time.Hour},
+			Candles: []Candle{
+				testCandle(day.Add(time.Hour), 1, 4, 1, 3, 1),
+				testCandle(day.Add(2*time.Hour), 3, 5, 2, 4, 2),
+				testCandle(day.Add(5*time.Hour), 4, 9, 4, 8, 3),
+			},
+			Result: []Candle{testCandle(day.Add(-2*time.Hour), 1, 4, 1, 3, 1)},
+			Head:   &offset,
+		},
+	}
+
+	for cn, c := range cc {
+		c := c
+
+		t.Run(cn, func(t *testing.T) {
+			t.Parallel()
+
+			sr, err := NewStreamResampler(c.Resampler)
+			require.NoError(t, err)
+
+			var res []Candle
+
+			for _, cd := range c.Candles {
+				if closed, ok := sr.Add(cd); ok {
+					res = append(res, closed)
+				}
+			}
+
+			assert.Equal(t, c.Result, res)
+			assert.Equal(t, c.Dropped, sr.Dropped())
+
+			h, ok := sr.Head()
+			assert.Equal(t, c.Head != nil, ok)
+
+			f, fok := sr.Flush()
+			assert.Equal(t, ok, fok)
+
+			if c.Head != nil {
+				assert.Equal(t, *c.Head, h)
+				assert.Equal(t, *c.Head, f)
+			}
+
+			_, ok = sr.Flush()
+			assert.False(t, ok)
+		})
+	}
+}
@@ -0,0 +1,61 @@
+package chartype
+
+var (
+	// ErrInvalidLength is returned when zero or negative length is
+	// used where a positive one is expected.
+	ErrInvalidLength = newError(CodeInvalidArgument, "invalid length")
+)
+
+// Stuck returns time ranges of at least n consecutive candles that
+// look like a dead feed: candles with zero volume or with the same
+// open, high, low and close prices as the previous candle. Candles
+// must be sorted by timestamp in ascending order; each time range
+// ends one interval after its last candle's timestamp.
+func (cc Candles) Stuck(n int, i Interval) ([]TimeRange, error) {
+	if n <= 0 {
+		return nil, ErrInvalidLength
+	}
+
+	if err := i.Validate(); err != nil {
+		return nil, err
+	}
+
+	var res []TimeRange
+
+	start := -1
+
+	flush := func(end int) {
+		if start >= 0 && end-start >= n {
+			res = append(res, TimeRange{
+				From: cc[start].Timestamp,
+				To:   cc[end-1].Timestamp.Add(i.Duration()),
+			})
+		}
+
+		start = -1
+	}
+
+	for j, c := range cc {
+		same := j > 0 && samePrices(cc[j-1], c)
+
+		switch {
+		case same && start < 0:
+			start = j - 1
+		case c.Volume.IsZero() && start < 0:
+			start = j
+		case !same && !c.Volume.IsZero():
+			flush(j)
+		}
+	}
+
+	flush(len(cc))
+
+	return res, nil
+}
+
+// samePrices checks whether both candles have equal open, high, low
+// and close prices.
+func samePrices(c1, c2 Candle) bool {
+	return c1.Open.Equal(c2.Open) && c1.High.Equal(c2.High) &&
+		c1.Low.Equal(c2.Low) && c1.Close.Equal(c2.Close)
+}
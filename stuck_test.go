@@ -0,0 +1,75 @@
+package chartype
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Candles_Stuck(t *testing.T) {
+	tm := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	at := func(m int) time.Time {
+		return tm.Add(time.Duration(m) * time.Minute)
+	}
+
+	series := Candles{
+		testCandle(at(0), 1, 2, 1, 2, 1),
+		testCandle(at(1), 2, 3, 1, 2, 1),
+		testCandle(at(2), 2, 3, 1, 2, 5),
+		testCandle(at(3), 2, 3, 1, 2, 0),
+		testCandle(at(4), 3, 3, 3, 3, 1),
+		testCandle(at(5), 3, 3, 3, 3, 0),
+		testCandle(at(6), 4, 5, 3, 4, 2),
+		testCandle(at(7), 4, 4, 4, 4, 0),
+		testCandle(at(8), 5, 5, 5, 5, 0),
+	}
+
+	cc := map[string]struct {
+		Length   int
+		Interval Interval
+		Result   []TimeRange
+		Err      error
+	}{
+		"Invalid length": {
+			Interval: IntervalMinute,
+			Err:      ErrInvalidLength,
+		},
+		"Invalid interval": {
+			Length: 2,
+			Err:    ErrInvalidInterval,
+		},
+		"Short stretches included": {
+			Length:   2,
+			Interval: IntervalMinute,
+			Result: []TimeRange{
+				{From: at(1), To: at(4)},
+				{From: at(4), To: at(6)},
+				{From: at(7), To: at(9)},
+			},
+		},
+		"Short stretches excluded": {
+			Length:   3,
+			Interval: IntervalMinute,
+			Result: []TimeRange{
+				{From: at(1), To: at(4)},
+			},
+		},
+	}
+
+	for cn, c := range cc {
+		c := c
+
+		t.Run(cn, func(t *testing.T) {
+			t.Parallel()
+
+			res, err := series.Stuck(c.Length, c.Interval)
+			equalError(t, c.Err, err)
+			if err != nil {
+				return
+			}
+
+			assert.Equal(t, c.Result, res)
+		})
+	}
+}
@@ -0,0 +1,120 @@
+package chartype
+
+import (
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+const (
+	// SwingHigh specifies a local high price.
+	SwingHigh SwingKind = iota + 1
+
+	// SwingLow specifies a local low price.
+	SwingLow
+)
+
+var (
+	// ErrInvalidSwingKind is returned when swing kind with invalid
+	// value is being used.
+	ErrInvalidSwingKind = newError(CodeInvalidArgument, "invalid swing kind")
+)
+
+// SwingKind specifies whether a swing point is a local high or low.
+// Can be included in configuration structures.
+type SwingKind int
+
+// Validate checks whether the swing kind is one of supported kind
+// types or not.
+func (sk SwingKind) Validate() error {
+	switch sk {
+	case SwingHigh, SwingLow:
+		return nil
+	default:
+		return ErrInvalidSwingKind
+	}
+}
+
+// MarshalText turns swing kind to appropriate string representation.
+func (sk SwingKind) MarshalText() ([]byte, error) {
+	var v string
+
+	switch sk {
+	case SwingHigh:
+		v = "high"
+	case SwingLow:
+		v = "low"
+	default:
+		return nil, ErrInvalidSwingKind
+	}
+
+	return []byte(v), nil
+}
+
+// UnmarshalText turns string to appropriate swing kind value.
+func (sk *SwingKind) UnmarshalText(d []byte) error {
+	switch string(d) {
+	case "high":
+		*sk = SwingHigh
+	case "low":
+		*sk = SwingLow
+	default:
+		return ErrInvalidSwingKind
+	}
+
+	return nil
+}
+
+// SwingPoint describes a candle whose high or low price is a local
+// extreme.
+type SwingPoint struct {
+	// Index specifies the index of the candle.
+	Index int `json:"index" yaml:"index"`
+
+	// Timestamp specifies the timestamp of the candle.
+	Timestamp time.Time `json:"timestamp" yaml:"timestamp"`
+
+	// Kind specifies whether the candle's high or low price is
+	// the extreme.
+	Kind SwingKind `json:"kind" yaml:"kind"`
+
+	// Price specifies the extreme price.
+	Price decimal.Decimal `json:"price" yaml:"price"`
+}
+
+// SwingPoints detects fractal swing points: candles whose high price
+// is strictly greater, or whose low price is strictly lower, than the
+// ones of lookback candles on each side. Candles closer than lookback
+// to either end of the series are not checked. A candle may be both
+// a swing high and a swing low, in which case the high is listed
+// first. Candles must be sorted by timestamp in ascending order.
+func SwingPoints(cc []Candle, lookback int) ([]SwingPoint, error) {
+	if lookback <= 0 {
+		return nil, ErrInvalidLength
+	}
+
+	var res []SwingPoint
+
+	for i := lookback; i < len(cc)-lookback; i++ {
+		high, low := true, true
+
+		for j := i - lookback; j <= i+lookback && (high || low); j++ {
+			if j == i {
+				continue
+			}
+
+			high = high && cc[i].High.GreaterThan(cc[j].High)
+			low = low && cc[i].Low.LessThan(cc[j].Low)
+		}
+
+		if high {
+			res = append(res, SwingPoint{Index: i, Timestamp: cc[i].Timestamp, Kind: SwingHigh, Price: cc[i].High})
+		}
+
+		if low {
+			res = append(res, SwingPoint{Index: i, Timestamp: cc[i].Timestamp, Kind: SwingLow, Price: cc[i].Low})
+		}
+	}
+
+	return res, nil
+}
@@ -0,0 +1,180 @@
+package chartype
+
+import (
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_SwingKind_Validate(t *testing.T) {
+	cc := map[string]struct {
+		Kind SwingKind
+		Err  error
+	}{
+		"Invalid SwingKind": {
+			Kind: 70,
+			Err:  ErrInvalidSwingKind,
+		},
+		"Successful SwingHigh validation": {
+			Kind: SwingHigh,
+		},
+		"Successful SwingLow validation": {
+			Kind: SwingLow,
+		},
+	}
+
+	for cn, c := range cc {
+		c := c
+
+		t.Run(cn, func(t *testing.T) {
+			t.Parallel()
+
+			equalError(t, c.Err, c.Kind.Validate())
+		})
+	}
+}
+
+func Test_SwingKind_MarshalText(t *testing.T) {
+	cc := map[string]struct {
+		Kind SwingKind
+		Text string
+		Err  error
+	}{
+		"Invalid SwingKind": {
+			Kind: 70,
+			Err:  ErrInvalidSwingKind,
+		},
+		"Successful SwingHigh marshal": {
+			Kind: SwingHigh,
+			Text: "high",
+		},
+		"Successful SwingLow marshal": {
+			Kind: SwingLow,
+			Text: "low",
+		},
+	}
+
+	for cn, c := range cc {
+		c := c
+
+		t.Run(cn, func(t *testing.T) {
+			t.Parallel()
+
+			d, err := c.Kind.MarshalText()
+			equalError(t, c.Err, err)
+			if err != nil {
+				return
+			}
+
+			assert.Equal(t, c.Text, string(d))
+		})
+	}
+}
+
+func Test_SwingKind_UnmarshalText(t *testing.T) {
+	cc := map[string]struct {
+		Text   string
+		Result SwingKind
+		Err    error
+	}{
+		"Invalid SwingKind": {
+			Text: "middle",
+			Err:  ErrInvalidSwingKind,
+		},
+		"Successful SwingHigh unmarshal": {
+			Text:   "high",
+			Result: SwingHigh,
+		},
+		"Successful SwingLow unmarshal": {
+			Text:   "low",
+			Result: SwingLow,
+		},
+	}
+
+	for cn, c := range cc {
+		c := c
+
+		t.Run(cn, func(t *testing.T) {
+			t.Parallel()
+
+			var sk SwingKind
+
+			err := sk.UnmarshalText([]byte(c.Text))
+			equalError(t, c.Err, err)
+			if err != nil {
+				return
+			}
+
+			assert.Equal(t, c.Result, sk)
+		})
+	}
+}
+
+func Test_SwingPoints(t *testing.T) {
+	tm := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	at := func(d int) time.Time {
+		return tm.AddDate(0, 0, d)
+	}
+
+	series := []Candle{
+		testCandle(at(0), 10, 12, 9, 11, 1),
+		testCandle(at(1), 11, 15, 10, 14, 1),
+		testCandle(at(2), 14, 14, 8, 9, 1),
+		testCandle(at(3), 9, 13, 9, 12, 1),
+		testCandle(at(4), 12, 13, 10, 11, 1),
+		testCandle(at(5), 11, 20, 5, 11, 1),
+		testCandle(at(6), 11, 12, 10, 11, 1),
+	}
+
+	cc := map[string]struct {
+		Candles  []Candle
+		Lookback int
+		Result   []SwingPoint
+		Err      error
+	}{
+		"Invalid lookback": {
+			Candles:  series,
+			Lookback: 0,
+			Err:      ErrInvalidLength,
+		},
+		"Series too short": {
+			Candles:  series[:2],
+			Lookback: 1,
+		},
+		"Lookback of one": {
+			Candles:  series,
+			Lookback: 1,
+			Result: []SwingPoint{
+				{Index: 1, Timestamp: at(1), Kind: SwingHigh, Price: decimal.NewFromInt(15)},
+				{Index: 2, Timestamp: at(2), Kind: SwingLow, Price: decimal.NewFromInt(8)},
+				{Index: 5, Timestamp: at(5), Kind: SwingHigh, Price: decimal.NewFromInt(20)},
+				{Index: 5, Timestamp: at(5), Kind: SwingLow, Price: decimal.NewFromInt(5)},
+			},
+		},
+		"Lookback of two": {
+			Candles:  series,
+			Lookback: 2,
+			Result: []SwingPoint{
+				{Index: 2, Timestamp: at(2), Kind: SwingLow, Price: decimal.NewFromInt(8)},
+			},
+		},
+	}
+
+	for cn, c := range cc {
+		c := c
+
+		t.Run(cn, func(t *testing.T) {
+			t.Parallel()
+
+			res, err := SwingPoints(c.Candles, c.Lookback)
+			equalError(t, c.Err, err)
+			if err != nil {
+				return
+			}
+
+			assert.Equal(t, c.Result, res)
+		})
+	}
+}
@@ -0,0 +1,213 @@
+package chartype
+
+import "strings"
+
+const (
+	// SymbolConcat specifies symbols with no separator between
+	// currencies, e.g. "BTCUSDT".
+	SymbolConcat SymbolStyle = iota + 1
+
+	// SymbolDash specifies symbols with currencies separated by a
+	// dash, e.g. "BTC-USD".
+	SymbolDash
+
+	// SymbolSlash specifies symbols with currencies separated by a
+	// slash, e.g. "BTC/USD".
+	SymbolSlash
+
+	// SymbolUnderscore specifies symbols with currencies separated by
+	// an underscore, e.g. "BTC_USDT". It matches pair's string
+	// representation.
+	SymbolUnderscore
+)
+
+// symbolSeparators holds characters that may separate currencies in
+// exchange symbols.
+const symbolSeparators = "-/_:"
+
+var (
+	// ErrInvalidSymbolStyle is returned when symbol style with
+	// invalid value is being used.
+	ErrInvalidSymbolStyle = newError(CodeInvalidArgument, "invalid symbol style")
+
+	// ErrInvalidSymbol is returned when symbol cannot be split into
+	// base and quote currencies.
+	ErrInvalidSymbol = newError(CodeInvalidArgument, "invalid symbol")
+)
+
+// SymbolStyle specifies the notation of exchange symbols.
+type SymbolStyle int
+
+// Validate checks whether the symbol style is one of supported
+// style types or not.
+func (ss SymbolStyle) Validate() error {
+	switch ss {
+	case SymbolConcat, SymbolDash, SymbolSlash, SymbolUnderscore:
+		return nil
+	default:
+		return ErrInvalidSymbolStyle
+	}
+}
+
+// MarshalText turns symbol style to appropriate string
+// representation.
+func (ss SymbolStyle) MarshalText() ([]byte, error) {
+	var v string
+
+	switch ss {
+	case SymbolConcat:
+		v = "concat"
+	case SymbolDash:
+		v = "dash"
+	case SymbolSlash:
+		v = "slash"
+	case SymbolUnderscore:
+		v = "underscore"
+	default:
+		return nil, ErrInvalidSymbolStyle
+	}
+
+	return []byte(v), nil
+}
+
+// UnmarshalText turns string to appropriate symbol style value.
+func (ss *SymbolStyle) UnmarshalText(d []byte) error {
+	switch string(d) {
+	case "concat":
+		*ss = SymbolConcat
+	case "dash":
+		*ss = SymbolDash
+	case "slash":
+		*ss = SymbolSlash
+	case "underscore":
+		*ss = SymbolUnderscore
+	default:
+		return ErrInvalidSymbolStyle
+	}
+
+	return nil
+}
+
+// separator returns the string placed between symbol's currencies.
+func (ss SymbolStyle) separator() string {
+	switch ss {
+	case SymbolDash:
+		return "-"
+	case SymbolSlash:
+		return "/"
+	case SymbolUnderscore:
+		return pairSeparator
+	default:
+		return ""
+	}
+}
+
+// FormatSymbol returns pair's symbol in the provided notation.
+func FormatSymbol(p Pair, ss SymbolStyle) (string, error) {
+	if err := ss.Validate(); err != nil {
+		return "", err
+	}
+
+	if err := p.Validate(); err != nil {
+		return "", err
+	}
+
+	return p.Base + ss.separator() + p.Quote, nil
+}
+
+// SymbolNormalizer converts exchange symbols between notations and
+// replaces exchange-specific currency codes with canonical ones.
+type SymbolNormalizer struct {
+	// Aliases maps exchange-specific currency codes to canonical
+	// ones, e.g. "XBT" to "BTC".
+	Aliases map[string]string
+
+	// Quotes holds quote currencies used to split symbols that have
+	// no separator. The longest matching suffix is used, so codes
+	// that end with another quote currency (e.g. "TUSD" and "USD")
+	// make symbols such as "XBTUSD" ambiguous.
+	Quotes []string
+}
+
+// NewSymbolNormalizer creates a new symbol normalizer with the
+// commonly used aliases and quote currencies.
+func NewSymbolNormalizer() *SymbolNormalizer {
+	return &SymbolNormalizer{
+		Aliases: map[string]string{
+			"XBT": "BTC",
+			"XDG": "DOGE",
+			"BCC": "BCH",
+		},
+		Quotes: []string{
+			"USDT", "USDC", "BUSD", "DAI", "USD", "EUR",
+			"GBP", "JPY", "TRY", "BTC", "XBT", "ETH", "BNB",
+		},
+	}
+}
+
+// Parse splits the symbol into a pair of canonical currency codes.
+// Symbols are case-insensitive and may use any supported notation.
+func (sn *SymbolNormalizer) Parse(symbol string) (Pair, error) {
+	s := strings.ToUpper(strings.TrimSpace(symbol))
+
+	var p Pair
+
+	if i := strings.IndexAny(s, symbolSeparators); i >= 0 {
+		p = Pair{Base: s[:i], Quote: s[i+1:]}
+	} else {
+		p = sn.split(s)
+	}
+
+	p.Base = sn.alias(p.Base)
+	p.Quote = sn.alias(p.Quote)
+
+	if p.Validate() != nil {
+		return Pair{}, ErrInvalidSymbol
+	}
+
+	return p, nil
+}
+
+// Normalize converts the symbol into the provided notation using
+// canonical currency codes.
+func (sn *SymbolNormalizer) Normalize(symbol string, ss SymbolStyle) (string, error) {
+	if err := ss.Validate(); err != nil {
+		return "", err
+	}
+
+	p, err := sn.Parse(symbol)
+	if err != nil {
+		return "", err
+	}
+
+	return FormatSymbol(p, ss)
+}
+
+// split splits the symbol with no separator by the longest known
+// quote currency suffix. Zero pair is returned if no quote currency
+// matches.
+func (sn *SymbolNormalizer) split(s string) Pair {
+	var q string
+
+	for _, v := range sn.Quotes {
+		v = strings.ToUpper(v)
+		if len(v) > len(q) && len(v) < len(s) && strings.HasSuffix(s, v) {
+			q = v
+		}
+	}
+
+	if q == "" {
+		return Pair{}
+	}
+
+	return Pair{Base: s[:len(s)-len(q)], Quote: q}
+}
+
+// alias returns the canonical code of the currency.
+func (sn *SymbolNormalizer) alias(c string) string {
+	if v, ok := sn.Aliases[c]; ok {
+		return v
+	}
+
+	return c
+}
@@ -0,0 +1,287 @@
+package chartype
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_SymbolStyle_Validate(t *testing.T) {
+	cc := map[string]struct {
+		Style SymbolStyle
+		Err   error
+	}{
+		"Invalid SymbolStyle": {
+			Style: 70,
+			Err:   ErrInvalidSymbolStyle,
+		},
+		"Successful SymbolConcat validation": {
+			Style: SymbolConcat,
+		},
+		"Successful SymbolDash validation": {
+			Style: SymbolDash,
+		},
+		"Successful SymbolSlash validation": {
+			Style: SymbolSlash,
+		},
+		"Successful SymbolUnderscore validation": {
+			Style: SymbolUnderscore,
+		},
+	}
+
+	for cn, c := range cc {
+		c := c
+
+		t.Run(cn, func(t *testing.T) {
+			t.Parallel()
+
+			equalError(t, c.Err, c.Style.Validate())
+		})
+	}
+}
+
+func Test_SymbolStyle_MarshalText(t *testing.T) {
+	cc := map[string]struct {
+		Style SymbolStyle
+		Text  string
+		Err   error
+	}{
+		"Invalid SymbolStyle": {
+			Style: 70,
+			Err:   ErrInvalidSymbolStyle,
+		},
+		"Successful SymbolConcat marshal": {
+			Style: SymbolConcat,
+			Text:  "concat",
+		},
+		"Successful SymbolDash marshal": {
+			Style: SymbolDash,
+			Text:  "dash",
+		},
+		"Successful SymbolSlash marshal": {
+			Style: SymbolSlash,
+			Text:  "slash",
+		},
+		"Successful SymbolUnderscore marshal": {
+			Style: SymbolUnderscore,
+			Text:  "underscore",
+		},
+	}
+
+	for cn, c := range cc {
+		c := c
+
+		t.Run(cn, func(t *testing.T) {
+			t.Parallel()
+
+			d, err := c.Style.MarshalText()
+			equalError(t, c.Err, err)
+			if err != nil {
+				return
+			}
+
+			assert.Equal(t, c.Text, string(d))
+		})
+	}
+}
+
+func Test_SymbolStyle_UnmarshalText(t *testing.T) {
+	cc := map[string]struct {
+		Text   string
+		Result SymbolStyle
+		Err    error
+	}{
+		"Invalid SymbolStyle": {
+			Text: "x",
+			Err:  ErrInvalidSymbolStyle,
+		},
+		"Successful SymbolConcat unmarshal": {
+			Text:   "concat",
+			Result: SymbolConcat,
+		},
+		"Successful SymbolDash unmarshal": {
+			Text:   "dash",
+			Result: SymbolDash,
+		},
+		"Successful SymbolSlash unmarshal": {
+			Text:   "slash",
+			Result: SymbolSlash,
+		},
+		"Successful SymbolUnderscore unmarshal": {
+			Text:   "underscore",
+			Result: SymbolUnderscore,
+		},
+	}
+
+	for cn, c := range cc {
+		c := c
+
+		t.Run(cn, func(t *testing.T) {
+			t.Parallel()
+
+			var ss SymbolStyle
+
+			err := ss.UnmarshalText([]byte(c.Text))
+			equalError(t, c.Err, err)
+			if err != nil {
+				return
+			}
+
+			assert.Equal(t, c.Result, ss)
+		})
+	}
+}
+
+func Test_FormatSymbol(t *testing.T) {
+	cc := map[string]struct {
+		Pair   Pair
+		Style  SymbolStyle
+		Result string
+		Err    error
+	}{
+		"Invalid SymbolStyle": {
+			Pair:  Pair{Base: "BTC", Quote: "USD"},
+			Style: 70,
+			Err:   ErrInvalidSymbolStyle,
+		},
+		"Invalid Pair": {
+			Style: SymbolDash,
+			Err:   ErrInvalidPair,
+		},
+		"Successful SymbolConcat format": {
+			Pair:   Pair{Base: "BTC", Quote: "USD"},
+			Style:  SymbolConcat,
+			Result: "BTCUSD",
+		},
+		"Successful SymbolDash format": {
+			Pair:   Pair{Base: "BTC", Quote: "USD"},
+			Style:  SymbolDash,
+			Result: "BTC-USD",
+		},
+		"Successful SymbolSlash format": {
+			Pair:   Pair{Base: "BTC", Quote: "USD"},
+			Style:  SymbolSlash,
+			Result: "BTC/USD",
+		},
+		"Successful SymbolUnderscore format": {
+			Pair:   Pair{Base: "BTC", Quote: "USD"},
+			Style:  SymbolUnderscore,
+			Result: "BTC_USD",
+		},
+	}
+
+	for cn, c := range cc {
+		c := c
+
+		t.Run(cn, func(t *testing.T) {
+			t.Parallel()
+
+			res, err := FormatSymbol(c.Pair, c.Style)
+			equalError(t, c.Err, err)
+			if err != nil {
+				return
+			}
+
+			assert.Equal(t, c.Result, res)
+		})
+	}
+}
+
+func Test_SymbolNormalizer_Parse(t *testing.T) {
+	cc := map[string]struct {
+		Symbol string
+		Result Pair
+		Err    error
+	}{
+		"Unknown quote currency": {
+			Symbol: "BTCXYZ",
+			Err:    ErrInvalidSymbol,
+		},
+		"Missing base currency": {
+			Symbol: "-USD",
+			Err:    ErrInvalidSymbol,
+		},
+		"Quote currency only": {
+			Symbol: "USDT",
+			Err:    ErrInvalidSymbol,
+		},
+		"Successful concatenated symbol parse": {
+			Symbol: "btcusdt",
+			Result: Pair{Base: "BTC", Quote: "USDT"},
+		},
+		"Successful concatenated symbol with alias parse": {
+			Symbol: "XBTUSD",
+			Result: Pair{Base: "BTC", Quote: "USD"},
+		},
+		"Successful dashed symbol parse": {
+			Symbol: " BTC-USD ",
+			Result: Pair{Base: "BTC", Quote: "USD"},
+		},
+		"Successful slashed symbol with alias parse": {
+			Symbol: "XDG/XBT",
+			Result: Pair{Base: "DOGE", Quote: "BTC"},
+		},
+	}
+
+	for cn, c := range cc {
+		c := c
+
+		t.Run(cn, func(t *testing.T) {
+			t.Parallel()
+
+			res, err := NewSymbolNormalizer().Parse(c.Symbol)
+			equalError(t, c.Err, err)
+			if err != nil {
+				return
+			}
+
+			assert.Equal(t, c.Result, res)
+		})
+	}
+}
+
+func Test_SymbolNormalizer_Normalize(t *testing.T) {
+	cc := map[string]struct {
+		Symbol string
+		Style  SymbolStyle
+		Result string
+		Err    error
+	}{
+		"Invalid SymbolStyle": {
+			Symbol: "BTCUSDT",
+			Style:  70,
+			Err:    ErrInvalidSymbolStyle,
+		},
+		"Invalid symbol": {
+			Symbol: "BTC",
+			Style:  SymbolDash,
+			Err:    ErrInvalidSymbol,
+		},
+		"Successful concatenated to dashed normalization": {
+			Symbol: "BTCUSDT",
+			Style:  SymbolDash,
+			Result: "BTC-USDT",
+		},
+		"Successful slashed to concatenated normalization": {
+			Symbol: "XBT/USD",
+			Style:  SymbolConcat,
+			Result: "BTCUSD",
+		},
+	}
+
+	for cn, c := range cc {
+		c := c
+
+		t.Run(cn, func(t *testing.T) {
+			t.Parallel()
+
+			res, err := NewSymbolNormalizer().Normalize(c.Symbol, c.Style)
+			equalError(t, c.Err, err)
+			if err != nil {
+				return
+			}
+
+			assert.Equal(t, c.Result, res)
+		})
+	}
+}
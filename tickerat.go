@@ -0,0 +1,86 @@
+package chartype
+
+import (
+	"sort"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+// TickerAt holds a ticker together with the time it was captured at.
+type TickerAt struct {
+	Timestamp time.Time `json:"timestamp" yaml:"timestamp"`
+	Ticker    Ticker    `json:"ticker" yaml:"ticker"`
+}
+
+// IsStale checks whether the ticker is older than the maximum age at
+// the provided time, e.g. because its feed went silent.
+func (t TickerAt) IsStale(now time.Time, maxAge time.Duration) bool {
+	return t.Timestamp.Before(now.Add(-maxAge))
+}
+
+// FilterFresh returns a new map with the latest tickers of each
+// source, such as an exchange or a feed, that are not older than the
+// maximum age at the provided time.
+func FilterFresh(tt map[string]TickerAt, now time.Time, maxAge time.Duration) map[string]TickerAt {
+	res := make(map[string]TickerAt, len(tt))
+
+	for k, t := range tt {
+		if !t.IsStale(now, maxAge) {
+			res[k] = t
+		}
+	}
+
+	return res
+}
+
+// ResampleTickers converts ticker history into interval-long candles
+// using last price semantics: candle's open and close are the first
+// and the last ticker's last price within the interval, its high and
+// low are the extremes of them. Tickers carry rolling volumes only,
+// so candle volumes are zero.
+//
+// Intervals without tickers are skipped or, if fill is true, filled
+// with flat candles at the previous candle's close. Tickers do not
+// need to be sorted.
+func ResampleTickers(tt []TickerAt, i Interval, fill bool) ([]Candle, error) {
+	if err := i.Validate(); err != nil {
+		return nil, err
+	}
+
+	sorted := make([]TickerAt, len(tt))
+	copy(sorted, tt)
+
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].Timestamp.Before(sorted[j].Timestamp)
+	})
+
+	var res []Candle
+
+	for _, t := range sorted {
+		ts := i.Truncate(t.Timestamp)
+		p := t.Ticker.Last
+
+		n := len(res)
+		if n > 0 && res[n-1].Timestamp.Equal(ts) {
+			c := &res[n-1]
+			c.High = decimal.Max(c.High, p)
+			c.Low = decimal.Min(c.Low, p)
+			c.Close = p
+
+			continue
+		}
+
+		if fill && n > 0 {
+			cl := res[n-1].Close
+
+			for fts := res[n-1].Timestamp.Add(i.Duration()); fts.Before(ts); fts = fts.Add(i.Duration()) {
+				res = append(res, Candle{Timestamp: fts, Open: cl, High: cl, Low: cl, Close: cl})
+			}
+		}
+
+		res = append(res, Candle{Timestamp: ts, Open: p, High: p, Low: p, Close: p})
+	}
+
+	return res, nil
+}
@@ -0,0 +1,107 @@
+package chartype
+
+import (
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+)
+
+func testTickerAt(tm time.Time, l int64) TickerAt {
+	return TickerAt{Timestamp: tm, Ticker: Ticker{Last: decimal.NewFromInt(l)}}
+}
+
+func Test_TickerAt_IsStale(t *testing.T) {
+	tm := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	ta := testTickerAt(tm, 1)
+
+	assert.False(t, ta.IsStale(tm, time.Minute))
+	assert.False(t, ta.IsStale(tm.Add(time.Minute), time.Minute))
+	assert.True(t, ta.IsStale(tm.Add(time.Minute+time.Nanosecond), time.Minute))
+}
+
+func Test_FilterFresh(t *testing.T) {
+	tm := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	tt := map[string]TickerAt{
+		"binance": testTickerAt(tm.Add(time.Minute), 1),
+		"kraken":  testTickerAt(tm, 2),
+	}
+
+	assert.Equal(t, map[string]TickerAt{
+		"binance": testTickerAt(tm.Add(time.Minute), 1),
+	}, FilterFresh(tt, tm.Add(90*time.Second), time.Minute))
+	assert.Empty(t, FilterFresh(tt, tm.Add(time.Hour), time.Minute))
+	assert.Len(t, tt, 2)
+}
+
+func Test_ResampleTickers(t *testing.T) {
+	tm := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	tt := []TickerAt{
+		testTickerAt(tm.Add(50*time.Second), 4),
+		testTickerAt(tm.Add(10*time.Second), 3),
+		testTickerAt(tm.Add(20*time.Second), 5),
+		testTickerAt(tm.Add(30*time.Second), 1),
+		testTickerAt(tm.Add(190*time.Second), 7),
+	}
+
+	cc := map[string]struct {
+		Tickers  []TickerAt
+		Interval Interval
+		Fill     bool
+		Result   []Candle
+		Err      error
+	}{
+		"Invalid interval": {
+			Err: ErrInvalidInterval,
+		},
+		"No tickers": {
+			Interval: IntervalMinute,
+			Fill:     true,
+		},
+		"Successful resample without filling": {
+			Tickers:  tt,
+			Interval: IntervalMinute,
+			Result: []Candle{
+				testCandle(tm, 3, 5, 1, 4, 0),
+				testCandle(tm.Add(3*time.Minute), 7, 7, 7, 7, 0),
+			},
+		},
+		"Successful resample with filling": {
+			Tickers:  tt,
+			Interval: IntervalMinute,
+			Fill:     true,
+			Result: []Candle{
+				testCandle(tm, 3, 5, 1, 4, 0),
+				testCandle(tm.Add(time.Minute), 4, 4, 4, 4, 0),
+				testCandle(tm.Add(2*time.Minute), 4, 4, 4, 4, 0),
+				testCandle(tm.Add(3*time.Minute), 7, 7, 7, 7, 0),
+			},
+		},
+	}
+
+	for cn, c := range cc {
+		c := c
+
+		t.Run(cn, func(t *testing.T) {
+			t.Parallel()
+
+			res, err := ResampleTickers(c.Tickers, c.Interval, c.Fill)
+			equalError(t, c.Err, err)
+			if err != nil {
+				return
+			}
+
+			assert.Len(t, res, len(c.Result))
+
+			for i := range c.Result {
+				assert.Equal(t, c.Result[i].Timestamp, res[i].Timestamp)
+				assert.Equal(t, c.Result[i].Open.String(), res[i].Open.String())
+				assert.Equal(t, c.Result[i].High.String(), res[i].High.String())
+				assert.Equal(t, c.Result[i].Low.String(), res[i].Low.String())
+				assert.Equal(t, c.Result[i].Close.String(), res[i].Close.String())
+				assert.True(t, res[i].Volume.IsZero())
+			}
+		})
+	}
+}
@@ -0,0 +1,92 @@
+package chartype
+
+import (
+	"sort"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+// two is the number of prices averaged by the mid price.
+var two = decimal.NewFromInt(2) //nolint:gochecknoglobals // decimal constants cannot be declared as consts
+
+// Spread returns the difference between ticker's ask and bid prices.
+func (t Ticker) Spread() decimal.Decimal {
+	return t.Ask.Sub(t.Bid)
+}
+
+// Mid returns the average of ticker's ask and bid prices.
+func (t Ticker) Mid() decimal.Decimal {
+	return t.Ask.Add(t.Bid).Div(two)
+}
+
+// Tickers is a series of timestamped tickers ordered by timestamp in
+// ascending order. It provides helpers for working with the whole
+// series.
+type Tickers []TickerAt
+
+// Sort sorts the tickers by timestamp in ascending order. Tickers
+// with equal timestamps keep their relative order.
+func (tt Tickers) Sort() {
+	sort.SliceStable(tt, func(i, j int) bool {
+		return tt[i].Timestamp.Before(tt[j].Timestamp)
+	})
+}
+
+// Range returns the part of the series that is within the time
+// range. The returned series shares the underlying array.
+func (tt Tickers) Range(tr TimeRange) Tickers {
+	from := sort.Search(len(tt), func(i int) bool {
+		return !tt[i].Timestamp.Before(tr.From)
+	})
+
+	to := sort.Search(len(tt), func(i int) bool {
+		return !tt[i].Timestamp.Before(tr.To)
+	})
+
+	if to < from {
+		to = from
+	}
+
+	return tt[from:to]
+}
+
+// Fresh returns a new series with tickers that are not older than
+// the maximum age at the provided time.
+func (tt Tickers) Fresh(now time.Time, maxAge time.Duration) Tickers {
+	var res Tickers
+
+	for _, t := range tt {
+		if !t.IsStale(now, maxAge) {
+			res = append(res, t)
+		}
+	}
+
+	return res
+}
+
+// Spreads returns spreads of all tickers.
+func (tt Tickers) Spreads() []decimal.Decimal {
+	res := make([]decimal.Decimal, len(tt))
+	for i, t := range tt {
+		res[i] = t.Ticker.Spread()
+	}
+
+	return res
+}
+
+// Mids returns mid prices of all tickers.
+func (tt Tickers) Mids() []decimal.Decimal {
+	res := make([]decimal.Decimal, len(tt))
+	for i, t := range tt {
+		res[i] = t.Ticker.Mid()
+	}
+
+	return res
+}
+
+// Candles converts the tickers into interval-long candles as
+// ResampleTickers does.
+func (tt Tickers) Candles(i Interval, fill bool) ([]Candle, error) {
+	return ResampleTickers(tt, i, fill)
+}
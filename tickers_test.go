@@ -0,0 +1,117 @@
+package chartype
+
+import (
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+)
+
+func testQuote(tm time.Time, a, b int64) TickerAt {
+	return TickerAt{
+		Timestamp: tm,
+		Ticker: Ticker{
+			Ask: decimal.NewFromInt(a),
+			Bid: decimal.NewFromInt(b),
+		},
+	}
+}
+
+func Test_Tickers_Sort(t *testing.T) {
+	tm := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	tt := Tickers{
+		testQuote(tm.Add(time.Minute), 1, 1),
+		testQuote(tm, 2, 2),
+		testQuote(tm, 3, 3),
+	}
+
+	tt.Sort()
+	assert.Equal(t, Tickers{
+		testQuote(tm, 2, 2),
+		testQuote(tm, 3, 3),
+		testQuote(tm.Add(time.Minute), 1, 1),
+	}, tt)
+}
+
+func Test_Tickers_Range(t *testing.T) {
+	tm := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	tt := Tickers{
+		testQuote(tm, 1, 1),
+		testQuote(tm.Add(time.Minute), 2, 2),
+		testQuote(tm.Add(2*time.Minute), 3, 3),
+	}
+
+	cc := map[string]struct {
+		Range  TimeRange
+		Result Tickers
+	}{
+		"Inverted range": {
+			Range:  TimeRange{From: tm.Add(time.Minute), To: tm},
+			Result: Tickers{},
+		},
+		"Outside range": {
+			Range:  TimeRange{From: tm.Add(time.Hour), To: tm.Add(2 * time.Hour)},
+			Result: Tickers{},
+		},
+		"Partial range": {
+			Range:  TimeRange{From: tm.Add(time.Second), To: tm.Add(2 * time.Minute)},
+			Result: Tickers{testQuote(tm.Add(time.Minute), 2, 2)},
+		},
+		"Whole range": {
+			Range:  TimeRange{From: tm, To: tm.Add(time.Hour)},
+			Result: tt,
+		},
+	}
+
+	for cn, c := range cc {
+		c := c
+
+		t.Run(cn, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, c.Result, tt.Range(c.Range))
+		})
+	}
+}
+
+func Test_Tickers_Fresh(t *testing.T) {
+	tm := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	tt := Tickers{
+		testQuote(tm, 1, 1),
+		testQuote(tm.Add(time.Minute), 2, 2),
+		testQuote(tm.Add(2*time.Minute), 3, 3),
+	}
+
+	assert.Equal(t, Tickers{
+		testQuote(tm.Add(time.Minute), 2, 2),
+		testQuote(tm.Add(2*time.Minute), 3, 3),
+	}, tt.Fresh(tm.Add(2*time.Minute), time.Minute))
+	assert.Nil(t, tt.Fresh(tm.Add(time.Hour), time.Minute))
+}
+
+func Test_Tickers_Spreads(t *testing.T) {
+	tt := Tickers{
+		testQuote(time.Time{}, 5, 3),
+		testQuote(time.Time{}, 4, 3),
+	}
+
+	ss := tt.Spreads()
+	assert.Len(t, ss, 2)
+	assert.Equal(t, "2", ss[0].String())
+	assert.Equal(t, "1", ss[1].String())
+
+	mm := tt.Mids()
+	assert.Len(t, mm, 2)
+	assert.Equal(t, "4", mm[0].String())
+	assert.Equal(t, "3.5", mm[1].String())
+}
+
+func Test_Tickers_Candles(t *testing.T) {
+	tm := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	cc, err := Tickers{testTickerAt(tm, 3)}.Candles(IntervalMinute, false)
+	assert.NoError(t, err)
+	assert.Len(t, cc, 1)
+	assert.Equal(t, "3", cc[0].Close.String())
+}
@@ -0,0 +1,113 @@
+package chartype
+
+import "time"
+
+var (
+	// ErrInvalidTimeRange is returned when time range's start
+	// is not before its end or when either of them is not set.
+	ErrInvalidTimeRange = newError(CodeInvalidArgument, "invalid time range")
+
+	// ErrInvalidDuration is returned when zero or negative duration
+	// is used where a positive one is expected.
+	ErrInvalidDuration = newError(CodeInvalidArgument, "invalid duration")
+)
+
+// TimeRange specifies a half-open [From, To) period of time.
+type TimeRange struct {
+	From time.Time `json:"from" yaml:"from"`
+	To   time.Time `json:"to" yaml:"to"`
+}
+
+// Validate checks whether both time range's boundaries are set
+// and whether its start is before its end.
+func (tr TimeRange) Validate() error {
+	if tr.From.IsZero() || tr.To.IsZero() || !tr.From.Before(tr.To) {
+		return ErrInvalidTimeRange
+	}
+
+	return nil
+}
+
+// Duration returns the amount of time between time range's start
+// and end.
+func (tr TimeRange) Duration() time.Duration {
+	return tr.To.Sub(tr.From)
+}
+
+// Contains checks whether the provided time is within the time range.
+// The start of the time range is inclusive, the end is exclusive.
+func (tr TimeRange) Contains(t time.Time) bool {
+	return !t.Before(tr.From) && t.Before(tr.To)
+}
+
+// Split divides the time range into consecutive time ranges, none of
+// which is longer than the provided maximum span. The last time range
+// may be shorter than the rest. If maximum span is zero or negative,
+// the time range is returned unchanged.
+func (tr TimeRange) Split(maxSpan time.Duration) []TimeRange {
+	if maxSpan <= 0 || tr.Duration() <= maxSpan {
+		return []TimeRange{tr}
+	}
+
+	res := make([]TimeRange, 0, int(tr.Duration()/maxSpan)+1)
+
+	for from := tr.From; from.Before(tr.To); from = from.Add(maxSpan) {
+		to := from.Add(maxSpan)
+		if to.After(tr.To) {
+			to = tr.To
+		}
+
+		res = append(res, TimeRange{From: from, To: to})
+	}
+
+	return res
+}
+
+// Buckets returns an iterator over consecutive interval-long buckets
+// that cover the time range. Buckets are aligned to interval
+// boundaries (as in time.Time.Truncate), so the first bucket may
+// start before the time range and the last one may end after it.
+func (tr TimeRange) Buckets(interval time.Duration) (*BucketIterator, error) {
+	if interval <= 0 {
+		return nil, ErrInvalidDuration
+	}
+
+	return newBucketIterator(tr, interval), nil
+}
+
+// newBucketIterator creates a new bucket iterator over the time range.
+// Interval must be positive.
+func newBucketIterator(tr TimeRange, interval time.Duration) *BucketIterator {
+	return &BucketIterator{
+		next:     tr.From.Truncate(interval),
+		end:      tr.To,
+		interval: interval,
+	}
+}
+
+// BucketIterator iterates over interval-long time ranges.
+// Next should be called before each Bucket call.
+type BucketIterator struct {
+	next     time.Time
+	end      time.Time
+	interval time.Duration
+	bucket   TimeRange
+}
+
+// Next advances the iterator to the next bucket and reports whether
+// there is one.
+func (bi *BucketIterator) Next() bool {
+	if !bi.next.Before(bi.end) {
+		return false
+	}
+
+	bi.bucket = TimeRange{From: bi.next, To: bi.next.Add(bi.interval)}
+	bi.next = bi.bucket.To
+
+	return true
+}
+
+// Bucket returns the current bucket.
+func (bi *BucketIterator) Bucket() TimeRange {
+	return bi.bucket
+}
@@ -0,0 +1,201 @@
+package chartype
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_TimeRange_Validate(t *testing.T) {
+	tm := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	cc := map[string]struct {
+		TimeRange TimeRange
+		Err       error
+	}{
+		"Missing From": {
+			TimeRange: TimeRange{To: tm},
+			Err:       ErrInvalidTimeRange,
+		},
+		"Missing To": {
+			TimeRange: TimeRange{From: tm},
+			Err:       ErrInvalidTimeRange,
+		},
+		"From equal to To": {
+			TimeRange: TimeRange{From: tm, To: tm},
+			Err:       ErrInvalidTimeRange,
+		},
+		"From after To": {
+			TimeRange: TimeRange{From: tm.Add(time.Hour), To: tm},
+			Err:       ErrInvalidTimeRange,
+		},
+		"Successful validation": {
+			TimeRange: TimeRange{From: tm, To: tm.Add(time.Hour)},
+		},
+	}
+
+	for cn, c := range cc {
+		c := c
+
+		t.Run(cn, func(t *testing.T) {
+			t.Parallel()
+
+			err := c.TimeRange.Validate()
+			equalError(t, c.Err, err)
+		})
+	}
+}
+
+func Test_TimeRange_Duration(t *testing.T) {
+	tm := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	tr := TimeRange{From: tm, To: tm.Add(90 * time.Minute)}
+	assert.Equal(t, 90*time.Minute, tr.Duration())
+}
+
+func Test_TimeRange_Contains(t *testing.T) {
+	tm := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	tr := TimeRange{From: tm, To: tm.Add(time.Hour)}
+
+	cc := map[string]struct {
+		Time   time.Time
+		Result bool
+	}{
+		"Before From": {
+			Time: tm.Add(-time.Nanosecond),
+		},
+		"Equal to From": {
+			Time:   tm,
+			Result: true,
+		},
+		"Between From and To": {
+			Time:   tm.Add(30 * time.Minute),
+			Result: true,
+		},
+		"Equal to To": {
+			Time: tm.Add(time.Hour),
+		},
+		"After To": {
+			Time: tm.Add(2 * time.Hour),
+		},
+	}
+
+	for cn, c := range cc {
+		c := c
+
+		t.Run(cn, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, c.Result, tr.Contains(c.Time))
+		})
+	}
+}
+
+func Test_TimeRange_Split(t *testing.T) {
+	tm := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	cc := map[string]struct {
+		TimeRange TimeRange
+		MaxSpan   time.Duration
+		Result    []TimeRange
+	}{
+		"Zero max span": {
+			TimeRange: TimeRange{From: tm, To: tm.Add(time.Hour)},
+			Result: []TimeRange{
+				{From: tm, To: tm.Add(time.Hour)},
+			},
+		},
+		"Max span longer than time range": {
+			TimeRange: TimeRange{From: tm, To: tm.Add(time.Hour)},
+			MaxSpan:   2 * time.Hour,
+			Result: []TimeRange{
+				{From: tm, To: tm.Add(time.Hour)},
+			},
+		},
+		"Even split": {
+			TimeRange: TimeRange{From: tm, To: tm.Add(time.Hour)},
+			MaxSpan:   30 * time.Minute,
+			Result: []TimeRange{
+				{From: tm, To: tm.Add(30 * time.Minute)},
+				{From: tm.Add(30 * time.Minute), To: tm.Add(time.Hour)},
+			},
+		},
+		"Uneven split": {
+			TimeRange: TimeRange{From: tm, To: tm.Add(time.Hour)},
+			MaxSpan:   25 * time.Minute,
+			Result: []TimeRange{
+				{From: tm, To: tm.Add(25 * time.Minute)},
+				{From: tm.Add(25 * time.Minute), To: tm.Add(50 * time.Minute)},
+				{From: tm.Add(50 * time.Minute), To: tm.Add(time.Hour)},
+			},
+		},
+	}
+
+	for cn, c := range cc {
+		c := c
+
+		t.Run(cn, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, c.Result, c.TimeRange.Split(c.MaxSpan))
+		})
+	}
+}
+
+func Test_TimeRange_Buckets(t *testing.T) {
+	tm := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	cc := map[string]struct {
+		TimeRange TimeRange
+		Interval  time.Duration
+		Result    []TimeRange
+		Err       error
+	}{
+		"Invalid interval": {
+			TimeRange: TimeRange{From: tm, To: tm.Add(time.Hour)},
+			Err:       ErrInvalidDuration,
+		},
+		"Empty time range": {
+			TimeRange: TimeRange{From: tm, To: tm},
+			Interval:  time.Minute,
+		},
+		"Aligned time range": {
+			TimeRange: TimeRange{From: tm, To: tm.Add(time.Hour)},
+			Interval:  30 * time.Minute,
+			Result: []TimeRange{
+				{From: tm, To: tm.Add(30 * time.Minute)},
+				{From: tm.Add(30 * time.Minute), To: tm.Add(time.Hour)},
+			},
+		},
+		"Unaligned time range": {
+			TimeRange: TimeRange{From: tm.Add(10 * time.Minute), To: tm.Add(40 * time.Minute)},
+			Interval:  30 * time.Minute,
+			Result: []TimeRange{
+				{From: tm, To: tm.Add(30 * time.Minute)},
+				{From: tm.Add(30 * time.Minute), To: tm.Add(time.Hour)},
+			},
+		},
+	}
+
+	for cn, c := range cc {
+		c := c
+
+		t.Run(cn, func(t *testing.T) {
+			t.Parallel()
+
+			bi, err := c.TimeRange.Buckets(c.Interval)
+			equalError(t, c.Err, err)
+			if err != nil {
+				return
+			}
+
+			var res []TimeRange
+			for bi.Next() {
+				res = append(res, bi.Bucket())
+			}
+
+			assert.Equal(t, c.Result, res)
+		})
+	}
+}
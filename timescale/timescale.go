@@ -0,0 +1,186 @@
+// Package timescale provides TimescaleDB schema and query builders
+// for storing and resampling chartype's candles.
+package timescale
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/jellydator/chartype"
+)
+
+var (
+	// ErrInvalidTable is returned when table with missing name or
+	// invalid key columns is being used.
+	ErrInvalidTable = &chartype.Error{
+		Code:    chartype.CodeInvalidArgument,
+		Message: "invalid table",
+	}
+
+	// ErrInvalidKeys is returned when the number of provided key
+	// values does not match the number of table's key columns.
+	ErrInvalidKeys = &chartype.Error{
+		Code:    chartype.CodeInvalidArgument,
+		Message: "invalid keys",
+	}
+)
+
+// Table describes a candle hypertable. Candle columns match the
+// db tags of chartype.Candle.
+type Table struct {
+	// Name specifies table's name.
+	Name string
+
+	// KeyColumns specifies additional text columns that identify
+	// a candle series, e.g. "pair" and "interval".
+	KeyColumns []string
+}
+
+// Validate checks whether table's name is set and key columns are
+// unique, non-empty and do not clash with candle columns.
+func (t Table) Validate() error {
+	if t.Name == "" {
+		return ErrInvalidTable
+	}
+
+	seen := map[string]struct{}{
+		"timestamp": {}, "open": {}, "high": {},
+		"low": {}, "close": {}, "volume": {}, "adj_close": {},
+		"delta": {},
+	}
+
+	for _, k := range t.KeyColumns {
+		if _, ok := seen[k]; ok || k == "" {
+			return ErrInvalidTable
+		}
+
+		seen[k] = struct{}{}
+	}
+
+	return nil
+}
+
+// DDL returns statements that create the candle table, if it does
+// not exist, and turn it into a hypertable partitioned by timestamp.
+func (t Table) DDL() (string, error) {
+	if err := t.Validate(); err != nil {
+		return "", err
+	}
+
+	var sb strings.Builder
+
+	sb.WriteString("CREATE TABLE IF NOT EXISTS ")
+	sb.WriteString(quote(t.Name))
+	sb.WriteString(" (\n")
+
+	for _, k := range t.KeyColumns {
+		sb.WriteString("\t" + quote(k) + " TEXT NOT NULL,\n")
+	}
+
+	sb.WriteString("\t\"timestamp\" TIMESTAMPTZ NOT NULL,\n" +
+		"\t\"open\" NUMERIC NOT NULL,\n" +
+		"\t\"high\" NUMERIC NOT NULL,\n" +
+		"\t\"low\" NUMERIC NOT NULL,\n" +
+		"\t\"close\" NUMERIC NOT NULL,\n" +
+		"\t\"volume\" NUMERIC NOT NULL,\n" +
+		"\t\"adj_close\" NUMERIC,\n" +
+		"\t\"delta\" NUMERIC,\n" +
+		"\tPRIMARY KEY (")
+
+	for _, k := range t.KeyColumns {
+		sb.WriteString(quote(k) + ", ")
+	}
+
+	sb.WriteString("\"timestamp\")\n);\n")
+	sb.WriteString("SELECT create_hypertable(")
+	sb.WriteString(literal(quote(t.Name)))
+	sb.WriteString(", 'timestamp', if_not_exists => TRUE);\n")
+
+	return sb.String(), nil
+}
+
+// ResampleQuery returns a query that aggregates stored candles into
+// interval-long buckets within the time range, along with its
+// arguments. Key values must be provided in the same order as
+// table's key columns. Resulting rows can be read with ScanCandles.
+func (t Table) ResampleQuery(i chartype.Interval, tr chartype.TimeRange, keys ...string) (string, []interface{}, error) {
+	if err := t.Validate(); err != nil {
+		return "", nil, err
+	}
+
+	if err := i.Validate(); err != nil {
+		return "", nil, err
+	}
+
+	if err := tr.Validate(); err != nil {
+		return "", nil, err
+	}
+
+	if len(keys) != len(t.KeyColumns) {
+		return "", nil, ErrInvalidKeys
+	}
+
+	args := []interface{}{
+		strconv.FormatInt(int64(i.Duration().Seconds()), 10) + " seconds",
+		tr.From,
+		tr.To,
+	}
+
+	var sb strings.Builder
+
+	sb.WriteString("SELECT time_bucket($1::interval, \"timestamp\") AS \"bucket\", " +
+		"first(\"open\", \"timestamp\"), max(\"high\"), min(\"low\"), " +
+		"last(\"close\", \"timestamp\"), sum(\"volume\"), " +
+		"last(\"adj_close\", \"timestamp\"), sum(\"delta\")\n")
+	sb.WriteString("FROM " + quote(t.Name) + "\n")
+	sb.WriteString("WHERE \"timestamp\" >= $2 AND \"timestamp\" < $3")
+
+	for j, k := range t.KeyColumns {
+		args = append(args, keys[j])
+		sb.WriteString(" AND " + quote(k) + " = $" + strconv.Itoa(len(args)))
+	}
+
+	sb.WriteString("\nGROUP BY \"bucket\"\nORDER BY \"bucket\"")
+
+	return sb.String(), args, nil
+}
+
+// Rows is the subset of *sql.Rows used to read query results.
+type Rows interface {
+	Next() bool
+	Scan(dest ...interface{}) error
+	Err() error
+}
+
+// ScanCandles reads all rows, each consisting of timestamp, open,
+// high, low, close, volume and nullable adjusted close and volume
+// delta columns, into candles.
+func ScanCandles(rows Rows) ([]chartype.Candle, error) {
+	var cc []chartype.Candle
+
+	for rows.Next() {
+		var c chartype.Candle
+
+		if err := rows.Scan(&c.Timestamp, &c.Open, &c.High, &c.Low, &c.Close, &c.Volume, &c.AdjClose, &c.Delta); err != nil {
+			return nil, err
+		}
+
+		cc = append(cc, c)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return cc, nil
+}
+
+// quote returns identifier quoted for use in SQL statements.
+func quote(s string) string {
+	return `"` + strings.ReplaceAll(s, `"`, `""`) + `"`
+}
+
+// literal returns string quoted as SQL string literal.
+func literal(s string) string {
+	return `'` + strings.ReplaceAll(s, `'`, `''`) + `'`
+}
@@ -0,0 +1,319 @@
+package timescale
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/jellydator/chartype"
+	"github.com/jellydator/chartype/chartypetest"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Table_Validate(t *testing.T) {
+	cc := map[string]struct {
+		Table Table
+		Err   error
+	}{
+		"Missing name": {
+			Table: Table{},
+			Err:   ErrInvalidTable,
+		},
+		"Empty key column": {
+			Table: Table{Name: "candles", KeyColumns: []string{""}},
+			Err:   ErrInvalidTable,
+		},
+		"Duplicate key column": {
+			Table: Table{Name: "candles", KeyColumns: []string{"pair", "pair"}},
+			Err:   ErrInvalidTable,
+		},
+		"Key column clashing with candle column": {
+			Table: Table{Name: "candles", KeyColumns: []string{"close"}},
+			Err:   ErrInvalidTable,
+		},
+		"Key column clashing with optional candle column": {
+			Table: Table{Name: "candles", KeyColumns: []string{"adj_close"}},
+			Err:   ErrInvalidTable,
+		},
+		"Key column clashing with volume delta column": {
+			Table: Table{Name: "candles", KeyColumns: []string{"delta"}},
+			Err:   ErrInvalidTable,
+		},
+		"Successful validation": {
+			Table: Table{Name: "candles", KeyColumns: []string{"pair", "interval"}},
+		},
+	}
+
+	for cn, c := range cc {
+		c := c
+
+		t.Run(cn, func(t *testing.T) {
+			t.Parallel()
+
+			err := c.Table.Validate()
+			chartypetest.AssertErrorEqual(t, c.Err, err)
+		})
+	}
+}
+
+func Test_Table_DDL(t *testing.T) {
+	cc := map[string]struct {
+		Table Table
+		DDL   string
+		Err   error
+	}{
+		"Invalid table": {
+			Table: Table{},
+			Err:   ErrInvalidTable,
+		},
+		"Successful DDL without key columns": {
+			Table: Table{Name: "candles"},
+			DDL: "CREATE TABLE IF NOT EXISTS \"candles\" (\n" +
+				"\t\"timestamp\" TIMESTAMPTZ NOT NULL,\n" +
+				"\t\"open\" NUMERIC NOT NULL,\n" +
+				"\t\"high\" NUMERIC NOT NULL,\n" +
+				"\t\"low\" NUMERIC NOT NULL,\n" +
+				"\t\"close\" NUMERIC NOT NULL,\n" +
+				"\t\"volume\" NUMERIC NOT NULL,\n" +
+				"\t\"adj_close\" NUMERIC,\n" +
+				"\t\"delta\" NUMERIC,\n" +
+				"\tPRIMARY KEY (\"timestamp\")\n" +
+				");\n" +
+				"SELECT create_hypertable('\"candles\"', 'timestamp', if_not_exists => TRUE);\n",
+		},
+		"Successful DDL with key columns": {
+			Table: Table{Name: `my "candles'`, KeyColumns: []string{"pair", "interval"}},
+			DDL: "CREATE TABLE IF NOT EXISTS \"my \"\"candles'\" (\n" +
+				"\t\"pair\" TEXT NOT NULL,\n" +
+				"\t\"interval\" TEXT NOT NULL,\n" +
+				"\t\"timestamp\" TIMESTAMPTZ NOT NULL,\n" +
+				"\t\"open\" NUMERIC NOT NULL,\n" +
+				"\t\"high\" NUMERIC NOT NULL,\n" +
+				"\t\"low\" NUMERIC NOT NULL,\n" +
+				"\t\"close\" NUMERIC NOT NULL,\n" +
+				"\t\"volume\" NUMERIC NOT NULL,\n" +
+				"\t\"adj_close\" NUMERIC,\n" +
+				"\t\"delta\" NUMERIC,\n" +
+				"\tPRIMARY KEY (\"pair\", \"interval\", \"timestamp\")\n" +
+				");\n" +
+				"SELECT create_hypertable('\"my \"\"candles''\"', 'timestamp', if_not_exists => TRUE);\n",
+		},
+	}
+
+	for cn, c := range cc {
+		c := c
+
+		t.Run(cn, func(t *testing.T) {
+			t.Parallel()
+
+			ddl, err := c.Table.DDL()
+			chartypetest.AssertErrorEqual(t, c.Err, err)
+			if err != nil {
+				return
+			}
+
+			assert.Equal(t, c.DDL, ddl)
+		})
+	}
+}
+
+func Test_Table_ResampleQuery(t *testing.T) {
+	tm := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	tr := chartype.TimeRange{From: tm, To: tm.Add(24 * time.Hour)}
+	tbl := Table{Name: "candles", KeyColumns: []string{"pair", "interval"}}
+
+	cc := map[string]struct {
+		Table     Table
+		Interval  chartype.Interval
+		TimeRange chartype.TimeRange
+		Keys      []string
+		Query     string
+		Args      []interface{}
+		Err       error
+	}{
+		"Invalid table": {
+			Table:     Table{},
+			Interval:  chartype.IntervalHour,
+			TimeRange: tr,
+			Err:       ErrInvalidTable,
+		},
+		"Invalid interval": {
+			Table:     tbl,
+			TimeRange: tr,
+			Keys:      []string{"BTC_USDT", "1m"},
+			Err:       chartype.ErrInvalidInterval,
+		},
+		"Invalid time range": {
+			Table:    tbl,
+			Interval: chartype.IntervalHour,
+			Keys:     []string{"BTC_USDT", "1m"},
+			Err:      chartype.ErrInvalidTimeRange,
+		},
+		"Invalid keys": {
+			Table:     tbl,
+			Interval:  chartype.IntervalHour,
+			TimeRange: tr,
+			Keys:      []string{"BTC_USDT"},
+			Err:       ErrInvalidKeys,
+		},
+		"Successful query": {
+			Table:     tbl,
+			Interval:  chartype.IntervalHour,
+			TimeRange: tr,
+			Keys:      []string{"BTC_USDT", "1m"},
+			Query: "SELECT time_bucket($1::interval, \"timestamp\") AS \"bucket\", " +
+				"first(\"open\", \"timestamp\"), max(\"high\"), min(\"low\"), " +
+				"last(\"close\", \"timestamp\"), sum(\"volume\"), " +
+				"last(\"adj_close\", \"timestamp\"), sum(\"delta\")\n" +
+				"FROM \"candles\"\n" +
+				"WHERE \"timestamp\" >= $2 AND \"timestamp\" < $3 AND \"pair\" = $4 AND \"interval\" = $5\n" +
+				"GROUP BY \"bucket\"\n" +
+				"ORDER BY \"bucket\"",
+			Args: []interface{}{"3600 seconds", tr.From, tr.To, "BTC_USDT", "1m"},
+		},
+	}
+
+	for cn, c := range cc {
+		c := c
+
+		t.Run(cn, func(t *testing.T) {
+			t.Parallel()
+
+			q, args, err := c.Table.ResampleQuery(c.Interval, c.TimeRange, c.Keys...)
+			chartypetest.AssertErrorEqual(t, c.Err, err)
+			if err != nil {
+				return
+			}
+
+			assert.Equal(t, c.Query, q)
+			assert.Equal(t, c.Args, args)
+		})
+	}
+}
+
+type rowsStub struct {
+	Rows    [][]interface{}
+	ScanErr error
+	Error   error
+	pos     int
+}
+
+func (r *rowsStub) Next() bool {
+	r.pos++
+	return r.pos <= len(r.Rows)
+}
+
+func (r *rowsStub) Scan(dest ...interface{}) error {
+	if r.ScanErr != nil {
+		return r.ScanErr
+	}
+
+	row := r.Rows[r.pos-1]
+	*(dest[0].(*time.Time)) = row[0].(time.Time)
+
+	for i := 1; i < len(dest); i++ {
+		d, ok := dest[i].(*decimal.Decimal)
+		if !ok {
+			// nullable columns are scanned the same way as by
+			// database/sql
+			if row[i] == nil {
+				continue
+			}
+
+			d = new(decimal.Decimal)
+			*(dest[i].(**decimal.Decimal)) = d
+		}
+
+		if err := d.Scan(row[i]); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (r *rowsStub) Err() error {
+	return r.Error
+}
+
+func Test_ScanCandles(t *testing.T) {
+	tm := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	row := []interface{}{tm, "1", "2", "0.5", "1.5", "10", nil, nil}
+	adjRow := []interface{}{tm, "1", "2", "0.5", "1.5", "10", "0.75", nil}
+	deltaRow := []interface{}{tm, "1", "2", "0.5", "1.5", "10", nil, "-4"}
+	adj := decimal.RequireFromString("0.75")
+	delta := decimal.RequireFromString("-4")
+
+	cc := map[string]struct {
+		Rows   *rowsStub
+		Result []chartype.Candle
+		Err    error
+	}{
+		"Scan error": {
+			Rows: &rowsStub{Rows: [][]interface{}{row}, ScanErr: errors.New("scan")},
+			Err:  assert.AnError,
+		},
+		"Rows error": {
+			Rows: &rowsStub{Error: errors.New("rows")},
+			Err:  assert.AnError,
+		},
+		"Successful scan": {
+			Rows: &rowsStub{Rows: [][]interface{}{row}},
+			Result: []chartype.Candle{
+				{
+					Timestamp: tm,
+					Open:      decimal.NewFromInt(1),
+					High:      decimal.NewFromInt(2),
+					Low:       decimal.RequireFromString("0.5"),
+					Close:     decimal.RequireFromString("1.5"),
+					Volume:    decimal.NewFromInt(10),
+				},
+			},
+		},
+		"Successful scan with adjusted close": {
+			Rows: &rowsStub{Rows: [][]interface{}{adjRow}},
+			Result: []chartype.Candle{
+				{
+					Timestamp: tm,
+					Open:      decimal.NewFromInt(1),
+					High:      decimal.NewFromInt(2),
+					Low:       decimal.RequireFromString("0.5"),
+					Close:     decimal.RequireFromString("1.5"),
+					Volume:    decimal.NewFromInt(10),
+					AdjClose:  &adj,
+				},
+			},
+		},
+		"Successful scan with volume delta": {
+			Rows: &rowsStub{Rows: [][]interface{}{deltaRow}},
+			Result: []chartype.Candle{
+				{
+					Timestamp: tm,
+					Open:      decimal.NewFromInt(1),
+					High:      decimal.NewFromInt(2),
+					Low:       decimal.RequireFromString("0.5"),
+					Close:     decimal.RequireFromString("1.5"),
+					Volume:    decimal.NewFromInt(10),
+					Delta:     &delta,
+				},
+			},
+		},
+	}
+
+	for cn, c := range cc {
+		c := c
+
+		t.Run(cn, func(t *testing.T) {
+			t.Parallel()
+
+			res, err := ScanCandles(c.Rows)
+			chartypetest.AssertErrorEqual(t, c.Err, err)
+			if err != nil {
+				return
+			}
+
+			assert.Equal(t, c.Result, res)
+		})
+	}
+}
@@ -0,0 +1,93 @@
+package chartype
+
+import (
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+const (
+	// SideBuy specifies that the trade was initiated by a buyer.
+	SideBuy Side = iota + 1
+
+	// SideSell specifies that the trade was initiated by a seller.
+	SideSell
+)
+
+var (
+	// ErrInvalidSide is returned when side with invalid value is
+	// being used.
+	ErrInvalidSide = newError(CodeInvalidArgument, "invalid side")
+)
+
+// Side specifies which party initiated the trade.
+// Can be included in configuration structures.
+type Side int
+
+// Validate checks whether the side is one of supported side
+// types or not.
+func (s Side) Validate() error {
+	switch s {
+	case SideBuy, SideSell:
+		return nil
+	default:
+		return ErrInvalidSide
+	}
+}
+
+// MarshalText turns side to appropriate string representation.
+func (s Side) MarshalText() ([]byte, error) {
+	var v string
+
+	switch s {
+	case SideBuy:
+		v = "buy"
+	case SideSell:
+		v = "sell"
+	default:
+		return nil, ErrInvalidSide
+	}
+
+	return []byte(v), nil
+}
+
+// UnmarshalText turns string to appropriate side value.
+func (s *Side) UnmarshalText(d []byte) error {
+	switch string(d) {
+	case "buy", "b":
+		*s = SideBuy
+	case "sell", "s":
+		*s = SideSell
+	default:
+		return ErrInvalidSide
+	}
+
+	return nil
+}
+
+// Trade holds a single executed trade's information.
+type Trade struct {
+	ID        string          `json:"id,omitempty" yaml:"id,omitempty" db:"id"`
+	Timestamp time.Time       `json:"timestamp" yaml:"timestamp" db:"timestamp"`
+	Price     decimal.Decimal `json:"price" yaml:"price" db:"price"`
+	Amount    decimal.Decimal `json:"amount" yaml:"amount" db:"amount"`
+	Side      Side            `json:"side" yaml:"side" db:"side"`
+}
+
+// ParseTrade parses provided string parameters into newly created
+// trade's fields and returns it. Parse options adjust how parameters
+// are parsed.
+func ParseTrade(t time.Time, ps, as string, s Side, opts ...ParseOption) (Trade, error) {
+	pc := newParseConfig(opts)
+
+	dd, err := pc.numbers(ps, as)
+	if err != nil {
+		return Trade{}, err
+	}
+
+	if err = s.Validate(); err != nil {
+		return Trade{}, err
+	}
+
+	return Trade{Timestamp: pc.timestamp(t), Price: dd[0], Amount: dd[1], Side: s}, nil
+}
@@ -0,0 +1,179 @@
+package chartype
+
+import (
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Side_Validate(t *testing.T) {
+	cc := map[string]struct {
+		Side Side
+		Err  error
+	}{
+		"Invalid Side": {
+			Side: 70,
+			Err:  ErrInvalidSide,
+		},
+		"Successful SideBuy validation": {
+			Side: SideBuy,
+		},
+		"Successful SideSell validation": {
+			Side: SideSell,
+		},
+	}
+
+	for cn, c := range cc {
+		c := c
+
+		t.Run(cn, func(t *testing.T) {
+			t.Parallel()
+
+			err := c.Side.Validate()
+			equalError(t, c.Err, err)
+		})
+	}
+}
+
+func Test_Side_MarshalText(t *testing.T) {
+	cc := map[string]struct {
+		Side Side
+		Text string
+		Err  error
+	}{
+		"Invalid Side": {
+			Side: 70,
+			Err:  ErrInvalidSide,
+		},
+		"Successful SideBuy marshal": {
+			Side: SideBuy,
+			Text: "buy",
+		},
+		"Successful SideSell marshal": {
+			Side: SideSell,
+			Text: "sell",
+		},
+	}
+
+	for cn, c := range cc {
+		c := c
+
+		t.Run(cn, func(t *testing.T) {
+			t.Parallel()
+
+			d, err := c.Side.MarshalText()
+			equalError(t, c.Err, err)
+			if err != nil {
+				return
+			}
+
+			assert.Equal(t, c.Text, string(d))
+		})
+	}
+}
+
+func Test_Side_UnmarshalText(t *testing.T) {
+	cc := map[string]struct {
+		Text   string
+		Result Side
+		Err    error
+	}{
+		"Invalid Side": {
+			Text: "hold",
+			Err:  ErrInvalidSide,
+		},
+		"Successful SideBuy unmarshal": {
+			Text:   "buy",
+			Result: SideBuy,
+		},
+		"Successful short SideBuy unmarshal": {
+			Text:   "b",
+			Result: SideBuy,
+		},
+		"Successful SideSell unmarshal": {
+			Text:   "sell",
+			Result: SideSell,
+		},
+		"Successful short SideSell unmarshal": {
+			Text:   "s",
+			Result: SideSell,
+		},
+	}
+
+	for cn, c := range cc {
+		c := c
+
+		t.Run(cn, func(t *testing.T) {
+			t.Parallel()
+
+			var s Side
+
+			err := s.UnmarshalText([]byte(c.Text))
+			equalError(t, c.Err, err)
+			if err != nil {
+				return
+			}
+
+			assert.Equal(t, c.Result, s)
+		})
+	}
+}
+
+func Test_ParseTrade(t *testing.T) {
+	tm := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	cc := map[string]struct {
+		Price  string
+		Amount string
+		Side   Side
+		Result Trade
+		Err    error
+	}{
+		"Invalid Price": {
+			Price:  "-",
+			Amount: "1",
+			Side:   SideBuy,
+			Err:    assert.AnError,
+		},
+		"Invalid Amount": {
+			Price:  "1",
+			Amount: "-",
+			Side:   SideBuy,
+			Err:    assert.AnError,
+		},
+		"Invalid Side": {
+			Price:  "1",
+			Amount: "2",
+			Err:    ErrInvalidSide,
+		},
+		"Successful parse": {
+			Price:  "1",
+			Amount: "2",
+			Side:   SideSell,
+			Result: Trade{
+				Timestamp: tm,
+				Price:     decimal.NewFromInt(1),
+				Amount:    decimal.NewFromInt(2),
+				Side:      SideSell,
+			},
+		},
+	}
+
+	for cn, c := range cc {
+		c := c
+
+		t.Run(cn, func(t *testing.T) {
+			t.Parallel()
+
+			res, err := ParseTrade(tm, c.Price, c.Amount, c.Side)
+			equalError(t, c.Err, err)
+			if err != nil {
+				return
+			}
+
+			assert.Equal(t, c.Result, res)
+		})
+	}
+}
@@ -0,0 +1,111 @@
+package chartype
+
+import (
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+// Trades is a tape of trades ordered by timestamp in ascending order.
+// It provides helpers for analysing the whole tape.
+type Trades []Trade
+
+// SideVolume holds the volumes of buyer and seller initiated trades
+// within a single interval.
+type SideVolume struct {
+	Timestamp time.Time       `json:"timestamp" yaml:"timestamp"`
+	Buy       decimal.Decimal `json:"buy" yaml:"buy"`
+	Sell      decimal.Decimal `json:"sell" yaml:"sell"`
+}
+
+// Ratio returns the buy to sell volume ratio and whether it is
+// defined, i.e. whether the sell volume is not zero.
+func (sv SideVolume) Ratio() (decimal.Decimal, bool) {
+	if sv.Sell.IsZero() {
+		return decimal.Zero, false
+	}
+
+	return sv.Buy.Div(sv.Sell), true
+}
+
+// FilterSide returns the trades initiated by the side.
+func (tt Trades) FilterSide(s Side) Trades {
+	var res Trades
+
+	for _, t := range tt {
+		if t.Side == s {
+			res = append(res, t)
+		}
+	}
+
+	return res
+}
+
+// Compress merges consecutive trades of the same side and price into
+// a single trade holding their total amount, e.g. to collapse a large
+// order filled against many resting orders. Merged trades keep the
+// first trade's ID and timestamp.
+func (tt Trades) Compress() Trades {
+	var res Trades
+
+	for _, t := range tt {
+		if n := len(res); n > 0 && res[n-1].Side == t.Side && res[n-1].Price.Equal(t.Price) {
+			res[n-1].Amount = res[n-1].Amount.Add(t.Amount)
+			continue
+		}
+
+		res = append(res, t)
+	}
+
+	return res
+}
+
+// SideVolumes returns the buy and sell volumes of each interval that
+// has trades.
+func (tt Trades) SideVolumes(i Interval) ([]SideVolume, error) {
+	if err := i.Validate(); err != nil {
+		return nil, err
+	}
+
+	var res []SideVolume
+
+	for _, t := range tt {
+		ts := i.Truncate(t.Timestamp)
+
+		if n := len(res); n == 0 || !res[n-1].Timestamp.Equal(ts) {
+			res = append(res, SideVolume{Timestamp: ts, Buy: decimal.Zero, Sell: decimal.Zero})
+		}
+
+		sv := &res[len(res)-1]
+
+		switch t.Side {
+		case SideBuy:
+			sv.Buy = sv.Buy.Add(t.Amount)
+		case SideSell:
+			sv.Sell = sv.Sell.Add(t.Amount)
+		}
+	}
+
+	return res, nil
+}
+
+// Candles aggregates the trades into interval-long candles using
+// a candle builder.
+func (tt Trades) Candles(i Interval) ([]Candle, error) {
+	cb, err := NewCandleBuilder(i, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	var res []Candle
+
+	for _, t := range tt {
+		res = append(res, cb.Add(t).Closed...)
+	}
+
+	if c, ok := cb.Flush(); ok {
+		res = append(res, c)
+	}
+
+	return res, nil
+}
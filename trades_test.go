@@ -0,0 +1,108 @@
+package chartype
+
+import (
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testSideTrade(tm time.Time, p, a int64, s Side) Trade {
+	t := testTrade(tm, p, a)
+	t.Side = s
+
+	return t
+}
+
+func Test_SideVolume_Ratio(t *testing.T) {
+	_, ok := SideVolume{Buy: decimal.NewFromInt(1), Sell: decimal.Zero}.Ratio()
+	assert.False(t, ok)
+
+	r, ok := SideVolume{Buy: decimal.NewFromInt(3), Sell: decimal.NewFromInt(2)}.Ratio()
+	assert.True(t, ok)
+	assert.Equal(t, "1.5", r.String())
+}
+
+func Test_Trades_FilterSide(t *testing.T) {
+	tm := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	tt := Trades{
+		testSideTrade(tm, 1, 1, SideBuy),
+		testSideTrade(tm, 2, 1, SideSell),
+		testSideTrade(tm, 3, 1, SideBuy),
+	}
+
+	assert.Equal(t, Trades{tt[0], tt[2]}, tt.FilterSide(SideBuy))
+	assert.Equal(t, Trades{tt[1]}, tt.FilterSide(SideSell))
+	assert.Nil(t, Trades(nil).FilterSide(SideBuy))
+}
+
+func Test_Trades_Compress(t *testing.T) {
+	tm := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	tt := Trades{
+		testSideTrade(tm, 1, 1, SideBuy),
+		testSideTrade(tm.Add(time.Second), 1, 2, SideBuy),
+		testSideTrade(tm.Add(2*time.Second), 1, 3, SideSell),
+		testSideTrade(tm.Add(3*time.Second), 2, 4, SideSell),
+		testSideTrade(tm.Add(4*time.Second), 2, 5, SideSell),
+	}
+	tt[0].ID = "a"
+
+	res := tt.Compress()
+	require.Len(t, res, 3)
+
+	assert.Equal(t, "a", res[0].ID)
+	assert.Equal(t, tm, res[0].Timestamp)
+	assert.Equal(t, "3", res[0].Amount.String())
+	assert.Equal(t, "3", res[1].Amount.String())
+	assert.Equal(t, "9", res[2].Amount.String())
+	assert.Equal(t, "1", tt[0].Amount.String())
+}
+
+func Test_Trades_SideVolumes(t *testing.T) {
+	tm := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	_, err := Trades{}.SideVolumes(0)
+	assert.Equal(t, ErrInvalidInterval, err)
+
+	res, err := Trades{
+		testSideTrade(tm, 1, 1, SideBuy),
+		testSideTrade(tm.Add(time.Second), 1, 2, SideSell),
+		testSideTrade(tm.Add(2*time.Second), 1, 3, SideBuy),
+		testSideTrade(tm.Add(time.Minute), 1, 4, SideSell),
+	}.SideVolumes(IntervalMinute)
+	require.NoError(t, err)
+	require.Len(t, res, 2)
+
+	assert.Equal(t, tm, res[0].Timestamp)
+	assert.Equal(t, "4", res[0].Buy.String())
+	assert.Equal(t, "2", res[0].Sell.String())
+	assert.Equal(t, tm.Add(time.Minute), res[1].Timestamp)
+	assert.Equal(t, "0", res[1].Buy.String())
+	assert.Equal(t, "4", res[1].Sell.String())
+}
+
+func Test_Trades_Candles(t *testing.T) {
+	tm := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	_, err := Trades{}.Candles(0)
+	assert.Equal(t, ErrInvalidInterval, err)
+
+	res, err := Trades{}.Candles(IntervalMinute)
+	assert.NoError(t, err)
+	assert.Empty(t, res)
+
+	res, err = Trades{
+		testTrade(tm, 2, 1),
+		testTrade(tm.Add(time.Second), 3, 1),
+		testTrade(tm.Add(2*time.Second), 1, 1),
+		testTrade(tm.Add(2*time.Minute), 4, 2),
+	}.Candles(IntervalMinute)
+	require.NoError(t, err)
+
+	assertEqualCandles(t, []Candle{
+		testBuiltCandle(tm, 2, 3, 1, 1, 3, 3),
+		testBuiltCandle(tm.Add(2*time.Minute), 4, 4, 4, 4, 2, 2),
+	}, res)
+}
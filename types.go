@@ -3,7 +3,7 @@
 package chartype
 
 import (
-	"errors"
+	"strings"
 	"time"
 
 	"github.com/shopspring/decimal"
@@ -24,54 +24,86 @@ const (
 
 	// CandleVolume specifies candle's volume value.
 	CandleVolume
+
+	// CandleAdjClose specifies candle's adjusted close value. Close
+	// value is used if candle has no adjusted close.
+	CandleAdjClose
+
+	// CandleDelta specifies candle's volume delta value. Zero is used
+	// if candle has no volume delta.
+	CandleDelta
 )
 
 var (
 	// ErrInvalidCandleField is returned when candle field
 	// with invalid value is being used.
-	ErrInvalidCandleField = errors.New("invalid candle field")
+	ErrInvalidCandleField = newError(CodeInvalidField, "invalid candle field")
 )
 
 // Candle stores specific timeframe's starting, closing,
 // highest and lowest price points.
 type Candle struct {
-	Timestamp time.Time       `json:"timestamp" db:"timestamp"`
-	Open      decimal.Decimal `json:"open" db:"open"`
-	High      decimal.Decimal `json:"high" db:"high"`
-	Low       decimal.Decimal `json:"low" db:"low"`
-	Close     decimal.Decimal `json:"close" db:"close"`
-	Volume    decimal.Decimal `json:"volume" db:"volume"`
+	Timestamp time.Time       `json:"timestamp" db:"timestamp" yaml:"timestamp"`
+	Open      decimal.Decimal `json:"open" db:"open" yaml:"open"`
+	High      decimal.Decimal `json:"high" db:"high" yaml:"high"`
+	Low       decimal.Decimal `json:"low" db:"low" yaml:"low"`
+	Close     decimal.Decimal `json:"close" db:"close" yaml:"close"`
+	Volume    decimal.Decimal `json:"volume" db:"volume" yaml:"volume"`
+
+	// AdjClose specifies split/dividend adjusted close value. It is
+	// optional and nil if the data source provides no adjusted prices.
+	AdjClose *decimal.Decimal `json:"adj_close,omitempty" db:"adj_close" yaml:"adj_close,omitempty"`
+
+	// Delta specifies the signed difference between buy and sell
+	// volumes, as needed by cumulative volume delta charts. It is
+	// optional and nil if the candle was not built from trades.
+	Delta *decimal.Decimal `json:"delta,omitempty" db:"delta" yaml:"delta,omitempty"`
 }
 
-// ParseCandle parses provided string parameters into newly created candle's fields
-// and returns it.
-func ParseCandle(t time.Time, os, hs, ls, cs, vs string) (Candle, error) {
-	o, err := decimal.NewFromString(os)
-	if err != nil {
-		return Candle{}, err
+// AdjustedClose returns candle's adjusted close value or its close
+// value if candle has no adjusted close.
+func (c Candle) AdjustedClose() decimal.Decimal {
+	if c.AdjClose == nil {
+		return c.Close
 	}
 
-	h, err := decimal.NewFromString(hs)
-	if err != nil {
-		return Candle{}, err
+	return *c.AdjClose
+}
+
+// VolumeDelta returns candle's volume delta value or zero if candle
+// has no volume delta.
+func (c Candle) VolumeDelta() decimal.Decimal {
+	if c.Delta == nil {
+		return decimal.Zero
 	}
 
-	l, err := decimal.NewFromString(ls)
+	return *c.Delta
+}
+
+// ParseCandle parses provided string parameters into newly created candle's fields
+// and returns it. Parse options adjust how parameters are parsed.
+func ParseCandle(t time.Time, os, hs, ls, cs, vs string, opts ...ParseOption) (Candle, error) {
+	pc := newParseConfig(opts)
+
+	dd, err := pc.numbers(os, hs, ls, cs, vs)
 	if err != nil {
 		return Candle{}, err
 	}
 
-	c, err := decimal.NewFromString(cs)
-	if err != nil {
-		return Candle{}, err
+	c := Candle{
+		Timestamp: pc.timestamp(t),
+		Open:      dd[0],
+		High:      dd[1],
+		Low:       dd[2],
+		Close:     dd[3],
+		Volume:    dd[4],
 	}
 
-	v, err := decimal.NewFromString(vs)
-	if err != nil {
+	if err = pc.checkCandle(c); err != nil {
 		return Candle{}, err
 	}
 
-	return Candle{Timestamp: t, Open: o, High: h, Low: l, Close: c, Volume: v}, nil
+	return c, nil
 }
 
 // CandleField specifies which field should be extracted
@@ -83,38 +115,75 @@ type CandleField int
 // supported field types or not.
 func (cf CandleField) Validate() error {
 	switch cf {
-	case CandleOpen, CandleHigh, CandleLow, CandleClose, CandleVolume:
+	case CandleOpen, CandleHigh, CandleLow, CandleClose, CandleVolume, CandleAdjClose, CandleDelta:
 		return nil
 	default:
 		return ErrInvalidCandleField
 	}
 }
 
+// candleFieldTexts holds text representations of candle fields
+// indexed by their values.
+var candleFieldTexts = [...][]byte{ //nolint:gochecknoglobals // lookup table
+	CandleOpen:     []byte("open"),
+	CandleHigh:     []byte("high"),
+	CandleLow:      []byte("low"),
+	CandleClose:    []byte("close"),
+	CandleVolume:   []byte("volume"),
+	CandleAdjClose: []byte("adj_close"),
+	CandleDelta:    []byte("delta"),
+}
+
+// candleFieldShortTexts holds short text representations of candle
+// fields indexed by their values.
+var candleFieldShortTexts = [...]string{ //nolint:gochecknoglobals // lookup table
+	CandleOpen:     "o",
+	CandleHigh:     "h",
+	CandleLow:      "l",
+	CandleClose:    "c",
+	CandleVolume:   "v",
+	CandleAdjClose: "ac",
+	CandleDelta:    "d",
+}
+
+// CandleFieldNames returns text representations of all candle
+// fields, e.g. for listing valid choices in user interfaces.
+func CandleFieldNames() map[CandleField]string {
+	res := make(map[CandleField]string, len(candleFieldTexts)-1)
+	for cf := CandleOpen; int(cf) < len(candleFieldTexts); cf++ {
+		res[cf] = string(candleFieldTexts[cf])
+	}
+
+	return res
+}
+
+// CandleFieldShortNames returns short text representations of all
+// candle fields, as accepted by UnmarshalText.
+func CandleFieldShortNames() map[CandleField]string {
+	res := make(map[CandleField]string, len(candleFieldShortTexts)-1)
+	for cf := CandleOpen; int(cf) < len(candleFieldShortTexts); cf++ {
+		res[cf] = candleFieldShortTexts[cf]
+	}
+
+	return res
+}
+
 // MarshalText turns candle field to appropriate string
-// representation.
+// representation. The returned slice is shared and must not be
+// modified.
 func (cf CandleField) MarshalText() ([]byte, error) {
-	var v string
-
-	switch cf {
-	case CandleOpen:
-		v = "open"
-	case CandleHigh:
-		v = "high"
-	case CandleLow:
-		v = "low"
-	case CandleClose:
-		v = "close"
-	case CandleVolume:
-		v = "volume" //nolint:goconst // we need to be explicit about these fields
-	default:
-		return nil, ErrInvalidCandleField
+	if err := cf.Validate(); err != nil {
+		return nil, err
 	}
 
-	return []byte(v), nil
+	t := candleFieldTexts[cf]
+
+	return t[:len(t):len(t)], nil
 }
 
 // UnmarshalText turns string to appropriate candle
-// field value.
+// field value. It does not allocate, as the byte slice converted for
+// the switch statement is compared without being copied.
 func (cf *CandleField) UnmarshalText(d []byte) error {
 	switch string(d) {
 	case "open", "o":
@@ -127,6 +196,10 @@ func (cf *CandleField) UnmarshalText(d []byte) error {
 		*cf = CandleClose
 	case "volume", "v":
 		*cf = CandleVolume
+	case "adj_close", "ac":
+		*cf = CandleAdjClose
+	case "delta", "d":
+		*cf = CandleDelta
 	default:
 		return ErrInvalidCandleField
 	}
@@ -148,6 +221,10 @@ func (cf CandleField) Extract(c Candle) decimal.Decimal {
 		return c.Close
 	case CandleVolume:
 		return c.Volume
+	case CandleAdjClose:
+		return c.AdjustedClose()
+	case CandleDelta:
+		return c.VolumeDelta()
 	default:
 		return decimal.Zero
 	}
@@ -189,53 +266,40 @@ const (
 var (
 	// ErrInvalidTickerField is returned when ticker field
 	// with invalid value is being used.
-	ErrInvalidTickerField = errors.New("invalid ticker field")
+	ErrInvalidTickerField = newError(CodeInvalidField, "invalid ticker field")
 )
 
 // Ticker holds current ask, last and bid prices.
 type Ticker struct {
-	Last          decimal.Decimal `json:"last"`
-	Ask           decimal.Decimal `json:"ask"`
-	Bid           decimal.Decimal `json:"bid"`
-	Change        decimal.Decimal `json:"change"`
-	PercentChange decimal.Decimal `json:"percent_change"`
-	Volume        decimal.Decimal `json:"volume"`
+	Last          decimal.Decimal `json:"last" yaml:"last"`
+	Ask           decimal.Decimal `json:"ask" yaml:"ask"`
+	Bid           decimal.Decimal `json:"bid" yaml:"bid"`
+	Change        decimal.Decimal `json:"change" yaml:"change"`
+	PercentChange Percent         `json:"percent_change" yaml:"percent_change"`
+	Volume        decimal.Decimal `json:"volume" yaml:"volume"`
 }
 
 // ParseTicker parses provided string parameters into decimal type values,
-// adds them into a new ticker instance and returns it.
-func ParseTicker(ls, as, bs, cs, pcs, vs string) (Ticker, error) {
-	l, err := decimal.NewFromString(ls)
+// adds them into a new ticker instance and returns it. Parse options
+// adjust how parameters are parsed; percent change may have a percent
+// sign.
+func ParseTicker(ls, as, bs, cs, pcs, vs string, opts ...ParseOption) (Ticker, error) {
+	pc := newParseConfig(opts)
+
+	dd, err := pc.numbers(ls, as, bs, cs,
+		strings.TrimSpace(strings.TrimSuffix(strings.TrimSpace(pcs), percentSign)), vs)
 	if err != nil {
 		return Ticker{}, err
 	}
 
-	a, err := decimal.NewFromString(as)
-	if err != nil {
-		return Ticker{}, err
-	}
-
-	b, err := decimal.NewFromString(bs)
-	if err != nil {
-		return Ticker{}, err
-	}
-
-	c, err := decimal.NewFromString(cs)
-	if err != nil {
-		return Ticker{}, err
-	}
-
-	pc, err := decimal.NewFromString(pcs)
-	if err != nil {
-		return Ticker{}, err
-	}
-
-	v, err := decimal.NewFromString(vs)
-	if err != nil {
-		return Ticker{}, err
-	}
-
-	return Ticker{Last: l, Ask: a, Bid: b, Change: c, PercentChange: pc, Volume: v}, nil
+	return Ticker{
+		Last:          dd[0],
+		Ask:           dd[1],
+		Bid:           dd[2],
+		Change:        dd[3],
+		PercentChange: NewPercent(dd[4]),
+		Volume:        dd[5],
+	}, nil
 }
 
 // TickerField specifies which field should be extracted
@@ -254,33 +318,66 @@ func (tf TickerField) Validate() error {
 	}
 }
 
+// tickerFieldTexts holds text representations of ticker fields
+// indexed by their values.
+var tickerFieldTexts = [...][]byte{ //nolint:gochecknoglobals // lookup table
+	TickerLast:          []byte("last"),
+	TickerAsk:           []byte("ask"),
+	TickerBid:           []byte("bid"),
+	TickerChange:        []byte("change"),
+	TickerPercentChange: []byte("percent_change"),
+	TickerVolume:        []byte("volume"),
+}
+
+// tickerFieldShortTexts holds short text representations of ticker
+// fields indexed by their values.
+var tickerFieldShortTexts = [...]string{ //nolint:gochecknoglobals // lookup table
+	TickerLast:          "l",
+	TickerAsk:           "a",
+	TickerBid:           "b",
+	TickerChange:        "c",
+	TickerPercentChange: "pc",
+	TickerVolume:        "v",
+}
+
+// TickerFieldNames returns text representations of all ticker
+// fields, e.g. for listing valid choices in user interfaces.
+func TickerFieldNames() map[TickerField]string {
+	res := make(map[TickerField]string, len(tickerFieldTexts)-1)
+	for tf := TickerLast; int(tf) < len(tickerFieldTexts); tf++ {
+		res[tf] = string(tickerFieldTexts[tf])
+	}
+
+	return res
+}
+
+// TickerFieldShortNames returns short text representations of all
+// ticker fields, as accepted by UnmarshalText.
+func TickerFieldShortNames() map[TickerField]string {
+	res := make(map[TickerField]string, len(tickerFieldShortTexts)-1)
+	for tf := TickerLast; int(tf) < len(tickerFieldShortTexts); tf++ {
+		res[tf] = tickerFieldShortTexts[tf]
+	}
+
+	return res
+}
+
 // MarshalText turns ticker field to appropriate string
-// representation.
+// representation. The returned slice is shared and must not be
+// modified.
 func (tf TickerField) MarshalText() ([]byte, error) {
-	var v string
-
-	switch tf {
-	case TickerLast:
-		v = "last"
-	case TickerAsk:
-		v = "ask"
-	case TickerBid:
-		v = "bid"
-	case TickerChange:
-		v = "change"
-	case TickerPercentChange:
-		v = "percent_change"
-	case TickerVolume:
-		v = "volume"
-	default:
-		return nil, ErrInvalidTickerField
+	if err := tf.Validate(); err != nil {
+		return nil, err
 	}
 
-	return []byte(v), nil
+	t := tickerFieldTexts[tf]
+
+	return t[:len(t):len(t)], nil
 }
 
 // UnmarshalText turns string to appropriate ticker
-// field value.
+// field value. It does not allocate, as the byte slice converted for
+// the switch statement is compared without being copied.
 func (tf *TickerField) UnmarshalText(d []byte) error {
 	switch string(d) {
 	case "last", "l":
@@ -315,7 +412,7 @@ func (tf TickerField) Extract(t Ticker) decimal.Decimal {
 	case TickerChange:
 		return t.Change
 	case TickerPercentChange:
-		return t.PercentChange
+		return t.PercentChange.Points()
 	case TickerVolume:
 		return t.Volume
 	default:
@@ -324,8 +421,10 @@ func (tf TickerField) Extract(t Ticker) decimal.Decimal {
 }
 
 // Packet holds ticker information as well as all
-// known candles for a specific timeframe.
+// known candles for a specific timeframe. Pair is optional
+// and nil if packet's instrument is not known.
 type Packet struct {
-	Ticker  Ticker   `json:"ticker"`
-	Candles []Candle `json:"candles"`
+	Pair    *Pair    `json:"pair,omitempty" yaml:"pair,omitempty"`
+	Ticker  Ticker   `json:"ticker" yaml:"ticker"`
+	Candles []Candle `json:"candles" yaml:"candles"`
 }
@@ -1,6 +1,7 @@
 package chartype
 
 import (
+	"encoding/json"
 	"testing"
 	"time"
 
@@ -120,6 +121,12 @@ func Test_CandleField_Validate(t *testing.T) {
 		"Successful CandleClose validation": {
 			CandleField: CandleClose,
 		},
+		"Successful CandleAdjClose validation": {
+			CandleField: CandleAdjClose,
+		},
+		"Successful CandleDelta validation": {
+			CandleField: CandleDelta,
+		},
 	}
 
 	for cn, c := range cc {
@@ -164,6 +171,14 @@ func Test_CandleField_MarshalText(t *testing.T) {
 			CandleField: CandleVolume,
 			Text:        "volume",
 		},
+		"Successful CandleAdjClose marshal": {
+			CandleField: CandleAdjClose,
+			Text:        "adj_close",
+		},
+		"Successful CandleDelta marshal": {
+			CandleField: CandleDelta,
+			Text:        "delta",
+		},
 	}
 
 	for cn, c := range cc {
@@ -233,6 +248,22 @@ func Test_CandleField_UnmarshalJSON(t *testing.T) {
 			Text:   "v",
 			Result: CandleVolume,
 		},
+		"Successful CandleAdjClose unmarshal (long form)": {
+			Text:   "adj_close",
+			Result: CandleAdjClose,
+		},
+		"Successful CandleAdjClose unmarshal (short form)": {
+			Text:   "ac",
+			Result: CandleAdjClose,
+		},
+		"Successful CandleDelta unmarshal (long form)": {
+			Text:   "delta",
+			Result: CandleDelta,
+		},
+		"Successful CandleDelta unmarshal (short form)": {
+			Text:   "d",
+			Result: CandleDelta,
+		},
 	}
 
 	for cn, c := range cc {
@@ -295,6 +326,26 @@ func Test_CandleField_Extract(t *testing.T) {
 			Candle:      Candle{Volume: decimal.NewFromInt(30)},
 			Result:      decimal.NewFromInt(30),
 		},
+		"Successful AdjClose extract": {
+			CandleField: CandleAdjClose,
+			Candle:      Candle{Close: decimal.NewFromInt(25), AdjClose: decimalPtr(20)},
+			Result:      decimal.NewFromInt(20),
+		},
+		"Successful AdjClose extract (missing adjusted close)": {
+			CandleField: CandleAdjClose,
+			Candle:      Candle{Close: decimal.NewFromInt(25)},
+			Result:      decimal.NewFromInt(25),
+		},
+		"Successful Delta extract": {
+			CandleField: CandleDelta,
+			Candle:      Candle{Volume: decimal.NewFromInt(30), Delta: decimalPtr(-5)},
+			Result:      decimal.NewFromInt(-5),
+		},
+		"Successful Delta extract (missing volume delta)": {
+			CandleField: CandleDelta,
+			Candle:      Candle{Volume: decimal.NewFromInt(30)},
+			Result:      decimal.Zero,
+		},
 	}
 
 	for cn, c := range cc {
@@ -309,6 +360,36 @@ func Test_CandleField_Extract(t *testing.T) {
 	}
 }
 
+func Test_Candle_JSON(t *testing.T) {
+	tm := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	c := testCandle(tm, 1, 2, 3, 4, 5)
+
+	d, err := json.Marshal(c)
+	assert.NoError(t, err)
+	assert.NotContains(t, string(d), "adj_close")
+
+	c.AdjClose = decimalPtr(3)
+
+	d, err = json.Marshal(c)
+	assert.NoError(t, err)
+	assert.Contains(t, string(d), `"adj_close":"3"`)
+
+	var res Candle
+	assert.NoError(t, json.Unmarshal(d, &res))
+	assert.Equal(t, c, res)
+
+	c.Delta = decimalPtr(-2)
+
+	d, err = json.Marshal(c)
+	assert.NoError(t, err)
+	assert.Contains(t, string(d), `"delta":"-2"`)
+
+	res = Candle{}
+	assert.NoError(t, json.Unmarshal(d, &res))
+	assert.Equal(t, c, res)
+}
+
 func Test_FromCandles(t *testing.T) {
 	cc := []Candle{
 		{
@@ -405,7 +486,7 @@ func Test_ParseTicker(t *testing.T) {
 				Ask:           decimal.NewFromInt(3),
 				Bid:           decimal.NewFromInt(5),
 				Change:        decimal.NewFromInt(4),
-				PercentChange: decimal.NewFromInt(2),
+				PercentChange: NewPercent(decimal.NewFromInt(2)),
 				Volume:        decimal.NewFromInt(1),
 			},
 		},
@@ -600,6 +681,22 @@ func Test_TickerField_UnmarshalText(t *testing.T) {
 	}
 }
 
+func Test_FieldUnmarshalText_Allocs(t *testing.T) {
+	cd, td := []byte("adj_close"), []byte("percent_change")
+
+	allocs := testing.AllocsPerRun(100, func() {
+		var (
+			cf CandleField
+			tf TickerField
+		)
+
+		_sinkErr = cf.UnmarshalText(cd)
+		_sinkErr = tf.UnmarshalText(td)
+	})
+
+	assert.Zero(t, allocs)
+}
+
 func Test_TickerField_Extract(t *testing.T) {
 	cc := map[string]struct {
 		TickerField TickerField
@@ -637,7 +734,7 @@ func Test_TickerField_Extract(t *testing.T) {
 		},
 		"Successful PercentChange extract": {
 			TickerField: TickerPercentChange,
-			Ticker:      Ticker{PercentChange: decimal.NewFromInt(203)},
+			Ticker:      Ticker{PercentChange: NewPercent(decimal.NewFromInt(203))},
 			Result:      decimal.NewFromInt(203),
 		},
 		"Successful Volume extract": {
@@ -658,3 +755,143 @@ func Test_TickerField_Extract(t *testing.T) {
 		})
 	}
 }
+
+func Test_CandleFieldNames(t *testing.T) {
+	assert.Equal(t, map[CandleField]string{
+		CandleOpen:     "open",
+		CandleHigh:     "high",
+		CandleLow:      "low",
+		CandleClose:    "close",
+		CandleVolume:   "volume",
+		CandleAdjClose: "adj_close",
+		CandleDelta:    "delta",
+	}, CandleFieldNames())
+
+	assert.Equal(t, map[CandleField]string{
+		CandleOpen:     "o",
+		CandleHigh:     "h",
+		CandleLow:      "l",
+		CandleClose:    "c",
+		CandleVolume:   "v",
+		CandleAdjClose: "ac",
+		CandleDelta:    "d",
+	}, CandleFieldShortNames())
+
+	for _, nn := range []map[CandleField]string{CandleFieldNames(), CandleFieldShortNames()} {
+		for cf, n := range nn {
+			var res CandleField
+
+			assert.NoError(t, res.UnmarshalText([]byte(n)))
+			assert.Equal(t, cf, res)
+		}
+	}
+}
+
+func Test_TickerFieldNames(t *testing.T) {
+	assert.Equal(t, map[TickerField]string{
+		TickerLast:          "last",
+		TickerAsk:           "ask",
+		TickerBid:           "bid",
+		TickerChange:        "change",
+		TickerPercentChange: "percent_change",
+		TickerVolume:        "volume",
+	}, TickerFieldNames())
+
+	assert.Equal(t, map[TickerField]string{
+		TickerLast:          "l",
+		TickerAsk:           "a",
+		TickerBid:           "b",
+		TickerChange:        "c",
+		TickerPercentChange: "pc",
+		TickerVolume:        "v",
+	}, TickerFieldShortNames())
+
+	for _, nn := range []map[TickerField]string{TickerFieldNames(), TickerFieldShortNames()} {
+		for tf, n := range nn {
+			var res TickerField
+
+			assert.NoError(t, res.UnmarshalText([]byte(n)))
+			assert.Equal(t, tf, res)
+		}
+	}
+}
+
+// benchmark sinks prevent the compiler from eliminating benchmarked
+// calls.
+var (
+	_sinkBytes    []byte
+	_sinkErr      error
+	_sinkDecimal  decimal.Decimal
+	_sinkDecimals []decimal.Decimal
+)
+
+func Benchmark_CandleField_MarshalText(b *testing.B) {
+	b.ReportAllocs()
+
+	for i := 0; i < b.N; i++ {
+		_sinkBytes, _sinkErr = CandleAdjClose.MarshalText()
+	}
+}
+
+func Benchmark_CandleField_UnmarshalText(b *testing.B) {
+	d := []byte("close")
+
+	b.ReportAllocs()
+
+	for i := 0; i < b.N; i++ {
+		var cf CandleField
+		_sinkErr = cf.UnmarshalText(d)
+	}
+}
+
+func Benchmark_CandleField_Extract(b *testing.B) {
+	c := Candle{Close: decimal.NewFromInt(10)}
+
+	b.ReportAllocs()
+
+	for i := 0; i < b.N; i++ {
+		_sinkDecimal = CandleClose.Extract(c)
+	}
+}
+
+func Benchmark_FromCandles(b *testing.B) {
+	cc := make([]Candle, 1000)
+	for i := range cc {
+		cc[i] = Candle{Close: decimal.NewFromInt(int64(i))}
+	}
+
+	b.ReportAllocs()
+
+	for i := 0; i < b.N; i++ {
+		_sinkDecimals = FromCandles(cc, CandleClose)
+	}
+}
+
+func Benchmark_TickerField_MarshalText(b *testing.B) {
+	b.ReportAllocs()
+
+	for i := 0; i < b.N; i++ {
+		_sinkBytes, _sinkErr = TickerPercentChange.MarshalText()
+	}
+}
+
+func Benchmark_TickerField_UnmarshalText(b *testing.B) {
+	d := []byte("percent_change")
+
+	b.ReportAllocs()
+
+	for i := 0; i < b.N; i++ {
+		var tf TickerField
+		_sinkErr = tf.UnmarshalText(d)
+	}
+}
+
+func Benchmark_TickerField_Extract(b *testing.B) {
+	t := Ticker{Last: decimal.NewFromInt(10)}
+
+	b.ReportAllocs()
+
+	for i := 0; i < b.N; i++ {
+		_sinkDecimal = TickerLast.Extract(t)
+	}
+}
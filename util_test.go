@@ -2,7 +2,9 @@ package chartype
 
 import (
 	"testing"
+	"time"
 
+	"github.com/shopspring/decimal"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -25,3 +27,48 @@ func equalError(t *testing.T, exp, err error) {
 
 	assert.NoError(t, err)
 }
+
+// decimalPtr returns a pointer to a new decimal created from the
+// integer.
+func decimalPtr(v int64) *decimal.Decimal {
+	d := decimal.NewFromInt(v)
+	return &d
+}
+
+// testLocation loads the named location or skips the test if time
+// zone data is not available.
+func testLocation(t *testing.T, name string) *time.Location {
+	t.Helper()
+
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		t.Skipf("time zone data is not available: %v", err)
+	}
+
+	return loc
+}
+
+// assertEqualCandles checks whether candles have equal timestamps and
+// numerically equal values, regardless of their decimal
+// representation.
+func assertEqualCandles(t *testing.T, exp, act []Candle) {
+	t.Helper()
+
+	if !assert.Len(t, act, len(exp)) {
+		return
+	}
+
+	for i := range exp {
+		e, a := exp[i], act[i]
+
+		assert.True(t, e.Timestamp.Equal(a.Timestamp), "candle %d timestamp", i)
+
+		for _, cf := range []CandleField{CandleOpen, CandleHigh, CandleLow, CandleClose, CandleVolume, CandleAdjClose, CandleDelta} {
+			assert.True(t, cf.Extract(e).Equal(cf.Extract(a)), "candle %d %v: expected %s, actual %s",
+				i, cf, cf.Extract(e), cf.Extract(a))
+		}
+
+		assert.Equal(t, e.AdjClose == nil, a.AdjClose == nil, "candle %d adjusted close presence", i)
+		assert.Equal(t, e.Delta == nil, a.Delta == nil, "candle %d volume delta presence", i)
+	}
+}
@@ -0,0 +1,222 @@
+package chartype
+
+import (
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/yaml.v3"
+)
+
+func Test_YAML_Enums(t *testing.T) {
+	type config struct {
+		CandleField CandleField `yaml:"candle_field"`
+		TickerField TickerField `yaml:"ticker_field"`
+		Interval    Interval    `yaml:"interval"`
+		Direction   Direction   `yaml:"direction"`
+		Pair        Pair        `yaml:"pair"`
+		Side        Side        `yaml:"side"`
+	}
+
+	cc := map[string]struct {
+		YAML   string
+		Result config
+		Err    error
+	}{
+		"Invalid CandleField": {
+			YAML: "candle_field: x",
+			Err:  assert.AnError,
+		},
+		"Invalid TickerField": {
+			YAML: "ticker_field: x",
+			Err:  assert.AnError,
+		},
+		"Invalid Interval": {
+			YAML: "interval: 1y",
+			Err:  assert.AnError,
+		},
+		"Invalid Direction": {
+			YAML: "direction: x",
+			Err:  assert.AnError,
+		},
+		"Invalid Pair": {
+			YAML: "pair: BTC",
+			Err:  assert.AnError,
+		},
+		"Invalid Side": {
+			YAML: "side: x",
+			Err:  assert.AnError,
+		},
+		"Successful plain form unmarshal": {
+			YAML: "candle_field: close\n" +
+				"ticker_field: percent_change\n" +
+				"interval: 4h\n" +
+				"direction: backward\n" +
+				"pair: BTC_USDT\n" +
+				"side: sell\n",
+			Result: config{
+				CandleField: CandleClose,
+				TickerField: TickerPercentChange,
+				Interval:    4 * IntervalHour,
+				Direction:   Backward,
+				Pair:        Pair{Base: "BTC", Quote: "USDT"},
+				Side:        SideSell,
+			},
+		},
+		"Successful !!str form unmarshal": {
+			YAML: "candle_field: !!str h\n" +
+				"ticker_field: !!str pc\n" +
+				"interval: !!str 1d\n" +
+				"direction: !!str f\n" +
+				"pair: !!str ETH_BTC\n" +
+				"side: !!str b\n",
+			Result: config{
+				CandleField: CandleHigh,
+				TickerField: TickerPercentChange,
+				Interval:    IntervalDay,
+				Direction:   Forward,
+				Pair:        Pair{Base: "ETH", Quote: "BTC"},
+				Side:        SideBuy,
+			},
+		},
+	}
+
+	for cn, c := range cc {
+		c := c
+
+		t.Run(cn, func(t *testing.T) {
+			t.Parallel()
+
+			var res config
+
+			err := yaml.Unmarshal([]byte(c.YAML), &res)
+			equalError(t, c.Err, err)
+			if err != nil {
+				return
+			}
+
+			assert.Equal(t, c.Result, res)
+
+			d, err := yaml.Marshal(res)
+			assert.NoError(t, err)
+
+			var rt config
+			assert.NoError(t, yaml.Unmarshal(d, &rt))
+			assert.Equal(t, res, rt)
+		})
+	}
+}
+
+func Test_YAML_Structs(t *testing.T) {
+	tm := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	p := Packet{
+		Ticker: Ticker{
+			Last:          decimal.NewFromInt(1),
+			Ask:           decimal.NewFromInt(2),
+			Bid:           decimal.NewFromInt(3),
+			Change:        decimal.NewFromInt(4),
+			PercentChange: NewPercent(decimal.RequireFromString("5.5")),
+			Volume:        decimal.NewFromInt(6),
+		},
+		Candles: []Candle{
+			{
+				Timestamp: tm,
+				Open:      decimal.NewFromInt(1),
+				High:      decimal.NewFromInt(2),
+				Low:       decimal.NewFromInt(3),
+				Close:     decimal.NewFromInt(4),
+				Volume:    decimal.NewFromInt(5),
+				AdjClose:  decimalPtr(3),
+			},
+		},
+	}
+
+	d, err := yaml.Marshal(p)
+	assert.NoError(t, err)
+	assert.Contains(t, string(d), "percent_change:")
+	assert.Contains(t, string(d), "adj_close:")
+
+	var res Packet
+	assert.NoError(t, yaml.Unmarshal(d, &res))
+	assert.Equal(t, p, res)
+}
+
+func Test_CandleRequest_MarshalYAML(t *testing.T) {
+	cc := map[string]struct {
+		Modify func(cr *CandleRequest)
+		Err    error
+	}{
+		"Invalid CandleRequest": {
+			Modify: func(cr *CandleRequest) { cr.Limit = -1 },
+			Err:    assert.AnError,
+		},
+		"Successful marshal": {
+			Modify: func(cr *CandleRequest) {},
+		},
+	}
+
+	for cn, c := range cc {
+		c := c
+
+		t.Run(cn, func(t *testing.T) {
+			t.Parallel()
+
+			cr := testCandleRequest()
+			c.Modify(&cr)
+
+			d, err := yaml.Marshal(cr)
+			equalError(t, c.Err, err)
+			if err != nil {
+				return
+			}
+
+			assert.Contains(t, string(d), "pair: BTC_USDT")
+		})
+	}
+}
+
+func Test_CandleRequest_UnmarshalYAML(t *testing.T) {
+	cc := map[string]struct {
+		YAML   string
+		Result CandleRequest
+		Err    error
+	}{
+		"Invalid YAML": {
+			YAML: "pair: [1]",
+			Err:  assert.AnError,
+		},
+		"Invalid CandleRequest": {
+			YAML: "pair: BTC_USDT\ninterval: 1h\n",
+			Err:  ErrInvalidTimeRange,
+		},
+		"Successful unmarshal": {
+			YAML: "pair: BTC_USDT\n" +
+				"interval: 1h\n" +
+				"range:\n" +
+				"  from: 2020-01-01T00:00:00Z\n" +
+				"  to: 2020-01-02T00:00:00Z\n" +
+				"limit: 10\n",
+			Result: testCandleRequest(),
+		},
+	}
+
+	for cn, c := range cc {
+		c := c
+
+		t.Run(cn, func(t *testing.T) {
+			t.Parallel()
+
+			var cr CandleRequest
+
+			err := yaml.Unmarshal([]byte(c.YAML), &cr)
+			equalError(t, c.Err, err)
+			if err != nil {
+				return
+			}
+
+			assert.Equal(t, c.Result, cr)
+		})
+	}
+}